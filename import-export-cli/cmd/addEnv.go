@@ -26,13 +26,13 @@ import (
 
 var envToBeAdded string // Name of the environment to be added
 
-var flagTokenEndpoint string        // token endpoint of the environment to be added
-var flagPublisherEndpoint string    // Publisher endpoint of the environment to be added
-var flagDevPortalEndpoint string    // DevPortal endpoint of the environment to be added
-var flagRegistrationEndpoint string // registration endpoint of the environment to be added
-var flagApiManagerEndpoint string   // api manager endpoint of the environment to be added
-var flagAdminEndpoint string        // admin endpoint of the environment to be added
-var flagMiManagementEndpoint string // mi management endpoint of the environment to be added
+var flagTokenEndpoint string           // token endpoint of the environment to be added
+var flagPublisherEndpoint string       // Publisher endpoint of the environment to be added
+var flagDevPortalEndpoint string       // DevPortal endpoint of the environment to be added
+var flagRegistrationEndpoint string    // registration endpoint of the environment to be added
+var flagApiManagerEndpoint string      // api manager endpoint of the environment to be added
+var flagAdminEndpoint string           // admin endpoint of the environment to be added
+var flagMiManagementEndpoints []string // mi management endpoints of the nodes of the environment to be added
 
 // AddEnv command related Info
 const AddEnvCmdLiteral = "env [environment]"
@@ -45,6 +45,10 @@ const addEnvCmdExamples = utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnv
 ` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnvCmdLiteralTrimmed + ` dev \
 --mi  https://localhost:9164
 
+` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnvCmdLiteralTrimmed + ` cluster \
+--mi  https://mi1.com:9164 \
+--mi  https://mi2.com:9164
+
 ` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnvCmdLiteralTrimmed + ` prod \
 --apim  https://apim.com:9443 \
 --mi https://localhost:9164
@@ -65,7 +69,8 @@ const addEnvCmdExamples = utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnv
 You can either provide only the flag --apim , or all the other 4 flags (--registration --publisher --devportal --admin) without providing --apim flag.
 If you are omitting any of --registration --publisher --devportal --admin flags, you need to specify --apim flag with the API Manager endpoint. In both of the
 cases --token flag is optional and use it to specify the gateway token endpoint. This will be used for "apictl get-keys" operation.
-To add a micro integrator instance to an environment you can use the --mi flag.`
+To add a micro integrator instance to an environment you can use the --mi flag. Repeat the --mi flag with the endpoint of each node
+to add a micro integrator cluster. The mi commands which get or update artifacts are executed on all the nodes of a cluster.`
 
 // addEnvCmd represents the addEnv command
 var addEnvCmd = &cobra.Command{
@@ -91,7 +96,12 @@ func executeAddEnvCmd(mainConfigFilePath string) {
 	envEndpoints.DevPortalEndpoint = flagDevPortalEndpoint
 	envEndpoints.AdminEndpoint = flagAdminEndpoint
 	envEndpoints.TokenEndpoint = flagTokenEndpoint
-	envEndpoints.MiManagementEndpoint = flagMiManagementEndpoint
+	if len(flagMiManagementEndpoints) > 0 {
+		envEndpoints.MiManagementEndpoint = flagMiManagementEndpoints[0]
+	}
+	if len(flagMiManagementEndpoints) > 1 {
+		envEndpoints.MiManagementNodes = flagMiManagementEndpoints
+	}
 	err := impl.AddEnv(envToBeAdded, envEndpoints, mainConfigFilePath, AddEnvCmdLiteral)
 	if err != nil {
 		utils.HandleErrorAndExit("Error adding environment", err)
@@ -109,6 +119,7 @@ func init() {
 	addEnvCmd.Flags().StringVar(&flagRegistrationEndpoint, "registration", "",
 		"Registration endpoint for the environment")
 	addEnvCmd.Flags().StringVar(&flagAdminEndpoint, "admin", "", "Admin endpoint for the environment")
-	addEnvCmd.Flags().StringVar(&flagMiManagementEndpoint, "mi", "", "Micro Integrator Management endpoint for the environment")
+	addEnvCmd.Flags().StringSliceVar(&flagMiManagementEndpoints, "mi", []string{},
+		"Micro Integrator Management endpoint for the environment. Repeat the flag to add each node of a Micro Integrator cluster")
 	_ = addEnvCmd.MarkFlagRequired("environment")
 }
@@ -40,7 +40,9 @@ const BundleCmdLongDesc = "Archive API, Application or API Product projects to a
 
 const BundleCmdExamples = utils.ProjectName + ` ` + BundleCmdLiteral + ` -s /home/prod/APIs/API1-1.0.0 -d /home/prod/Projects/
 ` + utils.ProjectName + ` ` + BundleCmdLiteral + ` -s /home/prod/APIs/API1-1.0.0 
-NOTE: The flag (--source (-s)) is mandatory.`
+` + utils.ProjectName + ` ` + BundleCmdLiteral + ` -d /home/prod/Projects/
+NOTE: The flag (--source (-s)) is mandatory when there is no apictl.yaml workspace manifest. If it is not given, all
+the projects declared in the workspace manifest of the current directory are bundled.`
 
 // BundleCmd represents the bundle command
 var BundleCmd = &cobra.Command{
@@ -51,21 +53,32 @@ var BundleCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + BundleCmdLiteral + " called")
 
+		// bundle all the projects of the workspace if the source is not given
+		if bundleSource == "" {
+			for _, project := range getWorkspaceProjects("--source (-s)") {
+				err := executeBundleCmd(project.Path)
+				if err != nil {
+					utils.HandleErrorAndContinue("Error archiving the "+project.RelativePath, err)
+				}
+			}
+			return
+		}
+
 		if stat, err := os.Stat(bundleSource); !os.IsNotExist(err) {
 			if !stat.IsDir() {
-				fmt.Printf("%s is not a directory\n", bundleSource)
+				fmt.Fprintf(utils.MessageWriter(), "%s is not a directory\n", bundleSource)
 				os.Exit(1)
 			}
 		}
 
-		err := executeBundleCmd()
+		err := executeBundleCmd(bundleSource)
 		if err != nil {
 			utils.HandleErrorAndContinue("Error archiving the "+bundleSource, err)
 		}
 	},
 }
 
-func executeBundleCmd() error {
+func executeBundleCmd(sourceDir string) error {
 	var bundleDirParent string
 
 	// Check the validity of destination path when it is given. if not given, use the working directory
@@ -87,25 +100,25 @@ func executeBundleCmd() error {
 		bundleDirParent = pwd
 	}
 
-	bundleName, err := generateBundleName(bundleSource)
+	bundleName, err := generateBundleName(sourceDir)
 	if err != nil {
 		return err
 	}
 
 	bundleLocation := filepath.Join(bundleDirParent, bundleName+utils.ZipFileSuffix)
-	err = utils.Zip(bundleSource, bundleLocation)
+	err = utils.Zip(sourceDir, bundleLocation)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("The bundle for the " + bundleName + " is generated at " + bundleLocation)
+	fmt.Fprintln(utils.MessageWriter(), "The bundle for the "+bundleName+" is generated at "+bundleLocation)
 	return nil
 }
 
 func generateBundleName(SourceDir string) (string, error) {
 	metaFileName, err := impl.GetFileLocationFromPattern(SourceDir, "*_meta.yaml")
 	if err != nil && err != io.EOF {
-		fmt.Println("Error reading the meta information.", err)
+		fmt.Fprintln(utils.MessageWriter(), "Error reading the meta information.", err)
 	}
 	bundleName := filepath.Base(SourceDir)
 	if metaFileName != "" {
@@ -116,7 +129,7 @@ func generateBundleName(SourceDir string) (string, error) {
 
 		bundleName = metaData.Name + "_" + metaData.Version
 	} else {
-		fmt.Println("Meta information for the Project is not found. Source directory name will be used as the bundle name.")
+		fmt.Fprintln(utils.MessageWriter(), "Meta information for the Project is not found. Source directory name will be used as the bundle name.")
 	}
 
 	return bundleName, nil
@@ -128,6 +141,5 @@ func init() {
 	BundleCmd.Flags().StringVarP(&bundleDestination, "destination", "d", "", "Path of "+
 		"the directory where the bundle should be generated")
 	BundleCmd.Flags().StringVarP(&bundleSource, "source", "s", "", "Path of "+
-		"the source directory to bundle")
-	_ = BundleCmd.MarkFlagRequired("source")
+		"the source directory to bundle. Bundles the projects of the workspace if not given")
 }
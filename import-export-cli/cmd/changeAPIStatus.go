@@ -63,6 +63,11 @@ var ChangeAPIStatusCmd = &cobra.Command{
 
 // executeChangeAPIStatusCmd executes the change api status command
 func executeChangeAPIStatusCmd(credential credentials.Credential) {
+	utils.SetResultResource("action", apiStateChangeAction)
+	utils.SetResultResource("name", apiNameForStateChange)
+	utils.SetResultResource("version", apiVersionForStateChange)
+	utils.SetResultResource("provider", apiProviderForStateChange)
+	utils.SetResultResource("environment", apiStateChangeEnvironment)
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, apiStateChangeEnvironment)
 	if preCommandErr == nil {
 		resp, err := impl.ChangeAPIStatusInEnv(accessToken, apiStateChangeEnvironment, apiStateChangeAction,
@@ -72,19 +77,16 @@ func executeChangeAPIStatusCmd(credential credentials.Credential) {
 		}
 		// Print info on response
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
+		utils.SetResultHttpStatus(resp.StatusCode())
 		if resp.StatusCode() == http.StatusOK {
 			// 200 OK
-			fmt.Println(apiNameForStateChange + " API state changed successfully!")
-		} else if resp.StatusCode() == http.StatusInternalServerError {
-			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
+			fmt.Fprintln(utils.MessageWriter(), apiNameForStateChange+" API state changed successfully!")
 		} else {
-			// Neither 200 nor 500
-			fmt.Println("Error while changing API Status: ", resp.Status(), "\n", string(resp.Body()))
+			utils.HandleErrorAndExit("Error while changing API Status", utils.NewHttpStatusError(resp))
 		}
 	} else {
 		// Error changing the API status
-		fmt.Println("Error getting OAuth tokens while changing status of the API:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while changing status of the API", preCommandErr)
 	}
 }
 
@@ -0,0 +1,49 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// credentials command related usage Info
+const credentialsCmdLiteral = "credentials"
+const credentialsCmdShortDesc = "Manage the store which keeps the credentials"
+const credentialsCmdLongDesc = `Manage the store which keeps the credentials of the environments. Credentials can be kept in
+json (default), pass (the pass password manager) or encrypted (an AES-GCM encrypted file unlocked by a master passphrase)
+stores. The passphrase of the encrypted store can be provided using the APICTL_CRED_STORE_PASSPHRASE environment variable.`
+const credentialsCmdExamples = utils.ProjectName + ` ` + credentialsCmdLiteral + ` ` + credentialsMigrateCmdLiteral +
+	` --to encrypted`
+
+// CredentialsCmd represents the credentials command
+var CredentialsCmd = &cobra.Command{
+	Use:     credentialsCmdLiteral,
+	Short:   credentialsCmdShortDesc,
+	Long:    credentialsCmdLongDesc,
+	Example: credentialsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + credentialsCmdLiteral + " called")
+		cmd.Help()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(CredentialsCmd)
+}
@@ -0,0 +1,94 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var flagCredentialsMigrateTo string // type of the store to move the credentials into
+
+// credentials migrate command related usage Info
+const credentialsMigrateCmdLiteral = "migrate"
+const credentialsMigrateCmdShortDesc = "Move the credentials to another store"
+const credentialsMigrateCmdLongDesc = `Move all the existing credentials from the current store to the store specified by --to
+and use that store from then onwards. Supported stores are ` + credentials.CredStoreJson + `, ` +
+	credentials.CredStorePass + ` and ` + credentials.CredStoreEncrypted + `
+NOTE: The flag --to is mandatory`
+const credentialsMigrateCmdExamples = utils.ProjectName + ` ` + credentialsCmdLiteral + ` ` +
+	credentialsMigrateCmdLiteral + ` --to encrypted
+` + utils.ProjectName + ` ` + credentialsCmdLiteral + ` ` + credentialsMigrateCmdLiteral + ` --to pass
+` + utils.ProjectName + ` ` + credentialsCmdLiteral + ` ` + credentialsMigrateCmdLiteral + ` --to json`
+
+// credentialsMigrateCmd represents the credentials migrate command
+var credentialsMigrateCmd = &cobra.Command{
+	Use:     credentialsMigrateCmdLiteral,
+	Short:   credentialsMigrateCmdShortDesc,
+	Long:    credentialsMigrateCmdLongDesc,
+	Example: credentialsMigrateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + credentialsCmdLiteral + " " + credentialsMigrateCmdLiteral + " called")
+		executeCredentialsMigrateCmd(flagCredentialsMigrateTo)
+	},
+}
+
+func executeCredentialsMigrateCmd(credStore string) {
+	if !isSupportedCredStore(credStore) {
+		utils.HandleErrorAndExit("Unsupported credential store "+credStore+". Supported stores are "+
+			strings.Join(credentials.CredStores, ", "), nil)
+	}
+	currentCredStore, err := credentials.GetDefaultCredentialStoreType()
+	if err != nil {
+		utils.HandleErrorAndExit("Error occurred while loading credential store", err)
+	}
+	if currentCredStore == credStore {
+		fmt.Fprintln(utils.MessageWriter(), "Credentials are already kept in the "+credStore+" store")
+		return
+	}
+	count, err := credentials.MigrateDefaultCredentialStore(credStore)
+	if err != nil {
+		utils.HandleErrorAndExit("Error occurred while migrating credentials from the "+currentCredStore+
+			" store to the "+credStore+" store", err)
+	}
+	fmt.Fprintln(utils.MessageWriter(), "Migrated "+strconv.Itoa(count)+" credential(s) from the "+currentCredStore+" store to the "+
+		credStore+" store")
+}
+
+// isSupportedCredStore returns whether the given type of credential store is supported
+func isSupportedCredStore(credStore string) bool {
+	for _, supported := range credentials.CredStores {
+		if credStore == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	CredentialsCmd.AddCommand(credentialsMigrateCmd)
+	credentialsMigrateCmd.Flags().StringVarP(&flagCredentialsMigrateTo, "to", "", "",
+		"Type of the store to move the credentials into ("+strings.Join(credentials.CredStores, ", ")+")")
+	_ = credentialsMigrateCmd.MarkFlagRequired("to")
+}
@@ -19,8 +19,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -69,16 +67,21 @@ var DeleteAPICmd = &cobra.Command{
 
 // executeDeleteAPICmd executes the delete api command
 func executeDeleteAPICmd(credential credentials.Credential) {
+	utils.SetResultResource("name", deleteAPIName)
+	utils.SetResultResource("version", deleteAPIVersion)
+	utils.SetResultResource("provider", deleteAPIProvider)
+	utils.SetResultResource("environment", deleteAPIEnvironment)
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, deleteAPIEnvironment)
 	if preCommandErr == nil {
 		resp, err := impl.DeleteAPI(accessToken, deleteAPIEnvironment, deleteAPIName, deleteAPIVersion, deleteAPIProvider)
 		if err != nil {
 			utils.HandleErrorAndExit("Error while deleting API ", err)
 		}
+		utils.SetResultHttpStatus(resp.StatusCode())
 		impl.PrintDeleteAPIResponse(resp, err)
 	} else {
 		// Error deleting API
-		fmt.Println("Error getting OAuth tokens while deleting API:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while deleting API", preCommandErr)
 	}
 }
 
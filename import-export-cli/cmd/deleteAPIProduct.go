@@ -71,7 +71,7 @@ func executeDeleteAPIProductCmd(credential credentials.Credential) {
 		impl.PrintDeleteAPIProductResponse(resp, err)
 	} else {
 		// Error deleting API Product
-		fmt.Println("Error getting OAuth tokens while deleting API Product:" + preCommandErr.Error())
+		fmt.Fprintln(utils.MessageWriter(), "Error getting OAuth tokens while deleting API Product:"+preCommandErr.Error())
 	}
 }
 
@@ -72,7 +72,7 @@ func executeDeleteAppCmd(credential credentials.Credential) {
 		impl.PrintDeleteAppResponse(resp, err)
 	} else {
 		// Error deleting Application
-		fmt.Println("Error getting OAuth tokens while deleting Application:" + preCommandErr.Error())
+		fmt.Fprintln(utils.MessageWriter(), "Error getting OAuth tokens while deleting Application:"+preCommandErr.Error())
 	}
 }
 
@@ -19,6 +19,8 @@
 package deprecated
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/cmd"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -67,11 +69,12 @@ var ImportAPICmdDeprecated = &cobra.Command{
 			utils.HandleErrorAndExit("Error while getting an access token for importing API", err)
 		}
 		err = impl.ImportAPIToEnv(accessOAuthToken, importEnvironment, importAPIFile, importAPIParamsFile, importAPIUpdate,
-			importAPICmdPreserveProvider, importAPISkipCleanup, false, false)
+			importAPICmdPreserveProvider, importAPISkipCleanup, false, false, false)
 		if err != nil {
 			utils.HandleErrorAndExit("Error importing API", err)
 			return
 		}
+		fmt.Println("Successfully imported API.")
 	},
 }
 
@@ -19,6 +19,8 @@
 package deprecated
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/cmd"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -70,10 +72,11 @@ func executeImportAppCmd(credential credentials.Credential) {
 		utils.HandleErrorAndExit("Error getting OAuth Tokens", err)
 	}
 	_, err = impl.ImportApplicationToEnv(accessToken, importAppEnvironment, importAppFile, importAppOwner,
-		importAppUpdateApplication, preserveOwner, skipSubscriptions, importAppSkipKeys, importAppSkipCleanup)
+		importAppUpdateApplication, preserveOwner, skipSubscriptions, importAppSkipKeys, importAppSkipCleanup, false)
 	if err != nil {
 		utils.HandleErrorAndExit("Error importing Application", err)
 	}
+	fmt.Println("Successfully imported Application.")
 }
 
 func init() {
@@ -0,0 +1,173 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Diff command related usage Info
+const DiffCmdLiteral = "diff"
+const diffCmdShortDesc = "Compare an API/API Product across environments or with a project"
+const diffCmdLongDesc = `Compare an API or an API Product in an environment specified by the flag (--environment, -e) with the
+same API or API Product in another environment (--to-environment) or with a local project (--project)`
+const diffCmdExamples = utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -e dev --to-environment prod
+` + utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPIProductCmdLiteral + ` -n LeasingAPIProduct -e dev --project ./LeasingAPIProduct`
+
+// DiffCmd represents the diff command
+var DiffCmd = &cobra.Command{
+	Use:     DiffCmdLiteral,
+	Short:   diffCmdShortDesc,
+	Long:    diffCmdLongDesc,
+	Example: diffCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DiffCmdLiteral + " called")
+		cmd.Help()
+	},
+}
+
+// projectExportFunc exports a project from an environment to a temporary directory
+type projectExportFunc func(accessToken, environment string) (string, error)
+
+// projectDiffFunc compares two projects
+type projectDiffFunc func(beforePath, afterPath string) ([]impl.ProjectFileDiff, error)
+
+// executeDiffCmd compares a project in an environment with the same project in another environment or a local project
+// and prints the differences. The failures are returned instead of exiting, so the exported projects are removed.
+func executeDiffCmd(resourceType, environment, toEnvironment, projectPath string, exportFunc projectExportFunc,
+	diffFunc projectDiffFunc) error {
+	if (toEnvironment == "") == (projectPath == "") {
+		return utils.NewCommandFailure("Invalid flags", utils.NewValidationError(
+			"either --to-environment or --project should be specified"))
+	}
+	utils.SetResultResource("environment", environment)
+	utils.SetResultResource("toEnvironment", toEnvironment)
+	utils.SetResultResource("project", projectPath)
+
+	beforePath, err := exportProjectForDiff(resourceType, environment, exportFunc)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(filepath.Dir(beforePath))
+
+	var afterPath, afterLabel string
+	if toEnvironment != "" {
+		afterPath, err = exportProjectForDiff(resourceType, toEnvironment, exportFunc)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(filepath.Dir(afterPath))
+		afterLabel = toEnvironment
+	} else {
+		info, err := os.Stat(projectPath)
+		if err != nil {
+			return utils.NewCommandFailure("Error reading the project", utils.NewValidationError(err.Error()))
+		}
+		afterPath = projectPath
+		afterLabel = projectPath
+		if !info.IsDir() {
+			// the project is an exported archive
+			clonePath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+			if err != nil {
+				return utils.NewCommandFailure("Error extracting "+projectPath, err)
+			}
+			defer os.RemoveAll(filepath.Dir(clonePath))
+			afterPath = clonePath
+		}
+	}
+
+	fileDiffs, err := diffFunc(beforePath, afterPath)
+	if err != nil {
+		return utils.NewCommandFailure("Error while comparing the "+resourceType, err)
+	}
+	utils.SetResultData(fileDiffs)
+	if !utils.IsJSONOutput() {
+		printProjectDiffs(environment, afterLabel, fileDiffs)
+	}
+	return nil
+}
+
+// exportProjectForDiff exports a project to be compared from the given environment
+func exportProjectForDiff(resourceType, environment string, exportFunc projectExportFunc) (string, error) {
+	cred, err := GetCredentials(environment)
+	if err != nil {
+		return "", utils.NewCommandFailure("Error getting credentials", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(cred, environment)
+	if err != nil {
+		return "", utils.NewCommandFailure("Error getting OAuth tokens of "+environment, err)
+	}
+	projectPath, err := exportFunc(accessToken, environment)
+	if err != nil {
+		return "", utils.NewCommandFailure("Error exporting the "+resourceType+" from "+environment, err)
+	}
+	if projectPath == "" {
+		return "", utils.NewCommandFailure("Error exporting the "+resourceType,
+			utils.NewNotFoundError("the "+resourceType+" is not available in "+environment))
+	}
+	return projectPath, nil
+}
+
+// printProjectDiffs prints the differences of each file of two projects
+func printProjectDiffs(before, after string, fileDiffs []impl.ProjectFileDiff) {
+	fmt.Fprintln(utils.MessageWriter(), "--- "+before)
+	fmt.Fprintln(utils.MessageWriter(), "+++ "+after)
+	if len(fileDiffs) == 0 {
+		fmt.Fprintln(utils.MessageWriter(), "No differences found")
+		return
+	}
+	for _, fileDiff := range fileDiffs {
+		switch fileDiff.Type {
+		case utils.DiffTypeAdded:
+			fmt.Fprintln(utils.MessageWriter(), "\n+ "+fileDiff.File)
+		case utils.DiffTypeRemoved:
+			fmt.Fprintln(utils.MessageWriter(), "\n- "+fileDiff.File)
+		case utils.DiffTypeModified:
+			if len(fileDiff.Diffs) == 0 {
+				fmt.Fprintln(utils.MessageWriter(), "\n~ "+fileDiff.File)
+			} else {
+				fmt.Fprintln(utils.MessageWriter(), "\n~ "+fileDiff.File+" ("+strconv.Itoa(len(fileDiff.Diffs))+" changes)")
+			}
+		}
+		for _, diff := range fileDiff.Diffs {
+			switch diff.Type {
+			case utils.DiffTypeAdded:
+				fmt.Fprintln(utils.MessageWriter(), "\t+ "+diff.Path+": "+utils.FormatDiffValue(diff.After))
+			case utils.DiffTypeRemoved:
+				fmt.Fprintln(utils.MessageWriter(), "\t- "+diff.Path+": "+utils.FormatDiffValue(diff.Before))
+			default:
+				fmt.Fprintln(utils.MessageWriter(), "\t~ "+diff.Path+": "+utils.FormatDiffValue(diff.Before)+" => "+
+					utils.FormatDiffValue(diff.After))
+			}
+		}
+	}
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(DiffCmd)
+}
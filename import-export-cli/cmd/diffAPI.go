@@ -0,0 +1,85 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var diffAPIName string
+var diffAPIVersion string
+var diffAPIProvider string
+var diffAPIEnvironment string
+var diffAPIToEnvironment string
+var diffAPIProjectPath string
+
+// DiffAPI command related usage info
+const DiffAPICmdLiteral = "api"
+const diffAPICmdShortDesc = "Compare an API across environments or with a project"
+
+const diffAPICmdLongDesc = `Compare an API in the environment specified by --environment (-e) with the same API in the
+environment specified by --to-environment or with the API project specified by --project. The differences of api.yaml,
+the API definition, the endpoints, the sequences and the deployment environments are shown. Server generated fields such
+as the IDs and the timestamps are ignored.`
+
+const diffAPICmdExamples = utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -e dev --to-environment prod
+` + utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -r admin -e dev --project ./PizzaShackAPI
+` + utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -e dev --to-environment prod --output json
+NOTE: The 3 flags (--name (-n), --version (-v) and --environment (-e)) and one of --to-environment or --project are mandatory.`
+
+// DiffAPICmd represents the diff api command
+var DiffAPICmd = &cobra.Command{
+	Use: DiffAPICmdLiteral + " (--name <name-of-the-api> --version <version-of-the-api> --provider <provider-of-the-api> " +
+		"--environment <environment> (--to-environment <environment-to-compare-with> | --project <path-to-api-project>))",
+	Short:   diffAPICmdShortDesc,
+	Long:    diffAPICmdLongDesc,
+	Example: diffAPICmdExamples,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		utils.Logln(utils.LogPrefixInfo + DiffAPICmdLiteral + " called")
+		utils.SetResultResource("name", diffAPIName)
+		utils.SetResultResource("version", diffAPIVersion)
+		utils.SetResultResource("provider", diffAPIProvider)
+		return executeDiffCmd("API", diffAPIEnvironment, diffAPIToEnvironment, diffAPIProjectPath,
+			func(accessToken, environment string) (string, error) {
+				return impl.ExportAPIToTempDir(accessToken, environment, diffAPIName, diffAPIVersion, diffAPIProvider)
+			}, impl.DiffAPIProjects)
+	},
+}
+
+// init using Cobra
+func init() {
+	DiffCmd.AddCommand(DiffAPICmd)
+	DiffAPICmd.Flags().StringVarP(&diffAPIName, "name", "n", "",
+		"Name of the API to be compared")
+	DiffAPICmd.Flags().StringVarP(&diffAPIVersion, "version", "v", "",
+		"Version of the API to be compared")
+	DiffAPICmd.Flags().StringVarP(&diffAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	DiffAPICmd.Flags().StringVarP(&diffAPIEnvironment, "environment", "e", "",
+		"Environment of the API to be compared")
+	DiffAPICmd.Flags().StringVarP(&diffAPIToEnvironment, "to-environment", "", "",
+		"Environment to compare the API with")
+	DiffAPICmd.Flags().StringVarP(&diffAPIProjectPath, "project", "", "",
+		"Path to the API project (directory or archive) to compare the API with")
+	_ = DiffAPICmd.MarkFlagRequired("name")
+	_ = DiffAPICmd.MarkFlagRequired("version")
+	_ = DiffAPICmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,81 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var diffAPIProductName string
+var diffAPIProductProvider string
+var diffAPIProductEnvironment string
+var diffAPIProductToEnvironment string
+var diffAPIProductProjectPath string
+
+// DiffAPIProduct command related usage info
+const DiffAPIProductCmdLiteral = "api-product"
+const diffAPIProductCmdShortDesc = "Compare an API Product across environments or with a project"
+
+const diffAPIProductCmdLongDesc = `Compare an API Product in the environment specified by --environment (-e) with the same
+API Product in the environment specified by --to-environment or with the API Product project specified by --project.
+The differences of api_product.yaml, the API Product definition, the sequences and the deployment environments are
+shown. Server generated fields such as the IDs and the timestamps are ignored.`
+
+const diffAPIProductCmdExamples = utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPIProductCmdLiteral + ` -n LeasingAPIProduct -e dev --to-environment prod
+` + utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPIProductCmdLiteral + ` -n LeasingAPIProduct -r admin -e dev --project ./LeasingAPIProduct
+NOTE: Both the flags (--name (-n) and --environment (-e)) and one of --to-environment or --project are mandatory.`
+
+// DiffAPIProductCmd represents the diff api-product command
+var DiffAPIProductCmd = &cobra.Command{
+	Use: DiffAPIProductCmdLiteral + " (--name <name-of-the-api-product> --provider <provider-of-the-api-product> " +
+		"--environment <environment> (--to-environment <environment-to-compare-with> | --project <path-to-api-product-project>))",
+	Short:   diffAPIProductCmdShortDesc,
+	Long:    diffAPIProductCmdLongDesc,
+	Example: diffAPIProductCmdExamples,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		utils.Logln(utils.LogPrefixInfo + DiffAPIProductCmdLiteral + " called")
+		utils.SetResultResource("name", diffAPIProductName)
+		utils.SetResultResource("provider", diffAPIProductProvider)
+		return executeDiffCmd("API Product", diffAPIProductEnvironment, diffAPIProductToEnvironment, diffAPIProductProjectPath,
+			func(accessToken, environment string) (string, error) {
+				// Since the user cannot specify the version, use the version as 1.0.0
+				return impl.ExportAPIProductToTempDir(accessToken, environment, diffAPIProductName,
+					utils.DefaultApiProductVersion, diffAPIProductProvider)
+			}, impl.DiffAPIProductProjects)
+	},
+}
+
+// init using Cobra
+func init() {
+	DiffCmd.AddCommand(DiffAPIProductCmd)
+	DiffAPIProductCmd.Flags().StringVarP(&diffAPIProductName, "name", "n", "",
+		"Name of the API Product to be compared")
+	DiffAPIProductCmd.Flags().StringVarP(&diffAPIProductProvider, "provider", "r", "",
+		"Provider of the API Product")
+	DiffAPIProductCmd.Flags().StringVarP(&diffAPIProductEnvironment, "environment", "e", "",
+		"Environment of the API Product to be compared")
+	DiffAPIProductCmd.Flags().StringVarP(&diffAPIProductToEnvironment, "to-environment", "", "",
+		"Environment to compare the API Product with")
+	DiffAPIProductCmd.Flags().StringVarP(&diffAPIProductProjectPath, "project", "", "",
+		"Path to the API Product project (directory or archive) to compare the API Product with")
+	_ = DiffAPIProductCmd.MarkFlagRequired("name")
+	_ = DiffAPIProductCmd.MarkFlagRequired("environment")
+}
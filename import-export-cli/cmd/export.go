@@ -30,12 +30,14 @@ const exportCmdShortDesc = "Export an API/API Product/Application in an environm
 const exportCmdLongDesc = `Export an API available in the environment specified by flag (--environment, -e)
 Export APIs available in the environment specified by flag (--environment, -e)
 Export an API Product available in the environment specified by flag (--environment, -e)
-Export an Application of a specific user (--owner, -o) in the environment specified by flag (--environment, -e)`
+Export an Application of a specific user (--owner, -o) in the environment specified by flag (--environment, -e)
+Export throttling policies, key managers or shared scopes of the environment specified by flag (--environment, -e)`
 
 const exportCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e dev
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIProductCmdLiteral + ` -n LeasingAPIProduct -e dev
-` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppCmdLiteral + ` -n SampleApp -o admin -e dev`
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppCmdLiteral + ` -n SampleApp -o admin -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPoliciesCmdLiteral + ` -e dev`
 
 // ExportCmd represents the export command
 var ExportCmd = &cobra.Command{
@@ -19,8 +19,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -75,6 +73,10 @@ var ExportAPICmd = &cobra.Command{
 
 func executeExportAPICmd(credential credentials.Credential, exportDirectory string) {
 	runningExportApiCommand = true
+	utils.SetResultResource("name", exportAPIName)
+	utils.SetResultResource("version", exportAPIVersion)
+	utils.SetResultResource("provider", exportProvider)
+	utils.SetResultResource("environment", CmdExportEnvironment)
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, CmdExportEnvironment)
 
 	if preCommandErr == nil {
@@ -85,19 +87,16 @@ func executeExportAPICmd(credential credentials.Credential, exportDirectory stri
 		}
 		// Print info on response
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
+		utils.SetResultHttpStatus(resp.StatusCode())
 		apiZipLocationPath := filepath.Join(exportDirectory, CmdExportEnvironment)
 		if resp.StatusCode() == http.StatusOK {
 			impl.WriteToZip(exportAPIName, exportAPIVersion, "", apiZipLocationPath, runningExportApiCommand, resp)
-		} else if resp.StatusCode() == http.StatusInternalServerError {
-			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
 		} else {
-			// neither 200 nor 500
-			fmt.Println("Error exporting API:", resp.Status(), "\n", string(resp.Body()))
+			utils.HandleErrorAndExit("Error exporting API", utils.NewHttpStatusError(resp))
 		}
 	} else {
 		// error exporting Api
-		fmt.Println("Error getting OAuth tokens while exporting API:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while exporting API", preCommandErr)
 	}
 }
 
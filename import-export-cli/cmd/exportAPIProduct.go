@@ -91,14 +91,14 @@ func executeExportAPIProductCmd(credential credentials.Credential, exportDirecto
 			impl.WriteAPIProductToZip(exportAPIProductName, exportAPIProductVersion, apiProductZipLocationPath, runningExportAPIProductCommand, resp)
 		} else if resp.StatusCode() == http.StatusInternalServerError {
 			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
+			fmt.Fprintln(utils.MessageWriter(), string(resp.Body()))
 		} else {
 			// neither 200 nor 500
-			fmt.Println("Error exporting API Product:", resp.Status(), "\n", string(resp.Body()))
+			fmt.Fprintln(utils.MessageWriter(), "Error exporting API Product:", resp.Status(), "\n", string(resp.Body()))
 		}
 	} else {
 		// error exporting API Product
-		fmt.Println("Error getting OAuth tokens while exporting API Product:" + preCommandErr.Error())
+		fmt.Fprintln(utils.MessageWriter(), "Error getting OAuth tokens while exporting API Product:"+preCommandErr.Error())
 	}
 }
 
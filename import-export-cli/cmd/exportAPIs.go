@@ -21,6 +21,7 @@ package cmd
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -32,17 +33,19 @@ const ExportAPIsCmdLiteral = "apis"
 const exportAPIsCmdShortDesc = "Export APIs for migration"
 
 const exportAPIsCmdLongDesc = "Export all the APIs of a tenant from one environment, to be imported " +
-	"into another environment"
+	"into another environment. The progress is recorded in a manifest, so an interrupted export is resumed from " +
+	"where it stopped when the command is executed again. The APIs failed to export are retried only with " +
+	"--retry-failed. Use --force to export the APIs from the beginning."
 const exportAPIsCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production --force
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production --concurrency 8
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production --retry-failed
 NOTE: The flag (--environment (-e)) is mandatory`
 
 var exportAPIsFormat string
 var exportAPIsAllRevisions bool
-
-//e.g. /home/samithac/.wso2apictl/exported/migration/production-2.5/wso2-dot-org
-var startFromBeginning bool
-var isProcessCompleted bool
+var exportAPIsRetryFailed bool
+var exportAPIsConcurrency int
 
 var ExportAPIsCmd = &cobra.Command{
 	Use: ExportAPIsCmdLiteral + " (--environment " +
@@ -52,42 +55,76 @@ var ExportAPIsCmd = &cobra.Command{
 	Example: exportAPIsCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + ExportAPIsCmdLiteral + " called")
+		utils.SetResultResource("environment", CmdExportEnvironment)
+		utils.SetResultResource("tenant", CmdResourceTenantDomain)
 		var artifactExportDirectory = filepath.Join(utils.ExportDirectory, utils.ExportedMigrationArtifactsDirName)
 
+		if CmdForceStartFromBegin && exportAPIsRetryFailed {
+			utils.HandleErrorAndExit("Invalid flags", utils.NewValidationError(
+				"--force and --retry-failed cannot be used together"))
+		}
+		if exportAPIsConcurrency < 1 {
+			utils.HandleErrorAndExit("Invalid value for --concurrency",
+				utils.NewValidationError("It should be a positive number"))
+		}
 		cred, err := GetCredentials(CmdExportEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
 		}
-		executeExportAPIsCmd(cred, artifactExportDirectory)
+		ExecuteExportAPIsCmd(cred, artifactExportDirectory, exportAPIsFormat, exportAPIPreserveStatus,
+			exportAPIsAllRevisions, exportAPIsRetryFailed, exportAPIsConcurrency)
 	},
 }
 
 // Do operations to export APIs for the migration into the directory passed as exportDirectory
 // <export_directory> is the patch defined in main_config.yaml
 // exportDirectory = <export_directory>/migration/
-func executeExportAPIsCmd(credential credentials.Credential, exportDirectory string) {
-	//create dir structure
-	apiExportDir := impl.CreateExportAPIsDirStructure(exportDirectory, CmdResourceTenantDomain, CmdExportEnvironment,
-		CmdForceStartFromBegin)
+func ExecuteExportAPIsCmd(credential credentials.Credential, exportDirectory, format string, preserveStatus,
+	allRevisions, retryFailed bool, concurrency int) {
 	exportRelatedFilesPath := filepath.Join(exportDirectory, CmdExportEnvironment,
 		utils.GetMigrationExportTenantDirName(CmdResourceTenantDomain))
 	//e.g. /home/samithac/.wso2apictl/exported/migration/production-2.5/wso2-dot-org
-	startFromBeginning = false
-	isProcessCompleted = false
-
-	fmt.Println("\nExporting APIs for the migration...")
 	if CmdForceStartFromBegin {
-		startFromBeginning = true
+		if err := impl.PrepareStartFromBeginning(exportRelatedFilesPath); err != nil {
+			utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
+				"exportation", err)
+		}
 	}
+	//create dir structure
+	apiExportDir := impl.CreateExportAPIsDirStructure(exportDirectory, CmdResourceTenantDomain, CmdExportEnvironment,
+		CmdForceStartFromBegin)
 
-	if (utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededApiFileName))) && !startFromBeginning {
-		impl.PrepareResumption(credential, exportRelatedFilesPath, CmdResourceTenantDomain, CmdUsername, CmdExportEnvironment)
-	} else {
-		impl.PrepareStartFromBeginning(credential, exportRelatedFilesPath, CmdResourceTenantDomain, CmdUsername, CmdExportEnvironment)
+	fmt.Fprintln(utils.MessageWriter(), "\nExporting APIs for the migration...")
+	summary, err := impl.ExportAPIs(credential, exportRelatedFilesPath, apiExportDir, CmdExportEnvironment,
+		CmdResourceTenantDomain, CmdUsername, format, preserveStatus, allRevisions, retryFailed, concurrency)
+	if err != nil {
+		utils.HandleErrorAndExit("Error exporting APIs", err)
+	}
+	utils.SetResultData(summary)
+	printExportAPIsSummary(summary)
+	if summary.Failed > 0 {
+		utils.HandleErrorAndExit(strconv.Itoa(summary.Failed)+" API(s) failed to export. Use --retry-failed to "+
+			"export them again", nil)
 	}
+}
 
-	impl.ExportAPIs(credential, exportRelatedFilesPath, CmdExportEnvironment, CmdResourceTenantDomain, exportAPIsFormat,
-		CmdUsername, apiExportDir, exportAPIPreserveStatus, runningExportApiCommand, exportAPIsAllRevisions)
+// printExportAPIsSummary prints the summary report of the export apis command
+func printExportAPIsSummary(summary *impl.ExportAPIsSummary) {
+	fmt.Fprintln(utils.MessageWriter(), "\nSummary:")
+	fmt.Fprintln(utils.MessageWriter(), "  Total APIs:            "+strconv.Itoa(summary.Total))
+	fmt.Fprintln(utils.MessageWriter(), "  Exported:              "+strconv.Itoa(summary.Succeeded)+" ("+
+		strconv.Itoa(summary.ExportedInThisRun)+" in this run, "+strconv.Itoa(summary.Files)+" archive(s))")
+	fmt.Fprintln(utils.MessageWriter(), "  Failed:                "+strconv.Itoa(summary.Failed))
+	if summary.Pending > 0 {
+		fmt.Fprintln(utils.MessageWriter(), "  Pending:               "+strconv.Itoa(summary.Pending))
+	}
+	for _, failure := range summary.FailedAPIs {
+		fmt.Fprintln(utils.MessageWriter(), "    "+failure.Name+" "+failure.Version+" of provider "+failure.Provider+" ("+
+			strconv.Itoa(failure.Attempts)+" attempt(s)): "+failure.Error)
+	}
+	fmt.Fprintln(utils.MessageWriter(), "API export path: "+summary.ExportDirectory)
+	fmt.Fprintln(utils.MessageWriter(), "Export manifest: "+summary.ManifestFile)
+	fmt.Fprintln(utils.MessageWriter(), "\nCommand: export-apis execution completed !")
 }
 
 func init() {
@@ -101,6 +138,10 @@ func init() {
 		"Preserve API status when exporting. Otherwise API will be exported in CREATED status")
 	ExportAPIsCmd.Flags().BoolVarP(&exportAPIsAllRevisions, "all", "", false,
 		"Export working copy and all revisions for the APIs in the environments ")
+	ExportAPIsCmd.Flags().BoolVarP(&exportAPIsRetryFailed, "retry-failed", "", false,
+		"Export only the APIs failed in the previous export")
+	ExportAPIsCmd.Flags().IntVarP(&exportAPIsConcurrency, "concurrency", "", utils.DefaultExportAPIsConcurrency,
+		"Maximum number of APIs to export at the same time")
 	ExportAPIsCmd.Flags().StringVarP(&exportAPIsFormat, "format", "", utils.DefaultExportFormat, "File format of exported archives(json or yaml)")
 	_ = ExportAPIsCmd.MarkFlagRequired("environment")
 }
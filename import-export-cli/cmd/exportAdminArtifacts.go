@@ -0,0 +1,157 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var exportAdminArtifactName string
+var exportPolicyTypes []string
+
+// ExportPolicies command related usage info
+const ExportPoliciesCmdLiteral = "policies"
+const exportPoliciesCmdShortDesc = "Export throttling policies"
+const exportPoliciesCmdLongDesc = `Export the advanced, application, subscription and custom throttling policies of the
+environment specified by the flag (--environment, -e). Use the flag (--type) to export only the policies of the given
+types. The policies are written to the policies directory of the export directory.`
+
+const exportPoliciesCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPoliciesCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPoliciesCmdLiteral + ` -e dev --type advanced --type subscription
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPoliciesCmdLiteral + ` -e dev --type advanced -n 10KPerMin
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// ExportKeyManagers command related usage info
+const ExportKeyManagersCmdLiteral = "key-managers"
+const exportKeyManagersCmdShortDesc = "Export key managers"
+const exportKeyManagersCmdLongDesc = `Export the key manager configurations of the environment specified by the flag
+(--environment, -e). The key managers are written to the key-managers directory of the export directory. The secrets
+of the key managers (such as client_secret) are not exported. They are replaced with keystore secret references
+(${secret:keystore:key_manager_<name>_<field>}), which are resolved when importing from the secrets created with
+"apictl secret create -o file", unless the params file gives the secrets.`
+
+const exportKeyManagersCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportKeyManagersCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportKeyManagersCmdLiteral + ` -e dev -n Okta
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// ExportScopes command related usage info
+const ExportScopesCmdLiteral = "scopes"
+const exportScopesCmdShortDesc = "Export shared scopes"
+const exportScopesCmdLongDesc = `Export the shared scopes of the environment specified by the flag (--environment, -e).
+The scopes are written to the scopes directory of the export directory.`
+
+const exportScopesCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportScopesCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportScopesCmdLiteral + ` -e dev -n admin_scope
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// ExportPoliciesCmd represents the export policies command
+var ExportPoliciesCmd = &cobra.Command{
+	Use:     ExportPoliciesCmdLiteral + " (--environment <environment-from-which-the-policies-should-be-exported>)",
+	Short:   exportPoliciesCmdShortDesc,
+	Long:    exportPoliciesCmdLongDesc,
+	Example: exportPoliciesCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportPoliciesCmdLiteral + " called")
+		executeExportAdminArtifactsCmd(impl.AdminArtifactTypeThrottlingPolicy, exportPolicyTypes,
+			utils.ExportedThrottlingPoliciesDirName)
+	},
+}
+
+// ExportKeyManagersCmd represents the export key-managers command
+var ExportKeyManagersCmd = &cobra.Command{
+	Use:     ExportKeyManagersCmdLiteral + " (--environment <environment-from-which-the-key-managers-should-be-exported>)",
+	Short:   exportKeyManagersCmdShortDesc,
+	Long:    exportKeyManagersCmdLongDesc,
+	Example: exportKeyManagersCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportKeyManagersCmdLiteral + " called")
+		executeExportAdminArtifactsCmd(impl.AdminArtifactTypeKeyManager, nil, utils.ExportedKeyManagersDirName)
+	},
+}
+
+// ExportScopesCmd represents the export scopes command
+var ExportScopesCmd = &cobra.Command{
+	Use:     ExportScopesCmdLiteral + " (--environment <environment-from-which-the-scopes-should-be-exported>)",
+	Short:   exportScopesCmdShortDesc,
+	Long:    exportScopesCmdLongDesc,
+	Example: exportScopesCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportScopesCmdLiteral + " called")
+		executeExportAdminArtifactsCmd(impl.AdminArtifactTypeScope, nil, utils.ExportedScopesDirName)
+	},
+}
+
+// executeExportAdminArtifactsCmd exports the admin artifacts of a type to <export-directory>/<dirName>/<environment>
+func executeExportAdminArtifactsCmd(artifactType string, subTypes []string, dirName string) {
+	utils.SetResultResource("environment", CmdExportEnvironment)
+	if exportAdminArtifactName != "" {
+		utils.SetResultResource("name", exportAdminArtifactName)
+	}
+	cred, err := GetCredentials(CmdExportEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting credentials", err)
+	}
+	accessToken, err := credentials.GetOAuthAdminAccessToken(cred, CmdExportEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting access token", err)
+	}
+
+	exportDirectory := filepath.Join(utils.ExportDirectory, dirName, CmdExportEnvironment)
+	files, err := impl.ExportAdminArtifacts(accessToken, CmdExportEnvironment, exportDirectory, artifactType,
+		subTypes, exportAdminArtifactName)
+	if err != nil {
+		utils.HandleErrorAndExit("Error exporting "+dirName, err)
+	}
+	utils.SetResultData(files)
+	fmt.Fprintln(utils.MessageWriter(), "Successfully exported "+strconv.Itoa(len(files))+" "+dirName+"!")
+	fmt.Fprintln(utils.MessageWriter(), "Find the exported "+dirName+" at "+exportDirectory)
+}
+
+// init using Cobra
+func init() {
+	ExportCmd.AddCommand(ExportPoliciesCmd)
+	ExportPoliciesCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e",
+		"", "Environment from which the throttling policies should be exported")
+	ExportPoliciesCmd.Flags().StringSliceVarP(&exportPolicyTypes, "type", "", impl.ThrottlingPolicyTypes,
+		"Types of the throttling policies to export (advanced, application, subscription or custom)")
+	ExportPoliciesCmd.Flags().StringVarP(&exportAdminArtifactName, "name", "n", "",
+		"Name of the throttling policy to export")
+	_ = ExportPoliciesCmd.MarkFlagRequired("environment")
+
+	ExportCmd.AddCommand(ExportKeyManagersCmd)
+	ExportKeyManagersCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e",
+		"", "Environment from which the key managers should be exported")
+	ExportKeyManagersCmd.Flags().StringVarP(&exportAdminArtifactName, "name", "n", "",
+		"Name of the key manager to export")
+	_ = ExportKeyManagersCmd.MarkFlagRequired("environment")
+
+	ExportCmd.AddCommand(ExportScopesCmd)
+	ExportScopesCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e",
+		"", "Environment from which the shared scopes should be exported")
+	ExportScopesCmd.Flags().StringVarP(&exportAdminArtifactName, "name", "n", "",
+		"Name of the shared scope to export")
+	_ = ExportScopesCmd.MarkFlagRequired("environment")
+}
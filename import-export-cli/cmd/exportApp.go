@@ -80,11 +80,11 @@ func executeExportAppCmd(credential credentials.Credential, appsExportDirectoryP
 		if resp.StatusCode() == http.StatusOK {
 			impl.WriteApplicationToZip(exportAppName, exportAppOwner, appsExportDirectoryPath, resp)
 		} else {
-			fmt.Println("Error " + string(resp.Body()))
+			fmt.Fprintln(utils.MessageWriter(), "Error "+string(resp.Body()))
 		}
 	} else {
 		// error exporting Application
-		fmt.Println("Error exporting Application:" + preCommandErr.Error())
+		fmt.Fprintln(utils.MessageWriter(), "Error exporting Application:"+preCommandErr.Error())
 	}
 }
 
@@ -200,8 +200,8 @@ func executeGenDeploymentDirCmd() error {
 		return err
 	}
 
-	fmt.Println("The deployment directory for " + genDeploymentDirSource + " file is generated at " +
-		deploymentDirParent + " directory")
+	fmt.Fprintln(utils.MessageWriter(), "The deployment directory for "+genDeploymentDirSource+" file is generated at "+
+		deploymentDirParent+" directory")
 
 	return nil
 }
@@ -243,7 +243,7 @@ var genDeploymentDirCmd = &cobra.Command{
 		if genDeploymentDirDestination != "" {
 			if stat, err := os.Stat(genDeploymentDirDestination); !os.IsNotExist(err) {
 				if !stat.IsDir() {
-					fmt.Printf("%s is not a directory\n", genDeploymentDirDestination)
+					fmt.Fprintf(utils.MessageWriter(), "%s is not a directory\n", genDeploymentDirDestination)
 					os.Exit(1)
 				}
 			}
@@ -19,6 +19,10 @@
 package cmd
 
 import (
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -29,11 +33,13 @@ const importCmdShortDesc = "Import an API/API Product/Application to an environm
 
 const importCmdLongDesc = `Import an API to the environment specified by flag (--environment, -e)
 Import an API Product to the environment specified by flag (--environment, -e)
-Import an Application to the environment specified by flag (--environment, -e)`
+Import an Application to the environment specified by flag (--environment, -e)
+Import throttling policies, key managers or shared scopes to the environment specified by flag (--environment, -e)`
 
 const importCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f qa/TwitterAPI.zip -e dev
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + importAPIProductCmdLiteral + ` -f qa/LeasingAPIProduct.zip -e dev
-` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f qa/apps/sampleApp.zip -e dev`
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f qa/apps/sampleApp.zip -e dev
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportPoliciesCmdLiteral + ` -f qa/policies -e dev`
 
 // ImportCmd represents the import command
 var ImportCmd = &cobra.Command{
@@ -51,3 +57,33 @@ var ImportCmd = &cobra.Command{
 func init() {
 	RootCmd.AddCommand(ImportCmd)
 }
+
+// addEnvSubstitutionFlags adds the flags which control the substitution of the environment variables of the projects
+func addEnvSubstitutionFlags(cmd *cobra.Command, substitutionReport *bool) {
+	cmd.Flags().StringVar(&utils.EnvSubstitutionMode, "substitution-mode", utils.EnvSubstitutionModeStrict,
+		"Fail if an environment variable without a default value is not set (strict) or leave it as it is (lenient)")
+	cmd.Flags().BoolVar(substitutionReport, "substitution-report", false, "Print the environment variables "+
+		"substituted in the project and params files with the source of their values")
+}
+
+// validateEnvSubstitutionMode exits if the substitution mode given by the flag is invalid
+func validateEnvSubstitutionMode() {
+	if utils.EnvSubstitutionMode != utils.EnvSubstitutionModeStrict &&
+		utils.EnvSubstitutionMode != utils.EnvSubstitutionModeLenient {
+		utils.HandleErrorAndExit("Invalid substitution mode", utils.NewValidationError("--substitution-mode "+
+			"should be "+utils.EnvSubstitutionModeStrict+" or "+utils.EnvSubstitutionModeLenient))
+	}
+}
+
+// printEnvSubstitutionReport prints the environment variables substituted during the import and returns them
+func printEnvSubstitutionReport() []utils.EnvSubstitution {
+	report := utils.GetEnvSubstitutionReport()
+	writer := tabwriter.NewWriter(utils.MessageWriter(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(writer, "FILE\tLINE\tVARIABLE\tSOURCE")
+	for _, substitution := range report {
+		fmt.Fprintln(writer, substitution.File+"\t"+strconv.Itoa(substitution.Line)+"\t"+substitution.Variable+"\t"+
+			substitution.Source)
+	}
+	_ = writer.Flush()
+	return report
+}
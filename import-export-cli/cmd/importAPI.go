@@ -19,6 +19,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -34,6 +36,8 @@ var (
 	importAPISkipCleanup         bool
 	importAPIRotateRevision      bool
 	importAPISkipDeployments     bool
+	importAPISkipValidation      bool
+	importAPISubstitutionReport  bool
 )
 
 const (
@@ -58,6 +62,9 @@ var ImportAPICmd = &cobra.Command{
 	Example: importAPICmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + ImportAPICmdLiteral + " called")
+		utils.SetResultResource("file", importAPIFile)
+		utils.SetResultResource("environment", importEnvironment)
+		validateEnvSubstitutionMode()
 		cred, err := GetCredentials(importEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
@@ -67,11 +74,16 @@ var ImportAPICmd = &cobra.Command{
 			utils.HandleErrorAndExit("Error while getting an access token for importing API", err)
 		}
 		err = impl.ImportAPIToEnv(accessOAuthToken, importEnvironment, importAPIFile, importAPIParamsFile, importAPIUpdate,
-			importAPICmdPreserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments)
+			importAPICmdPreserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments,
+			importAPISkipValidation)
 		if err != nil {
 			utils.HandleErrorAndExit("Error importing API", err)
 			return
 		}
+		if importAPISubstitutionReport {
+			utils.SetResultData(printEnvSubstitutionReport())
+		}
+		fmt.Fprintln(utils.MessageWriter(), "Successfully imported API.")
 	},
 }
 
@@ -92,8 +104,11 @@ func init() {
 		"the working copy and skip deployment steps in import")
 	ImportAPICmd.Flags().StringVarP(&importAPIParamsFile, "params", "", "", "Provide an API Manager params file "+
 		"or a directory generated using \"gen deployment-dir\" command")
+	ImportAPICmd.Flags().BoolVar(&importAPISkipValidation, "skip-validation", false, "Skip validating "+
+		"the API project before importing it")
 	ImportAPICmd.Flags().BoolVarP(&importAPISkipCleanup, "skip-cleanup", "", false, "Leave "+
 		"all temporary files created during import process")
+	addEnvSubstitutionFlags(ImportAPICmd, &importAPISubstitutionReport)
 	// Mark required flags
 	_ = ImportAPICmd.MarkFlagRequired("environment")
 	_ = ImportAPICmd.MarkFlagRequired("file")
@@ -19,6 +19,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -36,6 +38,8 @@ var (
 	importAPIProductSkipCleanup         bool
 	importAPIProductRotateRevision      bool
 	importAPIProductSkipDeployments     bool
+	importAPIProductSkipValidation      bool
+	importAPIProductSubstitutionReport  bool
 )
 
 const (
@@ -61,6 +65,7 @@ var ImportAPIProductCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + importAPIProductCmdLiteral + " called")
 
+		validateEnvSubstitutionMode()
 		cred, err := GetCredentials(importAPIProductEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
@@ -71,11 +76,15 @@ var ImportAPIProductCmd = &cobra.Command{
 		}
 		err = impl.ImportAPIProductToEnv(accessOAuthToken, importAPIProductEnvironment, importAPIProductFile, importAPIProductParamsFile,
 			importAPIs, importAPIsUpdate, importAPIProductUpdate, importAPIProductCmdPreserveProvider, importAPIProductSkipCleanup,
-			importAPIProductRotateRevision, importAPIProductSkipDeployments)
+			importAPIProductRotateRevision, importAPIProductSkipDeployments, importAPIProductSkipValidation)
 		if err != nil {
 			utils.HandleErrorAndExit("Error importing API Product", err)
 			return
 		}
+		if importAPIProductSubstitutionReport {
+			utils.SetResultData(printEnvSubstitutionReport())
+		}
+		fmt.Fprintln(utils.MessageWriter(), "Successfully imported API Product.")
 	},
 }
 
@@ -102,6 +111,9 @@ func init() {
 		"all temporary files created during import process")
 	ImportAPIProductCmd.Flags().BoolVar(&importAPIProductSkipDeployments, "skip-deployments", false, "Update only "+
 		"the working copy and skip deployment steps in import")
+	ImportAPIProductCmd.Flags().BoolVar(&importAPIProductSkipValidation, "skip-validation", false, "Skip validating "+
+		"the API Product project before importing it")
+	addEnvSubstitutionFlags(ImportAPIProductCmd, &importAPIProductSubstitutionReport)
 	// Mark required flags
 	_ = ImportAPIProductCmd.MarkFlagRequired("environment")
 	_ = ImportAPIProductCmd.MarkFlagRequired("file")
@@ -0,0 +1,174 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importAPIsSourceDir          string
+	importAPIsEnvironment        string
+	importAPIsParamsFile         string
+	importAPIsPreserveProvider   bool
+	importAPIsUpdateExisting     bool
+	importAPIsRotateRevision     bool
+	importAPIsSkipDeployments    bool
+	importAPIsSkipValidation     bool
+	importAPIsRetryFailed        bool
+	importAPIsForce              bool
+	importAPIsConcurrency        int
+	importAPIsSubstitutionReport bool
+)
+
+const (
+	// ImportAPIs command related usage info
+	ImportAPIsCmdLiteral   = "apis"
+	importAPIsCmdShortDesc = "Import APIs exported for migration"
+	importAPIsCmdLongDesc  = "Import all the APIs exported by \"export apis\" to an environment. The source (--file, -f) " +
+		"is the directory of the exported APIs of a tenant (or the apis directory inside it). The working copy and the " +
+		"revisions of an API are imported in order while different APIs are imported in parallel (--concurrency). " +
+		"The progress is recorded in a manifest inside the source directory, so an interrupted import is resumed " +
+		"from where it stopped when the command is executed again. The APIs failed to import are retried only with " +
+		"--retry-failed. Use --force to import all the APIs from the beginning."
+)
+
+const importAPIsCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` -f ~/.wso2apictl/exported/migration/production/tenant-default -e dev
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` -f ./tenant-default -e dev --params dev_params.yaml --update --concurrency 8
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` -f ./tenant-default -e dev --retry-failed
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
+
+// ImportAPIsCmd represents the import apis command
+var ImportAPIsCmd = &cobra.Command{
+	Use: ImportAPIsCmdLiteral + " --file <path-to-exported-apis> --environment " +
+		"<environment>",
+	Short:   importAPIsCmdShortDesc,
+	Long:    importAPIsCmdLongDesc,
+	Example: importAPIsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportAPIsCmdLiteral + " called")
+		utils.SetResultResource("file", importAPIsSourceDir)
+		utils.SetResultResource("environment", importAPIsEnvironment)
+		if importAPIsForce && importAPIsRetryFailed {
+			utils.HandleErrorAndExit("Invalid flags", utils.NewValidationError(
+				"--force and --retry-failed cannot be used together"))
+		}
+		validateEnvSubstitutionMode()
+		if importAPIsConcurrency < 1 {
+			utils.HandleErrorAndExit("Invalid value for --concurrency",
+				utils.NewValidationError("It should be a positive number"))
+		}
+		if info, err := os.Stat(importAPIsSourceDir); err != nil || !info.IsDir() {
+			utils.HandleErrorAndExit("Invalid value for --file", utils.NewValidationError(importAPIsSourceDir+
+				" is not a directory of exported APIs"))
+		}
+		executeImportAPIsCmd()
+	},
+}
+
+// executeImportAPIsCmd imports the exported APIs and prints the summary
+func executeImportAPIsCmd() {
+	cred, err := GetCredentials(importAPIsEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting credentials", err)
+	}
+	if importAPIsForce {
+		manifestPath := impl.GetImportAPIsManifestPath(importAPIsSourceDir, importAPIsEnvironment)
+		if err := utils.RemoveFileIfExists(manifestPath); err != nil {
+			utils.HandleErrorAndExit("Error removing the import manifest "+manifestPath, err)
+		}
+	}
+
+	options := impl.ImportAPIsOptions{
+		ParamsPath:       importAPIsParamsFile,
+		Update:           importAPIsUpdateExisting,
+		PreserveProvider: importAPIsPreserveProvider,
+		RotateRevision:   importAPIsRotateRevision,
+		SkipDeployments:  importAPIsSkipDeployments,
+		SkipValidation:   importAPIsSkipValidation,
+	}
+	summary, err := impl.ImportAPIs(cred, importAPIsEnvironment, filepath.Clean(importAPIsSourceDir), options,
+		importAPIsRetryFailed, importAPIsConcurrency)
+	if err != nil {
+		utils.HandleErrorAndExit("Error importing APIs", err)
+	}
+	utils.SetResultData(summary)
+	if importAPIsSubstitutionReport {
+		printEnvSubstitutionReport()
+	}
+	printImportAPIsSummary(summary)
+	if summary.Failed > 0 {
+		utils.HandleErrorAndExit(strconv.Itoa(summary.Failed)+" API archive(s) failed to import. Use --retry-failed "+
+			"to import them again", nil)
+	}
+}
+
+// printImportAPIsSummary prints the summary report of the import apis command
+func printImportAPIsSummary(summary *impl.ImportAPIsSummary) {
+	fmt.Fprintln(utils.MessageWriter(), "\nSummary:")
+	fmt.Fprintln(utils.MessageWriter(), "  Total API archives:    "+strconv.Itoa(summary.Total))
+	fmt.Fprintln(utils.MessageWriter(), "  Imported:              "+strconv.Itoa(summary.Succeeded)+" ("+
+		strconv.Itoa(summary.ImportedInThisRun)+" in this run)")
+	fmt.Fprintln(utils.MessageWriter(), "  Failed:                "+strconv.Itoa(summary.Failed))
+	if summary.Pending > 0 {
+		fmt.Fprintln(utils.MessageWriter(), "  Pending:               "+strconv.Itoa(summary.Pending))
+	}
+	for _, failure := range summary.FailedArchives {
+		fmt.Fprintln(utils.MessageWriter(), "    "+failure.File+" ("+strconv.Itoa(failure.Attempts)+" attempt(s)): "+failure.Error)
+	}
+	fmt.Fprintln(utils.MessageWriter(), "Import manifest: "+summary.ManifestFile)
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportAPIsCmd)
+	ImportAPIsCmd.Flags().StringVarP(&importAPIsSourceDir, "file", "f", "",
+		"Path to the directory of the APIs exported by export apis")
+	ImportAPIsCmd.Flags().StringVarP(&importAPIsEnvironment, "environment", "e",
+		"", "Environment to which the APIs should be imported")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsPreserveProvider, "preserve-provider", true,
+		"Preserve existing provider of the APIs after importing")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsUpdateExisting, "update", false, "Update the "+
+		"existing APIs or create new APIs")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsRotateRevision, "rotate-revision", false, "Rotate the "+
+		"revisions with each update")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsSkipDeployments, "skip-deployments", false, "Update only "+
+		"the working copy and skip deployment steps in import")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsSkipValidation, "skip-validation", false, "Skip validating "+
+		"the APIs before importing them")
+	ImportAPIsCmd.Flags().StringVarP(&importAPIsParamsFile, "params", "", "", "Provide an API Manager params file "+
+		"or a directory generated using \"gen deployment-dir\" command which is applied to all the APIs")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsRetryFailed, "retry-failed", false,
+		"Import only the APIs failed in the previous import")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsForce, "force", false,
+		"Discard the progress of the previous import and import all the APIs from the beginning")
+	addEnvSubstitutionFlags(ImportAPIsCmd, &importAPIsSubstitutionReport)
+	ImportAPIsCmd.Flags().IntVar(&importAPIsConcurrency, "concurrency", utils.DefaultImportAPIsConcurrency,
+		"Maximum number of APIs to import at the same time")
+	// Mark required flags
+	_ = ImportAPIsCmd.MarkFlagRequired("environment")
+	_ = ImportAPIsCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,151 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importAdminArtifactsFile        string
+	importAdminArtifactsEnvironment string
+	importAdminArtifactsParamsFile  string
+	importAdminArtifactsUpdate      bool
+)
+
+// ImportPolicies command related usage info
+const ImportPoliciesCmdLiteral = "policies"
+const importPoliciesCmdShortDesc = "Import throttling policies"
+const importPoliciesCmdLongDesc = `Import the throttling policies exported by "export policies" to the environment
+specified by the flag (--environment, -e). The source (--file, -f) is a policy file or a directory of policy files.
+Existing policies are updated only with the flag (--update).`
+
+const importPoliciesCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportPoliciesCmdLiteral + ` -f ~/.wso2apictl/exported/policies/dev -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportPoliciesCmdLiteral + ` -f ./policies/advanced/10KPerMin.yaml -e prod --update
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportPoliciesCmdLiteral + ` -f ./policies -e prod --params admin_params.yaml
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
+
+// ImportKeyManagers command related usage info
+const ImportKeyManagersCmdLiteral = "key-managers"
+const importKeyManagersCmdShortDesc = "Import key managers"
+const importKeyManagersCmdLongDesc = `Import the key managers exported by "export key-managers" to the environment
+specified by the flag (--environment, -e). The source (--file, -f) is a key manager file or a directory of key manager
+files. Existing key managers are updated only with the flag (--update).`
+
+const importKeyManagersCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportKeyManagersCmdLiteral + ` -f ~/.wso2apictl/exported/key-managers/dev -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportKeyManagersCmdLiteral + ` -f ./key-managers/Okta.yaml -e prod --params admin_params.yaml --update
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
+
+// ImportScopes command related usage info
+const ImportScopesCmdLiteral = "scopes"
+const importScopesCmdShortDesc = "Import shared scopes"
+const importScopesCmdLongDesc = `Import the shared scopes exported by "export scopes" to the environment specified by
+the flag (--environment, -e). The source (--file, -f) is a scope file or a directory of scope files. Existing scopes
+are updated only with the flag (--update).`
+
+const importScopesCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportScopesCmdLiteral + ` -f ~/.wso2apictl/exported/scopes/dev -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportScopesCmdLiteral + ` -f ./scopes/admin_scope.yaml -e prod --update
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
+
+// ImportPoliciesCmd represents the import policies command
+var ImportPoliciesCmd = &cobra.Command{
+	Use:     ImportPoliciesCmdLiteral + " --file <path-to-policies> --environment <environment>",
+	Short:   importPoliciesCmdShortDesc,
+	Long:    importPoliciesCmdLongDesc,
+	Example: importPoliciesCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportPoliciesCmdLiteral + " called")
+		executeImportAdminArtifactsCmd(impl.AdminArtifactTypeThrottlingPolicy, "throttling policies")
+	},
+}
+
+// ImportKeyManagersCmd represents the import key-managers command
+var ImportKeyManagersCmd = &cobra.Command{
+	Use:     ImportKeyManagersCmdLiteral + " --file <path-to-key-managers> --environment <environment>",
+	Short:   importKeyManagersCmdShortDesc,
+	Long:    importKeyManagersCmdLongDesc,
+	Example: importKeyManagersCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportKeyManagersCmdLiteral + " called")
+		executeImportAdminArtifactsCmd(impl.AdminArtifactTypeKeyManager, "key managers")
+	},
+}
+
+// ImportScopesCmd represents the import scopes command
+var ImportScopesCmd = &cobra.Command{
+	Use:     ImportScopesCmdLiteral + " --file <path-to-scopes> --environment <environment>",
+	Short:   importScopesCmdShortDesc,
+	Long:    importScopesCmdLongDesc,
+	Example: importScopesCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportScopesCmdLiteral + " called")
+		executeImportAdminArtifactsCmd(impl.AdminArtifactTypeScope, "shared scopes")
+	},
+}
+
+// executeImportAdminArtifactsCmd imports the admin artifacts of a type and prints the summary
+func executeImportAdminArtifactsCmd(artifactType, displayName string) {
+	utils.SetResultResource("file", importAdminArtifactsFile)
+	utils.SetResultResource("environment", importAdminArtifactsEnvironment)
+	cred, err := GetCredentials(importAdminArtifactsEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting credentials", err)
+	}
+	accessToken, err := credentials.GetOAuthAdminAccessToken(cred, importAdminArtifactsEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting access token", err)
+	}
+
+	summary, err := impl.ImportAdminArtifacts(accessToken, importAdminArtifactsEnvironment, importAdminArtifactsFile,
+		artifactType, importAdminArtifactsParamsFile, importAdminArtifactsUpdate)
+	if err != nil {
+		utils.HandleErrorAndExit("Error importing "+displayName, err)
+	}
+	utils.SetResultData(summary)
+	fmt.Fprintln(utils.MessageWriter(), "\nSummary:")
+	fmt.Fprintln(utils.MessageWriter(), "  Created: "+strconv.Itoa(len(summary.Created)))
+	fmt.Fprintln(utils.MessageWriter(), "  Updated: "+strconv.Itoa(len(summary.Updated)))
+	fmt.Fprintln(utils.MessageWriter(), "  Failed:  "+strconv.Itoa(len(summary.Failed)))
+	if len(summary.Failed) > 0 {
+		utils.HandleErrorAndExit(strconv.Itoa(len(summary.Failed))+" of the "+displayName+" failed to import", nil)
+	}
+}
+
+// init using Cobra
+func init() {
+	for _, command := range []*cobra.Command{ImportPoliciesCmd, ImportKeyManagersCmd, ImportScopesCmd} {
+		ImportCmd.AddCommand(command)
+		command.Flags().StringVarP(&importAdminArtifactsFile, "file", "f", "",
+			"Path to an exported file or a directory of exported files")
+		command.Flags().StringVarP(&importAdminArtifactsEnvironment, "environment", "e",
+			"", "Environment to which the artifacts should be imported")
+		command.Flags().StringVarP(&importAdminArtifactsParamsFile, "params", "", "",
+			"Provide a params file with the environment specific configurations of the artifacts")
+		command.Flags().BoolVar(&importAdminArtifactsUpdate, "update", false,
+			"Update the existing artifacts with the same name")
+		_ = command.MarkFlagRequired("file")
+		_ = command.MarkFlagRequired("environment")
+	}
+}
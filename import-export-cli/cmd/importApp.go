@@ -19,6 +19,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -33,6 +35,7 @@ var skipSubscriptions bool
 var importAppSkipKeys bool
 var importAppUpdateApplication bool
 var importAppSkipCleanup bool
+var importAppSkipValidation bool
 
 // ImportApp command related usage info
 const ImportAppCmdLiteral = "app"
@@ -68,10 +71,12 @@ func executeImportAppCmd(credential credentials.Credential) {
 		utils.HandleErrorAndExit("Error getting OAuth Tokens", err)
 	}
 	_, err = impl.ImportApplicationToEnv(accessToken, importAppEnvironment, importAppFile, importAppOwner,
-		importAppUpdateApplication, preserveOwner, skipSubscriptions, importAppSkipKeys, importAppSkipCleanup)
+		importAppUpdateApplication, preserveOwner, skipSubscriptions, importAppSkipKeys, importAppSkipCleanup,
+		importAppSkipValidation)
 	if err != nil {
 		utils.HandleErrorAndExit("Error importing Application", err)
 	}
+	fmt.Fprintln(utils.MessageWriter(), "Successfully imported Application.")
 }
 
 func init() {
@@ -92,6 +97,8 @@ func init() {
 		"Update the Application if it is already imported")
 	ImportAppCmd.Flags().BoolVarP(&importAppSkipCleanup, "skip-cleanup", "", false, "Leave "+
 		"all temporary files created during import process")
+	ImportAppCmd.Flags().BoolVar(&importAppSkipValidation, "skip-validation", false, "Skip validating "+
+		"the Application project before importing it")
 	_ = ImportAppCmd.MarkFlagRequired("file")
 	_ = ImportAppCmd.MarkFlagRequired("environment")
 }
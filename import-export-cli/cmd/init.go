@@ -33,6 +33,9 @@ import (
 var (
 	initCmdOutputDir         string
 	initCmdSwaggerPath       string
+	initCmdGraphQLPath       string
+	initCmdWSDLPath          string
+	initCmdAsyncAPIPath      string
 	initCmdApiDefinitionPath string
 	initCmdInitialState      string
 	initCmdForced            bool
@@ -41,31 +44,29 @@ var (
 const initCmdExample = `apictl init myapi --oas petstore.yaml
 apictl init Petstore --oas https://petstore.swagger.io/v2/swagger.json
 apictl init Petstore --oas https://petstore.swagger.io/v2/swagger.json --initial-state=PUBLISHED
-apictl init MyAwesomeAPI --oas ./swagger.yaml -d definition.yaml`
+apictl init MyAwesomeAPI --oas ./swagger.yaml -d definition.yaml
+apictl init PetstoreOAS3 --oas ./openapi.json
+apictl init StarWarsAPI --graphql ./schema.graphql
+apictl init PhoneVerification --wsdl ./phoneverify.wsdl
+apictl init CalculatorService --wsdl ./calculator.zip
+apictl init ChatAPI --asyncapi ./asyncapi.yaml`
+
+const initCmdLongDesc = `Initialize a new project in given path. If a OpenAPI specification provided API will be populated with details from it.
+Swagger 2.0, OpenAPI 3.0 and OpenAPI 3.1 specifications are supported.
+GraphQL, SOAP (pass-through) and asynchronous (WebSocket, SSE, WebSub) APIs can be initialized from a GraphQL schema,
+a WSDL document (or a zip archive of WSDL documents) and an AsyncAPI 2.x definition respectively`
 
 var InitCommand = &cobra.Command{
 	Use:     "init [project path]",
 	Short:   "Initialize a new project in given path",
-	Long:    "Initialize a new project in given path. If a OpenAPI specification provided API will be populated with details from it",
+	Long:    initCmdLongDesc,
 	Example: initCmdExample,
 	Args:    cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + "init called")
 		initCmdOutputDir = args[0]
 
-		// check for dir existence, if so stop it unless forced flag is present
-		if stat, err := os.Stat(initCmdOutputDir); !os.IsNotExist(err) {
-			fmt.Printf("%s already exists\n", initCmdOutputDir)
-			if !stat.IsDir() {
-				fmt.Printf("%s is not a directory\n", initCmdOutputDir)
-				os.Exit(1)
-			}
-			if !initCmdForced {
-				fmt.Println("Run with -f or --force to overwrite directory and create project")
-				os.Exit(1)
-			}
-			fmt.Println("Running command in forced mode")
-		}
+		checkInitProjectDirectory(initCmdOutputDir, initCmdForced)
 
 		// check the validity of initial-state before initializing
 		if initCmdInitialState != "" {
@@ -84,29 +85,82 @@ var InitCommand = &cobra.Command{
 			}
 		}
 
-		err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, initCmdApiDefinitionPath, false)
+		definitionType, definitionPath := getInitDefinition()
+		err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, definitionType, definitionPath,
+			initCmdApiDefinitionPath, false)
 		if err != nil {
-			utils.HandleErrorAndContinue("Error initializing project", err)
-			// Remove the already created project with its content since it is partially created and wrong
-			dir, err := filepath.Abs(initCmdOutputDir)
-			if err != nil {
-				utils.HandleErrorAndExit("Error retrieving file path of the project", err)
-			}
-			fmt.Println("Removing the project directory " + dir + " with its content")
-			err = os.RemoveAll(dir)
-			if err != nil {
-				utils.HandleErrorAndExit("Error removing project directory", err)
-			}
+			removeInitProjectDirectory(initCmdOutputDir, err)
 		}
 	},
 }
 
+// checkInitProjectDirectory checks for the existence of the project directory. Exits if it exists unless the forced
+// flag is present
+func checkInitProjectDirectory(projectDir string, forced bool) {
+	if stat, err := os.Stat(projectDir); !os.IsNotExist(err) {
+		fmt.Fprintf(utils.MessageWriter(), "%s already exists\n", projectDir)
+		if !stat.IsDir() {
+			fmt.Fprintf(utils.MessageWriter(), "%s is not a directory\n", projectDir)
+			os.Exit(1)
+		}
+		if !forced {
+			fmt.Fprintln(utils.MessageWriter(), "Run with -f or --force to overwrite directory and create project")
+			os.Exit(1)
+		}
+		fmt.Fprintln(utils.MessageWriter(), "Running command in forced mode")
+	}
+}
+
+// removeInitProjectDirectory removes the already created project with its content since it is partially created and
+// wrong, and exits with the error of initializing the project
+func removeInitProjectDirectory(projectDir string, initErr error) {
+	dir, err := filepath.Abs(projectDir)
+	if err != nil {
+		utils.HandleErrorAndExit("Error retrieving file path of the project", err)
+	}
+	fmt.Fprintln(utils.MessageWriter(), "Removing the project directory "+dir+" with its content")
+	err = os.RemoveAll(dir)
+	if err != nil {
+		utils.HandleErrorAndExit("Error removing project directory", err)
+	}
+	utils.HandleErrorAndExit("Error initializing project", initErr)
+}
+
+// getInitDefinition returns the type and the path of the definition given to populate the API. Exits if more than
+// one definition is given
+func getInitDefinition() (string, string) {
+	definitionType, definitionPath := impl.InitDefinitionTypeOAS, ""
+	definitions := map[string]string{
+		impl.InitDefinitionTypeOAS:      initCmdSwaggerPath,
+		impl.InitDefinitionTypeGraphQL:  initCmdGraphQLPath,
+		impl.InitDefinitionTypeWSDL:     initCmdWSDLPath,
+		impl.InitDefinitionTypeAsyncAPI: initCmdAsyncAPIPath,
+	}
+	for flag, path := range definitions {
+		if path == "" {
+			continue
+		}
+		if definitionPath != "" {
+			utils.HandleErrorAndExit("Only one of --oas, --graphql, --wsdl and --asyncapi can be given",
+				utils.NewValidationError("more than one definition is given"))
+		}
+		definitionType, definitionPath = flag, path
+	}
+	return definitionType, definitionPath
+}
+
 func init() {
 	RootCmd.AddCommand(InitCommand)
 	InitCommand.Flags().StringVarP(&initCmdApiDefinitionPath, "definition", "d", "", "Provide a "+
 		"YAML definition of API")
 	InitCommand.Flags().StringVarP(&initCmdSwaggerPath, "oas", "", "", "Provide an OpenAPI "+
 		"specification file for the API")
+	InitCommand.Flags().StringVar(&initCmdGraphQLPath, "graphql", "", "Provide a GraphQL SDL schema file "+
+		"for a GraphQL API")
+	InitCommand.Flags().StringVar(&initCmdWSDLPath, "wsdl", "", "Provide a WSDL file or a zip archive of WSDL "+
+		"files for a SOAP API")
+	InitCommand.Flags().StringVar(&initCmdAsyncAPIPath, "asyncapi", "", "Provide an AsyncAPI 2.x definition "+
+		"file for a WebSocket, SSE, WebSub or other asynchronous API")
 	InitCommand.Flags().StringVar(&initCmdInitialState, "initial-state", "", fmt.Sprintf("Provide the initial state "+
 		"of the API; Valid states: %v", utils.ValidInitialStates))
 	InitCommand.Flags().BoolVarP(&initCmdForced, "force", "f", false, "Force create project")
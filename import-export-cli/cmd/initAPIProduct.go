@@ -0,0 +1,72 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var initAPIProductCmdContext string
+var initAPIProductCmdAPIs []string
+var initAPIProductCmdOperations []string
+var initAPIProductCmdForced bool
+
+// InitAPIProduct command related usage Info
+const InitAPIProductCmdLiteral = "api-product"
+const initAPIProductCmdShortDesc = "Initialize a new API Product project in given path"
+const initAPIProductCmdLongDesc = `Initialize a new API Product project in given path using the operations of API projects (--api).
+All the operations of an API are included unless some of its operations are given with --operation in the format
+"<API name>:<VERB> <target>". The API projects are copied to the APIs directory of the project so that they can be
+imported with the API Product (--import-apis). The name of the directory is used as the name of the API Product.`
+const initAPIProductCmdExamples = utils.ProjectName + ` init ` + InitAPIProductCmdLiteral + ` LeasingProduct --api ./PizzaShackAPI --api ./PetstoreAPI
+` + utils.ProjectName + ` init ` + InitAPIProductCmdLiteral + ` LeasingProduct --api ./PizzaShackAPI --operation "PizzaShackAPI:GET /menu" --context /leasing`
+
+// InitAPIProductCmd represents the init api-product command
+var InitAPIProductCmd = &cobra.Command{
+	Use:     InitAPIProductCmdLiteral + " [project path] --api <api project path>...",
+	Short:   initAPIProductCmdShortDesc,
+	Long:    initAPIProductCmdLongDesc,
+	Example: initAPIProductCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + "init " + InitAPIProductCmdLiteral + " called")
+		projectDir := args[0]
+		checkInitProjectDirectory(projectDir, initAPIProductCmdForced)
+		err := impl.InitAPIProductProject(projectDir, initAPIProductCmdContext, initAPIProductCmdAPIs,
+			initAPIProductCmdOperations)
+		if err != nil {
+			removeInitProjectDirectory(projectDir, err)
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	InitCommand.AddCommand(InitAPIProductCmd)
+	InitAPIProductCmd.Flags().StringArrayVar(&initAPIProductCmdAPIs, "api", []string{},
+		"Path to an API project of which the operations are included in the API Product")
+	InitAPIProductCmd.Flags().StringArrayVar(&initAPIProductCmdOperations, "operation", []string{},
+		"Operation of an API to include in the format \"<API name>:<VERB> <target>\"")
+	InitAPIProductCmd.Flags().StringVar(&initAPIProductCmdContext, "context", "",
+		"Context of the API Product. /<name in lowercase> is used if not given")
+	InitAPIProductCmd.Flags().BoolVarP(&initAPIProductCmdForced, "force", "f", false, "Force create project")
+	_ = InitAPIProductCmd.MarkFlagRequired("api")
+}
@@ -0,0 +1,147 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var initAppCmdOwner string
+var initAppCmdThrottlingPolicy string
+var initAppCmdTokenType string
+var initAppCmdSubscriptions []string
+var initAppCmdKeyTypes []string
+var initAppCmdGrantTypes []string
+var initAppCmdCallbackURL string
+var initAppCmdKeyManager string
+var initAppCmdForced bool
+
+// Token types of the keys of an Application
+var initAppTokenTypes = []string{"JWT", "OAUTH"}
+
+// Types of the keys of an Application
+var initAppKeyTypes = []string{"PRODUCTION", "SANDBOX"}
+
+// InitApp command related usage Info
+const InitAppCmdLiteral = "app"
+const initAppCmdShortDesc = "Initialize a new Application project in given path"
+const initAppCmdLongDesc = `Initialize a new Application project in given path with its subscriptions (--subscription) in the format
+"[<provider>/]<API name>:<version>[:<throttling policy>]". The name of the directory is used as the name of the
+Application. The keys of the Application are not included in the project, so the generated application_params.yaml
+skips the keys when importing. The settings of the keys (--key-type, --grant-type, --callback-url and --key-manager)
+are added to application_params.yaml instead, and the keys are generated or updated with them when the project is
+deployed with "vcs deploy".`
+const initAppCmdExamples = utils.ProjectName + ` init ` + InitAppCmdLiteral + ` PizzaApp --subscription PizzaShackAPI:1.0.0
+` + utils.ProjectName + ` init ` + InitAppCmdLiteral + ` PizzaApp --owner devops --token-type OAUTH --subscription "admin/PizzaShackAPI:1.0.0:Gold" --subscription LeasingProduct:1.0.0
+` + utils.ProjectName + ` init ` + InitAppCmdLiteral + ` PizzaApp --key-type PRODUCTION --key-type SANDBOX --grant-type client_credentials --grant-type authorization_code --callback-url https://localhost/callback`
+
+// InitAppCmd represents the init app command
+var InitAppCmd = &cobra.Command{
+	Use:     InitAppCmdLiteral + " [project path]",
+	Short:   initAppCmdShortDesc,
+	Long:    initAppCmdLongDesc,
+	Example: initAppCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + "init " + InitAppCmdLiteral + " called")
+		projectDir := args[0]
+		if initAppCmdTokenType != "" && !containsIgnoreCase(initAppTokenTypes, initAppCmdTokenType) {
+			utils.HandleErrorAndExit("Invalid token type", utils.NewValidationError(fmt.Sprintf(
+				"%s is not one of %v", initAppCmdTokenType, initAppTokenTypes)))
+		}
+		keys := getInitAppKeyParams()
+		checkInitProjectDirectory(projectDir, initAppCmdForced)
+		err := impl.InitApplicationProject(projectDir, initAppCmdOwner, initAppCmdThrottlingPolicy,
+			initAppCmdTokenType, initAppCmdSubscriptions, keys)
+		if err != nil {
+			removeInitProjectDirectory(projectDir, err)
+		}
+	},
+}
+
+// getInitAppKeyParams returns the settings of the keys given with the flags. The PRODUCTION key is used if the other
+// key settings are given without a key type. Exits if a key type is invalid
+func getInitAppKeyParams() []params.ApplicationKeyParams {
+	keyTypes := initAppCmdKeyTypes
+	if len(keyTypes) == 0 {
+		if len(initAppCmdGrantTypes) == 0 && initAppCmdCallbackURL == "" && initAppCmdKeyManager == "" {
+			return nil
+		}
+		keyTypes = []string{"PRODUCTION"}
+	}
+	grantTypes := initAppCmdGrantTypes
+	if len(grantTypes) == 0 {
+		grantTypes = []string{utils.DefaultKeyGrantType}
+	}
+	keyManager := initAppCmdKeyManager
+	if keyManager == "" {
+		keyManager = utils.DefaultKeyManager
+	}
+	var keys []params.ApplicationKeyParams
+	for _, keyType := range keyTypes {
+		if !containsIgnoreCase(initAppKeyTypes, keyType) {
+			utils.HandleErrorAndExit("Invalid key type", utils.NewValidationError(fmt.Sprintf(
+				"%s is not one of %v", keyType, initAppKeyTypes)))
+		}
+		keys = append(keys, params.ApplicationKeyParams{
+			KeyType:     strings.ToUpper(keyType),
+			KeyManager:  keyManager,
+			GrantTypes:  grantTypes,
+			CallbackURL: initAppCmdCallbackURL,
+		})
+	}
+	return keys
+}
+
+// containsIgnoreCase returns whether the values contain the value ignoring the case
+func containsIgnoreCase(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// init using Cobra
+func init() {
+	InitCommand.AddCommand(InitAppCmd)
+	InitAppCmd.Flags().StringArrayVar(&initAppCmdSubscriptions, "subscription", []string{},
+		"Subscription in the format \"[<provider>/]<API name>:<version>[:<throttling policy>]\"")
+	InitAppCmd.Flags().StringVar(&initAppCmdOwner, "owner", "", "Owner of the Application (default \"admin\")")
+	InitAppCmd.Flags().StringVar(&initAppCmdThrottlingPolicy, "throttling-policy", "",
+		"Application level throttling policy (default \"Unlimited\")")
+	InitAppCmd.Flags().StringVar(&initAppCmdTokenType, "token-type", "", fmt.Sprintf("Type of the tokens "+
+		"generated for the keys; Valid types: %v (default \"JWT\")", initAppTokenTypes))
+	InitAppCmd.Flags().StringArrayVar(&initAppCmdKeyTypes, "key-type", []string{}, fmt.Sprintf("Type of the keys "+
+		"generated when deploying; Valid types: %v (default \"PRODUCTION\" if the other key settings are given)",
+		initAppKeyTypes))
+	InitAppCmd.Flags().StringArrayVar(&initAppCmdGrantTypes, "grant-type", []string{},
+		"Grant type supported by the keys (default \""+utils.DefaultKeyGrantType+"\")")
+	InitAppCmd.Flags().StringVar(&initAppCmdCallbackURL, "callback-url", "", "Callback URL of the keys")
+	InitAppCmd.Flags().StringVar(&initAppCmdKeyManager, "key-manager", "",
+		"Key manager of the keys (default \""+utils.DefaultKeyManager+"\")")
+	InitAppCmd.Flags().BoolVarP(&initAppCmdForced, "force", "f", false, "Force create project")
+}
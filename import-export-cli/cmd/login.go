@@ -54,22 +54,22 @@ var loginCmd = &cobra.Command{
 		environment := args[0]
 
 		if loginPassword != "" {
-			fmt.Println("Warning: Using --password in CLI is not secure. Use --password-stdin")
+			fmt.Fprintln(utils.MessageWriter(), "Warning: Using --password in CLI is not secure. Use --password-stdin")
 			if loginPasswordStdin {
-				fmt.Println("--password and --password-stdin are mutual exclusive")
+				fmt.Fprintln(utils.MessageWriter(), "--password and --password-stdin are mutual exclusive")
 				os.Exit(1)
 			}
 		}
 
 		if loginPasswordStdin {
 			if loginUsername == "" {
-				fmt.Println("An username is required to use password-stdin")
+				fmt.Fprintln(utils.MessageWriter(), "An username is required to use password-stdin")
 				os.Exit(1)
 			}
 
 			data, err := ioutil.ReadAll(os.Stdin)
 			if err != nil {
-				fmt.Println(err)
+				fmt.Fprintln(utils.MessageWriter(), err)
 				os.Exit(1)
 			}
 
@@ -78,12 +78,12 @@ var loginCmd = &cobra.Command{
 
 		store, err := credentials.GetDefaultCredentialStore()
 		if err != nil {
-			fmt.Println("Error occurred while loading credential store : ", err)
+			fmt.Fprintln(utils.MessageWriter(), "Error occurred while loading credential store : ", err)
 			os.Exit(1)
 		}
 		err = runLogin(store, environment, loginUsername, loginPassword)
 		if err != nil {
-			fmt.Println("Error occurred while login : ", err)
+			fmt.Fprintln(utils.MessageWriter(), "Error occurred while login : ", err)
 			os.Exit(1)
 		}
 	},
@@ -91,12 +91,12 @@ var loginCmd = &cobra.Command{
 
 func runLogin(store credentials.Store, environment, username, password string) error {
 	if !utils.APIMExistsInEnv(environment, utils.MainConfigFilePath) {
-		fmt.Println("APIM does not exists in", environment, "Add it using add env")
+		fmt.Fprintln(utils.MessageWriter(), "APIM does not exists in", environment, "Add it using add env")
 		os.Exit(1)
 	}
 
 	if username == "" {
-		fmt.Print("Username:")
+		fmt.Fprint(utils.MessageWriter(), "Username:")
 		scanner := bufio.NewScanner(os.Stdin)
 		if scanner.Scan() {
 			username = scanner.Text()
@@ -104,13 +104,13 @@ func runLogin(store credentials.Store, environment, username, password string) e
 	}
 
 	if password == "" {
-		fmt.Print("Password:")
+		fmt.Fprint(utils.MessageWriter(), "Password:")
 		pass, err := terminal.ReadPassword(int(syscall.Stdin))
 		if err != nil {
 			return err
 		}
 		password = string(pass)
-		fmt.Println()
+		fmt.Fprintln(utils.MessageWriter())
 	}
 
 	registrationEndpoint := utils.GetRegistrationEndpointOfEnv(environment, utils.MainConfigFilePath)
@@ -119,7 +119,7 @@ func runLogin(store credentials.Store, environment, username, password string) e
 		return err
 	}
 
-	fmt.Println("Logged into APIM in", environment, "environment")
+	fmt.Fprintln(utils.MessageWriter(), "Logged into APIM in", environment, "environment")
 	err = store.SetAPIMCredentials(environment, username, password, clientId, clientSecret)
 	if err != nil {
 		return err
@@ -137,18 +137,18 @@ func GetCredentials(env string) (credentials.Credential, error) {
 	}
 
 	if !utils.APIMExistsInEnv(env, utils.MainConfigFilePath) {
-		fmt.Println("APIM does not exists in", env, "Add it using add env")
+		fmt.Fprintln(utils.MessageWriter(), "APIM does not exists in", env, "Add it using add env")
 		os.Exit(1)
 	}
 
 	// check for creds
 	if !store.HasAPIM(env) {
-		fmt.Println("Login to APIM in", env)
+		fmt.Fprintln(utils.MessageWriter(), "Login to APIM in", env)
 		err = runLogin(store, env, "", "")
 		if err != nil {
 			return credentials.Credential{}, err
 		}
-		fmt.Println()
+		fmt.Fprintln(utils.MessageWriter())
 	}
 	cred, err := store.GetAPIMCredentials(env)
 	if err != nil {
@@ -42,26 +42,39 @@ var logoutCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		err := runLogout(args[0])
 		if err != nil {
-			fmt.Println(err.Error())
+			fmt.Fprintln(utils.MessageWriter(), err.Error())
 			os.Exit(1)
 		}
 	},
 }
 
 func runLogout(environment string) error {
-	cred, err := GetCredentials(environment)
-	//Get current access token for
-	accessToken, err := credentials.GetOAuthAccessToken(cred, environment)
-	error := credentials.RevokeAccessToken(cred, environment, accessToken)
-	if error != nil {
+	store, err := credentials.GetDefaultCredentialStore()
+	if err != nil {
 		return err
 	}
-	store, err := credentials.GetDefaultCredentialStore()
+	cred, err := store.GetAPIMCredentials(environment)
+	if err != nil {
+		return err
+	}
+	// revoke the access tokens cached in the store instead of issuing a new token to revoke
+	tokens, err := store.GetAPIMTokens(environment)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		err = credentials.RevokeAccessToken(cred, environment, token.AccessToken)
+		if err != nil {
+			// the credentials are removed even if the token cannot be revoked, so the user can still log out
+			utils.HandleErrorAndContinue("Error revoking the access token of "+environment, err)
+		}
+	}
+	err = store.EraseAPIM(environment)
 	if err != nil {
 		return err
 	}
-	fmt.Println("Logged out from APIM in ", environment, " environment")
-	return store.EraseAPIM(environment)
+	fmt.Fprintln(utils.MessageWriter(), "Logged out from APIM in ", environment, " environment")
+	return nil
 }
 
 // init using Cobra
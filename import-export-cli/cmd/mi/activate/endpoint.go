@@ -25,6 +25,7 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var activateEndpointCmdEnvironment string
@@ -55,6 +56,12 @@ func handleActivateEndpointCmdArguments(args []string) {
 }
 
 func executeActivateEndpoint(endpointName string) {
+	if utils.IsMIClusterEnv(activateEndpointCmdEnvironment, utils.MainConfigFilePath) {
+		impl.PrintNodeResults(impl.InvokeNodes(activateEndpointCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.ActivateEndpoint(nodeEnv, endpointName)
+		}))
+		return
+	}
 	resp, err := impl.ActivateEndpoint(activateEndpointCmdEnvironment, endpointName)
 	if err != nil {
 		printErrorForArtifact(artifactEndpoint, endpointName, err)
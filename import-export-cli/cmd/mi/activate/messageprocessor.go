@@ -25,6 +25,7 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var activateMessageProcessorCmdEnvironment string
@@ -55,6 +56,12 @@ func handleActivateMessageProcessorCmdArguments(args []string) {
 }
 
 func executeActivateMessageProcessor(messageProcessorName string) {
+	if utils.IsMIClusterEnv(activateMessageProcessorCmdEnvironment, utils.MainConfigFilePath) {
+		impl.PrintNodeResults(impl.InvokeNodes(activateMessageProcessorCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.ActivateMessageProcessor(nodeEnv, messageProcessorName)
+		}))
+		return
+	}
 	resp, err := impl.ActivateMessageProcessor(activateMessageProcessorCmdEnvironment, messageProcessorName)
 	if err != nil {
 		printErrorForArtifact(artifactMessageProcessor, messageProcessorName, err)
@@ -25,6 +25,7 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var activateProxyCmdEnvironment string
@@ -55,6 +56,12 @@ func handleActivateProxyCmdArguments(args []string) {
 }
 
 func executeActivateProxy(proxyName string) {
+	if utils.IsMIClusterEnv(activateProxyCmdEnvironment, utils.MainConfigFilePath) {
+		impl.PrintNodeResults(impl.InvokeNodes(activateProxyCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.ActivateProxy(nodeEnv, proxyName)
+		}))
+		return
+	}
 	resp, err := impl.ActivateProxy(activateProxyCmdEnvironment, proxyName)
 	if err != nil {
 		printErrorForArtifact(artifactProxy, proxyName, err)
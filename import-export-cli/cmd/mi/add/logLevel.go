@@ -63,6 +63,12 @@ func handleAddLogLevelCmdArguments(args []string) {
 }
 
 func executeAddNewLogger(loggerName, logClass, logLevel string) {
+	if utils.IsMIClusterEnv(addLogLevelCmdEnvironment, utils.MainConfigFilePath) {
+		impl.PrintNodeResults(impl.InvokeNodes(addLogLevelCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.AddMILogger(nodeEnv, loggerName, logClass, logLevel)
+		}))
+		return
+	}
 	resp, err := impl.AddMILogger(addLogLevelCmdEnvironment, loggerName, logClass, logLevel)
 	if err != nil {
 		fmt.Println(utils.LogPrefixError+"Adding new logger [ "+loggerName+" ] ", err)
@@ -0,0 +1,153 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package apply
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var applyCmdEnvironment string
+var applyCmdFile string
+var applyCmdDryRun bool
+
+const applyCmdLiteral = "apply"
+const applyCmdShortDesc = "Apply the desired state of artifacts and loggers to a Micro Integrator"
+
+const applyCmdLongDesc = `Apply the desired state of endpoints, message processors, proxy services and loggers declared in the file specified by
+the flag --file, -f to a Micro Integrator in the environment specified by the flag --environment, -e
+The artifacts are activated or deactivated and the log levels are updated only where they differ from the desired
+state. A logger which does not exist is added if its class is given. Nothing is changed if any artifact or logger in
+the file cannot be read. The changes are listed without being applied if the flag --dry-run is given
+
+Example file:
+  endpoints:
+    GrandOakEndpoint: inactive
+  messageProcessors:
+    JMSMessageProcessor: inactive
+  proxyServices:
+    StockQuoteProxy: active
+  loggers:
+    synapse-api:
+      level: DEBUG
+    org-apache-coyote:
+      level: WARN
+      class: org.apache.coyote`
+
+var applyCmdExamples = "To list the changes without applying them\n" +
+	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + applyCmdLiteral + " -f ops-state.yaml -e prod --dry-run\n" +
+	"To apply the changes\n" +
+	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + applyCmdLiteral + " -f ops-state.yaml -e prod\n" +
+	"NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory"
+
+// ApplyCmd represents the apply command
+var ApplyCmd = &cobra.Command{
+	Use:     applyCmdLiteral,
+	Short:   applyCmdShortDesc,
+	Long:    applyCmdLongDesc,
+	Example: applyCmdExamples,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + applyCmdLiteral + " called")
+		state, err := impl.LoadMIOperationalState(applyCmdFile)
+		if err != nil {
+			utils.HandleErrorAndExit("Error reading "+applyCmdFile, utils.NewValidationError(err.Error()))
+		}
+		credentials.HandleMissingCredentials(applyCmdEnvironment)
+		executeApplyCmd(state)
+	},
+}
+
+func init() {
+	ApplyCmd.Flags().StringVarP(&applyCmdEnvironment, "environment", "e", "", "Environment of the micro integrator to which the state should be applied")
+	ApplyCmd.Flags().StringVarP(&applyCmdFile, "file", "f", "", "File declaring the desired state of the artifacts and the loggers")
+	ApplyCmd.Flags().BoolVarP(&applyCmdDryRun, "dry-run", "", false, "List the changes without applying them")
+	ApplyCmd.MarkFlagRequired("environment")
+	ApplyCmd.MarkFlagRequired("file")
+}
+
+func executeApplyCmd(state *impl.MIOperationalState) {
+	utils.SetResultResource("environment", applyCmdEnvironment)
+	utils.SetResultResource("file", applyCmdFile)
+	utils.SetResultResource("dryRun", strconv.FormatBool(applyCmdDryRun))
+	if utils.IsMIClusterEnv(applyCmdEnvironment, utils.MainConfigFilePath) {
+		executeApplyCmdOnNodes(state)
+		return
+	}
+	changes, failed, err := applyState(applyCmdEnvironment, state)
+	if err != nil {
+		utils.HandleErrorAndExit("Error applying "+applyCmdFile+" to environment "+applyCmdEnvironment, err)
+	}
+	utils.SetResultData(changes)
+	if !utils.IsJSONOutput() {
+		impl.PrintMIStateChanges(changes, applyCmdDryRun)
+	}
+	if failed > 0 {
+		utils.HandleErrorAndExit("Error applying "+applyCmdFile+" to environment "+applyCmdEnvironment,
+			fmt.Errorf("%d of %d changes failed", failed, len(changes)))
+	}
+}
+
+// executeApplyCmdOnNodes applies the state to each node of a cluster separately as the nodes may have drifted apart
+func executeApplyCmdOnNodes(state *impl.MIOperationalState) {
+	results := impl.InvokeNodes(applyCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+		changes, _, err := applyState(nodeEnv, state)
+		if err != nil {
+			return nil, err
+		}
+		return changes, nil
+	})
+	nodeChanges := make(map[string]interface{})
+	for _, result := range results {
+		nodeChanges[result.Node.Name] = result.Value
+	}
+	utils.SetResultData(nodeChanges)
+	if !utils.IsJSONOutput() {
+		impl.PrintNodeDetails(results, func(value interface{}) {
+			impl.PrintMIStateChanges(value.([]impl.MIStateChange), applyCmdDryRun)
+		}, func(err error) {
+			fmt.Println(utils.LogPrefixError + err.Error())
+		})
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			utils.HandleErrorAndExit("Error applying "+applyCmdFile+" to node "+result.Node.Name, result.Err)
+		}
+		for _, change := range result.Value.([]impl.MIStateChange) {
+			if change.Error != "" {
+				utils.HandleErrorAndExit("Error applying "+applyCmdFile+" to node "+result.Node.Name,
+					errors.New(change.ArtifactType+"/"+change.Name+": "+change.Error))
+			}
+		}
+	}
+}
+
+// applyState plans the changes required by the state and applies them unless it is a dry run
+func applyState(env string, state *impl.MIOperationalState) ([]impl.MIStateChange, int, error) {
+	changes, err := impl.PlanMIOperationalState(env, state)
+	if err != nil || applyCmdDryRun {
+		return changes, 0, err
+	}
+	return changes, impl.ApplyMIStateChanges(env, changes), nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var deactivateEndpointCmdEnvironment string
@@ -55,6 +56,12 @@ func handleDeactivateEndpointCmdArguments(args []string) {
 }
 
 func executeDeactivateEndpoint(endpointName string) {
+	if utils.IsMIClusterEnv(deactivateEndpointCmdEnvironment, utils.MainConfigFilePath) {
+		impl.PrintNodeResults(impl.InvokeNodes(deactivateEndpointCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.DeactivateEndpoint(nodeEnv, endpointName)
+		}))
+		return
+	}
 	resp, err := impl.DeactivateEndpoint(deactivateEndpointCmdEnvironment, endpointName)
 	if err != nil {
 		printErrorForArtifact(artifactEndpoint, endpointName, err)
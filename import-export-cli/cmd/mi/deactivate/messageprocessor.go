@@ -25,6 +25,7 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var deactivateMessageProcessorCmdEnvironment string
@@ -55,6 +56,12 @@ func handleDeactivateMessageProcessorCmdArguments(args []string) {
 }
 
 func executeDeactivateMessageProcessor(messageProcessorName string) {
+	if utils.IsMIClusterEnv(deactivateMessageProcessorCmdEnvironment, utils.MainConfigFilePath) {
+		impl.PrintNodeResults(impl.InvokeNodes(deactivateMessageProcessorCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.DeactivateMessageProcessor(nodeEnv, messageProcessorName)
+		}))
+		return
+	}
 	resp, err := impl.DeactivateMessageProcessor(deactivateMessageProcessorCmdEnvironment, messageProcessorName)
 	if err != nil {
 		printErrorForArtifact(artifactMessageProcessor, messageProcessorName, err)
@@ -25,6 +25,7 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var deactivateProxyCmdEnvironment string
@@ -55,6 +56,12 @@ func handleDeactivateProxyCmdArguments(args []string) {
 }
 
 func executeDeactivateProxy(proxyName string) {
+	if utils.IsMIClusterEnv(deactivateProxyCmdEnvironment, utils.MainConfigFilePath) {
+		impl.PrintNodeResults(impl.InvokeNodes(deactivateProxyCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.DeactivateProxy(nodeEnv, proxyName)
+		}))
+		return
+	}
 	resp, err := impl.DeactivateProxy(deactivateProxyCmdEnvironment, proxyName)
 	if err != nil {
 		printErrorForArtifact(artifactProxy, proxyName, err)
@@ -0,0 +1,121 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package deploy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var deployCappCmdEnvironment string
+var deployCappCmdForce bool
+var deployCappCmdTimeout time.Duration
+var deployCappCmdInterval time.Duration
+
+const deployCappCmdLiteral = "capp [car-file]"
+const deployCappCmdShortDesc = "Deploy a Carbon App in a Micro Integrator"
+
+const deployCappCmdLongDesc = `Deploy the Carbon App (CAR file) specified by the command line argument [car-file] in a Micro Integrator in the
+environment specified by the flag --environment, -e
+A deployed Carbon App with the same name is replaced if its version is older. The same version is not redeployed and a
+newer version is not downgraded unless the flag --force is given. The command waits until the Carbon App and its
+artifacts are deployed. If the deployment fails, the new Carbon App is removed and the previous version is deployed
+again. Hence a deployed Carbon App which was not deployed with ` + utils.ProjectName + ` is not replaced unless the flag
+--force is given, as it cannot be deployed again`
+
+var deployCappCmdExamples = "To deploy a Carbon App\n" +
+	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + deployCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(deployCappCmdLiteral) + " HealthcareCompositeExporter_1.0.0.car -e dev\n" +
+	"To redeploy or downgrade a Carbon App\n" +
+	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + deployCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(deployCappCmdLiteral) + " HealthcareCompositeExporter_1.0.0.car --force -e dev\n" +
+	"NOTE: The flag (--environment (-e)) is mandatory"
+
+var deployCappCmd = &cobra.Command{
+	Use:     deployCappCmdLiteral,
+	Short:   deployCappCmdShortDesc,
+	Long:    deployCappCmdLongDesc,
+	Example: deployCappCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		handleDeployCappCmdArguments(args)
+	},
+}
+
+func init() {
+	DeployCmd.AddCommand(deployCappCmd)
+	deployCappCmd.Flags().StringVarP(&deployCappCmdEnvironment, "environment", "e", "", "Environment of the micro integrator in which the Carbon App should be deployed")
+	deployCappCmd.Flags().BoolVarP(&deployCappCmdForce, "force", "", false, "Redeploy the same version, downgrade a newer version or replace a Carbon App not deployed with apictl")
+	deployCappCmd.Flags().DurationVarP(&deployCappCmdTimeout, "timeout", "", 2*time.Minute, "Maximum time to wait until the Carbon App is deployed")
+	deployCappCmd.Flags().DurationVarP(&deployCappCmdInterval, "interval", "", 2*time.Second, "Time between two checks of the deployed Carbon Apps")
+	deployCappCmd.MarkFlagRequired("environment")
+}
+
+func handleDeployCappCmdArguments(args []string) {
+	printDeployCmdVerboseLog(miUtils.GetTrimmedCmdLiteral(deployCappCmdLiteral))
+	if deployCappCmdTimeout <= 0 || deployCappCmdInterval <= 0 {
+		utils.HandleErrorAndExit("Invalid value for --timeout or --interval", utils.NewValidationError("the "+
+			"timeout and the interval should be greater than zero"))
+	}
+	app, err := impl.ReadCarbonApp(args[0])
+	if err != nil {
+		utils.HandleErrorAndExit("Error reading Carbon App "+args[0], utils.NewValidationError(err.Error()))
+	}
+	credentials.HandleMissingCredentials(deployCappCmdEnvironment)
+	executeDeployCapp(app)
+}
+
+func executeDeployCapp(app *impl.CarbonApp) {
+	utils.SetResultResource("name", app.Name)
+	utils.SetResultResource("version", app.Version)
+	utils.SetResultResource("environment", deployCappCmdEnvironment)
+	options := impl.CarbonAppDeployOptions{
+		Force:    deployCappCmdForce,
+		Timeout:  deployCappCmdTimeout,
+		Interval: deployCappCmdInterval,
+	}
+	if utils.IsMIClusterEnv(deployCappCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(deployCappCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.DeployCarbonApp(nodeEnv, app, options)
+		})
+		impl.PrintNodeResults(results)
+		for _, result := range results {
+			if result.Err != nil {
+				utils.HandleErrorAndExit("Error deploying Carbon App "+app.Name+" in node "+result.Node.Name, result.Err)
+			}
+		}
+		saveDeployedCapp(app)
+		return
+	}
+	resp, err := impl.DeployCarbonApp(deployCappCmdEnvironment, app, options)
+	if err != nil {
+		utils.HandleErrorAndExit("Error deploying Carbon App "+app.Name, err)
+	}
+	saveDeployedCapp(app)
+	fmt.Println(resp)
+}
+
+func saveDeployedCapp(app *impl.CarbonApp) {
+	if err := impl.SaveDeployedCarbonApp(deployCappCmdEnvironment, app); err != nil {
+		fmt.Println(utils.LogPrefixWarning+"Unable to keep the CAR file to roll back a later deployment:", err)
+	}
+}
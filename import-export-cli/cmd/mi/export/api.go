@@ -0,0 +1,80 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package export
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var exportAPICmdEnvironment string
+var exportAPICmdAsAPIMProject bool
+var exportAPICmdDestination string
+
+const exportAPICmdLiteral = "api"
+const exportAPICmdShortDesc = "Export an integration API deployed in a Micro Integrator as an API Manager project"
+
+const exportAPICmdLongDesc = `Export an integration API deployed in a Micro Integrator in the environment specified by the flag (--environment, -e)
+as an API Manager project (--as-apim-project). An OpenAPI definition is generated from the resources of the integration
+API and the URL of the integration API is used as the backend endpoint. The project is created as <name>-<version> in
+the directory given by the flag (--destination, -d) and it can be imported with "` + utils.ProjectName + ` import api".`
+
+const exportAPICmdExamples = utils.ProjectName + " " + utils.MiCmdLiteral + " " + exportCmdLiteral + " " + exportAPICmdLiteral + ` HealthcareAPI --as-apim-project -e dev
+` + utils.ProjectName + " " + utils.MiCmdLiteral + " " + exportCmdLiteral + " " + exportAPICmdLiteral + ` HealthcareAPI --as-apim-project -d ./projects -e dev
+NOTE: The flags (--environment (-e)) and (--as-apim-project) are mandatory`
+
+var exportAPICmd = &cobra.Command{
+	Use:     exportAPICmdLiteral + " [api-name]",
+	Short:   exportAPICmdShortDesc,
+	Long:    exportAPICmdLongDesc,
+	Example: exportAPICmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + exportCmdLiteral + " " + exportAPICmdLiteral + " called")
+		if !exportAPICmdAsAPIMProject {
+			utils.HandleErrorAndExit("Invalid flags", utils.NewValidationError(
+				"only exporting as an API Manager project (--as-apim-project) is supported"))
+		}
+		credentials.HandleMissingCredentials(exportAPICmdEnvironment)
+		executeExportAPICmd(args[0])
+	},
+}
+
+func init() {
+	ExportCmd.AddCommand(exportAPICmd)
+	exportAPICmd.Flags().StringVarP(&exportAPICmdEnvironment, "environment", "e", "", "Environment of the Micro Integrator")
+	exportAPICmd.Flags().BoolVar(&exportAPICmdAsAPIMProject, "as-apim-project", false,
+		"Export the integration API as an API Manager project with a generated OpenAPI definition")
+	exportAPICmd.Flags().StringVarP(&exportAPICmdDestination, "destination", "d", ".",
+		"Directory in which the project is created")
+	_ = exportAPICmd.MarkFlagRequired("environment")
+}
+
+func executeExportAPICmd(apiName string) {
+	projectPath, err := impl.ExportIntegrationAPIAsAPIMProject(exportAPICmdEnvironment, apiName, exportAPICmdDestination)
+	if err != nil {
+		utils.HandleErrorAndExit("Error exporting the integration API "+apiName, err)
+	}
+	utils.SetResultResource("project", projectPath)
+	fmt.Println("Successfully exported the integration API " + apiName + " as an API Manager project to " + projectPath)
+}
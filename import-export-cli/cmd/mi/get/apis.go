@@ -23,6 +23,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getIntegrationAPICmdEnvironment string
@@ -60,6 +62,13 @@ func handleGetIntegrationAPICmdArguments(args []string) {
 }
 
 func executeListIntegrationAPIs() {
+	if utils.IsMIClusterEnv(getIntegrationAPICmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getIntegrationAPICmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetIntegrationAPIList(nodeEnv)
+		})
+		impl.PrintIntegrationAPIListOfNodes(results, getIntegrationAPICmdFormat)
+		return
+	}
 	apiList, err := impl.GetIntegrationAPIList(getIntegrationAPICmdEnvironment)
 	if err == nil {
 		impl.PrintIntegrationAPIList(apiList, getIntegrationAPICmdFormat)
@@ -69,6 +78,17 @@ func executeListIntegrationAPIs() {
 }
 
 func executeShowIntegrationAPI(apiName string) {
+	if utils.IsMIClusterEnv(getIntegrationAPICmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getIntegrationAPICmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetIntegrationAPI(nodeEnv, apiName)
+		})
+		impl.PrintNodeDetails(results, func(integrationAPI interface{}) {
+			impl.PrintIntegrationAPIDetails(integrationAPI.(*artifactutils.IntegrationAPI), getIntegrationAPICmdFormat)
+		}, func(err error) {
+			printErrorForArtifact(artifactAPIs, apiName, err)
+		})
+		return
+	}
 	integrationAPI, err := impl.GetIntegrationAPI(getIntegrationAPICmdEnvironment, apiName)
 	if err == nil {
 		impl.PrintIntegrationAPIDetails(integrationAPI, getIntegrationAPICmdFormat)
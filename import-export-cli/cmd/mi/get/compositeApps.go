@@ -23,6 +23,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getApplicationCmdEnvironment string
@@ -60,6 +62,13 @@ func handleGetApplicationCmdArguments(args []string) {
 }
 
 func executeListCarbonApps() {
+	if utils.IsMIClusterEnv(getApplicationCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getApplicationCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetCompositeAppList(nodeEnv)
+		})
+		impl.PrintCompositeAppListOfNodes(results, getApplicationCmdFormat)
+		return
+	}
 	appList, err := impl.GetCompositeAppList(getApplicationCmdEnvironment)
 	if err == nil {
 		impl.PrintCompositeAppList(appList, getApplicationCmdFormat)
@@ -69,6 +78,17 @@ func executeListCarbonApps() {
 }
 
 func executeShowCarbonApp(appname string) {
+	if utils.IsMIClusterEnv(getApplicationCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getApplicationCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetCompositeApp(nodeEnv, appname)
+		})
+		impl.PrintNodeDetails(results, func(app interface{}) {
+			impl.PrintCompositeAppDetails(app.(*artifactutils.CompositeApp), getApplicationCmdFormat)
+		}, func(err error) {
+			printErrorForArtifact(artifactCompositeApps, appname, err)
+		})
+		return
+	}
 	app, err := impl.GetCompositeApp(getApplicationCmdEnvironment, appname)
 	if err == nil {
 		impl.PrintCompositeAppDetails(app, getApplicationCmdFormat)
@@ -61,6 +61,13 @@ func handleGetConnectorCmdArguments(args []string) {
 }
 
 func executeListConnectors() {
+	if utils.IsMIClusterEnv(getConnectorCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getConnectorCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetConnectorList(nodeEnv)
+		})
+		impl.PrintConnectorListOfNodes(results, getConnectorCmdFormat)
+		return
+	}
 	connectorList, err := impl.GetConnectorList(getConnectorCmdEnvironment)
 	if err == nil {
 		impl.PrintConnectorList(connectorList, getConnectorCmdFormat)
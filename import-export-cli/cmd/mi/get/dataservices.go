@@ -23,6 +23,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getDataServiceCmdEnvironment string
@@ -60,6 +62,13 @@ func handleGetDataServiceCmdArguments(args []string) {
 }
 
 func executeListDataServices() {
+	if utils.IsMIClusterEnv(getDataServiceCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getDataServiceCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetDataServiceList(nodeEnv)
+		})
+		impl.PrintDataServiceListOfNodes(results, getDataServiceCmdFormat)
+		return
+	}
 	dataServiceList, err := impl.GetDataServiceList(getDataServiceCmdEnvironment)
 	if err == nil {
 		impl.PrintDataServiceList(dataServiceList, getDataServiceCmdFormat)
@@ -69,6 +78,17 @@ func executeListDataServices() {
 }
 
 func executeShowDataService(dataserviceName string) {
+	if utils.IsMIClusterEnv(getDataServiceCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getDataServiceCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetDataService(nodeEnv, dataserviceName)
+		})
+		impl.PrintNodeDetails(results, func(dataservice interface{}) {
+			impl.PrintDataServiceDetails(dataservice.(*artifactutils.DataServiceInfo), getDataServiceCmdFormat)
+		}, func(err error) {
+			printErrorForArtifact(artifactDataServices, dataserviceName, err)
+		})
+		return
+	}
 	dataservice, err := impl.GetDataService(getDataServiceCmdEnvironment, dataserviceName)
 	if err == nil {
 		impl.PrintDataServiceDetails(dataservice, getDataServiceCmdFormat)
@@ -23,6 +23,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getEndpointCmdEnvironment string
@@ -60,6 +62,13 @@ func handleGetEndpointCmdArguments(args []string) {
 }
 
 func executeListEndpoints() {
+	if utils.IsMIClusterEnv(getEndpointCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getEndpointCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetEndpointList(nodeEnv)
+		})
+		impl.PrintEndpointListOfNodes(results, getEndpointCmdFormat)
+		return
+	}
 	epList, err := impl.GetEndpointList(getEndpointCmdEnvironment)
 	if err == nil {
 		impl.PrintEndpointList(epList, getEndpointCmdFormat)
@@ -69,6 +78,17 @@ func executeListEndpoints() {
 }
 
 func executeShowEndpoint(epName string) {
+	if utils.IsMIClusterEnv(getEndpointCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getEndpointCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetEndpoint(nodeEnv, epName)
+		})
+		impl.PrintNodeDetails(results, func(endpoint interface{}) {
+			impl.PrintEndpointDetails(endpoint.(*artifactutils.Endpoint), getEndpointCmdFormat)
+		}, func(err error) {
+			printErrorForArtifact(artifactEndpoints, epName, err)
+		})
+		return
+	}
 	endpoint, err := impl.GetEndpoint(getEndpointCmdEnvironment, epName)
 	if err == nil {
 		impl.PrintEndpointDetails(endpoint, getEndpointCmdFormat)
@@ -23,6 +23,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getInboundEndpointCmdEnvironment string
@@ -60,6 +62,13 @@ func handleGetInboundEndpointCmdArguments(args []string) {
 }
 
 func executeListInboundEndpoints() {
+	if utils.IsMIClusterEnv(getInboundEndpointCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getInboundEndpointCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetInboundEndpointList(nodeEnv)
+		})
+		impl.PrintInboundEndpointListOfNodes(results, getInboundEndpointCmdFormat)
+		return
+	}
 	inboundEpList, err := impl.GetInboundEndpointList(getInboundEndpointCmdEnvironment)
 	if err == nil {
 		impl.PrintInboundEndpointList(inboundEpList, getInboundEndpointCmdFormat)
@@ -69,6 +78,17 @@ func executeListInboundEndpoints() {
 }
 
 func executeShowInboundEndpoint(inboundEpName string) {
+	if utils.IsMIClusterEnv(getInboundEndpointCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getInboundEndpointCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetInboundEndpoint(nodeEnv, inboundEpName)
+		})
+		impl.PrintNodeDetails(results, func(inboundEndpoint interface{}) {
+			impl.PrintInboundEndpointDetails(inboundEndpoint.(*artifactutils.InboundEndpoint), getInboundEndpointCmdFormat)
+		}, func(err error) {
+			printErrorForArtifact(artifactInboundEndpoints, inboundEpName, err)
+		})
+		return
+	}
 	inboundEndpoint, err := impl.GetInboundEndpoint(getInboundEndpointCmdEnvironment, inboundEpName)
 	if err == nil {
 		impl.PrintInboundEndpointDetails(inboundEndpoint, getInboundEndpointCmdFormat)
@@ -23,6 +23,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getLocalEntryCmdEnvironment string
@@ -60,6 +62,13 @@ func handleGetLocalEntryCmdArguments(args []string) {
 }
 
 func executeListLocalEntrys() {
+	if utils.IsMIClusterEnv(getLocalEntryCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getLocalEntryCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetLocalEntryList(nodeEnv)
+		})
+		impl.PrintLocalEntryListOfNodes(results, getLocalEntryCmdFormat)
+		return
+	}
 	localEntryList, err := impl.GetLocalEntryList(getLocalEntryCmdEnvironment)
 	if err == nil {
 		impl.PrintLocalEntryList(localEntryList, getLocalEntryCmdFormat)
@@ -69,6 +78,17 @@ func executeListLocalEntrys() {
 }
 
 func executeShowLocalEntry(localEntryName string) {
+	if utils.IsMIClusterEnv(getLocalEntryCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getLocalEntryCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetLocalEntry(nodeEnv, localEntryName)
+		})
+		impl.PrintNodeDetails(results, func(localEntry interface{}) {
+			impl.PrintLocalEntryDetails(localEntry.(*artifactutils.LocalEntryData), getLocalEntryCmdFormat)
+		}, func(err error) {
+			printErrorForArtifact(artifactLocalEntries, localEntryName, err)
+		})
+		return
+	}
 	localEntry, err := impl.GetLocalEntry(getLocalEntryCmdEnvironment, localEntryName)
 	if err == nil {
 		impl.PrintLocalEntryDetails(localEntry, getLocalEntryCmdFormat)
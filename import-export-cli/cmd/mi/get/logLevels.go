@@ -63,6 +63,13 @@ func handleGetLogLevelCmdArguments(args []string) {
 }
 
 func executeShowLogLevel(loggerName string) {
+	if utils.IsMIClusterEnv(getLogLevelCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getLogLevelCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetLoggerInfo(nodeEnv, loggerName)
+		})
+		impl.PrintLoggerInfoOfNodes(results, getLogLevelCmdFormat)
+		return
+	}
 	LogLevelList, err := impl.GetLoggerInfo(getLogLevelCmdEnvironment, loggerName)
 	if err == nil {
 		impl.PrintLoggerInfo(LogLevelList, getLogLevelCmdFormat)
@@ -20,6 +20,8 @@ package get
 
 import (
 	"os"
+	"regexp"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -31,18 +33,31 @@ import (
 var getLogCmdEnvironment string
 var getLogCmdFormat string
 var logFileDownloadPath string
+var getLogCmdFollow bool
+var getLogCmdSince string
+var getLogCmdGrep string
+var getLogCmdLevel string
+var getLogCmdInterval time.Duration
+var getLogCmdMaxSize int64
 
 const getLogCmdLiteral = "logs [file-name]"
 
 const getLogCmdShortDesc = "List all the available log files"
 const getLogCmdLongDesc = "Download a log file by providing the file name and download location,\n" +
-	"if not provided, list all the log files of the Micro Integrator in the environment specified by the flag --environment, -e"
+	"if not provided, list all the log files of the Micro Integrator in the environment specified by the flag --environment, -e\n" +
+	"Use --follow (-f) with the file name to print the log file and keep printing the new lines appended to it. " +
+	"The printed lines can be filtered by --since, --grep and --level. " +
+	"As the whole log file is downloaded at every poll, following stops when the file is larger than --max-size"
 
 var getLogCmdExamples = "Example:\n" +
 	"To list all the log files\n" +
 	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + GetCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(getLogCmdLiteral) + " -e dev\n" +
 	"To download a selected log file\n" +
 	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + GetCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(getLogCmdLiteral) + " [file-name] -p [download-location] -e dev\n" +
+	"To follow a log file and print the warnings and errors logged in the last 10 minutes\n" +
+	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + GetCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(getLogCmdLiteral) + " wso2carbon.log -f --since 10m --level warn -e dev\n" +
+	"To follow a log file and print only the lines matching a regular expression\n" +
+	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + GetCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(getLogCmdLiteral) + " wso2carbon.log -f --grep 'HealthcareAPI|Exception' -e dev\n" +
 	"NOTE: The flag (--environment (-e)) is mandatory"
 
 var getLogCmd = &cobra.Command{
@@ -61,12 +76,33 @@ func init() {
 	setEnvFlag(getLogCmd, &getLogCmdEnvironment)
 	setFormatFlag(getLogCmd, &getLogCmdFormat)
 	getLogCmd.Flags().StringVarP(&logFileDownloadPath, "path", "p", "", "Path the file should be downloaded")
+	getLogCmd.Flags().BoolVarP(&getLogCmdFollow, "follow", "f", false,
+		"Print the log file and keep printing the new lines appended to it")
+	getLogCmd.Flags().StringVarP(&getLogCmdSince, "since", "", "",
+		"Print only the log entries logged after a duration (eg: 10m) or a time (eg: \"2021-05-10 10:15:00\")")
+	getLogCmd.Flags().StringVarP(&getLogCmdGrep, "grep", "", "",
+		"Print only the lines matching the regular expression")
+	getLogCmd.Flags().StringVarP(&getLogCmdLevel, "level", "", "",
+		"Print only the log entries with this or a higher level (TRACE, DEBUG, INFO, WARN, ERROR or FATAL)")
+	getLogCmd.Flags().DurationVarP(&getLogCmdInterval, "interval", "", 2*time.Second,
+		"Time between two reads of the log file when following it")
+	getLogCmd.Flags().Int64VarP(&getLogCmdMaxSize, "max-size", "", 50,
+		"Size of the log file in MB after which following it stops, as the whole file is read at every poll")
 }
 
 func handleGetLogCmdArguments(args []string) {
 	printGetCmdVerboseLogForArtifact(miUtils.GetTrimmedCmdLiteral(getLogCmdLiteral))
 	credentials.HandleMissingCredentials(getLogCmdEnvironment)
-	if len(args) == 1 {
+	if getLogCmdFollow {
+		if len(args) != 1 {
+			utils.HandleErrorAndExit("Invalid arguments", utils.NewValidationError("the name of the log file to "+
+				"follow is required"))
+		}
+		executeFollowLogFile(args[0])
+	} else if getLogCmdSince != "" || getLogCmdGrep != "" || getLogCmdLevel != "" {
+		utils.HandleErrorAndExit("Invalid arguments", utils.NewValidationError("--since, --grep and --level "+
+			"can only be used with --follow (-f)"))
+	} else if len(args) == 1 {
 		var logFileName = args[0]
 		if isEmptyOrCurrentDir(logFileDownloadPath) {
 			logFileDownloadPath, _ = os.Getwd()
@@ -95,3 +131,41 @@ func executeDownloadLogFile(targetDirectory, logFileName string) {
 		printErrorForArtifact("log file", logFileName, err)
 	}
 }
+
+func executeFollowLogFile(logFileName string) {
+	options := impl.LogFollowOptions{
+		Interval: getLogCmdInterval,
+		MaxSize:  getLogCmdMaxSize * 1024 * 1024,
+	}
+	var err error
+	if getLogCmdSince != "" {
+		options.Since, err = impl.ParseLogSince(getLogCmdSince, time.Now())
+		if err != nil {
+			utils.HandleErrorAndExit("Invalid value for --since", utils.NewValidationError(err.Error()))
+		}
+	}
+	if getLogCmdGrep != "" {
+		options.Pattern, err = regexp.Compile(getLogCmdGrep)
+		if err != nil {
+			utils.HandleErrorAndExit("Invalid value for --grep", utils.NewValidationError(err.Error()))
+		}
+	}
+	if getLogCmdLevel != "" {
+		options.Level, err = impl.ParseLogLevel(getLogCmdLevel)
+		if err != nil {
+			utils.HandleErrorAndExit("Invalid value for --level", utils.NewValidationError(err.Error()))
+		}
+	}
+	if getLogCmdMaxSize <= 0 {
+		utils.HandleErrorAndExit("Invalid value for --max-size", utils.NewValidationError("the maximum size "+
+			"should be greater than zero"))
+	}
+	if options.Interval <= 0 {
+		utils.HandleErrorAndExit("Invalid value for --interval", utils.NewValidationError("the interval should be "+
+			"greater than zero"))
+	}
+	err = impl.FollowLogFile(getLogCmdEnvironment, logFileName, options, os.Stdout)
+	if err != nil {
+		utils.HandleErrorAndExit("Error following log file "+logFileName, err)
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getMessageProcessorCmdEnvironment string
@@ -60,6 +62,13 @@ func handleGetMessageProcessorCmdArguments(args []string) {
 }
 
 func executeListMessageProcessors() {
+	if utils.IsMIClusterEnv(getMessageProcessorCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getMessageProcessorCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetMessageProcessorList(nodeEnv)
+		})
+		impl.PrintMessageProcessorListOfNodes(results, getMessageProcessorCmdFormat)
+		return
+	}
 	msgProcessorList, err := impl.GetMessageProcessorList(getMessageProcessorCmdEnvironment)
 	if err == nil {
 		impl.PrintMessageProcessorList(msgProcessorList, getMessageProcessorCmdFormat)
@@ -69,6 +78,17 @@ func executeListMessageProcessors() {
 }
 
 func executeShowMessageProcessor(msgProcessorName string) {
+	if utils.IsMIClusterEnv(getMessageProcessorCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getMessageProcessorCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetMessageProcessor(nodeEnv, msgProcessorName)
+		})
+		impl.PrintNodeDetails(results, func(msgProcessor interface{}) {
+			impl.PrintMessageProcessorDetails(msgProcessor.(*artifactutils.MessageProcessorData), getMessageProcessorCmdFormat)
+		}, func(err error) {
+			printErrorForArtifact(artifactMessageProcessors, msgProcessorName, err)
+		})
+		return
+	}
 	msgProcessor, err := impl.GetMessageProcessor(getMessageProcessorCmdEnvironment, msgProcessorName)
 	if err == nil {
 		impl.PrintMessageProcessorDetails(msgProcessor, getMessageProcessorCmdFormat)
@@ -23,6 +23,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getMessageStoreCmdEnvironment string
@@ -60,6 +62,13 @@ func handleGetMessageStoreCmdArguments(args []string) {
 }
 
 func executeListMessageStores() {
+	if utils.IsMIClusterEnv(getMessageStoreCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getMessageStoreCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetMessageStoreList(nodeEnv)
+		})
+		impl.PrintMessageStoreListOfNodes(results, getMessageStoreCmdFormat)
+		return
+	}
 	messageStoreList, err := impl.GetMessageStoreList(getMessageStoreCmdEnvironment)
 	if err == nil {
 		impl.PrintMessageStoreList(messageStoreList, getMessageStoreCmdFormat)
@@ -69,6 +78,17 @@ func executeListMessageStores() {
 }
 
 func executeShowMessageStore(messageStoreName string) {
+	if utils.IsMIClusterEnv(getMessageStoreCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getMessageStoreCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetMessageStore(nodeEnv, messageStoreName)
+		})
+		impl.PrintNodeDetails(results, func(messageStore interface{}) {
+			impl.PrintMessageStoreDetails(messageStore.(*artifactutils.MessageStoreData), getMessageStoreCmdFormat)
+		}, func(err error) {
+			printErrorForArtifact(artifactMessageStores, messageStoreName, err)
+		})
+		return
+	}
 	messageStore, err := impl.GetMessageStore(getMessageStoreCmdEnvironment, messageStoreName)
 	if err == nil {
 		impl.PrintMessageStoreDetails(messageStore, getMessageStoreCmdFormat)
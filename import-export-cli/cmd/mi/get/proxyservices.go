@@ -23,6 +23,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getProxyServiceCmdEnvironment string
@@ -60,6 +62,13 @@ func handleGetProxyServiceCmdArguments(args []string) {
 }
 
 func executeListProxyServices() {
+	if utils.IsMIClusterEnv(getProxyServiceCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getProxyServiceCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetProxyServiceList(nodeEnv)
+		})
+		impl.PrintProxyServiceListOfNodes(results, getProxyServiceCmdFormat)
+		return
+	}
 	proxyList, err := impl.GetProxyServiceList(getProxyServiceCmdEnvironment)
 	if err == nil {
 		impl.PrintProxyServiceList(proxyList, getProxyServiceCmdFormat)
@@ -69,6 +78,17 @@ func executeListProxyServices() {
 }
 
 func executeShowProxyService(proxyName string) {
+	if utils.IsMIClusterEnv(getProxyServiceCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getProxyServiceCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetProxyService(nodeEnv, proxyName)
+		})
+		impl.PrintNodeDetails(results, func(proxyService interface{}) {
+			impl.PrintProxyServiceDetails(proxyService.(*artifactutils.Proxy), getProxyServiceCmdFormat)
+		}, func(err error) {
+			printErrorForArtifact(artifactProxyServices, proxyName, err)
+		})
+		return
+	}
 	proxyService, err := impl.GetProxyService(getProxyServiceCmdEnvironment, proxyName)
 	if err == nil {
 		impl.PrintProxyServiceDetails(proxyService, getProxyServiceCmdFormat)
@@ -23,6 +23,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getSequenceCmdEnvironment string
@@ -60,6 +62,13 @@ func handleGetSequenceCmdArguments(args []string) {
 }
 
 func executeListSequences() {
+	if utils.IsMIClusterEnv(getSequenceCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getSequenceCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetSequenceList(nodeEnv)
+		})
+		impl.PrintSequenceListOfNodes(results, getSequenceCmdFormat)
+		return
+	}
 	sequenceList, err := impl.GetSequenceList(getSequenceCmdEnvironment)
 	if err == nil {
 		impl.PrintSequenceList(sequenceList, getSequenceCmdFormat)
@@ -69,6 +78,17 @@ func executeListSequences() {
 }
 
 func executeShowSequence(sequenceName string) {
+	if utils.IsMIClusterEnv(getSequenceCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getSequenceCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetSequence(nodeEnv, sequenceName)
+		})
+		impl.PrintNodeDetails(results, func(sequence interface{}) {
+			impl.PrintSequenceDetails(sequence.(*artifactutils.Sequence), getSequenceCmdFormat)
+		}, func(err error) {
+			printErrorForArtifact(artifactSequences, sequenceName, err)
+		})
+		return
+	}
 	sequence, err := impl.GetSequence(getSequenceCmdEnvironment, sequenceName)
 	if err == nil {
 		impl.PrintSequenceDetails(sequence, getSequenceCmdFormat)
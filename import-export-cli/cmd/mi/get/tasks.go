@@ -23,6 +23,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getTaskCmdEnvironment string
@@ -60,6 +62,13 @@ func handleGetTaskCmdArguments(args []string) {
 }
 
 func executeListTasks() {
+	if utils.IsMIClusterEnv(getTaskCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getTaskCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetTaskList(nodeEnv)
+		})
+		impl.PrintTaskListOfNodes(results, getTaskCmdFormat)
+		return
+	}
 	taskList, err := impl.GetTaskList(getTaskCmdEnvironment)
 	if err == nil {
 		impl.PrintTaskList(taskList, getTaskCmdFormat)
@@ -69,6 +78,17 @@ func executeListTasks() {
 }
 
 func executeShowTask(taskName string) {
+	if utils.IsMIClusterEnv(getTaskCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(getTaskCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetTask(nodeEnv, taskName)
+		})
+		impl.PrintNodeDetails(results, func(task interface{}) {
+			impl.PrintTaskDetails(task.(*artifactutils.Task), getTaskCmdFormat)
+		}, func(err error) {
+			printErrorForArtifact(artifactTasks, taskName, err)
+		})
+		return
+	}
 	task, err := impl.GetTask(getTaskCmdEnvironment, taskName)
 	if err == nil {
 		impl.PrintTaskDetails(task, getTaskCmdFormat)
@@ -22,16 +22,21 @@ import (
 	"github.com/spf13/cobra"
 	miActivateCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/activate"
 	miAddCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/add"
+	miApplyCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/apply"
 	miDeactivateCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/deactivate"
 	miDeleteCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/delete"
+	miDeployCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/deploy"
+	miExportCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/export"
 	miGetCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/get"
+	miSnapshotCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/snapshot"
+	miUndeployCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/undeploy"
 	miUpdateCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/update"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 const miCmdShortDesc = "Micro Integrator related commands"
 
-const miCmdLongDesc = `Micro Integrator related commands such as login, logout, get, add, update, delete, activate, deactivate.`
+const miCmdLongDesc = `Micro Integrator related commands such as login, logout, get, add, update, delete, activate, deactivate, export, deploy, undeploy, snapshot, apply.`
 
 // MICmd represents the mi command
 var MICmd = &cobra.Command{
@@ -52,4 +57,9 @@ func init() {
 	MICmd.AddCommand(miUpdateCmd.UpdateCmd)
 	MICmd.AddCommand(miActivateCmd.ActivateCmd)
 	MICmd.AddCommand(miDeactivateCmd.DeactivateCmd)
+	MICmd.AddCommand(miExportCmd.ExportCmd)
+	MICmd.AddCommand(miDeployCmd.DeployCmd)
+	MICmd.AddCommand(miUndeployCmd.UndeployCmd)
+	MICmd.AddCommand(miSnapshotCmd.SnapshotCmd)
+	MICmd.AddCommand(miApplyCmd.ApplyCmd)
 }
@@ -0,0 +1,126 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package snapshot
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var snapshotDiffCmdEnvironment string
+
+const snapshotDiffCmdLiteral = "diff"
+const snapshotDiffCmdShortDesc = "Compare two snapshots of a Micro Integrator"
+
+const snapshotDiffCmdLongDesc = `Compare the snapshot given by the first command line argument with the snapshot given by the second argument, or
+with a snapshot of the Micro Integrator in the environment specified by the flag --environment, -e
+The artifacts added, removed and modified since the first snapshot are listed with the changed fields. The artifact types
+skipped by either snapshot are not compared`
+
+var snapshotDiffCmdExamples = "To compare two snapshots\n" +
+	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + snapshotCmdLiteral + " " + snapshotDiffCmdLiteral + " before.yaml after.yaml\n" +
+	"To compare a snapshot with the Micro Integrator in an environment\n" +
+	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + snapshotCmdLiteral + " " + snapshotDiffCmdLiteral + " snapshot.yaml -e prod\n" +
+	"NOTE: Either the second snapshot or the flag (--environment (-e)) should be given"
+
+var snapshotDiffCmd = &cobra.Command{
+	Use:     snapshotDiffCmdLiteral + " [snapshot] [snapshot]",
+	Short:   snapshotDiffCmdShortDesc,
+	Long:    snapshotDiffCmdLongDesc,
+	Example: snapshotDiffCmdExamples,
+	Args:    cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + snapshotCmdLiteral + " " + snapshotDiffCmdLiteral + " called")
+		if (len(args) == 2) == (snapshotDiffCmdEnvironment != "") {
+			utils.HandleErrorAndExit("Invalid arguments", utils.NewValidationError("either the second snapshot "+
+				"or the flag --environment (-e) should be given"))
+		}
+		executeSnapshotDiffCmd(args)
+	},
+}
+
+func init() {
+	SnapshotCmd.AddCommand(snapshotDiffCmd)
+	snapshotDiffCmd.Flags().StringVarP(&snapshotDiffCmdEnvironment, "environment", "e", "", "Environment of the micro integrator to compare the snapshot with")
+}
+
+func executeSnapshotDiffCmd(args []string) {
+	before := readSnapshot(args[0])
+	var after *impl.MISnapshot
+	afterLabel := snapshotDiffCmdEnvironment
+	if len(args) == 2 {
+		after = readSnapshot(args[1])
+		afterLabel = args[1]
+	} else {
+		credentials.HandleMissingCredentials(snapshotDiffCmdEnvironment)
+		after = takeSnapshot(snapshotDiffCmdEnvironment)
+	}
+	utils.SetResultResource("snapshot", args[0])
+	utils.SetResultResource("to", afterLabel)
+
+	diffs := impl.DiffMISnapshots(before, after)
+	utils.SetResultData(diffs)
+	if !utils.IsJSONOutput() {
+		printSnapshotDiffs(args[0], afterLabel, diffs)
+	}
+}
+
+func readSnapshot(filePath string) *impl.MISnapshot {
+	snapshot, err := impl.ReadMISnapshot(filePath)
+	if err != nil {
+		utils.HandleErrorAndExit("Error reading snapshot "+filePath, utils.NewValidationError(err.Error()))
+	}
+	return snapshot
+}
+
+// printSnapshotDiffs prints the differences of each artifact of two snapshots
+func printSnapshotDiffs(before, after string, artifactDiffs []impl.MISnapshotDiff) {
+	fmt.Println("--- " + before)
+	fmt.Println("+++ " + after)
+	if len(artifactDiffs) == 0 {
+		fmt.Println("No differences found")
+		return
+	}
+	for _, artifactDiff := range artifactDiffs {
+		artifact := artifactDiff.ArtifactType + "/" + artifactDiff.Name
+		switch artifactDiff.Type {
+		case utils.DiffTypeAdded:
+			fmt.Println("\n+ " + artifact)
+		case utils.DiffTypeRemoved:
+			fmt.Println("\n- " + artifact)
+		case utils.DiffTypeModified:
+			fmt.Println("\n~ " + artifact + " (" + strconv.Itoa(len(artifactDiff.Diffs)) + " changes)")
+		}
+		for _, diff := range artifactDiff.Diffs {
+			switch diff.Type {
+			case utils.DiffTypeAdded:
+				fmt.Println("\t+ " + diff.Path + ": " + utils.FormatDiffValue(diff.After))
+			case utils.DiffTypeRemoved:
+				fmt.Println("\t- " + diff.Path + ": " + utils.FormatDiffValue(diff.Before))
+			default:
+				fmt.Println("\t~ " + diff.Path + ": " + utils.FormatDiffValue(diff.Before) + " => " +
+					utils.FormatDiffValue(diff.After))
+			}
+		}
+	}
+}
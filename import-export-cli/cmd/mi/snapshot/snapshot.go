@@ -0,0 +1,107 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var snapshotCmdEnvironment string
+var snapshotCmdOutputFile string
+
+const snapshotCmdLiteral = "snapshot"
+const snapshotCmdShortDesc = "Take a snapshot of the configuration of a Micro Integrator"
+
+const snapshotCmdLongDesc = `Take a snapshot of the configuration of a Micro Integrator in the environment specified by the flag --environment, -e
+The details of all the APIs, proxy services, endpoints, sequences, templates, tasks, message stores, message processors,
+inbound endpoints, local entries, data services, Carbon Apps, connectors, loggers and users are written to a single
+YAML document. An artifact type which cannot be read is listed under skipped in the snapshot. The snapshot of a
+cluster is taken from its first node`
+
+var snapshotCmdExamples = "To write a snapshot to a file\n" +
+	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + snapshotCmdLiteral + " -e prod -o snapshot.yaml\n" +
+	"To compare two snapshots\n" +
+	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + snapshotCmdLiteral + " " + snapshotDiffCmdLiteral + " before.yaml after.yaml\n" +
+	"NOTE: The flag (--environment (-e)) is mandatory"
+
+// SnapshotCmd represents the snapshot command
+var SnapshotCmd = &cobra.Command{
+	Use:     snapshotCmdLiteral,
+	Short:   snapshotCmdShortDesc,
+	Long:    snapshotCmdLongDesc,
+	Example: snapshotCmdExamples,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + snapshotCmdLiteral + " called")
+		credentials.HandleMissingCredentials(snapshotCmdEnvironment)
+		executeSnapshotCmd()
+	},
+}
+
+func init() {
+	SnapshotCmd.Flags().StringVarP(&snapshotCmdEnvironment, "environment", "e", "", "Environment of the micro integrator of which the snapshot should be taken")
+	SnapshotCmd.Flags().StringVarP(&snapshotCmdOutputFile, "output", "o", "", "File to which the snapshot should be written. The snapshot is printed if not given")
+	SnapshotCmd.MarkFlagRequired("environment")
+}
+
+func executeSnapshotCmd() {
+	snapshot := takeSnapshot(snapshotCmdEnvironment)
+	data, err := impl.WriteMISnapshot(snapshot, snapshotCmdOutputFile)
+	if err != nil {
+		utils.HandleErrorAndExit("Error writing the snapshot", err)
+	}
+	utils.SetResultResource("environment", snapshotCmdEnvironment)
+	utils.SetResultResource("output", snapshotCmdOutputFile)
+	if snapshotCmdOutputFile == "" {
+		fmt.Print(string(data))
+		return
+	}
+	fmt.Println("Snapshot of the Micro Integrator in environment '" + snapshotCmdEnvironment + "' is written to " +
+		snapshotCmdOutputFile)
+}
+
+// takeSnapshot takes a snapshot of the micro integrator of an environment, or of the first node if it is a cluster
+func takeSnapshot(env string) *impl.MISnapshot {
+	nodeEnv := env
+	if utils.IsMIClusterEnv(env, utils.MainConfigFilePath) {
+		nodes, err := utils.GetMINodesOfEnv(env, utils.MainConfigFilePath)
+		if err != nil {
+			utils.HandleErrorAndExit("Error reading the nodes of environment "+env, err)
+		}
+		nodeEnv = nodes[0].Env
+	}
+	snapshot, err := impl.TakeMISnapshot(nodeEnv)
+	if err != nil {
+		utils.HandleErrorAndExit("Error taking the snapshot of environment "+env, err)
+	}
+	skipped := make([]string, 0, len(snapshot.Skipped))
+	for artifactType := range snapshot.Skipped {
+		skipped = append(skipped, artifactType)
+	}
+	sort.Strings(skipped)
+	for _, artifactType := range skipped {
+		fmt.Println(utils.LogPrefixWarning + "Skipped " + artifactType + ": " + snapshot.Skipped[artifactType])
+	}
+	return snapshot
+}
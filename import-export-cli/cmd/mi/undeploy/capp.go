@@ -0,0 +1,106 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package undeploy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var undeployCappCmdEnvironment string
+var undeployCappCmdTimeout time.Duration
+var undeployCappCmdInterval time.Duration
+
+const undeployCappCmdLiteral = "capp [capp-name]"
+const undeployCappCmdShortDesc = "Undeploy a Carbon App from a Micro Integrator"
+
+const undeployCappCmdLongDesc = "Undeploy the Carbon App specified by the command line argument [capp-name] from a Micro Integrator in the environment specified by the flag --environment, -e\n" +
+	"The command waits until the Carbon App is undeployed"
+
+var undeployCappCmdExamples = "To undeploy a Carbon App\n" +
+	"  " + utils.ProjectName + " " + utils.MiCmdLiteral + " " + undeployCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(undeployCappCmdLiteral) + " HealthcareCompositeExporter -e dev\n" +
+	"NOTE: The flag (--environment (-e)) is mandatory"
+
+var undeployCappCmd = &cobra.Command{
+	Use:     undeployCappCmdLiteral,
+	Short:   undeployCappCmdShortDesc,
+	Long:    undeployCappCmdLongDesc,
+	Example: undeployCappCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		handleUndeployCappCmdArguments(args)
+	},
+}
+
+func init() {
+	UndeployCmd.AddCommand(undeployCappCmd)
+	undeployCappCmd.Flags().StringVarP(&undeployCappCmdEnvironment, "environment", "e", "", "Environment of the micro integrator from which the Carbon App should be undeployed")
+	undeployCappCmd.Flags().DurationVarP(&undeployCappCmdTimeout, "timeout", "", 2*time.Minute, "Maximum time to wait until the Carbon App is undeployed")
+	undeployCappCmd.Flags().DurationVarP(&undeployCappCmdInterval, "interval", "", 2*time.Second, "Time between two checks of the deployed Carbon Apps")
+	undeployCappCmd.MarkFlagRequired("environment")
+}
+
+func handleUndeployCappCmdArguments(args []string) {
+	printUndeployCmdVerboseLog(miUtils.GetTrimmedCmdLiteral(undeployCappCmdLiteral))
+	if undeployCappCmdTimeout <= 0 || undeployCappCmdInterval <= 0 {
+		utils.HandleErrorAndExit("Invalid value for --timeout or --interval", utils.NewValidationError("the "+
+			"timeout and the interval should be greater than zero"))
+	}
+	credentials.HandleMissingCredentials(undeployCappCmdEnvironment)
+	executeUndeployCapp(args[0])
+}
+
+func executeUndeployCapp(appName string) {
+	utils.SetResultResource("name", appName)
+	utils.SetResultResource("environment", undeployCappCmdEnvironment)
+	options := impl.CarbonAppDeployOptions{
+		Timeout:  undeployCappCmdTimeout,
+		Interval: undeployCappCmdInterval,
+	}
+	if utils.IsMIClusterEnv(undeployCappCmdEnvironment, utils.MainConfigFilePath) {
+		results := impl.InvokeNodes(undeployCappCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.UndeployCarbonApp(nodeEnv, appName, options)
+		})
+		impl.PrintNodeResults(results)
+		for _, result := range results {
+			if result.Err != nil {
+				utils.HandleErrorAndExit("Error undeploying Carbon App "+appName+" from node "+result.Node.Name, result.Err)
+			}
+		}
+		removeDeployedCapp(appName)
+		return
+	}
+	resp, err := impl.UndeployCarbonApp(undeployCappCmdEnvironment, appName, options)
+	if err != nil {
+		utils.HandleErrorAndExit("Error undeploying Carbon App "+appName, err)
+	}
+	removeDeployedCapp(appName)
+	fmt.Println(resp)
+}
+
+func removeDeployedCapp(appName string) {
+	if err := impl.RemoveDeployedCarbonApp(undeployCappCmdEnvironment, appName); err != nil {
+		fmt.Println(utils.LogPrefixWarning+"Unable to remove the CAR file kept to roll back:", err)
+	}
+}
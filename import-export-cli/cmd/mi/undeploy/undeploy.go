@@ -0,0 +1,46 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package undeploy
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const undeployCmdLiteral = "undeploy"
+const undeployCmdShortDesc = "Undeploy Carbon Apps from a Micro Integrator instance"
+
+const undeployCmdLongDesc = "Undeploy Carbon Apps from a Micro Integrator instance in the environment specified by the flag (--environment, -e)"
+
+const undeployCmdExamples = utils.ProjectName + " " + utils.MiCmdLiteral + " " + undeployCmdLiteral + " " + "capp" + " HealthcareCompositeExporter -e dev"
+
+// UndeployCmd represents the undeploy command
+var UndeployCmd = &cobra.Command{
+	Use:     undeployCmdLiteral,
+	Short:   undeployCmdShortDesc,
+	Long:    undeployCmdLongDesc,
+	Example: undeployCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + undeployCmdLiteral + " called")
+		cmd.Help()
+	},
+}
+
+func printUndeployCmdVerboseLog(cmd string) {
+	utils.Logln(utils.LogPrefixInfo + undeployCmdLiteral + " " + cmd + " called")
+}
@@ -63,6 +63,12 @@ func handleupdateLogLevelCmdArguments(args []string) {
 }
 
 func executeUpdateLogger(loggerName, logLevel string) {
+	if utils.IsMIClusterEnv(updateLogLevelCmdEnvironment, utils.MainConfigFilePath) {
+		impl.PrintNodeResults(impl.InvokeNodes(updateLogLevelCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.UpdateMILogger(nodeEnv, loggerName, logLevel)
+		}))
+		return
+	}
 	resp, err := impl.UpdateMILogger(updateLogLevelCmdEnvironment, loggerName, logLevel)
 	if err != nil {
 		fmt.Println(utils.LogPrefixError+"updating logger [ "+loggerName+" ] ", err)
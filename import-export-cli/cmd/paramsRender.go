@@ -0,0 +1,69 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var paramsRenderFile string
+var paramsRenderEnvironment string
+
+// ParamsRender command related usage Info
+const ParamsRenderCmdLiteral = "render"
+const paramsRenderCmdShortDesc = "Print the effective params of an environment"
+const paramsRenderCmdLongDesc = `Print the effective params of a params file (--file, -f) as they are applied on import. The
+files it extends are merged first, then the defaults are merged into the configs of each environment and the environment
+variables (${VAR}) are substituted. Only the environment specified by the flag (--environment, -e) is printed if given.`
+const paramsRenderCmdExamples = utils.ProjectName + ` ` + ParamsCmdLiteral + ` ` + ParamsRenderCmdLiteral + ` -e prod
+` + utils.ProjectName + ` ` + ParamsCmdLiteral + ` ` + ParamsRenderCmdLiteral + ` -f ./PizzaShackAPI/api_params.yaml -e prod`
+
+// ParamsRenderCmd represents the params render command
+var ParamsRenderCmd = &cobra.Command{
+	Use:     ParamsRenderCmdLiteral + " [--file <path-to-params-file>] [--environment <environment>]",
+	Short:   paramsRenderCmdShortDesc,
+	Long:    paramsRenderCmdLongDesc,
+	Example: paramsRenderCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ParamsRenderCmdLiteral + " called")
+		utils.SetResultResource("file", paramsRenderFile)
+		utils.SetResultResource("environment", paramsRenderEnvironment)
+		content, err := params.RenderParams(paramsRenderFile, paramsRenderEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error rendering the params file "+paramsRenderFile, err)
+		}
+		utils.SetResultData(string(content))
+		if !utils.IsJSONOutput() {
+			fmt.Fprint(utils.MessageWriter(), string(content))
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	ParamsCmd.AddCommand(ParamsRenderCmd)
+	ParamsRenderCmd.Flags().StringVarP(&paramsRenderFile, "file", "f", utils.ParamFile,
+		"Path to the params file")
+	ParamsRenderCmd.Flags().StringVarP(&paramsRenderEnvironment, "environment", "e", "",
+		"Environment to render. All the environments are rendered if not given")
+}
@@ -0,0 +1,83 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var paramsValidateFile string
+var paramsValidateType string
+
+// ParamsValidate command related usage Info
+const ParamsValidateCmdLiteral = "validate"
+const paramsValidateCmdShortDesc = "Validate a params file"
+const paramsValidateCmdLongDesc = `Validate a params file (--file, -f) and the files it extends against the params schema. Unknown fields,
+values of wrong types and invalid configurations are reported with their line numbers. The type of the params (--type)
+is decided by the name of the file if not given. The command fails if any error is found, so it can be used in CI.`
+const paramsValidateCmdExamples = utils.ProjectName + ` ` + ParamsCmdLiteral + ` ` + ParamsValidateCmdLiteral + ` -f ./PizzaShackAPI/api_params.yaml
+` + utils.ProjectName + ` ` + ParamsCmdLiteral + ` ` + ParamsValidateCmdLiteral + ` -f ./LeasingAPIProduct/api_product_params.yaml
+` + utils.ProjectName + ` ` + ParamsCmdLiteral + ` ` + ParamsValidateCmdLiteral + ` -f ./admin_params.yaml --type admin`
+
+// ParamsValidateCmd represents the params validate command
+var ParamsValidateCmd = &cobra.Command{
+	Use:     ParamsValidateCmdLiteral + " [--file <path-to-params-file>] [--type <params-type>]",
+	Short:   paramsValidateCmdShortDesc,
+	Long:    paramsValidateCmdLongDesc,
+	Example: paramsValidateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ParamsValidateCmdLiteral + " called")
+		utils.SetResultResource("file", paramsValidateFile)
+		executeParamsValidateCmd(paramsValidateFile, paramsValidateType)
+	},
+}
+
+// executeParamsValidateCmd validates the params file and prints the errors
+func executeParamsValidateCmd(paramsFile, paramsType string) {
+	if paramsType == "" {
+		paramsType = params.GetParamsTypeOfFile(paramsFile)
+	}
+	validationErrors, err := params.ValidateParamsFile(paramsFile, paramsType)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while validating the params file "+paramsFile, err)
+	}
+	utils.SetResultData(validationErrors)
+	for _, validationError := range validationErrors {
+		fmt.Fprintln(utils.MessageWriter(), validationError.String())
+	}
+	if len(validationErrors) > 0 {
+		utils.HandleErrorAndExit("Validation of "+paramsFile+" failed",
+			utils.NewValidationError(strconv.Itoa(len(validationErrors))+" error(s)"))
+	}
+	fmt.Fprintln(utils.MessageWriter(), "Params file "+paramsFile+" is valid.")
+}
+
+// init using Cobra
+func init() {
+	ParamsCmd.AddCommand(ParamsValidateCmd)
+	ParamsValidateCmd.Flags().StringVarP(&paramsValidateFile, "file", "f", utils.ParamFile,
+		"Path to the params file")
+	ParamsValidateCmd.Flags().StringVarP(&paramsValidateType, "type", "", "",
+		"Type of the params (api, api_product, application or admin)")
+}
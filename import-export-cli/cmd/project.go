@@ -0,0 +1,48 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Project command related usage Info
+const ProjectCmdLiteral = "project"
+const projectCmdShortDesc = "Work with API, API Product and Application projects offline"
+const projectCmdLongDesc = `Work with the API, API Product and Application projects on the local file system without connecting to any
+of the environments.`
+const projectCmdExamples = utils.ProjectName + ` ` + ProjectCmdLiteral + ` ` + ProjectMigrateCmdLiteral + ` --from 3.2.0 --to 4.0.0 ./PizzaShackAPI-1.0.0`
+
+// ProjectCmd represents the project command
+var ProjectCmd = &cobra.Command{
+	Use:     ProjectCmdLiteral,
+	Short:   projectCmdShortDesc,
+	Long:    projectCmdLongDesc,
+	Example: projectCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ProjectCmdLiteral + " called")
+		cmd.Help()
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(ProjectCmd)
+}
@@ -0,0 +1,105 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var projectMigrateFrom string
+var projectMigrateTo string
+var projectMigrateDryRun bool
+
+// project migrate command related usage Info
+const ProjectMigrateCmdLiteral = "migrate"
+const projectMigrateCmdShortDesc = "Migrate projects exported from an older version of API Manager"
+const projectMigrateCmdLongDesc = `Migrate the API, API Product and Application projects exported from a version of API Manager (--from)
+so that they can be imported to another version (--to), without connecting to any of the environments. The path can be
+a project, a directory of projects or an exported archive (.zip) which are migrated in place. Definition fields are
+renamed, endpoint configurations are restructured, sequences are converted to mediation policies, documents are moved
+to their own directories and deployment_environments.yaml is generated from the gateway environments. The
+transformations which lose or change information are reported so that they can be reviewed before importing the
+projects. Use --dry-run to only report them.
+Supported migrations: ` + impl.ProjectVersion320 + ` to ` + impl.ProjectVersion400
+const projectMigrateCmdExamples = utils.ProjectName + ` ` + ProjectCmdLiteral + ` ` + ProjectMigrateCmdLiteral + ` --from 3.2.0 --to 4.0.0 ./PizzaShackAPI-1.0.0
+` + utils.ProjectName + ` ` + ProjectCmdLiteral + ` ` + ProjectMigrateCmdLiteral + ` --from 3.2.0 --to 4.0.0 ./exported-projects
+` + utils.ProjectName + ` ` + ProjectCmdLiteral + ` ` + ProjectMigrateCmdLiteral + ` --from 3.2.0 ./PizzaShackAPI_1.0.0.zip
+` + utils.ProjectName + ` ` + ProjectCmdLiteral + ` ` + ProjectMigrateCmdLiteral + ` --from 3.2.0 ./exported-projects --dry-run
+NOTE: The flag (--from) is mandatory`
+
+// ProjectMigrateCmd represents the project migrate command
+var ProjectMigrateCmd = &cobra.Command{
+	Use:     ProjectMigrateCmdLiteral + " --from <version> [--to <version>] <path-to-projects>",
+	Short:   projectMigrateCmdShortDesc,
+	Long:    projectMigrateCmdLongDesc,
+	Example: projectMigrateCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ProjectCmdLiteral + " " + ProjectMigrateCmdLiteral + " called")
+		utils.SetResultResource("path", args[0])
+		executeProjectMigrateCmd(args[0])
+	},
+}
+
+// executeProjectMigrateCmd migrates the projects and prints the lossy transformations of each project
+func executeProjectMigrateCmd(path string) {
+	migrated, err := impl.MigrateProjects(path, projectMigrateFrom, projectMigrateTo, projectMigrateDryRun)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while migrating the projects in "+path, err)
+	}
+	utils.SetResultData(migrated)
+
+	noteCount := 0
+	for _, project := range migrated {
+		if projectMigrateDryRun {
+			fmt.Fprintln(utils.MessageWriter(), "Project "+project.Path+" ("+project.Type+") can be migrated")
+		} else {
+			fmt.Fprintln(utils.MessageWriter(), "Project "+project.Path+" ("+project.Type+") migrated")
+		}
+		for _, note := range project.Notes {
+			fmt.Fprintln(utils.MessageWriter(), "  "+note.String())
+		}
+		noteCount += len(project.Notes)
+	}
+	count := strconv.Itoa(len(migrated)) + " project(s)"
+	summary := " from " + projectMigrateFrom + " to " + projectMigrateTo + " with " + strconv.Itoa(noteCount) +
+		" lossy transformation(s)"
+	if projectMigrateDryRun {
+		fmt.Fprintln(utils.MessageWriter(), "Dry run: "+count+" can be migrated"+summary+". No changes were made")
+		return
+	}
+	fmt.Fprintln(utils.MessageWriter(), "Migrated "+count+summary)
+}
+
+// init using Cobra
+func init() {
+	ProjectCmd.AddCommand(ProjectMigrateCmd)
+	ProjectMigrateCmd.Flags().StringVarP(&projectMigrateFrom, "from", "", "",
+		"Version of API Manager the projects are exported from")
+	ProjectMigrateCmd.Flags().StringVarP(&projectMigrateTo, "to", "", impl.ProjectVersion400,
+		"Version of API Manager the projects are migrated to")
+	ProjectMigrateCmd.Flags().BoolVarP(&projectMigrateDryRun, "dry-run", "", false,
+		"Report the lossy transformations without changing the projects")
+	_ = ProjectMigrateCmd.MarkFlagRequired("from")
+}
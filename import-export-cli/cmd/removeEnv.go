@@ -107,8 +107,8 @@ func removeEnv(envName, mainConfigFilePath, envKeysFilePath string) error {
 		return errors.New("environment '" + envName + "' not found in " + mainConfigFilePath)
 	}
 
-	fmt.Println("Successfully removed environment '" + envName + "'")
-	fmt.Println("Execute '" + utils.ProjectName + " " + AddCmdLiteral + " " + AddEnvCmdLiteralTrimmed + " --help' to see how to add a new environment")
+	fmt.Fprintln(utils.MessageWriter(), "Successfully removed environment '"+envName+"'")
+	fmt.Fprintln(utils.MessageWriter(), "Execute '"+utils.ProjectName+" "+AddCmdLiteral+" "+AddEnvCmdLiteralTrimmed+" --help' to see how to add a new environment")
 
 	return nil
 }
@@ -20,6 +20,7 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -49,6 +50,7 @@ var CmdUsername string
 var CmdExportEnvironment string
 var CmdResourceTenantDomain string
 var CmdForceStartFromBegin bool
+var outputFormat string
 
 // RootCmd related info
 const rootCmdShortDesc = "CLI for Importing and Exporting APIs and Applications and Managing WSO2 Micro Integrator"
@@ -75,14 +77,32 @@ var RootCmd = &cobra.Command{
 			cmd.Help()
 		}
 	},
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if outputFormat != utils.OutputFormatText && outputFormat != utils.OutputFormatJSON {
+			utils.HandleErrorAndExit("Invalid output format", utils.NewValidationError("output format should be "+
+				utils.OutputFormatText+" or "+utils.OutputFormatJSON+" but found "+outputFormat))
+		}
+		utils.OutputFormat = outputFormat
+		utils.StartCommandResult(cmd.CommandPath())
+		// the failures returned by the commands are printed by Execute
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		utils.PrintCommandResult()
+	},
 }
 
 // Execute adds all child commands to the root command sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	if err := RootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(-1)
+		var failure *utils.CommandFailure
+		if errors.As(err, &failure) {
+			utils.HandleErrorAndExit(failure.Message, failure.Err)
+		}
+		fmt.Fprintln(utils.MessageWriter(), err)
+		os.Exit(utils.ExitCodeValidationError)
 	}
 }
 
@@ -96,6 +116,9 @@ func init() {
 	RootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose mode")
 	RootCmd.PersistentFlags().BoolVarP(&insecure, "insecure", "k", false,
 		"Allow connections to SSL endpoints without certs")
+	RootCmd.PersistentFlags().StringVar(&outputFormat, "output", utils.OutputFormatText,
+		"Format of the result of the command (text or json). When json, a single result object is printed to the "+
+			"standard output and all the other messages are printed to the standard error")
 	//RootCmd.PersistentFlags().StringP("author", "a", "", "WSO2")
 
 	//viper.BindPFlag("author", RootCmd.PersistentFlags().Lookup("author"))
@@ -74,22 +74,22 @@ func executeSetCmd(mainConfigFilePath string, cmd *cobra.Command) {
 	if flagHttpRequestTimeout > 0 {
 		//Check whether the provided Http time out value is not equal to default value
 		if flagHttpRequestTimeout != configVars.Config.HttpRequestTimeout {
-			fmt.Println("Http Request Timeout is set to : ", flagHttpRequestTimeout)
+			fmt.Fprintln(utils.MessageWriter(), "Http Request Timeout is set to : ", flagHttpRequestTimeout)
 		}
 		configVars.Config.HttpRequestTimeout = flagHttpRequestTimeout
 	} else {
-		fmt.Println("Invalid input for flag --http-request-timeout")
+		fmt.Fprintln(utils.MessageWriter(), "Invalid input for flag --http-request-timeout")
 	}
 
 	//Change Export Directory path
 	if flagExportDirectory != "" && utils.IsValid(flagExportDirectory) {
 		//Check whether the provided export directory is not equal to default value
 		if flagExportDirectory != configVars.Config.ExportDirectory {
-			fmt.Println("Export Directory is set to  : ", flagExportDirectory)
+			fmt.Fprintln(utils.MessageWriter(), "Export Directory is set to  : ", flagExportDirectory)
 		}
 		configVars.Config.ExportDirectory = flagExportDirectory
 	} else {
-		fmt.Println("Invalid input for flag --export-directory")
+		fmt.Fprintln(utils.MessageWriter(), "Invalid input for flag --export-directory")
 	}
 
 	//Change Mode
@@ -97,12 +97,12 @@ func executeSetCmd(mainConfigFilePath string, cmd *cobra.Command) {
 		if strings.EqualFold(flagKubernetesMode, "kubernetes") || strings.EqualFold(flagKubernetesMode, "k8s") {
 			//Check whether the provided mode value is not equal to default value
 			if true != configVars.Config.KubernetesMode {
-				fmt.Println("Mode is set to : ", flagKubernetesMode)
+				fmt.Fprintln(utils.MessageWriter(), "Mode is set to : ", flagKubernetesMode)
 			}
 			configVars.Config.KubernetesMode = true
 		} else if strings.EqualFold(flagKubernetesMode, "default") {
 			if false != configVars.Config.KubernetesMode {
-				fmt.Println("Mode is set to : ", flagKubernetesMode)
+				fmt.Fprintln(utils.MessageWriter(), "Mode is set to : ", flagKubernetesMode)
 			}
 			configVars.Config.KubernetesMode = false
 		} else {
@@ -117,21 +117,21 @@ func executeSetCmd(mainConfigFilePath string, cmd *cobra.Command) {
 		flagTLSRenegotiationMode == utils.TLSRenegotiationFreely {
 		configVars.Config.TLSRenegotiationMode = flagTLSRenegotiationMode
 	} else {
-		fmt.Println("Invalid input for flag --tls-renegotiation-mode")
+		fmt.Fprintln(utils.MessageWriter(), "Invalid input for flag --tls-renegotiation-mode")
 	}
 
 	//VCS configs
 	if configVars.Config.VCSDeletionEnabled != flagVCSDeletionEnabled {
 		if flagVCSDeletionEnabled {
-			fmt.Println("Project deletion is enabled in VCS")
+			fmt.Fprintln(utils.MessageWriter(), "Project deletion is enabled in VCS")
 		} else {
-			fmt.Println("Project deletion is disabled in VCS")
+			fmt.Fprintln(utils.MessageWriter(), "Project deletion is disabled in VCS")
 		}
 		configVars.Config.VCSDeletionEnabled = flagVCSDeletionEnabled
 	}
 	if cmd.Flags().Changed(flagVCSConfigPathName) {
 		configVars.Config.VCSConfigFilePath = flagVCSConfigPath
-		fmt.Println("VCS config file path is set to : " + flagVCSConfigPath)
+		fmt.Fprintln(utils.MessageWriter(), "VCS config file path is set to : "+flagVCSConfigPath)
 	}
 
 	utils.WriteConfigFile(configVars, mainConfigFilePath)
@@ -85,12 +85,12 @@ func executeUndeployAPICmd(credential credentials.Credential, deployments []util
 		// Print info on response
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusCreated {
-			fmt.Println(apiNameForStateChange + " API revision successfully undeployed from the gateways!")
+			fmt.Fprintln(utils.MessageWriter(), apiNameForStateChange+" API revision successfully undeployed from the gateways!")
 		} else {
-			fmt.Println("Error while undeploying the  API: ", resp.Status(), "\n", string(resp.Body()))
+			fmt.Fprintln(utils.MessageWriter(), "Error while undeploying the  API: ", resp.Status(), "\n", string(resp.Body()))
 		}
 	} else {
-		fmt.Println("Error getting OAuth tokens to undeploy the API:" + preCommandErr.Error())
+		fmt.Fprintln(utils.MessageWriter(), "Error getting OAuth tokens to undeploy the API:"+preCommandErr.Error())
 	}
 }
 
@@ -85,12 +85,12 @@ func executeUndeployAPIProductCmd(credential credentials.Credential, deployments
 		// Print info on response
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusCreated {
-			fmt.Println(undeployAPIProductName + " API Product revision successfully undeployed from the gateways!")
+			fmt.Fprintln(utils.MessageWriter(), undeployAPIProductName+" API Product revision successfully undeployed from the gateways!")
 		} else {
-			fmt.Println("Error while undeploying the  APIProduct: ", resp.Status(), "\n", string(resp.Body()))
+			fmt.Fprintln(utils.MessageWriter(), "Error while undeploying the  APIProduct: ", resp.Status(), "\n", string(resp.Body()))
 		}
 	} else {
-		fmt.Println("Error getting OAuth tokens to undeploy the API Product:" + preCommandErr.Error())
+		fmt.Fprintln(utils.MessageWriter(), "Error getting OAuth tokens to undeploy the API Product:"+preCommandErr.Error())
 	}
 }
 
@@ -0,0 +1,125 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var validateProjectPath string
+var validateRulesetFile string
+
+// Validate command related usage Info
+const ValidateCmdLiteral = "validate"
+const validateCmdShortDesc = "Validate an API, API Product or Application project"
+const validateCmdLongDesc = `Validate an API, API Product or Application project (a directory or a zip file) specified by the flag
+(--file, -f) without importing it. If the flag is not given, all the projects declared in the apictl.yaml workspace
+manifest of the current directory are validated. The project files are validated against their schemas and the operations of an API are
+cross-checked with its swagger or OAS3 definition. Additional lint rules (naming conventions, required tags, security
+schemes, etc.) can be provided with a ruleset file (--ruleset). The command fails if any error is found.`
+const validateCmdExamples = utils.ProjectName + ` ` + ValidateCmdLiteral + ` -f ./PizzaShackAPI
+` + utils.ProjectName + ` ` + ValidateCmdLiteral + ` -f ./PizzaShackAPI_1.0.0.zip --ruleset ./lint-rules.yaml
+` + utils.ProjectName + ` ` + ValidateCmdLiteral + `
+NOTE: The flag (--file (-f)) is mandatory when there is no apictl.yaml workspace manifest`
+
+// ValidateCmd represents the validate command
+var ValidateCmd = &cobra.Command{
+	Use:     ValidateCmdLiteral + " [--file <path-to-project>] [--ruleset <path-to-ruleset>]",
+	Short:   validateCmdShortDesc,
+	Long:    validateCmdLongDesc,
+	Example: validateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ValidateCmdLiteral + " called")
+		utils.SetResultResource("file", validateProjectPath)
+		executeValidateCmd(validateProjectPath, validateRulesetFile)
+	},
+}
+
+// executeValidateCmd validates the project and prints the findings. If the project path is not given, all the projects
+// of the workspace manifest are validated
+func executeValidateCmd(projectPath, rulesetFile string) {
+	var ruleset *impl.LintRuleset
+	if rulesetFile != "" {
+		var err error
+		ruleset, err = impl.LoadLintRuleset(rulesetFile)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while reading the lint ruleset", utils.NewValidationError(err.Error()))
+		}
+	}
+
+	var findings []impl.ValidationFinding
+	if projectPath != "" {
+		findings = validateProjectAtPath(projectPath, ruleset)
+	} else {
+		projectPath = utils.WorkspaceManifestFile
+		for _, project := range getWorkspaceProjects("--file (-f)") {
+			fmt.Fprintln(utils.MessageWriter(), "Validating "+project.RelativePath+" ...")
+			for _, finding := range validateProjectAtPath(project.Path, ruleset) {
+				// files of the findings are relative to the workspace so that the projects can be told apart
+				finding.File = project.RelativePath + "/" + finding.File
+				findings = append(findings, finding)
+			}
+		}
+	}
+	utils.SetResultData(findings)
+
+	errorCount := 0
+	for _, finding := range findings {
+		fmt.Fprintln(utils.MessageWriter(), finding.String())
+		if finding.Severity == impl.ValidationSeverityError {
+			errorCount++
+		}
+	}
+	summary := strconv.Itoa(errorCount) + " error(s), " + strconv.Itoa(len(findings)-errorCount) + " warning(s)"
+	if errorCount > 0 {
+		utils.HandleErrorAndExit("Validation of "+projectPath+" failed", utils.NewValidationError(summary))
+	}
+	fmt.Fprintln(utils.MessageWriter(), "Project "+projectPath+" is valid. "+summary)
+}
+
+// validateProjectAtPath validates a project directory or a zip file
+func validateProjectAtPath(projectPath string, ruleset *impl.LintRuleset) []impl.ValidationFinding {
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while reading the project "+projectPath, err)
+	}
+	findings, err := impl.ValidateProject(tmpPath, ruleset)
+	os.RemoveAll(filepath.Dir(tmpPath))
+	if err != nil {
+		utils.HandleErrorAndExit("Error while validating the project "+projectPath, err)
+	}
+	return findings
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(ValidateCmd)
+	ValidateCmd.Flags().StringVarP(&validateProjectPath, "file", "f", "",
+		"Path to the API, API Product or Application project (directory or zip file). Validates the projects of the "+
+			"workspace if not given")
+	ValidateCmd.Flags().StringVarP(&validateRulesetFile, "ruleset", "", "",
+		"Path to a YAML file with the lint rules to apply")
+}
@@ -20,16 +20,21 @@ package cmd
 
 import (
 	"fmt"
-	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/git"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
-var flagVCSDeployEnvName string    // name of the environment the project changes need to be deployed
-var flagVCSDeploySkipRollback bool // specifies whether rolling back on error needs to be avoided
+var flagVCSDeployEnvName string          // name of the environment the project changes need to be deployed
+var flagVCSDeploySkipRollback bool       // specifies whether rolling back on error needs to be avoided
+var flagVCSDeployDryRun bool             // specifies whether only the deployment plan needs to be shown
+var flagVCSDeployParallel int            // maximum number of projects of the same type to deploy at the same time
+var flagVCSDeploySkipValidation bool     // specifies whether validating the projects before importing needs to be skipped
+var flagVCSDeploySubstitutionReport bool // specifies whether the substituted environment variables need to be printed
 
 // deploy command related usage Info
 const deployCmdLiteral = "deploy"
@@ -38,10 +43,17 @@ const deployCmdLongDesc = `Deploys projects to the specified environment specifi
 Only the changed projects compared to the revision at the last successful deployment will be deployed. 
 If any project(s) got failed during the deployment, by default, the operation will rollback the environment to the last successful state. 
 If this needs to be avoided, use --skip-rollback=true
+To only view what would be created, updated or deleted in the environment without deploying anything, use --dry-run
+To deploy several projects at the same time, use --parallel. APIs are always deployed before API Products and API Products before Applications
+The projects are validated before importing them. To skip the validation, use --skip-validation
+If the repository has an apictl.yaml workspace manifest, only the projects declared in it are deployed, in the declared deploy
+order and after the projects they depend on. The environment can be an alias declared in the manifest
 NOTE: --environment (-e) flag is mandatory`
 
 const deployCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev
-` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --skip-rollback=true`
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --skip-rollback=true
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --dry-run
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --parallel 4`
 
 // deployCmd represents the deploy command
 var DeployCmd = &cobra.Command{
@@ -51,9 +63,11 @@ var DeployCmd = &cobra.Command{
 	Example: deployCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + deployCmdLiteral + " called")
+		flagVCSDeployEnvName = resolveWorkspaceEnvironment(flagVCSDeployEnvName)
+		utils.SetResultResource("environment", flagVCSDeployEnvName)
 		if !utils.EnvExistsInMainConfigFile(flagVCSDeployEnvName, utils.MainConfigFilePath) {
-			fmt.Println(flagVCSDeployEnvName, "does not exists. Add it using add env")
-			os.Exit(1)
+			utils.HandleErrorAndExit("Invalid environment", utils.NewValidationError(flagVCSDeployEnvName+
+				" does not exists. Add it using add env"))
 		}
 
 		credential, err := GetCredentials(flagVCSDeployEnvName)
@@ -64,10 +78,25 @@ var DeployCmd = &cobra.Command{
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for deploying the project(s)", err)
 		}
-		failedProjects := git.DeployChangedFiles(accessOAuthToken, flagVCSDeployEnvName)
+		if flagVCSDeployParallel < 1 {
+			utils.HandleErrorAndExit("Invalid value for --parallel",
+				utils.NewValidationError("It should be a positive number"))
+		}
+		validateEnvSubstitutionMode()
+		if flagVCSDeployDryRun {
+			totalProjectsToUpdate, plansPerType := git.PlanChangedFiles(accessOAuthToken, flagVCSDeployEnvName)
+			printDeploymentPlan(flagVCSDeployEnvName, totalProjectsToUpdate, plansPerType)
+			return
+		}
+		failedProjects := git.DeployChangedFiles(accessOAuthToken, flagVCSDeployEnvName, flagVCSDeployParallel,
+			flagVCSDeploySkipValidation)
+		utils.SetResultData(getFailedProjectPaths(failedProjects))
+		if flagVCSDeploySubstitutionReport {
+			printEnvSubstitutionReport()
+		}
 		if failedProjects != nil && len(failedProjects) > 0 && flagVCSDeploySkipRollback == false {
-			fmt.Println("\nRolling back to the last successful revision as there are failures..")
-			err = git.Rollback(accessOAuthToken, flagVCSDeployEnvName)
+			fmt.Fprintln(utils.MessageWriter(), "\nRolling back to the last successful revision as there are failures..")
+			err = git.Rollback(accessOAuthToken, flagVCSDeployEnvName, flagVCSDeployParallel)
 			if err != nil {
 				utils.HandleErrorAndExit("There are project deployment failures. Failed to rollback.", err)
 			} else {
@@ -77,6 +106,59 @@ var DeployCmd = &cobra.Command{
 	},
 }
 
+// getFailedProjectPaths returns the relative paths of the failed projects grouped by the project type
+func getFailedProjectPaths(failedProjects map[string][]*params.ProjectParams) map[string][]string {
+	if len(failedProjects) == 0 {
+		return nil
+	}
+	failedProjectPaths := make(map[string][]string)
+	for projectType, projects := range failedProjects {
+		for _, project := range projects {
+			failedProjectPaths[projectType] = append(failedProjectPaths[projectType], project.RelativePath)
+		}
+	}
+	return failedProjectPaths
+}
+
+// printDeploymentPlan prints the planned operation and the field level changes of each project
+func printDeploymentPlan(environment string, totalProjectsToUpdate int, plansPerType map[string][]*git.ProjectPlan) {
+	if totalProjectsToUpdate == 0 {
+		fmt.Fprintln(utils.MessageWriter(), "Everything is up-to-date")
+		return
+	}
+	fmt.Fprintln(utils.MessageWriter(), "Deployment plan for "+environment+" ("+strconv.Itoa(totalProjectsToUpdate)+")")
+	for _, projectType := range []string{utils.ProjectTypeApi, utils.ProjectTypeApiProduct, utils.ProjectTypeApplication} {
+		plans := plansPerType[projectType]
+		if len(plans) == 0 {
+			continue
+		}
+		fmt.Fprintln(utils.MessageWriter(), "\n"+projectType+"s ("+strconv.Itoa(len(plans))+") ...")
+		for i, plan := range plans {
+			var failed string
+			if plan.Project.FailedDuringPreviousDeploy {
+				failed = "[failed]"
+			}
+			fmt.Fprintln(utils.MessageWriter(), strconv.Itoa(i+1)+": ["+plan.Operation+"]\t"+failed+"\t"+plan.Project.NickName+
+				": ("+plan.Project.RelativePath+")")
+			if plan.Err != nil {
+				fmt.Fprintln(utils.MessageWriter(), "\tError while planning:", plan.Err)
+				continue
+			}
+			for _, diff := range plan.Diffs {
+				switch diff.Type {
+				case utils.DiffTypeAdded:
+					fmt.Fprintln(utils.MessageWriter(), "\t+ "+diff.Path+": "+utils.FormatDiffValue(diff.After))
+				case utils.DiffTypeRemoved:
+					fmt.Fprintln(utils.MessageWriter(), "\t- "+diff.Path+": "+utils.FormatDiffValue(diff.Before))
+				default:
+					fmt.Fprintln(utils.MessageWriter(), "\t~ "+diff.Path+": "+utils.FormatDiffValue(diff.Before)+" => "+
+						utils.FormatDiffValue(diff.After))
+				}
+			}
+		}
+	}
+}
+
 func init() {
 	VCSCmd.AddCommand(DeployCmd)
 
@@ -87,6 +169,13 @@ func init() {
 	DeployCmd.Flags().BoolVarP(&flagVCSDeploySkipRollback, "skipRollback", "", false,
 		"Specifies whether rolling back to the last successful revision during an error situation should be skipped")
 	DeployCmd.Flags().MarkDeprecated("skipRollback", "Use skip-rollback flag")
+	DeployCmd.Flags().BoolVarP(&flagVCSDeployDryRun, "dry-run", "", false,
+		"Shows the create/update/delete operations and the changes per project without deploying them")
+	DeployCmd.Flags().IntVarP(&flagVCSDeployParallel, "parallel", "", git.DefaultDeployParallelism,
+		"Maximum number of projects of the same type to deploy at the same time")
+	DeployCmd.Flags().BoolVarP(&flagVCSDeploySkipValidation, "skip-validation", "", false,
+		"Skips validating the projects before importing them")
+	addEnvSubstitutionFlags(DeployCmd, &flagVCSDeploySubstitutionReport)
 
 	_ = DeployCmd.MarkFlagRequired("environment")
 }
@@ -49,7 +49,7 @@ var VcsInitCmd = &cobra.Command{
 		if err != nil {
 			utils.HandleErrorAndExit("Error initializing repository", err)
 		}
-		fmt.Println("Successfully initialized GIT repository")
+		fmt.Fprintln(utils.MessageWriter(), "Successfully initialized GIT repository")
 	},
 }
 
@@ -35,6 +35,7 @@ var flagVCSStatusEnvName string // name of the environment to be added
 const vcsStatusCmdLiteral = "status"
 const vcsStatusCmdShortDesc = "Shows the list of projects that are ready to deploy"
 const vcsStatusCmdLongDesc = `Shows the list of projects that are ready to deploy to the specified environment by --environment(-e)
+The environment can be an alias declared in the apictl.yaml workspace manifest of the repository.
 NOTE: --environment (-e) flag is mandatory`
 
 const vcsStatusCmdCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsStatusCmdLiteral + ` -e dev`
@@ -47,18 +48,19 @@ var VCSStatusCmd = &cobra.Command{
 	Example: vcsStatusCmdCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + vcsStatusCmdLiteral + " called")
+		flagVCSStatusEnvName = resolveWorkspaceEnvironment(flagVCSStatusEnvName)
 		if !utils.EnvExistsInMainConfigFile(flagVCSStatusEnvName, utils.MainConfigFilePath) {
-			fmt.Println(flagVCSStatusEnvName, "does not exists. Add it using add env")
+			fmt.Fprintln(utils.MessageWriter(), flagVCSStatusEnvName, "does not exists. Add it using add env")
 			os.Exit(1)
 		}
 
 		_, totalProjectsToUpdate, updatedProjectsPerType := git.GetStatus(flagVCSStatusEnvName, git.FromRevTypeLastAttempted)
 		if totalProjectsToUpdate == 0 {
-			fmt.Println("Everything is up-to-date")
+			fmt.Fprintln(utils.MessageWriter(), "Everything is up-to-date")
 			return
 		}
 
-		fmt.Println("Projects to Deploy (" + strconv.Itoa(totalProjectsToUpdate) + ")")
+		fmt.Fprintln(utils.MessageWriter(), "Projects to Deploy ("+strconv.Itoa(totalProjectsToUpdate)+")")
 		printProjectsToUpdate(utils.ProjectTypeApi, updatedProjectsPerType[utils.ProjectTypeApi])
 		printProjectsToUpdate(utils.ProjectTypeApiProduct, updatedProjectsPerType[utils.ProjectTypeApiProduct])
 		printProjectsToUpdate(utils.ProjectTypeApplication, updatedProjectsPerType[utils.ProjectTypeApplication])
@@ -67,7 +69,7 @@ var VCSStatusCmd = &cobra.Command{
 
 func printProjectsToUpdate(projectType string, projects []*params.ProjectParams) {
 	if len(projects) != 0 {
-		fmt.Println("\n" + projectType + "s (" + strconv.Itoa(len(projects)) + ") ...")
+		fmt.Fprintln(utils.MessageWriter(), "\n"+projectType+"s ("+strconv.Itoa(len(projects))+") ...")
 		for i, projectParam := range projects {
 			var operation string
 			var failed string
@@ -79,8 +81,8 @@ func printProjectsToUpdate(projectType string, projects []*params.ProjectParams)
 			if projectParam.FailedDuringPreviousDeploy {
 				failed = "[failed]"
 			}
-			fmt.Println(strconv.Itoa(i+1) + ": " + operation + "\t" + failed + "\t" + projectParam.NickName +
-				": (" + projectParam.RelativePath + ")")
+			fmt.Fprintln(utils.MessageWriter(), strconv.Itoa(i+1)+": "+operation+"\t"+failed+"\t"+projectParam.NickName+
+				": ("+projectParam.RelativePath+")")
 		}
 	}
 }
@@ -40,8 +40,8 @@ var VersionCmd = &cobra.Command{
 	Long:    versionCmdLongDesc,
 	Example: versionCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Version:", Version)
-		fmt.Println("Build Date:", BuildDate)
+		fmt.Fprintln(utils.MessageWriter(), "Version:", Version)
+		fmt.Fprintln(utils.MessageWriter(), "Build Date:", BuildDate)
 	},
 }
 
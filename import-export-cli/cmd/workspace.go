@@ -0,0 +1,58 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// findWorkspace finds the workspace manifest (apictl.yaml) of the current directory or its parents
+// Returns nil if there is no manifest
+func findWorkspace() *impl.Workspace {
+	workspace, err := impl.FindWorkspace(".")
+	if err != nil {
+		utils.HandleErrorAndExit("Error while reading the workspace manifest", err)
+	}
+	return workspace
+}
+
+// resolveWorkspaceEnvironment returns the environment name of an alias declared in the workspace manifest. Any other
+// name is returned as it is
+func resolveWorkspaceEnvironment(environment string) string {
+	workspace := findWorkspace()
+	if workspace == nil {
+		return environment
+	}
+	return workspace.ResolveEnvironment(environment)
+}
+
+// getWorkspaceProjects returns the projects of the workspace manifest which are used when the path of a project is
+// not given with the flag
+func getWorkspaceProjects(flag string) []*impl.WorkspaceProject {
+	workspace := findWorkspace()
+	if workspace == nil {
+		utils.HandleErrorAndExit("Invalid arguments", utils.NewValidationError("the flag "+flag+
+			" is required when there is no "+utils.WorkspaceManifestFile+" workspace manifest"))
+	}
+	projects, err := workspace.DiscoverProjects()
+	if err != nil {
+		utils.HandleErrorAndExit("Error while discovering the projects of the workspace", err)
+	}
+	return projects
+}
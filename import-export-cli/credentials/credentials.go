@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -31,6 +33,22 @@ import (
 // DefaultConfigFile name
 var DefaultConfigFile = "keys.json"
 
+// DefaultEncryptedConfigFile name of the file used by the encrypted credential store
+var DefaultEncryptedConfigFile = "keys.enc"
+
+// Types of the credential stores that can be set as credStore
+const (
+	// CredStoreJson stores base64 encoded credentials in keys.json (default)
+	CredStoreJson = "json"
+	// CredStorePass stores credentials using the pass password manager
+	CredStorePass = "pass"
+	// CredStoreEncrypted stores credentials in an AES-GCM encrypted file unlocked by a master passphrase
+	CredStoreEncrypted = "encrypted"
+)
+
+// CredStores are the supported types of credential stores
+var CredStores = []string{CredStoreJson, CredStorePass, CredStoreEncrypted}
+
 // Credential for storing apim user details
 type Credential struct {
 	// Username of user
@@ -57,8 +75,31 @@ type Credentials struct {
 type Environment struct {
 	APIM Credential   `json:"apim"`
 	MI   MiCredential `json:"mi"`
+	// APIMTokens are the cached access tokens of apim by the scopes they are issued for
+	APIMTokens map[string]APIMToken `json:"apimTokens,omitempty"`
+}
+
+// APIMToken for caching the access token issued to the cli for an apim environment
+type APIMToken struct {
+	// AccessToken issued to the cli
+	AccessToken string `json:"accessToken"`
+	// RefreshToken to get a new access token once the access token is expired
+	RefreshToken string `json:"refreshToken"`
+	// ExpiresAt is the time (unix seconds) the access token expires at
+	ExpiresAt int64 `json:"expiresAt"`
+	// ClientId of the cli client the token is issued to
+	ClientId string `json:"clientId"`
+	// Scopes requested for the access token
+	Scopes string `json:"scopes,omitempty"`
 }
 
+// tokenExpiryBufferSeconds is the time before the actual expiry an access token is considered as expired, so that the
+// token does not expire while the command is running
+const tokenExpiryBufferSeconds = 60
+
+// defaultCredentialStore is the loaded default credential store, so the store is loaded (and unlocked) only once
+var defaultCredentialStore Store
+
 type MgAdapterEnv struct {
 	// AccessToken of microgateway adapter
 	AccessToken string `json:"accessToken"`
@@ -73,27 +114,150 @@ func GetCredentialStore(f string) (Store, error) {
 	if err != nil {
 		return nil, err
 	}
-	return js, nil
+	if !js.IsKeychainEnabled() {
+		return js, nil
+	}
+	store, err := NewCredentialStore(js.CredStore(), filepath.Dir(f))
+	if err != nil {
+		return nil, err
+	}
+	err = store.Load()
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// NewCredentialStore creates a credential store of the given type without loading it
+// credStore is the type of the store (json, pass or encrypted)
+// dir is the directory which keeps the credential files of file based stores
+func NewCredentialStore(credStore, dir string) (Store, error) {
+	switch credStore {
+	case "", CredStoreJson:
+		return NewJsonStore(filepath.Join(dir, DefaultConfigFile)), nil
+	case CredStorePass:
+		return NewPassStore(), nil
+	case CredStoreEncrypted:
+		return NewEncryptedFileStore(filepath.Join(dir, DefaultEncryptedConfigFile)), nil
+	}
+	return nil, fmt.Errorf("unsupported credential store %s, supported stores are %s", credStore,
+		strings.Join(CredStores, ", "))
+}
+
+// SetDefaultCredentialStoreType selects the type of the store which keeps the credentials (json, pass or encrypted).
+// The selection is kept in the default keys.json file.
+func SetDefaultCredentialStoreType(credStore string) error {
+	js := NewJsonStore(filepath.Join(utils.LocalCredentialsDirectoryPath, DefaultConfigFile))
+	err := js.Load()
+	if err != nil {
+		return err
+	}
+	// the selected store should be loaded again
+	defaultCredentialStore = nil
+	return js.SetCredStore(credStore)
+}
+
+// GetDefaultCredentialStoreType returns the type of the store which keeps the credentials (json, pass or encrypted)
+func GetDefaultCredentialStoreType() (string, error) {
+	js := NewJsonStore(filepath.Join(utils.LocalCredentialsDirectoryPath, DefaultConfigFile))
+	err := js.Load()
+	if err != nil {
+		return "", err
+	}
+	if !js.IsKeychainEnabled() {
+		return CredStoreJson, nil
+	}
+	return js.CredStore(), nil
 }
 
 // GetDefaultCredentialStore returns store from default path
 func GetDefaultCredentialStore() (Store, error) {
-	return GetCredentialStore(filepath.Join(utils.LocalCredentialsDirectoryPath, DefaultConfigFile))
+	if defaultCredentialStore != nil {
+		return defaultCredentialStore, nil
+	}
+	store, err := GetCredentialStore(filepath.Join(utils.LocalCredentialsDirectoryPath, DefaultConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	defaultCredentialStore = store
+	return store, nil
 }
 
-// GetOAuthAccessToken generates an accesstoken for CLI
+// GetOAuthAccessToken returns an accesstoken for CLI. The access token is cached in the credential store and reused
+// until it expires. An expired access token is renewed using the refresh token and the credentials are used to
+// generate a new token only if the refresh fails.
 func GetOAuthAccessToken(credential Credential, env string) (string, error) {
+	store, err := GetDefaultCredentialStore()
+	if err != nil {
+		return "", err
+	}
+	return GetOAuthAccessTokenFromStore(store, credential, env, utils.OAuthTokenScopes, time.Now())
+}
+
+// GetOAuthAdminAccessToken returns an accesstoken with the scopes required to export and import the admin artifacts.
+// Only the commands managing the admin artifacts should use it.
+func GetOAuthAdminAccessToken(credential Credential, env string) (string, error) {
+	store, err := GetDefaultCredentialStore()
+	if err != nil {
+		return "", err
+	}
+	return GetOAuthAccessTokenFromStore(store, credential, env, utils.OAuthAdminTokenScopes, time.Now())
+}
+
+// GetOAuthAccessTokenFromStore returns an accesstoken for CLI using the token cached in the given store
+// The tokens are cached by the scopes, so the tokens issued for different scopes do not replace each other. now is the
+// current time which is used to check the expiry of the cached token
+func GetOAuthAccessTokenFromStore(store Store, credential Credential, env, scopes string, now time.Time) (string,
+	error) {
 	tokenEndpoint := utils.GetInternalTokenEndpointOfEnv(env, utils.MainConfigFilePath)
-	data, err := utils.GetOAuthTokens(credential.Username, credential.Password,
-		Base64Encode(credential.ClientId+":"+credential.ClientSecret),
-		tokenEndpoint)
+	b64EncodedClientIDClientSecret := Base64Encode(credential.ClientId + ":" + credential.ClientSecret)
+
+	token, err := store.GetAPIMToken(env, scopes)
+	if err == nil && token.ClientId == credential.ClientId {
+		if token.isValid(now) {
+			utils.Logln(utils.LogPrefixInfo + "Using the cached access token of " + env)
+			return token.AccessToken, nil
+		}
+		if token.RefreshToken != "" {
+			utils.Logln(utils.LogPrefixInfo + "Refreshing the expired access token of " + env)
+			tokenResponse, err := utils.RefreshOAuthTokens(token.RefreshToken, b64EncodedClientIDClientSecret,
+				tokenEndpoint, scopes)
+			if err == nil {
+				return cacheOAuthToken(store, credential, env, scopes, tokenResponse, now)
+			}
+			utils.Logln(utils.LogPrefixWarning + "Unable to refresh the access token, generating a new token: " +
+				err.Error())
+		}
+	}
+
+	tokenResponse, err := utils.GetOAuthTokenResponse(credential.Username, credential.Password,
+		b64EncodedClientIDClientSecret, tokenEndpoint, scopes)
 	if err != nil {
 		return "", err
 	}
-	if accessToken, ok := data["access_token"]; ok {
-		return accessToken, nil
+	return cacheOAuthToken(store, credential, env, scopes, tokenResponse, now)
+}
+
+// cacheOAuthToken keeps the issued token in the store and returns the access token. Failing to cache the token is
+// not an error as the token can still be used.
+func cacheOAuthToken(store Store, credential Credential, env, scopes string, tokenResponse *utils.TokenResponse,
+	now time.Time) (string, error) {
+	token := APIMToken{
+		AccessToken:  tokenResponse.AccessToken,
+		RefreshToken: tokenResponse.RefreshToken,
+		ExpiresAt:    now.Unix() + tokenResponse.ExpiresIn,
+		ClientId:     credential.ClientId,
+		Scopes:       scopes,
+	}
+	if err := store.SetAPIMToken(env, token); err != nil {
+		utils.Logln(utils.LogPrefixWarning + "Unable to cache the access token of " + env + ": " + err.Error())
 	}
-	return "", errors.New("access_token not found")
+	return token.AccessToken, nil
+}
+
+// isValid returns whether the access token can be used at the given time
+func (t APIMToken) isValid(now time.Time) bool {
+	return t.AccessToken != "" && now.Unix() < t.ExpiresAt-tokenExpiryBufferSeconds
 }
 
 // GetBasicAuth returns basic auth username:password encoded in base64
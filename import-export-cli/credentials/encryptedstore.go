@@ -0,0 +1,188 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// CredStorePassphraseEnvVar is the environment variable to provide the passphrase of the encrypted store without
+// prompting (eg: in CI environments)
+const CredStorePassphraseEnvVar = "APICTL_CRED_STORE_PASSPHRASE"
+
+// scrypt parameters used to derive the encryption key from the passphrase
+const (
+	encryptedStoreVersion   = 1
+	encryptedStoreKDF       = "scrypt"
+	encryptedStoreKeyLength = 32
+	encryptedStoreSaltSize  = 16
+	scryptN                 = 32768
+	scryptR                 = 8
+	scryptP                 = 1
+)
+
+// EncryptedFileStore is storing keys in a json file encrypted with AES-GCM using a key derived from a master
+// passphrase. The passphrase is read from APICTL_CRED_STORE_PASSPHRASE or prompted when it is first needed.
+type EncryptedFileStore struct {
+	*JsonStore
+}
+
+// encryptedFile is the content of the file written by the encrypted store
+type encryptedFile struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// NewEncryptedFileStore creates a new encrypted store
+func NewEncryptedFileStore(path string) *EncryptedFileStore {
+	return NewEncryptedFileStoreWithPassphrase(path, "")
+}
+
+// NewEncryptedFileStoreWithPassphrase creates a new encrypted store which is unlocked using the given passphrase.
+// If the passphrase is empty, it is resolved from the environment or prompted when needed.
+func NewEncryptedFileStoreWithPassphrase(path, passphrase string) *EncryptedFileStore {
+	return &EncryptedFileStore{
+		JsonStore: &JsonStore{
+			Path:  path,
+			codec: &aesGCMCodec{passphrase: passphrase},
+		},
+	}
+}
+
+// aesGCMCodec encrypts and decrypts the content of the credential file
+type aesGCMCodec struct {
+	passphrase string
+}
+
+// encrypts the given data with a new salt and a nonce
+func (c *aesGCMCodec) encode(data []byte) ([]byte, error) {
+	passphrase, err := c.getPassphrase(true)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, encryptedStoreSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(encryptedFile{
+		Version: encryptedStoreVersion,
+		KDF:     encryptedStoreKDF,
+		Salt:    salt,
+		Nonce:   nonce,
+		Data:    gcm.Seal(nil, nonce, data, nil),
+	}, "", "  ")
+}
+
+// decrypts the given content of an encrypted file
+func (c *aesGCMCodec) decode(content []byte) ([]byte, error) {
+	var file encryptedFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+	if file.Version != encryptedStoreVersion || file.KDF != encryptedStoreKDF {
+		return nil, fmt.Errorf("unsupported encrypted credential store version %d", file.Version)
+	}
+	passphrase, err := c.getPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid nonce in encrypted credential store")
+	}
+	data, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, errors.New("unable to decrypt the credential store, the passphrase may be incorrect")
+	}
+	return data, nil
+}
+
+// returns the passphrase from the codec, the environment or by prompting the user. The prompted passphrase is
+// confirmed when a new passphrase is created.
+func (c *aesGCMCodec) getPassphrase(confirm bool) (string, error) {
+	if c.passphrase != "" {
+		return c.passphrase, nil
+	}
+	if passphrase := os.Getenv(CredStorePassphraseEnvVar); passphrase != "" {
+		c.passphrase = passphrase
+		return passphrase, nil
+	}
+
+	fmt.Fprint(utils.MessageWriter(), "Credential store passphrase:")
+	passphrase, err := terminal.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(utils.MessageWriter())
+	if err != nil {
+		return "", err
+	}
+	if len(passphrase) == 0 {
+		return "", errors.New("passphrase of the credential store cannot be empty")
+	}
+	if confirm {
+		fmt.Fprint(utils.MessageWriter(), "Confirm passphrase:")
+		confirmation, err := terminal.ReadPassword(int(syscall.Stdin))
+		fmt.Fprintln(utils.MessageWriter())
+		if err != nil {
+			return "", err
+		}
+		if string(confirmation) != string(passphrase) {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+	c.passphrase = string(passphrase)
+	return c.passphrase, nil
+}
+
+// creates an AES-GCM cipher with a key derived from the passphrase and the salt
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, encryptedStoreKeyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
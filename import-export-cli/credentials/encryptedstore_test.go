@@ -0,0 +1,95 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptedFileStoreRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-credentials")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, DefaultEncryptedConfigFile)
+
+	store := NewEncryptedFileStoreWithPassphrase(path, "secret")
+	assert.Nil(t, store.Load())
+	assert.Nil(t, store.SetAPIMCredentials("dev", "admin", "admin-pass", "client-id", "client-secret"))
+
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(content), Base64Encode("admin-pass"), "Credentials should not be in plain text")
+
+	reloaded := NewEncryptedFileStoreWithPassphrase(path, "secret")
+	assert.Nil(t, reloaded.Load())
+	cred, err := reloaded.GetAPIMCredentials("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, Credential{"admin", "admin-pass", "client-id", "client-secret"}, cred)
+}
+
+func TestEncryptedFileStoreWrongPassphrase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-credentials")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, DefaultEncryptedConfigFile)
+
+	store := NewEncryptedFileStoreWithPassphrase(path, "secret")
+	assert.Nil(t, store.Load())
+	assert.Nil(t, store.SetMGToken("mg", "token"))
+
+	err = NewEncryptedFileStoreWithPassphrase(path, "wrong").Load()
+	assert.NotNil(t, err, "Store should not be loaded with a wrong passphrase")
+}
+
+func TestMigrateCredentialsFromJsonToEncryptedStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-credentials")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	from := NewJsonStore(filepath.Join(dir, DefaultConfigFile))
+	assert.Nil(t, from.Load())
+	assert.Nil(t, from.SetAPIMCredentials("dev", "admin", "admin", "id", "secret"))
+	assert.Nil(t, from.SetMICredentials("dev", "mi-admin", "mi-pass", "mi-token"))
+	assert.Nil(t, from.SetMINodeAccessToken("dev", "dev#1", "node-token"))
+	assert.Nil(t, from.SetMGToken("mg", "mg-token"))
+
+	to := NewEncryptedFileStoreWithPassphrase(filepath.Join(dir, DefaultEncryptedConfigFile), "secret")
+	assert.Nil(t, to.Load())
+
+	count, err := MigrateCredentials(from, to)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	assert.False(t, from.HasAPIM("dev"))
+	assert.False(t, from.HasMI("dev"))
+	assert.False(t, from.HasMG("mg"))
+
+	miCred, err := to.GetMICredentials("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, MiCredential{Username: "mi-admin", Password: "mi-pass", AccessToken: "mi-token",
+		NodeAccessTokens: map[string]string{"dev#1": "node-token"}}, miCred)
+	mgToken, err := to.GetMGToken("mg")
+	assert.Nil(t, err)
+	assert.Equal(t, "mg-token", mgToken.AccessToken)
+}
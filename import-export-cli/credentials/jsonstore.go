@@ -23,6 +23,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 // PlainTextWarnMessage warning message
@@ -35,6 +38,14 @@ type JsonStore struct {
 
 	// internal usage
 	credentials Credentials
+	// codec to encode the file content before writing and decode after reading. Content is plain text if nil
+	codec fileCodec
+}
+
+// fileCodec encodes and decodes the content of a credential file
+type fileCodec interface {
+	encode(data []byte) ([]byte, error)
+	decode(data []byte) ([]byte, error)
 }
 
 // NewJsonStore creates a new store
@@ -49,6 +60,12 @@ func (s *JsonStore) Load() error {
 		if err != nil {
 			return err
 		}
+		if s.codec != nil {
+			data, err = s.codec.decode(data)
+			if err != nil {
+				return err
+			}
+		}
 
 		var cred Credentials
 		err = json.Unmarshal(data, &cred)
@@ -56,6 +73,12 @@ func (s *JsonStore) Load() error {
 			return err
 		}
 
+		if cred.Environments == nil {
+			cred.Environments = make(map[string]Environment)
+		}
+		if cred.MgwAdapterEnvs == nil {
+			cred.MgwAdapterEnvs = make(map[string]MgAdapterEnv)
+		}
 		s.credentials = cred
 		return nil
 	} else if err == nil && info.IsDir() {
@@ -75,7 +98,14 @@ func (s *JsonStore) persist() error {
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(s.Path, data, os.ModePerm)
+	if s.codec != nil {
+		data, err = s.codec.encode(data)
+		if err != nil {
+			return err
+		}
+	}
+	// credentials should only be readable by the owner
+	err = ioutil.WriteFile(s.Path, data, 0600)
 	if err != nil {
 		return err
 	}
@@ -118,15 +148,78 @@ func (s *JsonStore) SetAPIMCredentials(env, username, password, clientId, client
 		ClientId:     Base64Encode(clientId),
 		ClientSecret: Base64Encode(clientSecret),
 	}
+	// tokens issued for the previous credentials are no longer used
+	environment.APIMTokens = nil
 	s.credentials.Environments[env] = environment
 	err := s.persist()
 	if err != nil {
 		return err
 	}
-	fmt.Printf(PlainTextWarnMessage, s.Path)
+	s.warnIfPlainText()
 	return nil
 }
 
+// GetAPIMToken returns the cached access token of apim issued for the given scopes from the store or an error
+func (s *JsonStore) GetAPIMToken(env, scopes string) (APIMToken, error) {
+	if environment, ok := s.credentials.Environments[env]; ok {
+		if token, ok := environment.APIMTokens[scopes]; ok {
+			return decodeAPIMToken(token)
+		}
+	}
+	return APIMToken{}, fmt.Errorf("access token not found for APIM in %s", env)
+}
+
+// GetAPIMTokens returns all the cached access tokens of apim in a given environment
+func (s *JsonStore) GetAPIMTokens(env string) ([]APIMToken, error) {
+	environment := s.credentials.Environments[env]
+	scopes := make([]string, 0, len(environment.APIMTokens))
+	for scope := range environment.APIMTokens {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	var tokens []APIMToken
+	for _, scope := range scopes {
+		token, err := decodeAPIMToken(environment.APIMTokens[scope])
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// SetAPIMToken caches the access token of apim in the store by the scopes of the token
+func (s *JsonStore) SetAPIMToken(env string, token APIMToken) error {
+	environment := s.credentials.Environments[env]
+	if environment.APIMTokens == nil {
+		environment.APIMTokens = make(map[string]APIMToken)
+	}
+	environment.APIMTokens[token.Scopes] = APIMToken{
+		AccessToken:  Base64Encode(token.AccessToken),
+		RefreshToken: Base64Encode(token.RefreshToken),
+		ExpiresAt:    token.ExpiresAt,
+		ClientId:     token.ClientId,
+		Scopes:       token.Scopes,
+	}
+	s.credentials.Environments[env] = environment
+	return s.persist()
+}
+
+// decodes a token cached in the store
+func decodeAPIMToken(token APIMToken) (APIMToken, error) {
+	accessToken, err := Base64Decode(token.AccessToken)
+	if err != nil {
+		return APIMToken{}, err
+	}
+	refreshToken, err := Base64Decode(token.RefreshToken)
+	if err != nil {
+		return APIMToken{}, err
+	}
+	token.AccessToken = accessToken
+	token.RefreshToken = refreshToken
+	return token, nil
+}
+
 // GetMICredentials returns credentials for micro integrator from the store or an error
 func (s *JsonStore) GetMICredentials(env string) (MiCredential, error) {
 	if environment, ok := s.credentials.Environments[env]; ok {
@@ -143,7 +236,19 @@ func (s *JsonStore) GetMICredentials(env string) (MiCredential, error) {
 			return MiCredential{}, err
 		}
 		credential := MiCredential{
-			username, password, accessToken,
+			Username:    username,
+			Password:    password,
+			AccessToken: accessToken,
+		}
+		for nodeEnv, encodedToken := range environment.MI.NodeAccessTokens {
+			nodeAccessToken, err := Base64Decode(encodedToken)
+			if err != nil {
+				return MiCredential{}, err
+			}
+			if credential.NodeAccessTokens == nil {
+				credential.NodeAccessTokens = make(map[string]string)
+			}
+			credential.NodeAccessTokens[nodeEnv] = nodeAccessToken
 		}
 		return credential, nil
 	}
@@ -163,10 +268,32 @@ func (s *JsonStore) SetMICredentials(env, username, password, accessToken string
 	if err != nil {
 		return err
 	}
-	fmt.Printf(PlainTextWarnMessage, s.Path)
+	s.warnIfPlainText()
 	return nil
 }
 
+// SetMINodeAccessToken sets the access token of a node of the mi cluster in env. The access tokens of the nodes are
+// kept with the credentials of env and removed with them
+func (s *JsonStore) SetMINodeAccessToken(env, nodeEnv, accessToken string) error {
+	environment, ok := s.credentials.Environments[env]
+	if !ok || !miCredentialsExists(environment.MI) {
+		return fmt.Errorf("credentials not found for Mi in %s, use login", env)
+	}
+	if environment.MI.NodeAccessTokens == nil {
+		environment.MI.NodeAccessTokens = make(map[string]string)
+	}
+	environment.MI.NodeAccessTokens[nodeEnv] = Base64Encode(accessToken)
+	s.credentials.Environments[env] = environment
+	return s.persist()
+}
+
+// warns the user if the credentials are written to the file without encrypting
+func (s *JsonStore) warnIfPlainText() {
+	if s.codec == nil {
+		fmt.Fprintf(utils.MessageWriter(), PlainTextWarnMessage, s.Path)
+	}
+}
+
 // GetMGToken returns token for microgateway adapter from the store or an error
 func (s *JsonStore) GetMGToken(env string) (MgAdapterEnv, error) {
 	if mgAdapterEnv, ok := s.credentials.MgwAdapterEnvs[env]; ok {
@@ -199,6 +326,7 @@ func (s *JsonStore) EraseAPIM(env string) error {
 	} else {
 		// remove only apim credentials
 		environment.APIM = Credential{}
+		environment.APIMTokens = nil
 		s.credentials.Environments[env] = environment
 	}
 	return s.persist()
@@ -235,7 +363,42 @@ func (s *JsonStore) EraseMG(env string) error {
 
 // IsKeychainEnabled returns if another store is activated
 func (s *JsonStore) IsKeychainEnabled() bool {
-	return s.credentials.CredStore != ""
+	return s.credentials.CredStore != "" && s.credentials.CredStore != CredStoreJson
+}
+
+// CredStore returns the type of the store which is selected to keep the credentials
+func (s *JsonStore) CredStore() string {
+	return s.credentials.CredStore
+}
+
+// SetCredStore selects the type of the store to keep the credentials
+func (s *JsonStore) SetCredStore(credStore string) error {
+	if credStore == CredStoreJson {
+		// json store is the default
+		credStore = ""
+	}
+	s.credentials.CredStore = credStore
+	return s.persist()
+}
+
+// Environments returns the names of the environments which have apim or mi credentials in the store
+func (s *JsonStore) Environments() ([]string, error) {
+	var envs []string
+	for env := range s.credentials.Environments {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	return envs, nil
+}
+
+// MGEnvironments returns the names of the microgateway adapter environments which have tokens in the store
+func (s *JsonStore) MGEnvironments() ([]string, error) {
+	var envs []string
+	for env := range s.credentials.MgwAdapterEnvs {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	return envs, nil
 }
 
 // HasAPIM return the existance of apim credentials in the store for a given environment
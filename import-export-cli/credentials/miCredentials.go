@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 	"syscall"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -39,10 +40,18 @@ type MiCredential struct {
 	Password string `json:"password"`
 	// AccessToken of mi
 	AccessToken string `json:"accessToken"`
+	// NodeAccessTokens of the nodes of a mi cluster by the environment addressing the node
+	NodeAccessTokens map[string]string `json:"nodeAccessTokens,omitempty"`
 }
 
+// miCredentialStoreMutex guards the credential store when the nodes of a Micro Integrator cluster are invoked concurrently
+var miCredentialStoreMutex sync.Mutex
+
 // GetMICredentials returns credentials for mi
 func GetMICredentials(env string) (MiCredential, error) {
+	if clusterEnv, ok := utils.GetClusterEnvOfMINode(env); ok {
+		return getMINodeCredentials(clusterEnv, env)
+	}
 
 	store, err := GetDefaultCredentialStore()
 	if err != nil {
@@ -50,18 +59,18 @@ func GetMICredentials(env string) (MiCredential, error) {
 	}
 
 	if !utils.MIExistsInEnv(env, utils.MainConfigFilePath) {
-		fmt.Println("MI does not exists in", env, "Add it using add env")
+		fmt.Fprintln(utils.MessageWriter(), "MI does not exists in", env, "Add it using add env")
 		os.Exit(1)
 	}
 
 	if !store.HasMI(env) {
 
-		fmt.Println("Login to MI in", env)
+		fmt.Fprintln(utils.MessageWriter(), "Login to MI in", env)
 		err = RunMILogin(store, env, "", "")
 		if err != nil {
 			return MiCredential{}, err
 		}
-		fmt.Println()
+		fmt.Fprintln(utils.MessageWriter())
 	}
 	cred, err := store.GetMICredentials(env)
 	if err != nil {
@@ -70,13 +79,61 @@ func GetMICredentials(env string) (MiCredential, error) {
 	return cred, nil
 }
 
+// getMINodeCredentials returns credentials for a node of the Micro Integrator cluster in env
+// The nodes share the credentials used to login to the environment while each node has its own access token, which is
+// kept with the credentials of the environment
+func getMINodeCredentials(env, nodeEnv string) (MiCredential, error) {
+	store, err := GetDefaultCredentialStore()
+	if err != nil {
+		return MiCredential{}, err
+	}
+
+	miCredentialStoreMutex.Lock()
+	cred, err := store.GetMICredentials(env)
+	miCredentialStoreMutex.Unlock()
+	if err != nil {
+		return MiCredential{}, err
+	}
+	if accessToken, ok := cred.NodeAccessTokens[nodeEnv]; ok {
+		return MiCredential{Username: cred.Username, Password: cred.Password, AccessToken: accessToken}, nil
+	}
+
+	accessToken, err := GetOAuthAccessTokenForMI(cred.Username, cred.Password, nodeEnv)
+	if err != nil {
+		return MiCredential{}, err
+	}
+
+	miCredentialStoreMutex.Lock()
+	defer miCredentialStoreMutex.Unlock()
+	err = store.SetMINodeAccessToken(env, nodeEnv, accessToken)
+	if err != nil {
+		return MiCredential{}, err
+	}
+	return MiCredential{Username: cred.Username, Password: cred.Password, AccessToken: accessToken}, nil
+}
+
+// revokeMINodeAccessTokens revokes the access tokens of the nodes of the Micro Integrator cluster kept in cred
+func revokeMINodeAccessTokens(cred MiCredential) {
+	for nodeEnv, accessToken := range cred.NodeAccessTokens {
+		err := RevokeAccessTokenForMI(nodeEnv, accessToken)
+		if err != nil {
+			fmt.Fprintln(utils.MessageWriter(), "Error logging out of the MI node", nodeEnv+":", err)
+		}
+	}
+}
+
 // UpdateMIAccessToken updates the access token for mi
 func UpdateMIAccessToken(env, accessToken string) error {
+	miCredentialStoreMutex.Lock()
+	defer miCredentialStoreMutex.Unlock()
 
 	store, err := GetDefaultCredentialStore()
 	if err != nil {
 		return err
 	}
+	if clusterEnv, ok := utils.GetClusterEnvOfMINode(env); ok {
+		return store.SetMINodeAccessToken(clusterEnv, env, accessToken)
+	}
 	cred, err := store.GetMICredentials(env)
 	if err != nil {
 		return err
@@ -142,11 +199,11 @@ func RevokeAccessTokenForMI(env, token string) error {
 // RunMILogin prompt user to input MI management API username and password
 func RunMILogin(store Store, environment, username, password string) error {
 	if !utils.MIExistsInEnv(environment, utils.MainConfigFilePath) {
-		fmt.Println("MI does not exists in", environment, "Add it using add env")
+		fmt.Fprintln(utils.MessageWriter(), "MI does not exists in", environment, "Add it using add env")
 		os.Exit(1)
 	}
 	if username == "" {
-		fmt.Print("Username:")
+		fmt.Fprint(utils.MessageWriter(), "Username:")
 		scanner := bufio.NewScanner(os.Stdin)
 		if scanner.Scan() {
 			username = scanner.Text()
@@ -154,13 +211,13 @@ func RunMILogin(store Store, environment, username, password string) error {
 	}
 
 	if password == "" {
-		fmt.Print("Password:")
+		fmt.Fprint(utils.MessageWriter(), "Password:")
 		pass, err := terminal.ReadPassword(int(syscall.Stdin))
 		if err != nil {
 			return err
 		}
 		password = string(pass)
-		fmt.Println()
+		fmt.Fprintln(utils.MessageWriter())
 	}
 
 	accessToken, err := GetOAuthAccessTokenForMI(username, password, environment)
@@ -168,12 +225,10 @@ func RunMILogin(store Store, environment, username, password string) error {
 		return err
 	}
 
-	fmt.Println("Logged into MI in", environment, "environment")
-	err = store.SetMICredentials(environment, username, password, accessToken)
-	if err != nil {
-		return err
-	}
-	return nil
+	fmt.Fprintln(utils.MessageWriter(), "Logged into MI in", environment, "environment")
+	// the access tokens of the nodes of a cluster are removed with the old credentials, so the nodes obtain new ones
+	// with the new credentials when they are invoked next
+	return store.SetMICredentials(environment, username, password, accessToken)
 }
 
 // RunMILogout revoke mi management token and remove credentials from the store
@@ -186,11 +241,12 @@ func RunMILogout(environment string) error {
 	if err != nil {
 		return err
 	}
+	revokeMINodeAccessTokens(cred)
 	store, err := GetDefaultCredentialStore()
 	if err != nil {
 		return err
 	}
-	fmt.Println("Logged out from MI in", environment, "environment")
+	fmt.Fprintln(utils.MessageWriter(), "Logged out from MI in", environment, "environment")
 	return store.EraseMI(environment)
 }
 
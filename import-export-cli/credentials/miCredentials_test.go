@@ -0,0 +1,66 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonStoreMINodeAccessTokens(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-credentials")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewJsonStore(filepath.Join(dir, DefaultConfigFile))
+	assert.Nil(t, store.Load())
+	assert.NotNil(t, store.SetMINodeAccessToken("cluster", "cluster#1", "node-token"),
+		"Node tokens should not be kept without logging in to the environment")
+	assert.Nil(t, store.SetMICredentials("cluster", "admin", "admin", "token"))
+	assert.Nil(t, store.SetMINodeAccessToken("cluster", "cluster#1", "node-token-1"))
+	assert.Nil(t, store.SetMINodeAccessToken("cluster", "cluster#2", "node-token-2"))
+
+	reloaded := NewJsonStore(filepath.Join(dir, DefaultConfigFile))
+	assert.Nil(t, reloaded.Load())
+	environments, err := reloaded.Environments()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cluster"}, environments, "Nodes should not be listed as environments")
+	assert.False(t, reloaded.HasMI("cluster#1"))
+	cred, err := reloaded.GetMICredentials("cluster")
+	assert.Nil(t, err)
+	assert.Equal(t, "token", cred.AccessToken)
+	assert.Equal(t, map[string]string{"cluster#1": "node-token-1", "cluster#2": "node-token-2"},
+		cred.NodeAccessTokens)
+
+	// logging in again should discard the tokens of the nodes
+	assert.Nil(t, reloaded.SetMICredentials("cluster", "admin", "new-admin", "new-token"))
+	cred, err = reloaded.GetMICredentials("cluster")
+	assert.Nil(t, err)
+	assert.Empty(t, cred.NodeAccessTokens)
+
+	assert.Nil(t, reloaded.SetMINodeAccessToken("cluster", "cluster#1", "node-token-1"))
+	assert.Nil(t, reloaded.EraseMI("cluster"))
+	environments, err = reloaded.Environments()
+	assert.Nil(t, err)
+	assert.Empty(t, environments)
+}
@@ -0,0 +1,115 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// MigrateCredentials moves all the apim, mi and mg credentials from one store to another. All the entries are copied
+// to the target store before erasing them from the source store, so a failure does not lose any credentials.
+// Returns the number of migrated entries
+func MigrateCredentials(from, to Store) (int, error) {
+	envs, err := from.Environments()
+	if err != nil {
+		return 0, err
+	}
+	mgEnvs, err := from.MGEnvironments()
+	if err != nil {
+		return 0, err
+	}
+
+	var erasers []func() error
+	for _, env := range envs {
+		env := env
+		if from.HasAPIM(env) {
+			cred, err := from.GetAPIMCredentials(env)
+			if err != nil {
+				return 0, err
+			}
+			err = to.SetAPIMCredentials(env, cred.Username, cred.Password, cred.ClientId, cred.ClientSecret)
+			if err != nil {
+				return 0, err
+			}
+			erasers = append(erasers, func() error { return from.EraseAPIM(env) })
+		}
+		if from.HasMI(env) {
+			cred, err := from.GetMICredentials(env)
+			if err != nil {
+				return 0, err
+			}
+			err = to.SetMICredentials(env, cred.Username, cred.Password, cred.AccessToken)
+			if err != nil {
+				return 0, err
+			}
+			for nodeEnv, nodeAccessToken := range cred.NodeAccessTokens {
+				err = to.SetMINodeAccessToken(env, nodeEnv, nodeAccessToken)
+				if err != nil {
+					return 0, err
+				}
+			}
+			erasers = append(erasers, func() error { return from.EraseMI(env) })
+		}
+	}
+	for _, env := range mgEnvs {
+		env := env
+		if !from.HasMG(env) {
+			continue
+		}
+		mgAdapterEnv, err := from.GetMGToken(env)
+		if err != nil {
+			return 0, err
+		}
+		err = to.SetMGToken(env, mgAdapterEnv.AccessToken)
+		if err != nil {
+			return 0, err
+		}
+		erasers = append(erasers, func() error { return from.EraseMG(env) })
+	}
+
+	for _, erase := range erasers {
+		if err := erase(); err != nil {
+			return len(erasers), err
+		}
+	}
+	return len(erasers), nil
+}
+
+// MigrateDefaultCredentialStore moves the credentials from the currently selected store to the given type of store
+// and selects the new store as the default.
+// Returns the number of migrated entries
+func MigrateDefaultCredentialStore(credStore string) (int, error) {
+	from, err := GetDefaultCredentialStore()
+	if err != nil {
+		return 0, err
+	}
+	to, err := NewCredentialStore(credStore, utils.LocalCredentialsDirectoryPath)
+	if err != nil {
+		return 0, err
+	}
+	if err = to.Load(); err != nil {
+		return 0, err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Migrating credentials to the " + credStore + " store")
+	count, err := MigrateCredentials(from, to)
+	if err != nil {
+		return count, err
+	}
+	return count, SetDefaultCredentialStoreType(credStore)
+}
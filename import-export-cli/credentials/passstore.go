@@ -0,0 +1,375 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// PassStoreDirEnvVar is the environment variable used by pass to override the location of the password store
+const PassStoreDirEnvVar = "PASSWORD_STORE_DIR"
+
+// DefaultPassStorePrefix is the folder inside the password store which keeps the apictl entries
+const DefaultPassStorePrefix = "wso2apictl"
+
+// names of the entries in the password store
+const (
+	passEnvironmentsFolder = "environments"
+	passMgwAdapterFolder   = "mgw-clusters"
+	passAPIMEntry          = "apim"
+	passAPIMTokensFolder   = "apim-tokens"
+	passMIEntry            = "mi"
+	passEntryExtension     = ".gpg"
+)
+
+// PassStore is storing keys in the password store of the pass password manager (https://www.passwordstore.org).
+// Each credential is kept as a gpg encrypted json entry, eg: wso2apictl/environments/<env>/apim
+// The names of the environments are escaped (see escapePassEntryName) so that they are kept inside the prefix.
+// The access tokens are kept by the hash of their scopes, eg: wso2apictl/environments/<env>/apim-tokens/<hash>
+type PassStore struct {
+	// Prefix is the folder inside the password store which keeps the entries
+	Prefix string
+}
+
+// NewPassStore creates a new store
+func NewPassStore() *PassStore {
+	return &PassStore{Prefix: DefaultPassStorePrefix}
+}
+
+// Load pass store. Checks whether pass is installed and the password store is initialized
+func (s *PassStore) Load() error {
+	if _, err := exec.LookPath("pass"); err != nil {
+		return fmt.Errorf("pass is not installed. Install pass to use the %s credential store", CredStorePass)
+	}
+	if !utils.IsFileExist(filepath.Join(passStoreDir(), ".gpg-id")) {
+		return fmt.Errorf("password store is not initialized in %s, use 'pass init <gpg-id>'", passStoreDir())
+	}
+	return nil
+}
+
+// GetAPIMCredentials returns credentials for apim from the store or an error
+func (s *PassStore) GetAPIMCredentials(env string) (Credential, error) {
+	var credential Credential
+	if !s.HasAPIM(env) {
+		return credential, fmt.Errorf("credentials not found for APIM in %s, use login", env)
+	}
+	err := s.read(s.environmentEntry(env, passAPIMEntry), &credential)
+	return credential, err
+}
+
+// SetAPIMCredentials sets credentials for apim using username, password, clientID and client secret
+func (s *PassStore) SetAPIMCredentials(env, username, password, clientId, clientSecret string) error {
+	err := s.write(s.environmentEntry(env, passAPIMEntry), Credential{
+		Username:     username,
+		Password:     password,
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+	})
+	if err != nil {
+		return err
+	}
+	// tokens issued for the previous credentials are no longer used
+	return s.removeAPIMTokens(env)
+}
+
+// GetAPIMToken returns the cached access token of apim issued for the given scopes from the store or an error
+func (s *PassStore) GetAPIMToken(env, scopes string) (APIMToken, error) {
+	var token APIMToken
+	if !s.exists(s.apimTokenEntry(env, scopes)) {
+		return token, fmt.Errorf("access token not found for APIM in %s", env)
+	}
+	err := s.read(s.apimTokenEntry(env, scopes), &token)
+	return token, err
+}
+
+// GetAPIMTokens returns all the cached access tokens of apim in a given environment
+func (s *PassStore) GetAPIMTokens(env string) ([]APIMToken, error) {
+	entries, err := s.list(s.environmentEntry(env, passAPIMTokensFolder), false)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []APIMToken
+	for _, entry := range entries {
+		var token APIMToken
+		if err := s.read(path.Join(s.environmentEntry(env, passAPIMTokensFolder), entry), &token); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// SetAPIMToken caches the access token of apim in the store by the scopes of the token
+func (s *PassStore) SetAPIMToken(env string, token APIMToken) error {
+	return s.write(s.apimTokenEntry(env, token.Scopes), token)
+}
+
+// removes the cached access tokens of apim if they exist
+func (s *PassStore) removeAPIMTokens(env string) error {
+	folder := s.environmentEntry(env, passAPIMTokensFolder)
+	if !utils.IsFileExist(filepath.Join(passStoreDir(), filepath.FromSlash(folder))) {
+		return nil
+	}
+	_, err := runPass(nil, "rm", "--recursive", "--force", folder)
+	return err
+}
+
+// GetMICredentials returns credentials for micro integrator from the store or an error
+func (s *PassStore) GetMICredentials(env string) (MiCredential, error) {
+	var credential MiCredential
+	if !s.HasMI(env) {
+		return credential, fmt.Errorf("credentials not found for Mi in %s, use login", env)
+	}
+	err := s.read(s.environmentEntry(env, passMIEntry), &credential)
+	return credential, err
+}
+
+// SetMICredentials set credentials for mi using username, password, accessToken
+func (s *PassStore) SetMICredentials(env, username, password, accessToken string) error {
+	return s.write(s.environmentEntry(env, passMIEntry), MiCredential{
+		Username:    username,
+		Password:    password,
+		AccessToken: accessToken,
+	})
+}
+
+// SetMINodeAccessToken sets the access token of a node of the mi cluster in env. The access tokens of the nodes are
+// kept in the mi entry of env
+func (s *PassStore) SetMINodeAccessToken(env, nodeEnv, accessToken string) error {
+	credential, err := s.GetMICredentials(env)
+	if err != nil {
+		return err
+	}
+	if credential.NodeAccessTokens == nil {
+		credential.NodeAccessTokens = make(map[string]string)
+	}
+	credential.NodeAccessTokens[nodeEnv] = accessToken
+	return s.write(s.environmentEntry(env, passMIEntry), credential)
+}
+
+// GetMGToken returns token for microgateway adapter from the store or an error
+func (s *PassStore) GetMGToken(env string) (MgAdapterEnv, error) {
+	var mgAdapterEnv MgAdapterEnv
+	if !s.HasMG(env) {
+		return mgAdapterEnv, fmt.Errorf(
+			"Tokens not found for Mgw in %s. Log in with `apictl mg login [env]`", env)
+	}
+	err := s.read(s.mgEntry(env), &mgAdapterEnv)
+	return mgAdapterEnv, err
+}
+
+// SetMGToken set token for microgateway adapter
+func (s *PassStore) SetMGToken(env, accessToken string) error {
+	return s.write(s.mgEntry(env), MgAdapterEnv{AccessToken: accessToken})
+}
+
+// EraseAPIM remove apim credentials from the store
+func (s *PassStore) EraseAPIM(env string) error {
+	if !s.HasAPIM(env) {
+		return fmt.Errorf("%s was not found", env)
+	}
+	if err := s.removeAPIMTokens(env); err != nil {
+		return err
+	}
+	return s.remove(s.environmentEntry(env, passAPIMEntry))
+}
+
+// EraseMI remove mi credentials from the store
+func (s *PassStore) EraseMI(env string) error {
+	if !s.HasMI(env) {
+		return fmt.Errorf("%s was not found", env)
+	}
+	return s.remove(s.environmentEntry(env, passMIEntry))
+}
+
+// EraseMG remove mg tokens from the store
+func (s *PassStore) EraseMG(env string) error {
+	if !s.HasMG(env) {
+		return fmt.Errorf("%s was not found", env)
+	}
+	return s.remove(s.mgEntry(env))
+}
+
+// HasAPIM return the existance of apim credentials in the store for a given environment
+func (s *PassStore) HasAPIM(env string) bool {
+	return s.exists(s.environmentEntry(env, passAPIMEntry))
+}
+
+// HasMI return the existance of mi credentials in the store for a given environment
+func (s *PassStore) HasMI(env string) bool {
+	return s.exists(s.environmentEntry(env, passMIEntry))
+}
+
+// HasMG return the existance of mg tokens in the store for a given environment
+func (s *PassStore) HasMG(env string) bool {
+	return s.exists(s.mgEntry(env))
+}
+
+// Environments returns the names of the environments which have apim or mi credentials in the store
+func (s *PassStore) Environments() ([]string, error) {
+	names, err := s.list(path.Join(s.Prefix, passEnvironmentsFolder), true)
+	if err != nil {
+		return nil, err
+	}
+	return unescapePassEntryNames(names), nil
+}
+
+// MGEnvironments returns the names of the microgateway adapter environments which have tokens in the store
+func (s *PassStore) MGEnvironments() ([]string, error) {
+	names, err := s.list(path.Join(s.Prefix, passMgwAdapterFolder), false)
+	if err != nil {
+		return nil, err
+	}
+	return unescapePassEntryNames(names), nil
+}
+
+// returns the name of an entry of an environment
+func (s *PassStore) environmentEntry(env, entry string) string {
+	return path.Join(s.Prefix, passEnvironmentsFolder, escapePassEntryName(env), entry)
+}
+
+// returns the name of the entry of the access token of apim issued for the given scopes. The scopes are hashed as
+// they contain characters which are not allowed in the entry names.
+func (s *PassStore) apimTokenEntry(env, scopes string) string {
+	return path.Join(s.environmentEntry(env, passAPIMTokensFolder), fmt.Sprintf("%x", sha256.Sum256([]byte(scopes))))
+}
+
+// returns the name of the entry of a microgateway adapter environment
+func (s *PassStore) mgEntry(env string) string {
+	return path.Join(s.Prefix, passMgwAdapterFolder, escapePassEntryName(env))
+}
+
+// escapes a name (eg: of an environment) to a single entry name of the password store. The characters other than
+// letters, digits, '-', '_' and '.' are percent encoded as well as a leading '.', so that the names with separators
+// (eg: ../../other) cannot refer to the entries outside the prefix. The names which are valid entry names are kept.
+func escapePassEntryName(name string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_' ||
+			c == '.' && i > 0 {
+			escaped.WriteByte(c)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", c)
+		}
+	}
+	return escaped.String()
+}
+
+// returns the names escaped by escapePassEntryName. The names which cannot be unescaped are kept as they are
+func unescapePassEntryNames(names []string) []string {
+	for i, name := range names {
+		if unescaped, err := url.PathUnescape(name); err == nil {
+			names[i] = unescaped
+		}
+	}
+	return names
+}
+
+// checks whether the given entry exists in the password store
+func (s *PassStore) exists(entry string) bool {
+	return utils.IsFileExist(filepath.Join(passStoreDir(), filepath.FromSlash(entry)+passEntryExtension))
+}
+
+// lists the names of the folders (if dirs is true) or the entries inside the given folder of the password store
+func (s *PassStore) list(folder string, dirs bool) ([]string, error) {
+	files, err := ioutil.ReadDir(filepath.Join(passStoreDir(), filepath.FromSlash(folder)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, file := range files {
+		if dirs && file.IsDir() {
+			names = append(names, file.Name())
+		} else if !dirs && !file.IsDir() && strings.HasSuffix(file.Name(), passEntryExtension) {
+			names = append(names, strings.TrimSuffix(file.Name(), passEntryExtension))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// decrypts the given entry and unmarshals it to value
+func (s *PassStore) read(entry string, value interface{}) error {
+	output, err := runPass(nil, "show", entry)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(output, value)
+}
+
+// marshals the value and inserts it to the given entry replacing the existing value
+func (s *PassStore) write(entry string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = runPass(data, "insert", "--multiline", "--force", entry)
+	return err
+}
+
+// removes the given entry from the password store
+func (s *PassStore) remove(entry string) error {
+	_, err := runPass(nil, "rm", "--force", entry)
+	return err
+}
+
+// executes the pass command with the given arguments and input
+func runPass(input []byte, args ...string) ([]byte, error) {
+	utils.Logln(utils.LogPrefixInfo + "Executing pass " + args[0])
+	cmd := exec.Command("pass", args...)
+	if input != nil {
+		cmd.Stdin = bytes.NewReader(input)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("pass %s failed: %v %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
+
+// returns the location of the password store
+func passStoreDir() string {
+	if dir := os.Getenv(PassStoreDirEnvVar); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = utils.HomeDirectory
+	}
+	return filepath.Join(home, ".password-store")
+}
@@ -0,0 +1,68 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPassStoreEntriesOfEnvironments(t *testing.T) {
+	store := NewPassStore()
+	assert.Equal(t, "wso2apictl/environments/dev/apim", store.environmentEntry("dev", passAPIMEntry))
+	assert.Equal(t, "wso2apictl/environments/dev.local/mi", store.environmentEntry("dev.local", passMIEntry))
+	assert.Equal(t, "wso2apictl/mgw-clusters/prod-1", store.mgEntry("prod-1"))
+
+	for _, env := range []string{"..", "../../other", "team/dev", ".hidden", `..\other`, "dev%2F"} {
+		entry := store.environmentEntry(env, passAPIMEntry)
+		assert.True(t, strings.HasPrefix(entry, "wso2apictl/environments/"), entry)
+		assert.Equal(t, 4, len(strings.Split(entry, "/")), "should keep "+env+" in a single entry name")
+		assert.NotContains(t, strings.Split(entry, "/")[2], "/")
+		assert.False(t, strings.HasPrefix(strings.Split(entry, "/")[2], "."), entry)
+		assert.Equal(t, []string{env}, unescapePassEntryNames([]string{escapePassEntryName(env)}))
+
+		mgEntry := store.mgEntry(env)
+		assert.Equal(t, 3, len(strings.Split(mgEntry, "/")), mgEntry)
+	}
+}
+
+func TestPassStoreEnvironmentsAreUnescaped(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-pass")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	_ = os.Setenv(PassStoreDirEnvVar, dir)
+	defer os.Unsetenv(PassStoreDirEnvVar)
+
+	store := NewPassStore()
+	for _, env := range []string{"dev", "team/dev"} {
+		entry := filepath.Join(dir, filepath.FromSlash(store.environmentEntry(env, passAPIMEntry))+passEntryExtension)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(entry), 0700))
+		assert.Nil(t, ioutil.WriteFile(entry, []byte("encrypted"), 0600))
+		assert.True(t, store.HasAPIM(env))
+	}
+	environments, err := store.Environments()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"dev", "team/dev"}, environments)
+	assert.False(t, store.HasAPIM("team"), "should not find the escaped environment by its parent folder")
+}
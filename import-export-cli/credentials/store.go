@@ -31,10 +31,18 @@ type Store interface {
 	GetMICredentials(env string) (MiCredential, error)
 	// GetMgwAdapterToken returns the Access Token of the Microgateway Adapter
 	GetMGToken(env string) (MgAdapterEnv, error)
+	// GetAPIMToken returns the cached access token of apim issued for the given scopes from the store or an error
+	GetAPIMToken(env, scopes string) (APIMToken, error)
+	// GetAPIMTokens returns all the cached access tokens of apim in a given environment
+	GetAPIMTokens(env string) ([]APIMToken, error)
+	// SetAPIMToken caches the access token of apim in the store by the scopes of the token
+	SetAPIMToken(env string, token APIMToken) error
 	// SetAPIMCredentials sets credentials for micro integrator using username, password, clientID and client secret
 	SetAPIMCredentials(env, username, password, clientID, clientSecret string) error
 	// SetMICredentials sets credentials for micro integrator using username, password and access token
 	SetMICredentials(env, username, password, accessToken string) error
+	// SetMINodeAccessToken sets the access token of a node of the micro integrator cluster in env
+	SetMINodeAccessToken(env, nodeEnv, accessToken string) error
 	// SetMGToken sets the Access Token for a Microgateway Adapter env
 	SetMGToken(env, accessToken string) error
 	// Erase apim credentials in a given environment
@@ -43,6 +51,10 @@ type Store interface {
 	EraseMI(env string) error
 	// Erase mg token in a given microgateway Adapter env
 	EraseMG(env string) error
+	// Environments returns the names of the environments which have apim or mi credentials in the store
+	Environments() ([]string, error)
+	// MGEnvironments returns the names of the microgateway adapter environments which have tokens in the store
+	MGEnvironments() ([]string, error)
 	// Load store
 	Load() error
 }
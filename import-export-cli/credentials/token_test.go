@@ -0,0 +1,132 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestAPIMTokenIsValid(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	assert.True(t, APIMToken{AccessToken: "token", ExpiresAt: now.Unix() + 3600}.isValid(now))
+	assert.False(t, APIMToken{AccessToken: "token", ExpiresAt: now.Unix() + 10}.isValid(now),
+		"Token about to expire should not be used")
+	assert.False(t, APIMToken{AccessToken: "token", ExpiresAt: now.Unix() - 10}.isValid(now))
+	assert.False(t, APIMToken{ExpiresAt: now.Unix() + 3600}.isValid(now))
+}
+
+func TestJsonStoreAPIMToken(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-credentials")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewJsonStore(filepath.Join(dir, DefaultConfigFile))
+	assert.Nil(t, store.Load())
+	assert.Nil(t, store.SetAPIMCredentials("dev", "admin", "admin", "id", "secret"))
+	_, err = store.GetAPIMToken("dev", utils.OAuthTokenScopes)
+	assert.NotNil(t, err, "Token should not exist before caching")
+
+	token := APIMToken{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: 100, ClientId: "id",
+		Scopes: utils.OAuthTokenScopes}
+	assert.Nil(t, store.SetAPIMToken("dev", token))
+	adminToken := APIMToken{AccessToken: "admin-access", RefreshToken: "admin-refresh", ExpiresAt: 100, ClientId: "id",
+		Scopes: utils.OAuthAdminTokenScopes}
+	assert.Nil(t, store.SetAPIMToken("dev", adminToken))
+
+	reloaded := NewJsonStore(filepath.Join(dir, DefaultConfigFile))
+	assert.Nil(t, reloaded.Load())
+	cachedToken, err := reloaded.GetAPIMToken("dev", utils.OAuthTokenScopes)
+	assert.Nil(t, err)
+	assert.Equal(t, token, cachedToken)
+	cachedToken, err = reloaded.GetAPIMToken("dev", utils.OAuthAdminTokenScopes)
+	assert.Nil(t, err)
+	assert.Equal(t, adminToken, cachedToken)
+	tokens, err := reloaded.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []APIMToken{token, adminToken}, tokens)
+
+	// logging in again should discard the cached tokens
+	assert.Nil(t, reloaded.SetAPIMCredentials("dev", "admin", "admin", "id2", "secret2"))
+	_, err = reloaded.GetAPIMToken("dev", utils.OAuthTokenScopes)
+	assert.NotNil(t, err)
+	tokens, err = reloaded.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestGetOAuthAccessTokenFromStoreScopes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-credentials")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	var requestedScopes []string
+	tokenStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedScopes = append(requestedScopes, r.FormValue("scope"))
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		w.Write([]byte(`{"access_token": "admin-access", "refresh_token": "admin-refresh", "expires_in": 3600}`))
+	}))
+	defer tokenStub.Close()
+	mainConfigFilePath := utils.MainConfigFilePath
+	defer func() { utils.MainConfigFilePath = mainConfigFilePath }()
+	utils.MainConfigFilePath = filepath.Join(dir, utils.MainConfigFileName)
+	utils.WriteConfigFile(utils.MainConfig{Environments: map[string]utils.EnvEndpoints{
+		"dev": {ApiManagerEndpoint: tokenStub.URL, TokenEndpoint: tokenStub.URL + "/oauth2/token"},
+	}}, utils.MainConfigFilePath)
+
+	store := NewJsonStore(filepath.Join(dir, DefaultConfigFile))
+	assert.Nil(t, store.Load())
+	assert.Nil(t, store.SetAPIMCredentials("dev", "admin", "admin", "id", "secret"))
+	now := time.Unix(1000000, 0)
+	token := APIMToken{AccessToken: "access", ExpiresAt: now.Unix() + 3600, ClientId: "id",
+		Scopes: utils.OAuthTokenScopes}
+	assert.Nil(t, store.SetAPIMToken("dev", token))
+
+	credential := Credential{Username: "admin", Password: "admin", ClientId: "id", ClientSecret: "secret"}
+	accessToken, err := GetOAuthAccessTokenFromStore(store, credential, "dev", utils.OAuthTokenScopes, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "access", accessToken, "Cached token should be used for the same scopes")
+	assert.Empty(t, requestedScopes)
+
+	accessToken, err = GetOAuthAccessTokenFromStore(store, credential, "dev", utils.OAuthAdminTokenScopes, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "admin-access", accessToken, "Cached token should not be used for other scopes")
+	assert.Equal(t, []string{strings.Replace(utils.OAuthAdminTokenScopes, "+", " ", -1)}, requestedScopes)
+	cachedToken, err := store.GetAPIMToken("dev", utils.OAuthAdminTokenScopes)
+	assert.Nil(t, err)
+	assert.Equal(t, utils.OAuthAdminTokenScopes, cachedToken.Scopes)
+
+	// the admin token should not replace the cached token of the other scopes
+	accessToken, err = GetOAuthAccessTokenFromStore(store, credential, "dev", utils.OAuthTokenScopes, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "access", accessToken)
+	accessToken, err = GetOAuthAccessTokenFromStore(store, credential, "dev", utils.OAuthAdminTokenScopes, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "admin-access", accessToken)
+	assert.Len(t, requestedScopes, 1, "Cached tokens should be used for both the scopes")
+}
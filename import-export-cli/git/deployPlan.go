@@ -0,0 +1,323 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+    "errors"
+    "os"
+    "path"
+    "path/filepath"
+    "strings"
+
+    "github.com/Jeffail/gabs"
+    "github.com/wso2/product-apim-tooling/import-export-cli/impl"
+    "github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+    "github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Operations that can be planned for a project
+const (
+    PlanOperationCreate  = "create"
+    PlanOperationUpdate  = "update"
+    PlanOperationDelete  = "delete"
+    PlanOperationNoOp    = "no-op"
+    PlanOperationUnknown = "unknown"
+)
+
+// File names (without extension) of the project files that are compared when planning a deployment
+const (
+    planDefinitionFileAPI          = "api"
+    planDefinitionFileAPIProduct   = "api_product"
+    planDefinitionFileApplication  = "application"
+    planDeploymentEnvironmentsFile = "deployment_environments"
+)
+
+// ProjectPlan represents what would happen to a single project if the changes are deployed
+type ProjectPlan struct {
+    Project   *params.ProjectParams
+    Operation string
+    Diffs     []utils.FieldDiff
+    Err       error
+}
+
+// Plans the deployment of the changed projects without deploying anything. The current state of each changed project
+//  is fetched from the environment and compared with the project in the repository after applying the environment
+//  specific params.
+// accesstoken is the access token to access the APIM product REST APIs
+// environment is the environment name
+// Returns int, the total number of projects to deploy
+// Returns map[string][]*ProjectPlan, a map of project type (API, App.. ) to the plan of each project
+func PlanChangedFiles(accessToken, environment string) (int, map[string][]*ProjectPlan) {
+    repoId, totalProjectsToUpdate, updatedProjectsPerType := GetStatus(environment, FromRevTypeLastAttempted)
+    _, envVCSConfig, _ := getVCSEnvironmentDetails(repoId, environment)
+
+    var lastSuccessfulRev string
+    if len(envVCSConfig.LastSuccessfulRev) > 0 {
+        lastSuccessfulRev = envVCSConfig.LastSuccessfulRev[0]
+    }
+
+    plansPerType := make(map[string][]*ProjectPlan)
+    for _, projectType := range []string{utils.ProjectTypeApi, utils.ProjectTypeApiProduct, utils.ProjectTypeApplication} {
+        for _, projectParam := range updatedProjectsPerType[projectType] {
+            plansPerType[projectType] = append(plansPerType[projectType],
+                planProject(accessToken, environment, lastSuccessfulRev, projectParam))
+        }
+    }
+    return totalProjectsToUpdate, plansPerType
+}
+
+// Plans the deployment of a single project by comparing it with the state in the environment
+// lastSuccessfulRev is the last successfully deployed revision which is used to read the definitions of deleted projects
+func planProject(accessToken, environment, lastSuccessfulRev string, projectParam *params.ProjectParams) *ProjectPlan {
+    plan := &ProjectPlan{
+        Project:   projectParam,
+        Operation: PlanOperationUnknown,
+    }
+    definitionFile := getDefinitionFileNameOfProjectType(projectParam.Type)
+
+    var localDefinition map[string]interface{}
+    var err error
+    if projectParam.Deleted {
+        if lastSuccessfulRev == "" {
+            plan.Err = errors.New("no last successful revision available to read the deleted project")
+            return plan
+        }
+        localDefinition, err = readProjectFileFromRevision(lastSuccessfulRev, projectParam.RelativePath, definitionFile)
+    } else {
+        localDefinition, err = impl.ReadProjectFileAsJSON(projectParam.AbsolutePath, definitionFile)
+    }
+    if err != nil {
+        plan.Err = err
+        return plan
+    }
+    if localDefinition == nil {
+        plan.Err = errors.New("project definition " + definitionFile + ".yaml is not found")
+        return plan
+    }
+
+    name, version, owner := getProjectIdentifiers(projectParam.Type, localDefinition)
+    projectParam.ProjectInfo.Name = name
+    projectParam.ProjectInfo.Version = version
+    projectParam.ProjectInfo.Owner = owner
+
+    remoteProjectPath, err := exportProjectToTempDir(accessToken, environment, projectParam)
+    if err != nil {
+        plan.Err = err
+        return plan
+    }
+    if remoteProjectPath != "" {
+        // the exported project is extracted into its own temporary directory
+        defer func() {
+            _ = os.RemoveAll(filepath.Dir(remoteProjectPath))
+        }()
+    }
+
+    var localFiles, remoteFiles map[string]map[string]interface{}
+    if !projectParam.Deleted {
+        localFiles, err = readPlanProjectFiles(projectParam.AbsolutePath, definitionFile)
+        if err != nil {
+            plan.Err = err
+            return plan
+        }
+    }
+    if remoteProjectPath != "" {
+        remoteFiles, err = readPlanProjectFiles(remoteProjectPath, definitionFile)
+        if err != nil {
+            plan.Err = err
+            return plan
+        }
+    }
+    plan.Operation, plan.Diffs, plan.Err = compareProjectFiles(projectParam, environment, localFiles, remoteFiles)
+    if plan.Err != nil {
+        plan.Operation = PlanOperationUnknown
+    }
+    return plan
+}
+
+// Decides the operation planned for a project by comparing the files of the project in the repository, after applying
+//  the params of the environment, with the files exported from the environment.
+// localFiles are nil if the project is deleted from the repository and remoteFiles are nil if the project does not
+//  exist in the environment.
+// Returns the operation and the differences of the files for an update
+func compareProjectFiles(projectParam *params.ProjectParams, environment string,
+        localFiles, remoteFiles map[string]map[string]interface{}) (string, []utils.FieldDiff, error) {
+    if projectParam.Deleted {
+        if remoteFiles == nil {
+            return PlanOperationNoOp, nil, nil
+        }
+        return PlanOperationDelete, nil, nil
+    }
+    if remoteFiles == nil {
+        return PlanOperationCreate, nil, nil
+    }
+
+    definitionFile := getDefinitionFileNameOfProjectType(projectParam.Type)
+    if envParams := getProjectEnvParams(projectParam, environment); envParams != nil {
+        err := impl.ApplyEnvParamsToProjectFiles(localFiles, definitionFile, envParams)
+        if err != nil {
+            return PlanOperationUnknown, nil, err
+        }
+    }
+
+    var diffs []utils.FieldDiff
+    for _, fileName := range getPlanProjectFileNames(definitionFile) {
+        diffs = append(diffs, diffProjectFile(fileName, remoteFiles[fileName], localFiles[fileName])...)
+    }
+    if len(diffs) == 0 {
+        return PlanOperationNoOp, nil, nil
+    }
+    return PlanOperationUpdate, diffs, nil
+}
+
+// Returns the params of the environment the project is deployed to, or nil if the project has no params for it
+func getProjectEnvParams(projectParam *params.ProjectParams, environment string) *params.Environment {
+    switch {
+    case projectParam.Type == utils.ProjectTypeApi && projectParam.ApiParams != nil:
+        return projectParam.ApiParams.GetEnv(environment)
+    case projectParam.Type == utils.ProjectTypeApiProduct && projectParam.ApiProductParams != nil:
+        for i := range projectParam.ApiProductParams.Environments {
+            if projectParam.ApiProductParams.Environments[i].Name == environment {
+                return &projectParam.ApiProductParams.Environments[i]
+            }
+        }
+    }
+    return nil
+}
+
+// Returns the names (without the extension) of the project files compared when planning a deployment
+func getPlanProjectFileNames(definitionFile string) []string {
+    if definitionFile == planDefinitionFileApplication {
+        return []string{definitionFile}
+    }
+    return []string{definitionFile, planDeploymentEnvironmentsFile, impl.ProjectFileEndpointCertificates,
+        impl.ProjectFileClientCertificates}
+}
+
+// Reads the project files compared when planning a deployment. Files which do not exist are left out.
+func readPlanProjectFiles(projectPath, definitionFile string) (map[string]map[string]interface{}, error) {
+    files := make(map[string]map[string]interface{})
+    for _, fileName := range getPlanProjectFileNames(definitionFile) {
+        file, err := impl.ReadProjectFileAsJSON(projectPath, filepath.FromSlash(fileName))
+        if err != nil {
+            return nil, err
+        }
+        if file != nil {
+            files[fileName] = file
+        }
+    }
+    return files, nil
+}
+
+// Returns the field level differences of a project file prefixed with the file name
+func diffProjectFile(fileName string, remote, local map[string]interface{}) []utils.FieldDiff {
+    // a missing deployment environments or certificates file in the project does not remove the existing ones
+    if local == nil && fileName != planDefinitionFileAPI && fileName != planDefinitionFileAPIProduct &&
+            fileName != planDefinitionFileApplication {
+        return nil
+    }
+    var remoteDoc, localDoc interface{}
+    if remote != nil {
+        remoteDoc = remote
+    }
+    if local != nil {
+        localDoc = local
+    }
+    diffs := utils.DiffValues(remoteDoc, localDoc)
+    for i := range diffs {
+        diffs[i].Path = fileName + ".yaml:" + diffs[i].Path
+    }
+    return diffs
+}
+
+// Returns the name of the definition file (without the extension) of the given project type
+func getDefinitionFileNameOfProjectType(projectType string) string {
+    switch projectType {
+    case utils.ProjectTypeApiProduct:
+        return planDefinitionFileAPIProduct
+    case utils.ProjectTypeApplication:
+        return planDefinitionFileApplication
+    default:
+        return planDefinitionFileAPI
+    }
+}
+
+// Returns the name, version and the owner (provider) of the project from its definition
+func getProjectIdentifiers(projectType string, definition map[string]interface{}) (string, string, string) {
+    root, _ := gabs.Consume(definition)
+    data := root.Path("data")
+    if projectType == utils.ProjectTypeApplication {
+        appInfo := data.Path("applicationInfo")
+        name, _ := appInfo.Path("name").Data().(string)
+        owner, _ := appInfo.Path("owner").Data().(string)
+        if name == "" {
+            name, _ = data.Path("name").Data().(string)
+            owner, _ = data.Path("subscriber.name").Data().(string)
+        }
+        return name, "", owner
+    }
+    name, _ := data.Path("name").Data().(string)
+    version, _ := data.Path("version").Data().(string)
+    provider, _ := data.Path("provider").Data().(string)
+    return name, version, provider
+}
+
+// Exports the current state of the project from the environment into a temporary directory.
+// Returns an empty path if the project does not exist in the environment.
+func exportProjectToTempDir(accessToken, environment string, projectParam *params.ProjectParams) (string, error) {
+    info := projectParam.ProjectInfo
+    switch projectParam.Type {
+    case utils.ProjectTypeApi:
+        return impl.ExportAPIToTempDir(accessToken, environment, info.Name, info.Version, info.Owner)
+    case utils.ProjectTypeApiProduct:
+        return impl.ExportAPIProductToTempDir(accessToken, environment, info.Name, info.Version, info.Owner)
+    case utils.ProjectTypeApplication:
+        return impl.ExportAppToTempDir(accessToken, environment, info.Name, info.Owner)
+    }
+    return "", errors.New("unsupported project type: " + projectParam.Type)
+}
+
+// Reads a project file (given without the extension) of a project at the given git revision. This is used for the
+//  projects which are already deleted from the current revision.
+func readProjectFileFromRevision(revision, relativePath, fileNameWithoutExtension string) (map[string]interface{},
+        error) {
+    projectPath := filepath.ToSlash(relativePath)
+    files, err := executeGitCommand("ls-tree", "--name-only", revision, projectPath+"/")
+    if err != nil {
+        return nil, err
+    }
+    for _, file := range strings.Split(strings.TrimSpace(files), "\n") {
+        fileName := path.Base(file)
+        if fileName != fileNameWithoutExtension+".yaml" && fileName != fileNameWithoutExtension+".json" {
+            continue
+        }
+        content, err := executeGitCommand("show", revision+":"+file)
+        if err != nil {
+            return nil, err
+        }
+        jsonContent := []byte(content)
+        if strings.HasSuffix(fileName, ".yaml") {
+            jsonContent, err = utils.YamlToJson(jsonContent)
+            if err != nil {
+                return nil, err
+            }
+        }
+        return impl.ParseProjectFileContent(jsonContent)
+    }
+    return nil, nil
+}
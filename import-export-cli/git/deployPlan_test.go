@@ -0,0 +1,150 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package git
+
+import (
+    "encoding/json"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/wso2/product-apim-tooling/import-export-cli/impl"
+    "github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+    "github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const planTestAPI = `{"type": "api", "data": {"name": "PizzaShackAPI", "version": "1.0.0",
+    "policies": ["Unlimited"],
+    "endpointConfig": {"endpoint_type": "http", "production_endpoints": {"url": "https://dev.pizzashack"}}}}`
+
+const planTestDeploymentEnvironments = `{"type": "deployment_environments",
+    "data": [{"deploymentEnvironment": "Default", "displayOnDevportal": true}]}`
+
+func planTestFiles(t *testing.T, files map[string]string) map[string]map[string]interface{} {
+    documents := make(map[string]map[string]interface{})
+    for name, content := range files {
+        document := map[string]interface{}{}
+        assert.Nil(t, json.Unmarshal([]byte(content), &document))
+        documents[name] = document
+    }
+    return documents
+}
+
+func planTestParams(t *testing.T, configs string) *params.ApiParams {
+    envConfigs := &params.EnvironmentConfigs{}
+    assert.Nil(t, json.Unmarshal([]byte(configs), envConfigs))
+    return &params.ApiParams{Environments: []params.Environment{{Name: "prod", Config: envConfigs}}}
+}
+
+func TestCompareProjectFiles(t *testing.T) {
+    remote := map[string]string{
+        "api":                     planTestAPI,
+        "deployment_environments": planTestDeploymentEnvironments,
+    }
+    tests := []struct {
+        name      string
+        deleted   bool
+        local     map[string]string
+        remote    map[string]string
+        params    string
+        operation string
+        diffs     []string
+    }{
+        {
+            name:      "add",
+            local:     remote,
+            operation: PlanOperationCreate,
+        },
+        {
+            name:      "delete",
+            deleted:   true,
+            remote:    remote,
+            operation: PlanOperationDelete,
+        },
+        {
+            name:      "delete a project which is not deployed",
+            deleted:   true,
+            operation: PlanOperationNoOp,
+        },
+        {
+            name:      "no-op",
+            local:     remote,
+            remote:    remote,
+            operation: PlanOperationNoOp,
+        },
+        {
+            name:      "no-op without a deployment environments file",
+            local:     map[string]string{"api": planTestAPI},
+            remote:    remote,
+            operation: PlanOperationNoOp,
+        },
+        {
+            name: "no-op after applying the params",
+            local: map[string]string{"api": `{"type": "api", "data": {"name": "PizzaShackAPI", "version": "1.0.0",
+                "endpointConfig": {"endpoint_type": "http",
+                    "production_endpoints": {"url": "https://localhost"}}}}`},
+            remote: remote,
+            params: `{"endpoints": {"production": {"url": "https://dev.pizzashack"}}, "policies": ["Unlimited"],
+                "deploymentEnvironments": [{"deploymentEnvironment": "Default", "displayOnDevportal": true}]}`,
+            operation: PlanOperationNoOp,
+        },
+        {
+            name:      "modify the definition",
+            local:     map[string]string{"api": `{"type": "api", "data": {"name": "PizzaShackAPI", "version": "1.0.0"}}`},
+            remote:    remote,
+            operation: PlanOperationUpdate,
+            diffs:     []string{"api.yaml:data.endpointConfig", "api.yaml:data.policies"},
+        },
+        {
+            name:  "modify with the params",
+            local: remote,
+            remote: remote,
+            params: `{"security": {"production": {"type": "basic", "username": "admin", "password": "admin"}},
+                "certs": [{"hostName": "https://dev.pizzashack", "alias": "pizzashack", "path": "pizzashack.crt"}],
+                "mutualSslCerts": [{"alias": "client", "path": "client.crt", "tierName": "Gold"}]}`,
+            operation: PlanOperationUpdate,
+            diffs: []string{
+                "api.yaml:data.endpointConfig.endpoint_security",
+                impl.ProjectFileEndpointCertificates + ".yaml:",
+                impl.ProjectFileClientCertificates + ".yaml:",
+            },
+        },
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            projectParam := &params.ProjectParams{Type: utils.ProjectTypeApi, Deleted: test.deleted}
+            if test.params != "" {
+                projectParam.ApiParams = planTestParams(t, test.params)
+            }
+            var local, remote map[string]map[string]interface{}
+            if test.local != nil {
+                local = planTestFiles(t, test.local)
+            }
+            if test.remote != nil {
+                remote = planTestFiles(t, test.remote)
+            }
+            operation, diffs, err := compareProjectFiles(projectParam, "prod", local, remote)
+            assert.Nil(t, err)
+            assert.Equal(t, test.operation, operation)
+            var paths []string
+            for _, diff := range diffs {
+                paths = append(paths, diff.Path)
+            }
+            assert.Equal(t, test.diffs, paths)
+        })
+    }
+}
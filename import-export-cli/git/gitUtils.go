@@ -103,6 +103,7 @@ func GetStatus(environment, fromRevType string) (string, int, map[string][]*para
     if err != nil {
         utils.HandleErrorAndExit("Error while getting repository base folder location", err)
     }
+    workspace, workspaceProjects := loadRepoWorkspace(basePath)
 
     var changedFiles string
     if envRevision == "" {
@@ -132,7 +133,13 @@ func GetStatus(environment, fromRevType string) (string, int, map[string][]*para
 
     var totalProjectsToUpdate = 0
     for _, changedFile := range changedFileList {
-        projectParam := getProjectInfoFromProjectFile(envVCSConfig, basePath, changedFile, changedPathInfoMap)
+        var projectParam *params.ProjectParams
+        if workspace != nil {
+            projectParam = getProjectInfoFromWorkspace(envVCSConfig, basePath, changedFile, workspace,
+                workspaceProjects, changedPathInfoMap)
+        } else {
+            projectParam = getProjectInfoFromProjectFile(envVCSConfig, basePath, changedFile, changedPathInfoMap)
+        }
         if projectParam.Type != utils.ProjectTypeNone {
             if updatedProjectsPerType[projectParam.Type] == nil {
                 updatedProjectsPerType[projectParam.Type] = []*params.ProjectParams{}
@@ -158,6 +165,13 @@ func GetStatus(environment, fromRevType string) (string, int, map[string][]*para
         }
     }
 
+    // projects declared in the workspace manifest are deployed in the declared order
+    if workspace != nil {
+        for projectType, projects := range updatedProjectsPerType {
+            updatedProjectsPerType[projectType] = orderProjectsByWorkspace(workspaceProjects, projects)
+        }
+    }
+
     return repoId, totalProjectsToUpdate, updatedProjectsPerType
 }
 
@@ -177,7 +191,8 @@ func failedDuringEarlierDeploy(vcsEnvConfig Environment, projectParams *params.P
 // Rollbacks the projects to the initial state when any of the projects were failed during deployment
 // accesstoken is the access token to access the APIM product REST APIs
 // environment is the environment name
-func Rollback(accessToken, environment string) error {
+// parallel is the maximum number of projects of the same type to deploy at the same time
+func Rollback(accessToken, environment string, parallel int) error {
     repoId, totalProjectsToUpdate, updatedProjectsPerType := GetStatus(environment, FromRevTypeLastSuccessful)
     _, envVCSConfig, hasEnv := getVCSEnvironmentDetails(repoId, environment)
 
@@ -193,7 +208,9 @@ func Rollback(accessToken, environment string) error {
     currentBranch := getCurrentBranch()
     tmpBranchName := "tmp-" + lastSuccessfulRevision[0:8]
     checkoutNewBranchFromRevision(tmpBranchName, lastSuccessfulRevision)
-    deployUpdatedProjects(accessToken, repoId, environment, totalProjectsToUpdate, updatedProjectsPerType)
+    // the last successful revision was already validated when it was deployed
+    deployUpdatedProjects(accessToken, repoId, environment, totalProjectsToUpdate, updatedProjectsPerType, parallel,
+        true)
     checkoutBranch(currentBranch)
     deleteTmpBranch(tmpBranchName)
     return nil
@@ -244,175 +261,190 @@ func deleteTmpBranch(tmpBranch string) {
 // accesstoken is the access token to access the APIM product REST APIs
 // environment is the environment name
 // deletedProjectsPerType A map that has keys as Apps/APIs or API Products and values as deleted projects of each type
+// parallel is the maximum number of projects of the same type to delete at the same time
 // This will return the failed projects with the same structure at the end if such projects exist during deletion.
 func deployProjectDeletions(accessToken, environment string, deletedProjectsPerType map[string][]*params.ProjectParams,
-    failedProjects map[string][]*params.ProjectParams) map[string][]*params.ProjectParams {
+    failedProjects map[string][]*params.ProjectParams, parallel int) map[string][]*params.ProjectParams {
     // Deleting Application projects
     applicationProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApplication]
     if len(applicationProjectsToDelete) != 0 {
-        fmt.Println("\nApplications (" + strconv.Itoa(len(applicationProjectsToDelete)) + ") ...")
-        for i, projectParam := range applicationProjectsToDelete {
-            fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-            appInfo, _, err := impl.GetApplicationDefinition(projectParam.AbsolutePath)
-            if handleIfError(err, failedProjects, projectParam) {
-                continue
-            }
-            projectParam.ProjectInfo.Name = appInfo.Name
-            projectParam.ProjectInfo.Owner = appInfo.Subscriber.Name
-            resp, err := impl.DeleteApplication(accessToken, environment, appInfo.Name, appInfo.Subscriber.Name)
-            if handleIfError(err, failedProjects, projectParam) {
-                continue
-            }
-            impl.PrintDeleteAppResponse(resp, err)
-        }
+        fmt.Fprintln(utils.MessageWriter(), "\nApplications (" + strconv.Itoa(len(applicationProjectsToDelete)) + ") ...")
+        deployProjectsInParallel(utils.MessageWriter(), applicationProjectsToDelete, parallel, failedProjects,
+            func(i int, projectParam *params.ProjectParams, out io.Writer) error {
+                printProjectHeader(out, i, projectParam)
+                appInfo, _, err := impl.GetApplicationDefinition(projectParam.AbsolutePath)
+                if err != nil {
+                    return err
+                }
+                projectParam.ProjectInfo.Name = appInfo.Name
+                projectParam.ProjectInfo.Owner = appInfo.Subscriber.Name
+                resp, err := impl.DeleteApplication(accessToken, environment, appInfo.Name, appInfo.Subscriber.Name)
+                if err != nil {
+                    return err
+                }
+                fmt.Fprintln(out, "Application deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+                return nil
+            })
     }
 
     // Deleting API Product projects
     apiProductProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApiProduct]
     if len(apiProductProjectsToDelete) != 0 {
-        fmt.Println("\nAPI Products (" + strconv.Itoa(len(apiProductProjectsToDelete)) + ") ...")
-        for i, projectParam := range apiProductProjectsToDelete {
-            fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-            apiProductInfo, _, err := impl.GetAPIProductDefinition(projectParam.AbsolutePath)
-            if handleIfError(err, failedProjects, projectParam) {
-                continue
-            }
-            projectParam.ProjectInfo.Name = apiProductInfo.ID.APIProductName
-            projectParam.ProjectInfo.Owner = apiProductInfo.ID.ProviderName
-            projectParam.ProjectInfo.Version = apiProductInfo.ID.Version
-            resp, err := impl.DeleteAPIProduct(accessToken, environment, apiProductInfo.ID.APIProductName, apiProductInfo.ID.ProviderName)
-            if handleIfError(err, failedProjects, projectParam) {
-                continue
-            }
-            impl.PrintDeleteAPIProductResponse(resp, err)
-        }
+        fmt.Fprintln(utils.MessageWriter(), "\nAPI Products (" + strconv.Itoa(len(apiProductProjectsToDelete)) + ") ...")
+        deployProjectsInParallel(utils.MessageWriter(), apiProductProjectsToDelete, parallel, failedProjects,
+            func(i int, projectParam *params.ProjectParams, out io.Writer) error {
+                printProjectHeader(out, i, projectParam)
+                apiProductInfo, _, err := impl.GetAPIProductDefinition(projectParam.AbsolutePath)
+                if err != nil {
+                    return err
+                }
+                projectParam.ProjectInfo.Name = apiProductInfo.ID.APIProductName
+                projectParam.ProjectInfo.Owner = apiProductInfo.ID.ProviderName
+                projectParam.ProjectInfo.Version = apiProductInfo.ID.Version
+                resp, err := impl.DeleteAPIProduct(accessToken, environment, apiProductInfo.ID.APIProductName,
+                    apiProductInfo.ID.ProviderName)
+                if err != nil {
+                    return err
+                }
+                fmt.Fprintln(out, "API Product deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+                return nil
+            })
     }
 
     // Deleting API projects
     apiProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApi]
     if len(apiProjectsToDelete) != 0 {
-        fmt.Println("\nAPIs (" + strconv.Itoa(len(apiProjectsToDelete)) + ") ...")
-        for i, projectParam := range apiProjectsToDelete {
-            fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-            apiInfo, _, err := impl.GetAPIDefinition(projectParam.AbsolutePath)
-            if handleIfError(err, failedProjects, projectParam) {
-                continue
-            }
-            projectParam.ProjectInfo.Name = apiInfo.ID.APIName
-            projectParam.ProjectInfo.Owner = apiInfo.ID.ProviderName
-            projectParam.ProjectInfo.Version = apiInfo.ID.Version
-            resp, err := impl.DeleteAPI(accessToken, environment, apiInfo.ID.APIName, apiInfo.ID.Version, apiInfo.ID.ProviderName)
-            if handleIfError(err, failedProjects, projectParam) {
-                continue
-            }
-            impl.PrintDeleteAPIResponse(resp, err)
-        }
+        fmt.Fprintln(utils.MessageWriter(), "\nAPIs (" + strconv.Itoa(len(apiProjectsToDelete)) + ") ...")
+        deployProjectsInParallel(utils.MessageWriter(), apiProjectsToDelete, parallel, failedProjects,
+            func(i int, projectParam *params.ProjectParams, out io.Writer) error {
+                printProjectHeader(out, i, projectParam)
+                apiInfo, _, err := impl.GetAPIDefinition(projectParam.AbsolutePath)
+                if err != nil {
+                    return err
+                }
+                projectParam.ProjectInfo.Name = apiInfo.ID.APIName
+                projectParam.ProjectInfo.Owner = apiInfo.ID.ProviderName
+                projectParam.ProjectInfo.Version = apiInfo.ID.Version
+                resp, err := impl.DeleteAPI(accessToken, environment, apiInfo.ID.APIName, apiInfo.ID.Version,
+                    apiInfo.ID.ProviderName)
+                if err != nil {
+                    return err
+                }
+                fmt.Fprintln(out, "API deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+                return nil
+            })
     }
 
     return failedProjects
 }
 
-// Logs the error and appends the failed project given from projectParam into the failedProjects map.
-func handleIfError(err error, failedProjects map[string][]*params.ProjectParams, projectParam *params.ProjectParams) bool {
-    if err != nil {
-        fmt.Println("Error... ", err)
-        failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
-    }
-    return err != nil
-}
-
 // Deploys the updated projects. It will only handle new or updated projects and deleted projects will be tracked and
 // skipped. Those deleted projects will be returned from the 2nd return argument.
+// Projects of the same type are deployed in parallel, but all the APIs are deployed before the API Products and all
+// the API Products are deployed before the Applications, as the latter can depend on the former.
 // accesstoken is the access token to access the APIM product REST APIs
 // repoId is the id of the git repository (located in vcs.yaml)
 // environment is the environment name
 // totalProjectsToUpdate is the number of total projects that needs to be deployed.
 // updatedProjectsPerType is a map of string -> ProjectParams which consists of updated projects per each type (API, App..)
+// parallel is the maximum number of projects of the same type to deploy at the same time
 // Returns bool, true if any deleted projects exists so the process should continue with project deletion path
 // Returns map[string][]*params.ProjectParams, a map of project type (API, App.. ) to each project detail which are
 //  deleted projects
 // Returns map[string][]*params.ProjectParams, a map of project type (API, App.. ) to each project detail which are
 //  failed during the deployment
 func deployUpdatedProjects(accessToken, repoId, environment string, totalProjectsToUpdate int,
-        updatedProjectsPerType map[string][]*params.ProjectParams) (bool, map[string][]*params.ProjectParams,
-        map[string][]*params.ProjectParams) {
+        updatedProjectsPerType map[string][]*params.ProjectParams, parallel int, skipValidation bool) (bool,
+        map[string][]*params.ProjectParams, map[string][]*params.ProjectParams) {
     if totalProjectsToUpdate == 0 {
-        fmt.Println("Everything is up-to-date")
+        fmt.Fprintln(utils.MessageWriter(), "Everything is up-to-date")
         return false, nil, nil
     }
 
-    fmt.Println("Deploying Projects (" + strconv.Itoa(totalProjectsToUpdate) + ")..." )
+    fmt.Fprintln(utils.MessageWriter(), "Deploying Projects (" + strconv.Itoa(totalProjectsToUpdate) + ")..." )
 
     var failedProjects = make(map[string][]*params.ProjectParams)
     var hasDeletedProjects bool
-    var deletedProjectsPerType =make(map[string][]*params.ProjectParams)
+    var deletedProjectsPerType = make(map[string][]*params.ProjectParams)
 
-    // deploying API projects
-    apiProjects := updatedProjectsPerType[utils.ProjectTypeApi]
-    if len(apiProjects) != 0 {
-        fmt.Println("\nAPIs (" + strconv.Itoa(len(apiProjects)) + ") ...")
-        for i, projectParam := range apiProjects {
-            // if the project is a deleted one, we do it later. So keep it for now.
+    // if the project is a deleted one, we do it later. So keep it for now.
+    for _, projectType := range []string{utils.ProjectTypeApi, utils.ProjectTypeApiProduct, utils.ProjectTypeApplication} {
+        for _, projectParam := range updatedProjectsPerType[projectType] {
             if projectParam.Deleted {
-                handleProjectDeletion(i, projectParam, deletedProjectsPerType)
+                deletedProjectsPerType[projectType] = append(deletedProjectsPerType[projectType], projectParam)
                 hasDeletedProjects = true
-                continue
-            }
-            importParams := projectParam.ApiParams.Deploy.Import
-            fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-            err := impl.ImportAPIToEnv(accessToken, environment, projectParam.AbsolutePath, utils.ParamFileAPI,
-                importParams.Update, importParams.PreserveProvider, false, false, false)
-            if err != nil {
-                fmt.Println("Error... ", err)
-                failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
             }
         }
     }
 
+    // deploying API projects
+    apiProjects := updatedProjectsPerType[utils.ProjectTypeApi]
+    if len(apiProjects) != 0 {
+        fmt.Fprintln(utils.MessageWriter(), "\nAPIs (" + strconv.Itoa(len(apiProjects)) + ") ...")
+        deployProjectStagesInParallel(utils.MessageWriter(), apiProjects, parallel, failedProjects,
+            func(i int, projectParam *params.ProjectParams, out io.Writer) error {
+                if projectParam.Deleted {
+                    printProjectAwaitingDeletion(out, i, projectParam)
+                    return nil
+                }
+                importParams := projectParam.ApiParams.Deploy.Import
+                printProjectHeader(out, i, projectParam)
+                err := impl.ImportAPIToEnv(accessToken, environment, projectParam.AbsolutePath,
+                    getParamsFileOfProject(projectParam, utils.ParamFileAPI),
+                    importParams.Update, importParams.PreserveProvider, false, false, false, skipValidation)
+                if err != nil {
+                    return err
+                }
+                fmt.Fprintln(out, "Successfully imported API.")
+                return nil
+            })
+    }
+
     // deploying API product projects
     apiProductProjects := updatedProjectsPerType[utils.ProjectTypeApiProduct]
     if len(apiProductProjects) != 0 {
-        fmt.Println("\nAPI Products (" + strconv.Itoa(len(apiProductProjects)) + ") ...")
-        for i, projectParam := range apiProductProjects {
-            // if the project is a deleted one, we do it later. So keep it for now.
-            if projectParam.Deleted {
-                handleProjectDeletion(i, projectParam, deletedProjectsPerType)
-                hasDeletedProjects = true
-                continue
-            }
-            importParams := projectParam.ApiProductParams.Deploy.Import
-            fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-            err := impl.ImportAPIProductToEnv(accessToken, environment, projectParam.AbsolutePath, utils.ParamFileAPIProduct,
-                importParams.ImportAPIs, importParams.UpdateAPIs, importParams.UpdateAPIProduct,
-                importParams.PreserveProvider, false, false, false)
-            if err != nil {
-                fmt.Println("\terror... ", err)
-                failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
-            }
-        }
+        fmt.Fprintln(utils.MessageWriter(), "\nAPI Products (" + strconv.Itoa(len(apiProductProjects)) + ") ...")
+        deployProjectStagesInParallel(utils.MessageWriter(), apiProductProjects, parallel, failedProjects,
+            func(i int, projectParam *params.ProjectParams, out io.Writer) error {
+                if projectParam.Deleted {
+                    printProjectAwaitingDeletion(out, i, projectParam)
+                    return nil
+                }
+                importParams := projectParam.ApiProductParams.Deploy.Import
+                printProjectHeader(out, i, projectParam)
+                err := impl.ImportAPIProductToEnv(accessToken, environment, projectParam.AbsolutePath,
+                    getParamsFileOfProject(projectParam, utils.ParamFileAPIProduct), importParams.ImportAPIs,
+                    importParams.UpdateAPIs, importParams.UpdateAPIProduct, importParams.PreserveProvider, false, false,
+                    false, skipValidation)
+                if err != nil {
+                    return err
+                }
+                fmt.Fprintln(out, "Successfully imported API Product.")
+                return nil
+            })
     }
 
     // deploying Application projects
     applicationProjects := updatedProjectsPerType[utils.ProjectTypeApplication]
     if len(applicationProjects) != 0 {
-        fmt.Println("\nApplications (" + strconv.Itoa(len(applicationProjects)) + ") ...")
-        for i, projectParam := range applicationProjects {
-            // if the project is a deleted one, we do it later. So keep it for now.
-            if projectParam.Deleted {
-                handleProjectDeletion(i, projectParam, deletedProjectsPerType)
-                hasDeletedProjects = true
-                continue
-            }
-            importParams := projectParam.ApplicationParams.Deploy.Import
-            fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-            _, err := impl.ImportApplicationToEnv(accessToken, environment, projectParam.AbsolutePath,
-                importParams.TargetOwner, importParams.Update, importParams.PreserveOwner,
-                importParams.SkipSubscriptions, importParams.SkipKeys, false)
-            if err != nil {
-                fmt.Println("\terror... ", err)
-                failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
-            }
-        }
+        fmt.Fprintln(utils.MessageWriter(), "\nApplications (" + strconv.Itoa(len(applicationProjects)) + ") ...")
+        deployProjectStagesInParallel(utils.MessageWriter(), applicationProjects, parallel, failedProjects,
+            func(i int, projectParam *params.ProjectParams, out io.Writer) error {
+                if projectParam.Deleted {
+                    printProjectAwaitingDeletion(out, i, projectParam)
+                    return nil
+                }
+                importParams := projectParam.ApplicationParams.Deploy.Import
+                printProjectHeader(out, i, projectParam)
+                _, err := impl.ImportApplicationToEnv(accessToken, environment, projectParam.AbsolutePath,
+                    importParams.TargetOwner, importParams.Update, importParams.PreserveOwner,
+                    importParams.SkipSubscriptions, importParams.SkipKeys, false, skipValidation)
+                if err != nil {
+                    return err
+                }
+                fmt.Fprintln(out, "Successfully imported Application.")
+                return applyApplicationKeysOfProject(out, accessToken, environment, projectParam)
+            })
     }
 
     // If there are no deleted projects, update the VCS config file as there is nothing remaining to do.
@@ -424,6 +456,25 @@ func deployUpdatedProjects(accessToken, repoId, environment string, totalProject
     return hasDeletedProjects, deletedProjectsPerType, failedProjects
 }
 
+// Generates or updates the keys of a deployed Application project with the key settings in its params
+func applyApplicationKeysOfProject(out io.Writer, accessToken, environment string,
+    projectParam *params.ProjectParams) error {
+    keys := projectParam.ApplicationParams.Deploy.Keys
+    if len(keys) == 0 {
+        return nil
+    }
+    definition, err := impl.ReadProjectFileAsJSON(projectParam.AbsolutePath,
+        getDefinitionFileNameOfProjectType(projectParam.Type))
+    if err != nil {
+        return err
+    }
+    if definition == nil {
+        return errors.New("application definition of the project is not found to generate its keys")
+    }
+    name, _, _ := getProjectIdentifiers(projectParam.Type, definition)
+    return impl.ApplyApplicationKeys(out, accessToken, environment, name, keys)
+}
+
 // This method is responsible for updating the vcs configuration file at the end of the deployment
 // repoId is the id of the git repository (located in vcs.yaml)
 // environment is the environment name
@@ -454,26 +505,25 @@ func updateVCSConfig(repoId, environment string, failedProjects map[string][]*pa
     utils.WriteConfigFile(vcsConfig, VCSConfigFilePath)
 }
 
-// Logs the deletion project info message and appends the project to delete (projectParam) into deletedProjectsPerType map.
+// Writes the deletion project info message into the given writer. The project is deleted later.
 // i is the index of the project
 // projectParam is the project to be deleted
-// deletedProjectsPerType is the map of project type -> projects which are keeping the projects to delete
-func handleProjectDeletion(i int, projectParam *params.ProjectParams, deletedProjectsPerType map[string][]*params.ProjectParams) {
-    fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ") awaiting deletion..")
-    if deletedProjectsPerType[projectParam.Type] == nil {
-        deletedProjectsPerType[projectParam.Type] = []*params.ProjectParams{}
-    }
-    deletedProjectsPerType[projectParam.Type] = append(deletedProjectsPerType[projectParam.Type], projectParam)
+func printProjectAwaitingDeletion(out io.Writer, i int, projectParam *params.ProjectParams) {
+    fmt.Fprintln(out, strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath +
+        ") awaiting deletion..")
 }
 
 // Scan and detects all the changes in projects by comparing the current revision with the last successful revision.
 // Deploy all the changes to the specified environment.
 // accesstoken is the access token to access the APIM product REST APIs
 // environment is the environment name
-func DeployChangedFiles(accessToken, environment string) map[string][]*params.ProjectParams {
+// parallel is the maximum number of projects of the same type to deploy at the same time
+// skipValidation specifies whether validating the projects before importing them needs to be skipped
+func DeployChangedFiles(accessToken, environment string, parallel int,
+        skipValidation bool) map[string][]*params.ProjectParams {
     repoId, totalProjectsToUpdate, updatedProjectsPerType := GetStatus(environment, FromRevTypeLastAttempted)
-    hasDeletedProjects, deletedProjectsPerType, failedProjects :=
-        deployUpdatedProjects(accessToken, repoId, environment, totalProjectsToUpdate, updatedProjectsPerType)
+    hasDeletedProjects, deletedProjectsPerType, failedProjects := deployUpdatedProjects(accessToken, repoId,
+        environment, totalProjectsToUpdate, updatedProjectsPerType, parallel, skipValidation)
 
     if hasDeletedProjects {
         //check whether project deletion is disabled
@@ -494,9 +544,10 @@ func DeployChangedFiles(accessToken, environment string) map[string][]*params.Pr
         lastSuccessfulRev := envVCSConfig.LastSuccessfulRev[0]
         tmpBranchName := "tmp-" + lastSuccessfulRev[0:8]
 
-        fmt.Println("\nDeleting projects ..")
+        fmt.Fprintln(utils.MessageWriter(), "\nDeleting projects ..")
         checkoutNewBranchFromRevision(tmpBranchName, lastSuccessfulRev)
-        failedProjects = deployProjectDeletions(accessToken, environment, deletedProjectsPerType, failedProjects)
+        failedProjects = deployProjectDeletions(accessToken, environment, deletedProjectsPerType, failedProjects,
+            parallel)
         checkoutBranch(currentBranch)
         deleteTmpBranch(tmpBranchName)
 
@@ -0,0 +1,115 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+    "bytes"
+    "fmt"
+    "io"
+    "strconv"
+    "sync"
+
+    "github.com/wso2/product-apim-tooling/import-export-cli/impl"
+    "github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+)
+
+// DefaultDeployParallelism is the number of projects deployed at the same time when it is not specified
+const DefaultDeployParallelism = 1
+
+// projectDeployFunc deploys (or deletes) a single project. All the console output of the project should be written
+//  to out. A returned error marks the project as failed.
+type projectDeployFunc func(i int, projectParam *params.ProjectParams, out io.Writer) error
+
+// Deploys the given projects of a single project type using a pool of at most `parallel` workers. The output of each
+//  project is buffered and written to console as a single block once the project is completed. The blocks are written
+//  in the same order as the projects, so the console output is identical to a sequential deployment.
+// console is the writer that the output of the projects is written into
+// projects is the list of projects to deploy
+// parallel is the maximum number of projects to deploy at the same time
+// failedProjects is the map of project type -> projects that the failed projects are appended into
+// deployFunc is the function which deploys a single project
+func deployProjectsInParallel(console io.Writer, projects []*params.ProjectParams, parallel int,
+        failedProjects map[string][]*params.ProjectParams, deployFunc projectDeployFunc) {
+    if parallel < 1 {
+        parallel = DefaultDeployParallelism
+    }
+
+    outputs := make([]bytes.Buffer, len(projects))
+    errs := make([]error, len(projects))
+    completed := make([]bool, len(projects))
+    nextToPrint := 0
+    var outputLock sync.Mutex
+
+    impl.RunInParallel(len(projects), parallel, func(i int) {
+        err := deployFunc(i, projects[i], &outputs[i])
+        if err != nil {
+            fmt.Fprintln(&outputs[i], "Error... ", err)
+        }
+
+        outputLock.Lock()
+        defer outputLock.Unlock()
+        errs[i] = err
+        completed[i] = true
+        // print the completed projects which are next in the order
+        for nextToPrint < len(projects) && completed[nextToPrint] {
+            fmt.Fprint(console, outputs[nextToPrint].String())
+            outputs[nextToPrint].Reset()
+            nextToPrint++
+        }
+    })
+
+    // failed projects are collected after all the workers are done to keep the same order as the projects
+    for i, projectParam := range projects {
+        if errs[i] != nil {
+            failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+        }
+    }
+}
+
+// Deploys the given projects of a single project type stage by stage. The projects are expected to be ordered by their
+//  deploy stage (see orderProjectsByWorkspace). The projects of a stage are deployed in parallel once all the projects
+//  of the previous stage are completed, so a project is never deployed before the projects it depends on.
+// console is the writer that the output of the projects is written into
+// projects is the list of projects to deploy
+// parallel is the maximum number of projects to deploy at the same time
+// failedProjects is the map of project type -> projects that the failed projects are appended into
+// deployFunc is the function which deploys a single project
+func deployProjectStagesInParallel(console io.Writer, projects []*params.ProjectParams, parallel int,
+        failedProjects map[string][]*params.ProjectParams, deployFunc projectDeployFunc) {
+    stageStart := 0
+    for i := 1; i <= len(projects); i++ {
+        if i < len(projects) && projects[i].DeployStage == projects[stageStart].DeployStage {
+            continue
+        }
+        // keep the indexes of the projects continuous across the stages
+        offset := stageStart
+        deployProjectsInParallel(console, projects[stageStart:i], parallel, failedProjects,
+            func(j int, projectParam *params.ProjectParams, out io.Writer) error {
+                return deployFunc(offset+j, projectParam, out)
+            })
+        stageStart = i
+    }
+}
+
+// Writes the line which identifies the project being deployed into the given writer
+// i is the index of the project
+// projectParam is the project being deployed
+func printProjectHeader(out io.Writer, i int, projectParam *params.ProjectParams) {
+    fmt.Fprintln(out, strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
+}
@@ -0,0 +1,160 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package git
+
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "io"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+    "github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func parallelTestProjects(stages ...int) []*params.ProjectParams {
+    var projects []*params.ProjectParams
+    for i, stage := range stages {
+        projects = append(projects, &params.ProjectParams{Type: utils.ProjectTypeApi,
+            NickName: fmt.Sprintf("project-%d", i), RelativePath: fmt.Sprintf("project-%d", i), DeployStage: stage})
+    }
+    return projects
+}
+
+func TestDeployProjectsInParallelKeepsTheOrderOfTheOutput(t *testing.T) {
+    projects := parallelTestProjects(0, 0, 0, 0, 0)
+    // each project waits until the next one is completed, so the projects are completed in the reverse order
+    done := make([]chan struct{}, len(projects)+1)
+    for i := range done {
+        done[i] = make(chan struct{})
+    }
+    close(done[len(projects)])
+
+    var console bytes.Buffer
+    failedProjects := make(map[string][]*params.ProjectParams)
+    deployProjectsInParallel(&console, projects, len(projects), failedProjects,
+        func(i int, projectParam *params.ProjectParams, out io.Writer) error {
+            <-done[i+1]
+            printProjectHeader(out, i, projectParam)
+            fmt.Fprintln(out, "deployed")
+            close(done[i])
+            return nil
+        })
+
+    var expected strings.Builder
+    for i, projectParam := range projects {
+        printProjectHeader(&expected, i, projectParam)
+        fmt.Fprintln(&expected, "deployed")
+    }
+    assert.Equal(t, expected.String(), console.String())
+    assert.Empty(t, failedProjects)
+}
+
+func TestDeployProjectsInParallelLimitsTheWorkers(t *testing.T) {
+    projects := parallelTestProjects(0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+    var running, maxRunning int32
+    var deployed sync.Map
+
+    deployProjectsInParallel(&bytes.Buffer{}, projects, 3, make(map[string][]*params.ProjectParams),
+        func(i int, projectParam *params.ProjectParams, out io.Writer) error {
+            current := atomic.AddInt32(&running, 1)
+            for {
+                previous := atomic.LoadInt32(&maxRunning)
+                if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+                    break
+                }
+            }
+            _, loaded := deployed.LoadOrStore(i, projectParam)
+            assert.False(t, loaded, "project %d deployed more than once", i)
+            atomic.AddInt32(&running, -1)
+            return nil
+        })
+
+    assert.LessOrEqual(t, maxRunning, int32(3))
+    for i := range projects {
+        _, ok := deployed.Load(i)
+        assert.True(t, ok, "project %d is not deployed", i)
+    }
+}
+
+func TestDeployProjectsInParallelCollectsTheFailedProjects(t *testing.T) {
+    projects := parallelTestProjects(0, 0, 0, 0, 0, 0, 0, 0)
+    var console bytes.Buffer
+    failedProjects := map[string][]*params.ProjectParams{
+        utils.ProjectTypeApplication: {{Type: utils.ProjectTypeApplication, NickName: "app"}},
+    }
+
+    deployProjectsInParallel(&console, projects, 4, failedProjects,
+        func(i int, projectParam *params.ProjectParams, out io.Writer) error {
+            printProjectHeader(out, i, projectParam)
+            if i%3 == 1 {
+                return errors.New("cannot deploy " + projectParam.NickName)
+            }
+            return nil
+        })
+
+    assert.Equal(t, []*params.ProjectParams{projects[1], projects[4], projects[7]},
+        failedProjects[utils.ProjectTypeApi])
+    assert.Len(t, failedProjects[utils.ProjectTypeApplication], 1)
+    assert.Equal(t, 3, strings.Count(console.String(), "Error... "))
+    assert.Contains(t, console.String(), "2: project-1: (project-1)\nError...  cannot deploy project-1\n")
+}
+
+func TestDeployProjectStagesInParallelWaitsForThePreviousStages(t *testing.T) {
+    projects := parallelTestProjects(0, 0, 0, 1, 1, 2, 2, 2)
+    var completedPerStage [3]int32
+    stageSizes := [3]int32{3, 2, 3}
+    var indexes []int
+    var indexLock sync.Mutex
+    var console bytes.Buffer
+    failedProjects := make(map[string][]*params.ProjectParams)
+
+    deployProjectStagesInParallel(&console, projects, 4, failedProjects,
+        func(i int, projectParam *params.ProjectParams, out io.Writer) error {
+            for stage := 0; stage < projectParam.DeployStage; stage++ {
+                assert.Equal(t, stageSizes[stage], atomic.LoadInt32(&completedPerStage[stage]),
+                    "%s is deployed before stage %d is completed", projectParam.NickName, stage)
+            }
+            assert.Equal(t, projects[i], projectParam)
+            indexLock.Lock()
+            indexes = append(indexes, i)
+            indexLock.Unlock()
+            printProjectHeader(out, i, projectParam)
+            atomic.AddInt32(&completedPerStage[projectParam.DeployStage], 1)
+            if projectParam.DeployStage == 1 {
+                return errors.New("stage failed")
+            }
+            return nil
+        })
+
+    assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, indexes)
+    assert.Equal(t, []*params.ProjectParams{projects[3], projects[4]}, failedProjects[utils.ProjectTypeApi])
+    var expected strings.Builder
+    for i, projectParam := range projects {
+        printProjectHeader(&expected, i, projectParam)
+        if projectParam.DeployStage == 1 {
+            fmt.Fprintln(&expected, "Error... ", "stage failed")
+        }
+    }
+    assert.Equal(t, expected.String(), console.String())
+}
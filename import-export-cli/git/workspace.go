@@ -0,0 +1,159 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package git
+
+import (
+    "path/filepath"
+
+    "github.com/wso2/product-apim-tooling/import-export-cli/impl"
+    "github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+    "github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Loads the workspace manifest (apictl.yaml) in the repository root and discovers the projects declared in it
+// repoBasePath is the basepath of the git repository
+// Returns the workspace and its projects. The workspace is nil if the repository does not have a manifest
+func loadRepoWorkspace(repoBasePath string) (*impl.Workspace, []*impl.WorkspaceProject) {
+    manifestPath := filepath.Join(repoBasePath, utils.WorkspaceManifestFile)
+    if !utils.IsFileExist(manifestPath) {
+        return nil, nil
+    }
+    workspace, err := impl.LoadWorkspace(manifestPath)
+    if err != nil {
+        utils.HandleErrorAndExit("Error while reading the workspace manifest", err)
+    }
+    workspaceProjects, err := workspace.DiscoverProjects()
+    if err != nil {
+        utils.HandleErrorAndExit("Error while discovering the projects of the workspace", err)
+    }
+    return workspace, workspaceProjects
+}
+
+// subPath denotes a single changed file retrieved from "git diff" command. The project of the file is looked up from
+//  the projects declared in the workspace manifest. Files of the ignored paths and the projects which are not declared
+//  are skipped. As the files of a deleted project no longer exist, deleted projects are identified from their
+//  *_params.yaml files similar to a repository without a manifest.
+// envVCSConfig is the environment related VCS configuration
+// repoBasePath is the basepath of the git repository
+// workspace is the workspace manifest of the repository
+// workspaceProjects are the projects discovered from the workspace manifest
+// pathInfoMap is a map of path (string) to project info. This is used for caching and avoid repetitive checking
+// Returns the identified project details. If it is not related to a project, a NONE project info item will be returned
+func getProjectInfoFromWorkspace(envVCSConfig Environment, repoBasePath, subPath string, workspace *impl.Workspace,
+    workspaceProjects []*impl.WorkspaceProject, pathInfoMap map[string]*params.ProjectParams) *params.ProjectParams {
+    noneProject := &params.ProjectParams{
+        Type: utils.ProjectTypeNone,
+    }
+    if workspace.IsIgnored(filepath.ToSlash(subPath)) {
+        return noneProject
+    }
+
+    workspaceProject := impl.FindWorkspaceProject(workspaceProjects, filepath.Join(repoBasePath, subPath))
+    if workspaceProject == nil {
+        projectParams := getProjectInfoFromProjectFile(envVCSConfig, repoBasePath, subPath, pathInfoMap)
+        if projectParams.Deleted {
+            return projectParams
+        }
+        return noneProject
+    }
+
+    projectParams := pathInfoMap[workspaceProject.Path]
+    if projectParams == nil {
+        projectParams = getProjectParamsOfWorkspaceProject(workspaceProject)
+        pathInfoMap[workspaceProject.Path] = projectParams
+    }
+    projectParams.FailedDuringPreviousDeploy = failedDuringEarlierDeploy(envVCSConfig, projectParams)
+    return projectParams
+}
+
+// Creates the project details of a project declared in the workspace manifest. The params are loaded from the params
+//  file declared for the project. If the params file does not exist, the default params are used.
+func getProjectParamsOfWorkspaceProject(workspaceProject *impl.WorkspaceProject) *params.ProjectParams {
+    projectParams := &params.ProjectParams{
+        Type:         workspaceProject.Type,
+        AbsolutePath: workspaceProject.Path,
+        RelativePath: filepath.FromSlash(workspaceProject.RelativePath),
+        NickName:     filepath.Base(workspaceProject.Path),
+        ParamsFile:   workspaceProject.ParamsFile,
+    }
+    hasParamsFile := utils.IsFileExist(workspaceProject.ParamsFile)
+
+    var err error
+    switch workspaceProject.Type {
+    case utils.ProjectTypeApi:
+        projectParams.ApiParams = &params.ApiParams{}
+        if hasParamsFile {
+            projectParams.ApiParams, err = params.LoadApiParamsFromFile(workspaceProject.ParamsFile)
+        }
+    case utils.ProjectTypeApiProduct:
+        projectParams.ApiProductParams = &params.ApiProductParams{}
+        if hasParamsFile {
+            projectParams.ApiProductParams, err = params.LoadApiProductParamsFromFile(workspaceProject.ParamsFile)
+        }
+    case utils.ProjectTypeApplication:
+        projectParams.ApplicationParams = &params.ApplicationParams{}
+        if hasParamsFile {
+            projectParams.ApplicationParams, err = params.LoadApplicationParamsFromFile(workspaceProject.ParamsFile)
+        }
+    }
+    if err != nil {
+        utils.HandleErrorAndExit("Error while parsing the params file: " + workspaceProject.ParamsFile, err)
+    }
+    return projectParams
+}
+
+// Orders the projects of a single project type in the deploy order of the workspace and sets the deploy stage of each
+//  project. Projects which are not declared in the workspace (eg: deleted projects) are kept first in the stage 0.
+// workspaceProjects are the projects discovered from the workspace manifest
+// projects are the projects to deploy
+// Returns the ordered projects
+func orderProjectsByWorkspace(workspaceProjects []*impl.WorkspaceProject,
+    projects []*params.ProjectParams) []*params.ProjectParams {
+    var orderedProjects []*params.ProjectParams
+    var projectsToDeploy []*impl.WorkspaceProject
+    projectsPerPath := make(map[string]*params.ProjectParams)
+    for _, projectParam := range projects {
+        workspaceProject := impl.FindWorkspaceProject(workspaceProjects, projectParam.AbsolutePath)
+        if workspaceProject == nil || workspaceProject.Path != projectParam.AbsolutePath {
+            orderedProjects = append(orderedProjects, projectParam)
+            continue
+        }
+        projectsPerPath[workspaceProject.Path] = projectParam
+        projectsToDeploy = append(projectsToDeploy, workspaceProject)
+    }
+    for i, stage := range impl.WorkspaceDeployStages(projectsToDeploy) {
+        for _, workspaceProject := range stage {
+            projectParam := projectsPerPath[workspaceProject.Path]
+            projectParam.DeployStage = i + 1
+            orderedProjects = append(orderedProjects, projectParam)
+        }
+    }
+    return orderedProjects
+}
+
+// Returns the params file to import the given project with. The params file declared in the workspace manifest is
+//  used if the project is declared in it, otherwise the given default params file is used.
+func getParamsFileOfProject(projectParam *params.ProjectParams, defaultParamsFile string) string {
+    if projectParam.ParamsFile == "" {
+        return defaultParamsFile
+    }
+    if !utils.IsFileExist(projectParam.ParamsFile) {
+        return ""
+    }
+    return projectParam.ParamsFile
+}
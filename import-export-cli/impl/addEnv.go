@@ -21,6 +21,7 @@ package impl
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -40,6 +41,10 @@ func AddEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePath
 		return errors.New("Name of the environment cannot be blank")
 	}
 
+	if strings.Contains(envName, utils.MINodeEnvSeparator) {
+		return errors.New("Name of the environment cannot contain '" + utils.MINodeEnvSeparator + "'")
+	}
+
 	if !utils.HasOnlyMIEndpoint(envEndpoints) && envEndpoints.TokenEndpoint == "" {
 		// If token endpoint string is empty,then assign the default value
 		if envEndpoints.ApiManagerEndpoint != "" && !isDefaultTokenEndpointSet {
@@ -49,7 +54,7 @@ func AddEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePath
 		if envEndpoints.PublisherEndpoint != "" && !isDefaultTokenEndpointSet {
 			envEndpoints.TokenEndpoint = utils.GetTokenEndPointFromPublisherEndpoint(envEndpoints.PublisherEndpoint)
 		}
-		fmt.Printf("Default token endpoint '%s' is added as the token endpoint \n", envEndpoints.TokenEndpoint)
+		fmt.Fprintf(utils.MessageWriter(), "Default token endpoint '%s' is added as the token endpoint \n", envEndpoints.TokenEndpoint)
 	}
 
 	if envEndpoints.ApiManagerEndpoint == "" {
@@ -96,10 +101,14 @@ func AddEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePath
 		validatedEnvEndpoints.MiManagementEndpoint = envEndpoints.MiManagementEndpoint
 	}
 
+	if len(envEndpoints.MiManagementNodes) > 0 {
+		validatedEnvEndpoints.MiManagementNodes = envEndpoints.MiManagementNodes
+	}
+
 	mainConfig.Environments[envName] = validatedEnvEndpoints
 	utils.WriteConfigFile(mainConfig, mainConfigFilePath)
 
-	fmt.Printf("Successfully added environment '%s'\n", envName)
+	fmt.Fprintf(utils.MessageWriter(), "Successfully added environment '%s'\n", envName)
 
 	return nil
 }
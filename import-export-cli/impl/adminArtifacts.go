@@ -0,0 +1,609 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Types of the admin artifacts
+const (
+	AdminArtifactTypeThrottlingPolicy = "throttling_policy"
+	AdminArtifactTypeKeyManager       = "key_manager"
+	AdminArtifactTypeScope            = "scope"
+)
+
+// Types of the throttling policies
+const (
+	ThrottlingPolicyTypeAdvanced     = "advanced"
+	ThrottlingPolicyTypeApplication  = "application"
+	ThrottlingPolicyTypeSubscription = "subscription"
+	ThrottlingPolicyTypeCustom       = "custom"
+)
+
+// ThrottlingPolicyTypes are the types of the throttling policies which can be exported and imported
+var ThrottlingPolicyTypes = []string{ThrottlingPolicyTypeAdvanced, ThrottlingPolicyTypeApplication,
+	ThrottlingPolicyTypeSubscription, ThrottlingPolicyTypeCustom}
+
+// version of the files of the exported admin artifacts
+const adminArtifactVersion = "v4.0.0"
+
+// number of shared scopes retrieved at once
+const adminArtifactsPageSize = 100
+
+// characters which are not allowed in the file names of the exported admin artifacts
+var adminArtifactFileNameRegex = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// fields of the exported admin artifacts which hold secrets
+var adminArtifactSecretFieldRegex = regexp.MustCompile(`(?i)secret|password`)
+
+// AdminArtifact is a throttling policy, key manager or shared scope exported to a file. The data is the object
+// returned by the REST API without the fields generated by the server.
+//
+//	type: throttling_policy
+//	subtype: advanced
+//	version: v4.0.0
+//	data:
+//	  policyName: 10KPerMin
+//	  ...
+type AdminArtifact struct {
+	Type    string                 `yaml:"type" json:"type"`
+	SubType string                 `yaml:"subtype,omitempty" json:"subtype,omitempty"`
+	Version string                 `yaml:"version" json:"version"`
+	Data    map[string]interface{} `yaml:"data" json:"data"`
+}
+
+// ImportAdminArtifactsSummary is the result of importing admin artifacts
+type ImportAdminArtifactsSummary struct {
+	Created []string                     `json:"created"`
+	Updated []string                     `json:"updated"`
+	Failed  []ImportAdminArtifactFailure `json:"failed,omitempty"`
+}
+
+// ImportAdminArtifactFailure is an admin artifact failed to import
+type ImportAdminArtifactFailure struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// adminArtifactKind describes how an admin artifact type is managed through the REST APIs
+type adminArtifactKind struct {
+	artifactType string
+	subType      string
+	// resource is the path of the artifacts relative to the REST API
+	resource string
+	// idField and nameField are the fields of the data containing the id and the unique name of an artifact
+	idField   string
+	nameField string
+	// readOnlyFields are generated by the server and are removed from the exported data
+	readOnlyFields []string
+	// secretsField is the object of the data whose secrets are exported as secret references
+	secretsField string
+	// publisher is set for the artifacts managed by the publisher REST API instead of the admin REST API
+	publisher bool
+	// paginated is set when the artifacts are listed page by page
+	paginated bool
+}
+
+// newAdminArtifactKind returns the kind of an admin artifact type
+// @param artifactType : Type of the artifact (throttling_policy, key_manager or scope)
+// @param subType : Type of the throttling policy (advanced, application, subscription or custom)
+// @return the kind of the artifact
+// @return error if the type is unknown
+func newAdminArtifactKind(artifactType, subType string) (*adminArtifactKind, error) {
+	switch artifactType {
+	case AdminArtifactTypeThrottlingPolicy:
+		if !containsString(ThrottlingPolicyTypes, subType) {
+			return nil, utils.NewValidationError("Invalid throttling policy type '" + subType + "'. It should be one of " +
+				strings.Join(ThrottlingPolicyTypes, ", "))
+		}
+		return &adminArtifactKind{artifactType: artifactType, subType: subType,
+			resource: "throttling/policies/" + subType, idField: "policyId", nameField: "policyName",
+			readOnlyFields: []string{"policyId", "isDeployed"}}, nil
+	case AdminArtifactTypeKeyManager:
+		return &adminArtifactKind{artifactType: artifactType, resource: "key-managers", idField: "id",
+			nameField: "name", readOnlyFields: []string{"id"}, secretsField: "additionalProperties"}, nil
+	case AdminArtifactTypeScope:
+		return &adminArtifactKind{artifactType: artifactType, resource: "scopes", idField: "id", nameField: "name",
+			readOnlyFields: []string{"id", "usageCount"}, publisher: true, paginated: true}, nil
+	}
+	return nil, utils.NewValidationError("Invalid admin artifact type '" + artifactType + "'")
+}
+
+// String returns the name of the kind used in the messages
+func (k *adminArtifactKind) String() string {
+	switch k.artifactType {
+	case AdminArtifactTypeThrottlingPolicy:
+		return k.subType + " throttling policy"
+	case AdminArtifactTypeKeyManager:
+		return "key manager"
+	}
+	return "shared scope"
+}
+
+// adminArtifactsClient invokes the REST APIs managing the admin artifacts of an environment
+type adminArtifactsClient struct {
+	accessToken       string
+	adminEndpoint     string
+	publisherEndpoint string
+}
+
+// newAdminArtifactsClient creates a client for the REST APIs of an environment
+func newAdminArtifactsClient(accessToken, environment string) *adminArtifactsClient {
+	return &adminArtifactsClient{
+		accessToken:       accessToken,
+		adminEndpoint:     utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath),
+		publisherEndpoint: utils.GetPublisherEndpointOfEnv(environment, utils.MainConfigFilePath),
+	}
+}
+
+// url returns the url of the artifacts of a kind
+func (c *adminArtifactsClient) url(kind *adminArtifactKind) string {
+	if kind.publisher {
+		return utils.AppendSlashToString(c.publisherEndpoint) + kind.resource
+	}
+	return utils.AppendSlashToString(c.adminEndpoint) + kind.resource
+}
+
+// headers returns the headers of the requests
+func (c *adminArtifactsClient) headers() map[string]string {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + c.accessToken
+	headers[utils.HeaderAccept] = utils.HeaderValueApplicationJSON
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	return headers
+}
+
+// list returns all the artifacts of a kind. The items of the list may not contain all the fields of the artifacts.
+func (c *adminArtifactsClient) list(kind *adminArtifactKind) ([]map[string]interface{}, error) {
+	var artifacts []map[string]interface{}
+	for offset := 0; ; offset += adminArtifactsPageSize {
+		queryParams := make(map[string]string)
+		if kind.paginated {
+			queryParams["limit"] = strconv.Itoa(adminArtifactsPageSize)
+			queryParams["offset"] = strconv.Itoa(offset)
+		}
+		utils.Logln(utils.LogPrefixInfo+"Listing "+kind.String()+"s: URL:", c.url(kind))
+		resp, err := utils.InvokeGETRequestWithMultipleQueryParams(queryParams, c.url(kind), c.headers())
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return nil, utils.NewHttpStatusError(resp)
+		}
+		page := struct {
+			List []map[string]interface{} `json:"list"`
+		}{}
+		if err := json.Unmarshal(resp.Body(), &page); err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, page.List...)
+		if !kind.paginated || len(page.List) < adminArtifactsPageSize {
+			return artifacts, nil
+		}
+	}
+}
+
+// get returns an artifact
+func (c *adminArtifactsClient) get(kind *adminArtifactKind, id string) (map[string]interface{}, error) {
+	resp, err := utils.InvokeGETRequest(c.url(kind)+"/"+id, c.headers())
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, utils.NewHttpStatusError(resp)
+	}
+	data := make(map[string]interface{})
+	if err := json.Unmarshal(resp.Body(), &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// create adds an artifact
+func (c *adminArtifactsClient) create(kind *adminArtifactKind, data map[string]interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	resp, err := utils.InvokePOSTRequest(c.url(kind), c.headers(), body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		return utils.NewHttpStatusError(resp)
+	}
+	return nil
+}
+
+// update replaces an existing artifact
+func (c *adminArtifactsClient) update(kind *adminArtifactKind, id string, data map[string]interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	resp, err := utils.InvokePutRequest(nil, c.url(kind)+"/"+id, c.headers(), string(body))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return utils.NewHttpStatusError(resp)
+	}
+	return nil
+}
+
+// ExportAdminArtifacts exports the admin artifacts of an environment to files
+// @param accessToken : Access token to call the REST APIs
+// @param environment : Environment from which the artifacts are exported
+// @param exportDirectory : Directory the artifacts are written to. Throttling policies are written to a sub directory
+// by the policy type
+// @param artifactType : Type of the artifacts (throttling_policy, key_manager or scope)
+// @param subTypes : Types of the throttling policies to export
+// @param name : Name of the artifact to export. All the artifacts are exported if empty
+// @return the paths of the exported files
+// @return error
+func ExportAdminArtifacts(accessToken, environment, exportDirectory, artifactType string, subTypes []string,
+	name string) ([]string, error) {
+	return exportAdminArtifacts(newAdminArtifactsClient(accessToken, environment), exportDirectory, artifactType,
+		subTypes, name)
+}
+
+func exportAdminArtifacts(client *adminArtifactsClient, exportDirectory, artifactType string, subTypes []string,
+	name string) ([]string, error) {
+	if artifactType != AdminArtifactTypeThrottlingPolicy {
+		subTypes = []string{""}
+	}
+	var files []string
+	for _, subType := range subTypes {
+		kind, err := newAdminArtifactKind(artifactType, subType)
+		if err != nil {
+			return nil, err
+		}
+		artifacts, err := client.list(kind)
+		if err != nil {
+			return nil, err
+		}
+		directory := filepath.Join(exportDirectory, subType)
+		for _, item := range artifacts {
+			artifactName := fmt.Sprint(item[kind.nameField])
+			if name != "" && artifactName != name {
+				continue
+			}
+			data, err := client.get(kind, fmt.Sprint(item[kind.idField]))
+			if err != nil {
+				return nil, errors.New("Error while getting the " + kind.String() + " " + artifactName + ": " +
+					err.Error())
+			}
+			file, err := writeAdminArtifact(kind, data, directory)
+			if err != nil {
+				return nil, err
+			}
+			utils.Logln(utils.LogPrefixInfo + "Exported the " + kind.String() + " " + artifactName + " to " + file)
+			files = append(files, file)
+		}
+	}
+	if name != "" && len(files) == 0 {
+		return nil, utils.NewNotFoundError("Requested artifact " + name + " is not available")
+	}
+	return files, nil
+}
+
+// writeAdminArtifact writes an artifact to a file in the directory named after the artifact
+func writeAdminArtifact(kind *adminArtifactKind, data map[string]interface{}, directory string) (string, error) {
+	for _, field := range kind.readOnlyFields {
+		delete(data, field)
+	}
+	maskAdminArtifactSecrets(kind, data)
+	content, err := yaml.Marshal(&AdminArtifact{
+		Type:    kind.artifactType,
+		SubType: kind.subType,
+		Version: adminArtifactVersion,
+		Data:    data,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := utils.CreateDirIfNotExist(directory); err != nil {
+		return "", err
+	}
+	file := filepath.Join(directory,
+		adminArtifactFileNameRegex.ReplaceAllString(fmt.Sprint(data[kind.nameField]), "_")+".yaml")
+	if err := ioutil.WriteFile(file, content, 0644); err != nil {
+		return "", err
+	}
+	return file, nil
+}
+
+// maskAdminArtifactSecrets replaces the secrets of an artifact with keystore secret references, so that the secrets
+// are not written to the exported file. The secrets are given when importing by encrypting them with
+// "apictl secret create" under the alias of the reference, or with the params file.
+func maskAdminArtifactSecrets(kind *adminArtifactKind, data map[string]interface{}) {
+	secrets, ok := data[kind.secretsField].(map[string]interface{})
+	if kind.secretsField == "" || !ok {
+		return
+	}
+	for field, value := range secrets {
+		secret, isString := value.(string)
+		if !adminArtifactSecretFieldRegex.MatchString(field) || !isString || secret == "" ||
+			strings.HasPrefix(secret, "${") {
+			continue
+		}
+		alias := adminArtifactFileNameRegex.ReplaceAllString(kind.artifactType+"_"+fmt.Sprint(data[kind.nameField])+
+			"_"+field, "_")
+		secrets[field] = "${secret:" + utils.SecretSourceKeyStore + ":" + alias + "}"
+		fmt.Fprintln(utils.MessageWriter(), "Exported "+kind.secretsField+"."+field+" of the "+kind.String()+" "+
+			fmt.Sprint(data[kind.nameField])+" as the secret reference "+secrets[field].(string))
+	}
+}
+
+// ImportAdminArtifacts imports admin artifacts exported to files to an environment
+// @param accessToken : Access token to call the REST APIs
+// @param environment : Environment to which the artifacts are imported
+// @param importPath : Path to an artifact file or a directory of artifact files. The files of other artifact types
+// in the directory are skipped
+// @param artifactType : Type of the artifacts (throttling_policy, key_manager or scope)
+// @param paramsPath : Path to the params file with the environment specific configurations of the artifacts
+// @param update : Update the existing artifacts with the same name
+// @return the summary of the import
+// @return error if the artifacts cannot be read
+func ImportAdminArtifacts(accessToken, environment, importPath, artifactType, paramsPath string,
+	update bool) (*ImportAdminArtifactsSummary, error) {
+	return importAdminArtifacts(newAdminArtifactsClient(accessToken, environment), environment, importPath,
+		artifactType, paramsPath, update)
+}
+
+func importAdminArtifacts(client *adminArtifactsClient, environment, importPath, artifactType, paramsPath string,
+	update bool) (*ImportAdminArtifactsSummary, error) {
+	var envParams *params.AdminArtifactEnvironment
+	if paramsPath != "" {
+		adminArtifactParams, err := params.LoadAdminArtifactParamsFromFile(paramsPath)
+		if err != nil {
+			return nil, err
+		}
+		envParams = adminArtifactParams.GetEnv(environment)
+		if envParams == nil {
+			return nil, utils.NewValidationError("Environment '" + environment + "' does not exist in " + paramsPath)
+		}
+	}
+
+	info, err := os.Stat(importPath)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{importPath}
+	if info.IsDir() {
+		if files, err = findAdminArtifactFiles(importPath); err != nil {
+			return nil, err
+		}
+	}
+
+	summary := &ImportAdminArtifactsSummary{Created: []string{}, Updated: []string{}}
+	// ids of the existing artifacts by the name, for each kind
+	existingArtifacts := make(map[string]map[string]string)
+	for _, file := range files {
+		artifact, err := readAdminArtifact(file)
+		if err == nil && artifact.Type != artifactType {
+			// the other files in the directory (eg: params files) are skipped
+			if info.IsDir() {
+				utils.Logln(utils.LogPrefixInfo + "Skipping " + file + " which is not a " + artifactType)
+				continue
+			}
+			err = utils.NewValidationError("Expected an artifact of type " + artifactType + " but found '" +
+				artifact.Type + "'")
+		}
+		var name string
+		var updated bool
+		if err == nil {
+			name, updated, err = importAdminArtifact(client, artifact, envParams, update, existingArtifacts)
+		}
+		if err != nil {
+			summary.Failed = append(summary.Failed, ImportAdminArtifactFailure{File: file, Error: err.Error()})
+			fmt.Fprintln(utils.MessageWriter(), "Failed to import "+file+": "+err.Error())
+		} else if updated {
+			summary.Updated = append(summary.Updated, name)
+			fmt.Fprintln(utils.MessageWriter(), "Updated "+name+" from "+file)
+		} else {
+			summary.Created = append(summary.Created, name)
+			fmt.Fprintln(utils.MessageWriter(), "Imported "+name+" from "+file)
+		}
+	}
+	return summary, nil
+}
+
+// importAdminArtifact creates an artifact or updates the existing artifact with the same name
+// @return the name of the artifact
+// @return whether an existing artifact is updated
+// @return error
+func importAdminArtifact(client *adminArtifactsClient, artifact *AdminArtifact,
+	envParams *params.AdminArtifactEnvironment, update bool,
+	existingArtifacts map[string]map[string]string) (string, bool, error) {
+	kind, err := newAdminArtifactKind(artifact.Type, artifact.SubType)
+	if err != nil {
+		return "", false, err
+	}
+	if artifact.Data == nil {
+		return "", false, utils.NewValidationError("data is required")
+	}
+	name, ok := artifact.Data[kind.nameField].(string)
+	if !ok || name == "" {
+		return "", false, utils.NewValidationError("data." + kind.nameField + " is required")
+	}
+	if envParams != nil {
+		artifact.Data, err = mergeAdminArtifactData(artifact.Data, getAdminArtifactConfigs(envParams, kind, name))
+		if err != nil {
+			return name, false, utils.NewValidationError("Error while merging the params: " + err.Error())
+		}
+	}
+	for _, field := range kind.readOnlyFields {
+		delete(artifact.Data, field)
+	}
+	if err := resolveAdminArtifactSecrets(artifact.Data); err != nil {
+		return name, false, err
+	}
+
+	kindKey := kind.artifactType + "/" + kind.subType
+	ids, ok := existingArtifacts[kindKey]
+	if !ok {
+		items, err := client.list(kind)
+		if err != nil {
+			return name, false, err
+		}
+		ids = make(map[string]string)
+		for _, item := range items {
+			ids[fmt.Sprint(item[kind.nameField])] = fmt.Sprint(item[kind.idField])
+		}
+		existingArtifacts[kindKey] = ids
+	}
+
+	id, exists := ids[name]
+	if !exists {
+		if err := client.create(kind, artifact.Data); err != nil {
+			return name, false, err
+		}
+		return name, false, nil
+	}
+	if !update {
+		return name, false, utils.NewConflictError("The " + kind.String() + " " + name + " already exists. " +
+			"Use --update to update it")
+	}
+	artifact.Data[kind.idField] = id
+	if err := client.update(kind, id, artifact.Data); err != nil {
+		return name, true, err
+	}
+	return name, true, nil
+}
+
+// readAdminArtifact reads an artifact file substituting the environment variables defined as ${var}. The secret
+// references are resolved after merging the params, just before the artifact is sent to the server.
+func readAdminArtifact(file string) (*AdminArtifact, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	substitutedContent, err := utils.EnvSubstituteForCurlyBraces(string(content))
+	if err != nil {
+		return nil, err
+	}
+	jsonContent, err := utils.YamlToJson([]byte(substitutedContent))
+	if err != nil {
+		return nil, utils.NewValidationError("Invalid artifact file: " + err.Error())
+	}
+	artifact := &AdminArtifact{}
+	if err := json.Unmarshal(jsonContent, artifact); err != nil {
+		return nil, utils.NewValidationError("Invalid artifact file: " + err.Error())
+	}
+	return artifact, nil
+}
+
+// findAdminArtifactFiles returns the YAML and JSON files in a directory and its sub directories in order
+func findAdminArtifactFiles(directory string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+			if info.Mode().IsRegular() {
+				files = append(files, path)
+			}
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// getAdminArtifactConfigs returns the configurations of an artifact in the params of an environment
+func getAdminArtifactConfigs(envParams *params.AdminArtifactEnvironment, kind *adminArtifactKind,
+	name string) map[string]interface{} {
+	switch kind.artifactType {
+	case AdminArtifactTypeThrottlingPolicy:
+		return envParams.Configs.Policies[kind.subType][name]
+	case AdminArtifactTypeKeyManager:
+		return envParams.Configs.KeyManagers[name]
+	}
+	return envParams.Configs.Scopes[name]
+}
+
+// resolveAdminArtifactSecrets substitutes the secret references in the string values of the data of an artifact
+func resolveAdminArtifactSecrets(data map[string]interface{}) error {
+	for key, value := range data {
+		resolvedValue, err := resolveSecretReferencesInValue(value)
+		if err != nil {
+			return err
+		}
+		data[key] = resolvedValue
+	}
+	return nil
+}
+
+func resolveSecretReferencesInValue(value interface{}) (interface{}, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return utils.ResolveSecretReferences(typedValue)
+	case map[string]interface{}:
+		return typedValue, resolveAdminArtifactSecrets(typedValue)
+	case []interface{}:
+		for i, item := range typedValue {
+			resolvedItem, err := resolveSecretReferencesInValue(item)
+			if err != nil {
+				return nil, err
+			}
+			typedValue[i] = resolvedItem
+		}
+	}
+	return value, nil
+}
+
+// mergeAdminArtifactData deep merges the configurations into the data of an artifact using utils.MergeJSON. The
+// objects are merged recursively while the other values (including lists) are replaced.
+func mergeAdminArtifactData(data, configs map[string]interface{}) (map[string]interface{}, error) {
+	dataContent, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	configsContent, err := json.Marshal(configs)
+	if err != nil {
+		return nil, err
+	}
+	mergedContent, err := utils.MergeJSON(dataContent, configsContent)
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]interface{})
+	if err := json.Unmarshal(mergedContent, &merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
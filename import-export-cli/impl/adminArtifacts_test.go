@@ -0,0 +1,223 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// adminArtifactsStub is an in memory admin REST API of the advanced throttling policies
+type adminArtifactsStub struct {
+	lock     sync.Mutex
+	policies map[string]map[string]interface{}
+	requests []string
+}
+
+func (s *adminArtifactsStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+	id := strings.TrimPrefix(r.URL.Path, "/throttling/policies/advanced/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/throttling/policies/advanced":
+		var list []map[string]interface{}
+		for policyId, policy := range s.policies {
+			list = append(list, map[string]interface{}{"policyId": policyId, "policyName": policy["policyName"]})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(list), "list": list})
+	case r.Method == http.MethodGet && s.policies[id] != nil:
+		_ = json.NewEncoder(w).Encode(s.policies[id])
+	case r.Method == http.MethodPost && r.URL.Path == "/throttling/policies/advanced":
+		policy := make(map[string]interface{})
+		_ = json.NewDecoder(r.Body).Decode(&policy)
+		s.policies["new-id"] = policy
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && s.policies[id] != nil:
+		policy := make(map[string]interface{})
+		_ = json.NewDecoder(r.Body).Decode(&policy)
+		s.policies[id] = policy
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestExportAndImportAdminArtifacts(t *testing.T) {
+	stub := &adminArtifactsStub{policies: map[string]map[string]interface{}{
+		"policy-1": {"policyId": "policy-1", "policyName": "10KPerMin", "isDeployed": true,
+			"defaultLimit": map[string]interface{}{"type": "REQUESTCOUNTLIMIT",
+				"requestCount": map[string]interface{}{"timeUnit": "min", "requestCount": 10000}}},
+	}}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+	client := &adminArtifactsClient{accessToken: "token", adminEndpoint: server.URL}
+
+	exportDir, err := ioutil.TempDir("", "apictl-admin-artifacts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(exportDir)
+
+	// export
+	files, err := exportAdminArtifacts(client, exportDir, AdminArtifactTypeThrottlingPolicy,
+		[]string{ThrottlingPolicyTypeAdvanced}, "")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(exportDir, "advanced", "10KPerMin.yaml")}, files)
+	artifact, err := readAdminArtifact(files[0])
+	assert.Nil(t, err)
+	assert.Equal(t, AdminArtifactTypeThrottlingPolicy, artifact.Type)
+	assert.Equal(t, ThrottlingPolicyTypeAdvanced, artifact.SubType)
+	assert.Nil(t, artifact.Data["policyId"])
+	assert.Nil(t, artifact.Data["isDeployed"])
+
+	_, err = exportAdminArtifacts(client, exportDir, AdminArtifactTypeThrottlingPolicy,
+		[]string{ThrottlingPolicyTypeAdvanced}, "20KPerMin")
+	assert.NotNil(t, err)
+
+	// an existing policy is not updated without --update
+	summary, err := importAdminArtifacts(client, "dev", exportDir, AdminArtifactTypeThrottlingPolicy, "", false)
+	assert.Nil(t, err)
+	assert.Empty(t, summary.Created)
+	assert.Equal(t, 1, len(summary.Failed))
+	assert.Contains(t, summary.Failed[0].Error, "already exists")
+
+	// the params of the environment are merged into the policy on update
+	paramsFile := filepath.Join(exportDir, "params.yaml")
+	assert.Nil(t, ioutil.WriteFile(paramsFile, []byte(`environments:
+  - name: dev
+    configs:
+      policies:
+        advanced:
+          10KPerMin:
+            defaultLimit:
+              requestCount:
+                requestCount: 20000
+`), 0644))
+	summary, err = importAdminArtifacts(client, "dev", exportDir, AdminArtifactTypeThrottlingPolicy, paramsFile, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10KPerMin"}, summary.Updated)
+	assert.Empty(t, summary.Failed)
+	assert.Equal(t, map[string]interface{}{"timeUnit": "min", "requestCount": float64(20000)},
+		stub.policies["policy-1"]["defaultLimit"].(map[string]interface{})["requestCount"])
+
+	// a new policy is created
+	delete(stub.policies, "policy-1")
+	summary, err = importAdminArtifacts(client, "dev", files[0], AdminArtifactTypeThrottlingPolicy, "", false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10KPerMin"}, summary.Created)
+	assert.Equal(t, "10KPerMin", stub.policies["new-id"]["policyName"])
+
+	// a file of another type is rejected
+	summary, err = importAdminArtifacts(client, "dev", files[0], AdminArtifactTypeKeyManager, "", false)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(summary.Failed))
+
+	_, err = importAdminArtifacts(client, "prod", exportDir, AdminArtifactTypeThrottlingPolicy, paramsFile, false)
+	assert.NotNil(t, err)
+}
+
+func TestWriteKeyManagerWithoutSecrets(t *testing.T) {
+	exportDir, err := ioutil.TempDir("", "apictl-admin-artifacts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(exportDir)
+	kind, err := newAdminArtifactKind(AdminArtifactTypeKeyManager, "")
+	assert.Nil(t, err)
+
+	file, err := writeAdminArtifact(kind, map[string]interface{}{
+		"id":   "km-1",
+		"name": "Okta Prod",
+		"additionalProperties": map[string]interface{}{
+			"client_id":          "id",
+			"client_secret":      "okta-secret",
+			"admin_password":     "${ADMIN_PASSWORD}",
+			"introspection_path": "/introspect",
+		},
+	}, exportDir)
+	assert.Nil(t, err)
+	content, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(content), "okta-secret", "should not write the secrets")
+
+	artifact := &AdminArtifact{}
+	assert.Nil(t, yaml.Unmarshal(content, artifact))
+	assert.Nil(t, artifact.Data["id"])
+	properties := artifact.Data["additionalProperties"].(map[interface{}]interface{})
+	assert.Equal(t, "${secret:keystore:key_manager_Okta_Prod_client_secret}", properties["client_secret"])
+	assert.Equal(t, "${ADMIN_PASSWORD}", properties["admin_password"], "should keep the references")
+	assert.Equal(t, "id", properties["client_id"])
+	assert.Equal(t, "/introspect", properties["introspection_path"])
+}
+
+func TestMergeAdminArtifactData(t *testing.T) {
+	data := map[string]interface{}{
+		"name": "Okta",
+		"additionalProperties": map[string]interface{}{
+			"client_id":     "id",
+			"client_secret": "secret",
+		},
+		"availableGrantTypes": []interface{}{"password", "client_credentials"},
+	}
+	merged, err := mergeAdminArtifactData(data, map[string]interface{}{
+		"additionalProperties": map[string]interface{}{"client_secret": "new-secret"},
+		"availableGrantTypes":  []interface{}{"client_credentials"},
+		"enabled":              false,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name": "Okta",
+		"additionalProperties": map[string]interface{}{
+			"client_id":     "id",
+			"client_secret": "new-secret",
+		},
+		"availableGrantTypes": []interface{}{"client_credentials"},
+		"enabled":             false,
+	}, merged)
+}
+
+func TestResolveAdminArtifactSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-secrets")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	secretFile := filepath.Join(dir, "client_secret")
+	assert.Nil(t, ioutil.WriteFile(secretFile, []byte("okta-secret\n"), 0600))
+
+	data := map[string]interface{}{
+		"name": "Okta",
+		"additionalProperties": map[string]interface{}{
+			"client_secret": "${secret:file:" + secretFile + "}",
+		},
+		"claimMappings": []interface{}{map[string]interface{}{"remoteClaim": "${secret:file:" + secretFile + "}"}},
+	}
+	assert.Nil(t, resolveAdminArtifactSecrets(data))
+	assert.Equal(t, "okta-secret", data["additionalProperties"].(map[string]interface{})["client_secret"])
+	assert.Equal(t, "okta-secret", data["claimMappings"].([]interface{})[0].(map[string]interface{})["remoteClaim"])
+
+	data["name"] = "${secret:file:" + filepath.Join(dir, "missing") + "}"
+	assert.NotNil(t, resolveAdminArtifactSecrets(data))
+}
@@ -63,17 +63,17 @@ func GetAPIId(accessToken, environment, apiName, apiVersion, apiProvider string)
 			return apiId, err
 		}
 		if apiProvider != "" {
-			return "", errors.New("Requested API is not available in the Publisher. API: " + apiName +
+			return "", utils.NewNotFoundError("Requested API is not available in the Publisher. API: " + apiName +
 				" Version: " + apiVersion + " Provider: " + apiProvider)
 		}
-		return "", errors.New("Requested API is not available in the Publisher. API: " + apiName +
+		return "", utils.NewNotFoundError("Requested API is not available in the Publisher. API: " + apiName +
 			" Version: " + apiVersion)
 	} else {
 		utils.Logf("Error: %s\n", resp.Error())
 		utils.Logf("Body: %s\n", resp.Body())
 		if resp.StatusCode() == http.StatusUnauthorized {
 			// 401 Unauthorized
-			return "", fmt.Errorf("Authorization failed while searching API: " + apiName)
+			return "", utils.NewAuthError("Authorization failed while searching API: " + apiName)
 		}
 		return "", errors.New("Request didn't respond 200 OK for searching APIs. Status: " + resp.Status())
 	}
@@ -0,0 +1,196 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// ApplyApplicationKeys generates the keys of an Application in an environment with the given key settings. Existing
+// keys of the same type and key manager are updated instead.
+// @param out : Writer to print the applied keys to
+// @param accessToken : Access token to call the devportal REST API
+// @param environment : Environment of the Application
+// @param appName : Name of the Application. It has to be accessible to the user of the access token
+// @param keys : Settings of the keys
+// @return error
+func ApplyApplicationKeys(out io.Writer, accessToken, environment, appName string,
+	keys []params.ApplicationKeyParams) error {
+	appId, err := getDevPortalAppId(accessToken, environment, appName)
+	if err != nil {
+		return err
+	}
+	existingKeys, err := getApplicationOAuthKeys(accessToken, environment, appId)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		keyType := strings.ToUpper(key.KeyType)
+		keyManager := key.KeyManager
+		if keyManager == "" {
+			keyManager = utils.DefaultKeyManager
+		}
+		var existingKey *utils.ApplicationKey
+		for i := range existingKeys {
+			if existingKeys[i].KeyType == keyType && existingKeys[i].KeyManager == keyManager {
+				existingKey = &existingKeys[i]
+			}
+		}
+		if existingKey == nil {
+			grantTypes := key.GrantTypes
+			if len(grantTypes) == 0 {
+				grantTypes = []string{utils.DefaultKeyGrantType}
+			}
+			err = generateApplicationOAuthKeys(accessToken, environment, appId, utils.KeygenRequest{
+				KeyType:                 keyType,
+				KeyManager:              keyManager,
+				GrantTypesToBeSupported: grantTypes,
+				CallbackURL:             key.CallbackURL,
+				ValidityTime:            utils.DefaultTokenValidityPeriod,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Generated the %s keys of %s\n", keyType, keyManager)
+			continue
+		}
+		// The settings which are not given are kept as they are
+		updateRequest := utils.ApplicationKeyUpdateRequest{
+			KeyType:             keyType,
+			KeyManager:          keyManager,
+			SupportedGrantTypes: key.GrantTypes,
+			CallbackURL:         key.CallbackURL,
+		}
+		if len(updateRequest.SupportedGrantTypes) == 0 {
+			updateRequest.SupportedGrantTypes = existingKey.SupportedGrantTypes
+		}
+		if callbackURL, ok := existingKey.CallbackURL.(string); ok && updateRequest.CallbackURL == "" {
+			updateRequest.CallbackURL = callbackURL
+		}
+		err = updateApplicationOAuthKeys(accessToken, environment, appId, existingKey.KeyMappingID, updateRequest)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Updated the %s keys of %s\n", keyType, keyManager)
+	}
+	return nil
+}
+
+// getDevPortalAppId returns the ID of an Application with the exact name in the devportal
+func getDevPortalAppId(accessToken, environment, appName string) (string, error) {
+	applicationEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequestWithQueryParam("query", appName, applicationEndpoint, headers)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Body: %s\n", resp.Body())
+		return "", errors.New("Request didn't respond 200 OK for searching the application " + appName +
+			". Status: " + resp.Status())
+	}
+	appData := &utils.AppList{}
+	err = json.Unmarshal(resp.Body(), appData)
+	if err != nil {
+		return "", err
+	}
+	for _, app := range appData.List {
+		if app.Name == appName {
+			return app.ApplicationID, nil
+		}
+	}
+	return "", utils.NewNotFoundError("cannot find the application " + appName +
+		" in the devportal of the logged in user to generate its keys")
+}
+
+// getApplicationOAuthKeys returns the keys of all the key managers of an Application
+func getApplicationOAuthKeys(accessToken, environment, appId string) ([]utils.ApplicationKey, error) {
+	keysEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath) +
+		"/" + appId + "/oauth-keys"
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(keysEndpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Body: %s\n", resp.Body())
+		return nil, errors.New("Request didn't respond 200 OK for retrieving the keys of the application. " +
+			"Status: " + resp.Status())
+	}
+	keyList := &utils.AppKeyList{}
+	err = json.Unmarshal(resp.Body(), keyList)
+	return keyList.List, err
+}
+
+// generateApplicationOAuthKeys generates a new key of an Application
+func generateApplicationOAuthKeys(accessToken, environment, appId string, request utils.KeygenRequest) error {
+	generateKeysEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath) +
+		"/" + appId + "/generate-keys"
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	resp, err := utils.InvokePOSTRequest(generateKeysEndpoint, headers, string(body))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusCreated {
+		utils.Logf("Body: %s\n", resp.Body())
+		return errors.New("Request didn't respond 200 OK for generating the " + request.KeyType +
+			" keys of the application. Status: " + resp.Status())
+	}
+	return nil
+}
+
+// updateApplicationOAuthKeys updates the grant types and the callback URL of an existing key of an Application
+func updateApplicationOAuthKeys(accessToken, environment, appId, keyMappingId string,
+	request utils.ApplicationKeyUpdateRequest) error {
+	keyEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath) +
+		"/" + appId + "/oauth-keys/" + keyMappingId
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	resp, err := utils.InvokePutRequest(nil, keyEndpoint, headers, string(body))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Body: %s\n", resp.Body())
+		return errors.New("Request didn't respond 200 OK for updating the " + request.KeyType +
+			" keys of the application. Status: " + resp.Status())
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// applicationKeysStub is an in memory devportal REST API of the keys of an Application
+type applicationKeysStub struct {
+	keys     []map[string]interface{}
+	requests []string
+}
+
+func (s *applicationKeysStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests = append(s.requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/api/am/devportal/v2"))
+	w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+	switch r.Method + " " + strings.TrimPrefix(r.URL.Path, "/api/am/devportal/v2/applications") {
+	case "GET ":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": 2, "list": []map[string]interface{}{
+			{"applicationId": "app-2", "name": "PizzaAppV2"}, {"applicationId": "app-1", "name": "PizzaApp"}}})
+	case "GET /app-1/oauth-keys":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(s.keys), "list": s.keys})
+	case "POST /app-1/generate-keys", "PUT /app-1/oauth-keys/mapping-1":
+		key := make(map[string]interface{})
+		_ = json.NewDecoder(r.Body).Decode(&key)
+		s.keys = append(s.keys, key)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestApplyApplicationKeys(t *testing.T) {
+	stub := &applicationKeysStub{keys: []map[string]interface{}{
+		{"keyMappingId": "mapping-1", "keyManager": utils.DefaultKeyManager, "keyType": "PRODUCTION",
+			"supportedGrantTypes": []string{"password"}, "callbackUrl": "https://localhost/callback"},
+	}}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "apictl-application-keys")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	mainConfigFilePath := utils.MainConfigFilePath
+	defer func() { utils.MainConfigFilePath = mainConfigFilePath }()
+	utils.MainConfigFilePath = filepath.Join(dir, utils.MainConfigFileName)
+	assert.Nil(t, ioutil.WriteFile(utils.MainConfigFilePath, []byte("environments:\n  dev:\n    apim: "+server.URL+
+		"\n    token: "+server.URL+"/oauth2/token\n"), 0644))
+
+	var out bytes.Buffer
+	err = ApplyApplicationKeys(&out, "token", "dev", "PizzaApp", []params.ApplicationKeyParams{
+		{KeyType: "production", GrantTypes: []string{"client_credentials"}},
+		{KeyType: "SANDBOX", KeyManager: "Okta"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"GET /applications", "GET /applications/app-1/oauth-keys",
+		"PUT /applications/app-1/oauth-keys/mapping-1", "POST /applications/app-1/generate-keys"}, stub.requests)
+	assert.Equal(t, "Updated the PRODUCTION keys of Resident Key Manager\nGenerated the SANDBOX keys of Okta\n",
+		out.String())
+
+	// the callback URL which is not given is kept
+	updated := stub.keys[1]
+	assert.Equal(t, []interface{}{"client_credentials"}, updated["supportedGrantTypes"])
+	assert.Equal(t, "https://localhost/callback", updated["callbackUrl"])
+	// the default grant type is used for the new keys
+	generated := stub.keys[2]
+	assert.Equal(t, "Okta", generated["keyManager"])
+	assert.Equal(t, []interface{}{utils.DefaultKeyGrantType}, generated["grantTypesToBeSupported"])
+
+	err = ApplyApplicationKeys(&out, "token", "dev", "LeasingApp", []params.ApplicationKeyParams{{KeyType: "SANDBOX"}})
+	assert.NotNil(t, err, "should fail for an application which is not in the devportal")
+	assert.Equal(t, utils.ExitCodeNotFound, utils.GetExitCode(err))
+}
@@ -50,6 +50,16 @@ func ExecuteNewFileUploadRequest(uri string, params map[string]string, paramName
 	}
 	headers[utils.HeaderAccept] = "application/json"
 	headers[utils.HeaderConnection] = utils.HeaderValueKeepAlive
+
+	// the secret references of the project are resolved in memory so that the secrets are never written to the disk
+	content, err := utils.ResolveSecretReferencesInZip(path)
+	if err != nil {
+		return nil, err
+	}
+	if content != nil {
+		return utils.InvokePOSTRequestWithFileContentAndQueryParams(params, uri, headers, paramName,
+			filepath.Base(path), content)
+	}
 	return utils.InvokePOSTRequestWithFileAndQueryParams(params, uri, headers, paramName, path)
 }
 
@@ -19,7 +19,6 @@
 package impl
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -53,15 +52,15 @@ func DeleteAPI(accessToken, environment, deleteAPIName, deleteAPIVersion, delete
 		return nil, err
 	}
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
-		return nil, errors.New(strconv.Itoa(resp.StatusCode()) + ":<" + string(resp.Body()) + ">")
+		return nil, utils.NewHttpStatusError(resp)
 	}
 	return resp, nil
 }
 
 func PrintDeleteAPIResponse(resp *resty.Response, err error) {
 	if err != nil {
-		fmt.Println("Error deleting API:", err)
+		fmt.Fprintln(utils.MessageWriter(), "Error deleting API:", err)
 	} else {
-		fmt.Println("API deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+		fmt.Fprintln(utils.MessageWriter(), "API deleted successfully!. Status: "+strconv.Itoa(resp.StatusCode()))
 	}
 }
@@ -60,8 +60,8 @@ func DeleteAPIProduct(accessToken, environment, apiProductName, apiProductProvid
 
 func PrintDeleteAPIProductResponse(resp *resty.Response, err error) {
 	if err != nil {
-		fmt.Println("Error deleting API Product:", err)
+		fmt.Fprintln(utils.MessageWriter(), "Error deleting API Product:", err)
 	} else {
-		fmt.Println("API Product deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+		fmt.Fprintln(utils.MessageWriter(), "API Product deleted successfully!. Status: "+strconv.Itoa(resp.StatusCode()))
 	}
 }
@@ -60,8 +60,8 @@ func DeleteApplication(accessToken, environment, deleteAppName, deleteAppOwner s
 
 func PrintDeleteAppResponse(resp *resty.Response, err error) {
 	if err != nil {
-		fmt.Println("Error deleting Application:", err)
+		fmt.Fprintln(utils.MessageWriter(), "Error deleting Application:", err)
 	} else {
-		fmt.Println("Application deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+		fmt.Fprintln(utils.MessageWriter(), "Application deleted successfully!. Status: "+strconv.Itoa(resp.StatusCode()))
 	}
 }
@@ -0,0 +1,246 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// File names (without extension) of the project files compared by the diff
+const (
+	diffDefinitionFileAPI          = "api"
+	diffDefinitionFileAPIProduct   = "api_product"
+	diffDeploymentEnvironmentsFile = "deployment_environments"
+)
+
+// ProjectFileDiff is the difference of a single file of two projects. The field level differences are available for
+// the YAML and JSON files which exist in both projects.
+type ProjectFileDiff struct {
+	File  string            `json:"file"`
+	Type  string            `json:"type"`
+	Diffs []utils.FieldDiff `json:"diffs,omitempty"`
+}
+
+// DiffAPIProjects compares two API projects and returns the differences of api.yaml, the API definitions (swagger,
+// graphql schema, etc.), the deployment environments and the sequences. Server generated volatile fields (IDs and
+// timestamps) are ignored.
+// @param beforePath : Path to the API project to compare from
+// @param afterPath : Path to the API project to compare to
+// @return the differences of each changed file
+// @return error
+func DiffAPIProjects(beforePath, afterPath string) ([]ProjectFileDiff, error) {
+	return diffProjects(beforePath, afterPath, diffDefinitionFileAPI)
+}
+
+// DiffAPIProductProjects compares two API Product projects and returns the differences of api_product.yaml, the API
+// Product definition, the deployment environments and the sequences. Server generated volatile fields are ignored.
+// @param beforePath : Path to the API Product project to compare from
+// @param afterPath : Path to the API Product project to compare to
+// @return the differences of each changed file
+// @return error
+func DiffAPIProductProjects(beforePath, afterPath string) ([]ProjectFileDiff, error) {
+	return diffProjects(beforePath, afterPath, diffDefinitionFileAPIProduct)
+}
+
+// diffProjects compares the definition file, the deployment environments and the Definitions and the Sequences
+// directories of two projects
+func diffProjects(beforePath, afterPath, definitionFile string) ([]ProjectFileDiff, error) {
+	fileDiffs := []ProjectFileDiff{}
+	for _, file := range []string{definitionFile, diffDeploymentEnvironmentsFile} {
+		fileDiff, err := diffProjectFile(beforePath, afterPath, file)
+		if err != nil {
+			return nil, err
+		}
+		if fileDiff != nil {
+			fileDiffs = append(fileDiffs, *fileDiff)
+		}
+	}
+	for _, dir := range []string{utils.InitProjectDefinitions, utils.InitProjectSequences} {
+		dirDiffs, err := diffProjectDirectory(beforePath, afterPath, dir)
+		if err != nil {
+			return nil, err
+		}
+		fileDiffs = append(fileDiffs, dirDiffs...)
+	}
+	return fileDiffs, nil
+}
+
+// diffProjectFile compares a YAML or JSON file (given without the extension) at the root of two projects.
+// Returns nil if the file is the same in both projects.
+func diffProjectFile(beforePath, afterPath, fileNameWithoutExtension string) (*ProjectFileDiff, error) {
+	before, err := ReadProjectFileAsJSON(beforePath, fileNameWithoutExtension)
+	if err != nil {
+		return nil, err
+	}
+	after, err := ReadProjectFileAsJSON(afterPath, fileNameWithoutExtension)
+	if err != nil {
+		return nil, err
+	}
+	fileName := fileNameWithoutExtension + ".yaml"
+	if fileNameWithoutExtension == diffDeploymentEnvironmentsFile {
+		// the order of the deployments is not significant
+		sortListByJSON(before, "data")
+		sortListByJSON(after, "data")
+	}
+	switch {
+	case before == nil && after == nil:
+		return nil, nil
+	case before == nil:
+		return &ProjectFileDiff{File: fileName, Type: utils.DiffTypeAdded}, nil
+	case after == nil:
+		return &ProjectFileDiff{File: fileName, Type: utils.DiffTypeRemoved}, nil
+	}
+	diffs := utils.DiffValues(before, after)
+	if len(diffs) == 0 {
+		return nil, nil
+	}
+	return &ProjectFileDiff{File: fileName, Type: utils.DiffTypeModified, Diffs: diffs}, nil
+}
+
+// diffProjectDirectory compares all the files inside a directory of two projects. YAML and JSON files are compared
+// field by field while the other files (eg: sequences) are compared by the content.
+func diffProjectDirectory(beforePath, afterPath, dir string) ([]ProjectFileDiff, error) {
+	beforeFiles, err := listProjectFiles(filepath.Join(beforePath, dir))
+	if err != nil {
+		return nil, err
+	}
+	afterFiles, err := listProjectFiles(filepath.Join(afterPath, dir))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(beforeFiles)+len(afterFiles))
+	for file := range beforeFiles {
+		files = append(files, file)
+	}
+	for file := range afterFiles {
+		if !beforeFiles[file] {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+
+	fileDiffs := []ProjectFileDiff{}
+	for _, file := range files {
+		displayName := filepath.ToSlash(filepath.Join(dir, file))
+		if !afterFiles[file] {
+			fileDiffs = append(fileDiffs, ProjectFileDiff{File: displayName, Type: utils.DiffTypeRemoved})
+			continue
+		}
+		if !beforeFiles[file] {
+			fileDiffs = append(fileDiffs, ProjectFileDiff{File: displayName, Type: utils.DiffTypeAdded})
+			continue
+		}
+		beforeContent, err := ioutil.ReadFile(filepath.Join(beforePath, dir, file))
+		if err != nil {
+			return nil, err
+		}
+		afterContent, err := ioutil.ReadFile(filepath.Join(afterPath, dir, file))
+		if err != nil {
+			return nil, err
+		}
+		fileDiff, err := diffFileContent(displayName, beforeContent, afterContent)
+		if err != nil {
+			return nil, err
+		}
+		if fileDiff != nil {
+			fileDiffs = append(fileDiffs, *fileDiff)
+		}
+	}
+	return fileDiffs, nil
+}
+
+// diffFileContent compares the content of a file in two projects. Returns nil if the content is the same.
+func diffFileContent(fileName string, beforeContent, afterContent []byte) (*ProjectFileDiff, error) {
+	extension := strings.ToLower(filepath.Ext(fileName))
+	if extension == ".yaml" || extension == ".yml" || extension == ".json" {
+		before, err := utils.YamlToJson(beforeContent)
+		if err != nil {
+			return nil, err
+		}
+		after, err := utils.YamlToJson(afterContent)
+		if err != nil {
+			return nil, err
+		}
+		diffs, err := utils.DiffJSON(before, after)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffs) == 0 {
+			return nil, nil
+		}
+		return &ProjectFileDiff{File: fileName, Type: utils.DiffTypeModified, Diffs: diffs}, nil
+	}
+	// line endings may differ depending on where the project was created
+	normalize := func(content []byte) []byte {
+		return bytes.TrimSpace(bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n")))
+	}
+	if bytes.Equal(normalize(beforeContent), normalize(afterContent)) {
+		return nil, nil
+	}
+	return &ProjectFileDiff{File: fileName, Type: utils.DiffTypeModified}, nil
+}
+
+// listProjectFiles returns the paths of all the files inside the given directory relative to the directory.
+// Returns an empty set if the directory does not exist.
+func listProjectFiles(dir string) (map[string]bool, error) {
+	files := make(map[string]bool)
+	if !utils.IsFileExist(dir) {
+		return files, nil
+	}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[relativePath] = true
+		return nil
+	})
+	return files, err
+}
+
+// sortListByJSON sorts the list in the given field of a document by the JSON representation of the items
+func sortListByJSON(document map[string]interface{}, field string) {
+	if document == nil {
+		return
+	}
+	list, ok := document[field].([]interface{})
+	if !ok {
+		return
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		before, _ := json.Marshal(list[i])
+		after, _ := json.Marshal(list[j])
+		return string(before) < string(after)
+	})
+}
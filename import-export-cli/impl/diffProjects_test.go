@@ -0,0 +1,88 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func writeDiffProjectFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "apictl-diff")
+	assert.Nil(t, err)
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(path, []byte(content), os.ModePerm))
+	}
+	return dir
+}
+
+func TestDiffAPIProjects(t *testing.T) {
+	before := writeDiffProjectFiles(t, map[string]string{
+		"api.yaml": "type: api\ndata:\n  id: 1234\n  name: PizzaShackAPI\n  lastUpdatedTime: 1\n" +
+			"  endpointConfig:\n    production_endpoints:\n      url: https://dev.pizza\n",
+		"deployment_environments.yaml": "type: deployment_environments\ndata:\n" +
+			"  - deploymentEnvironment: Production and Sandbox\n  - deploymentEnvironment: Default\n",
+		"Definitions/swagger.yaml":                 "openapi: 3.0.1\npaths:\n  /menu: {}\n",
+		"Sequences/in-sequence/Custom/log.xml":     "<sequence name=\"log\"/>\n",
+		"Sequences/out-sequence/Custom/remove.xml": "<sequence name=\"remove\"/>",
+	})
+	defer os.RemoveAll(before)
+	after := writeDiffProjectFiles(t, map[string]string{
+		"api.yaml": "type: api\ndata:\n  id: 5678\n  name: PizzaShackAPI\n  lastUpdatedTime: 2\n" +
+			"  endpointConfig:\n    production_endpoints:\n      url: https://prod.pizza\n",
+		"deployment_environments.yaml": "type: deployment_environments\ndata:\n" +
+			"  - deploymentEnvironment: Default\n  - deploymentEnvironment: Production and Sandbox\n",
+		"Definitions/swagger.yaml":             "openapi: 3.0.1\npaths:\n  /menu: {}\n  /order: {}\n",
+		"Sequences/in-sequence/Custom/log.xml": "<sequence name=\"log\">\r\n</sequence>",
+		"Sequences/in-sequence/Custom/add.xml": "<sequence name=\"add\"/>",
+	})
+	defer os.RemoveAll(after)
+
+	fileDiffs, err := DiffAPIProjects(before, after)
+	assert.Nil(t, err)
+	assert.Equal(t, []ProjectFileDiff{
+		{File: "api.yaml", Type: utils.DiffTypeModified, Diffs: []utils.FieldDiff{{
+			Path: "data.endpointConfig.production_endpoints.url", Type: utils.DiffTypeModified,
+			Before: "https://dev.pizza", After: "https://prod.pizza"}}},
+		{File: "Definitions/swagger.yaml", Type: utils.DiffTypeModified, Diffs: []utils.FieldDiff{{
+			Path: "paths./order", Type: utils.DiffTypeAdded, After: map[string]interface{}{}}}},
+		{File: "Sequences/in-sequence/Custom/add.xml", Type: utils.DiffTypeAdded},
+		{File: "Sequences/in-sequence/Custom/log.xml", Type: utils.DiffTypeModified},
+		{File: "Sequences/out-sequence/Custom/remove.xml", Type: utils.DiffTypeRemoved},
+	}, fileDiffs)
+}
+
+func TestDiffAPIProjectsWithoutDifferences(t *testing.T) {
+	project := writeDiffProjectFiles(t, map[string]string{
+		"api.yaml":                 "type: api\ndata:\n  name: PizzaShackAPI\n",
+		"Definitions/swagger.yaml": "openapi: 3.0.1\n",
+	})
+	defer os.RemoveAll(project)
+
+	fileDiffs, err := DiffAPIProjects(project, project)
+	assert.Nil(t, err)
+	assert.Empty(t, fileDiffs)
+}
@@ -0,0 +1,234 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+)
+
+// Types of the endpoints in the endpoint configuration of an API
+const (
+	endpointTypeHTTP        = "http"
+	endpointTypeLoadBalance = "load_balance"
+	endpointTypeFailover    = "failover"
+)
+
+// algorithm of the load balanced endpoints set by the server
+const loadBalanceAlgorithmRoundRobin = "org.apache.synapse.endpoints.algorithms.RoundRobin"
+
+// Files of a project (without the extension, relative to the project) changed by the params of an environment, in
+// addition to the definition and deployment_environments
+const (
+	ProjectFileEndpointCertificates = "Endpoint-certificates/endpoint_certificates"
+	ProjectFileClientCertificates   = "Client-certificates/client_certificates"
+)
+
+// ApplyEnvParamsToProjectFiles applies the params of an environment to the files of an API or an API Product project
+// in the same way the server applies the intermediate params (see handleEnvParams) while importing the project. It
+// gives the state of the project after importing it, without importing it. The passwords and the client secrets of the
+// endpoints are not applied since the server does not export them, and the certificates are applied without their
+// content.
+// @param files : Files of the project as JSON documents by the name without the extension (eg: api,
+// deployment_environments). The files changed by the params are added if they do not exist
+// @param definitionFile : Name of the definition file (api or api_product)
+// @param envParams : Params of the environment
+// @return error if the params cannot be applied
+func ApplyEnvParamsToProjectFiles(files map[string]map[string]interface{}, definitionFile string,
+	envParams *params.Environment) error {
+	configs := envParams.Config
+	if configs == nil {
+		return nil
+	}
+	definitionData := projectFileData(files, definitionFile, definitionFile)
+
+	endpointConfig, _ := definitionData["endpointConfig"].(map[string]interface{})
+	switch configs.EndpointRoutingPolicy {
+	case params.EndpointRoutingPolicyLoadBalanced:
+		if lb := configs.LoadBalanceEndpoints; lb != nil {
+			endpointConfig = withEndpointSecurityOf(endpointConfig, map[string]interface{}{
+				"endpoint_type":     endpointTypeLoadBalance,
+				"algoCombo":         loadBalanceAlgorithmRoundRobin,
+				"algoClassName":     loadBalanceAlgorithmRoundRobin,
+				"sessionManagement": stringOrEmpty(lb.SessionManagement),
+			})
+			if lb.SessionTimeOut != nil {
+				endpointConfig["sessionTimeOut"] = toJSONDocument(*lb.SessionTimeOut)
+			}
+			setEndpointList(endpointConfig, "production_endpoints", lb.Production)
+			setEndpointList(endpointConfig, "sandbox_endpoints", lb.Sandbox)
+		}
+	case params.EndpointRoutingPolicyFailover:
+		if failover := configs.FailoverEndpoints; failover != nil {
+			endpointConfig = withEndpointSecurityOf(endpointConfig,
+				map[string]interface{}{"endpoint_type": endpointTypeFailover})
+			setEndpoint(endpointConfig, "production_endpoints", failover.Production)
+			setEndpoint(endpointConfig, "sandbox_endpoints", failover.Sandbox)
+			setEndpointList(endpointConfig, "production_failovers", failover.ProductionFailovers)
+			setEndpointList(endpointConfig, "sandbox_failovers", failover.SandboxFailovers)
+		}
+	default:
+		if endpoints := configs.Endpoints; endpoints != nil {
+			endpointConfig = withEndpointSecurityOf(endpointConfig,
+				map[string]interface{}{"endpoint_type": endpointTypeHTTP})
+			setEndpoint(endpointConfig, "production_endpoints", endpoints.Production)
+			setEndpoint(endpointConfig, "sandbox_endpoints", endpoints.Sandbox)
+		}
+	}
+	if security := configs.Security; security != nil {
+		if endpointConfig == nil {
+			endpointConfig = make(map[string]interface{})
+		}
+		endpointSecurity, _ := endpointConfig["endpoint_security"].(map[string]interface{})
+		if endpointSecurity == nil {
+			endpointSecurity = make(map[string]interface{})
+		}
+		if security.Production != nil {
+			endpointSecurity["production"] = endpointSecurityToJSON(security.Production)
+		}
+		if security.Sandbox != nil {
+			endpointSecurity["sandbox"] = endpointSecurityToJSON(security.Sandbox)
+		}
+		endpointConfig["endpoint_security"] = endpointSecurity
+	}
+	if endpointConfig != nil {
+		definitionData["endpointConfig"] = endpointConfig
+	}
+
+	if configs.Policies != nil {
+		policies := make([]interface{}, 0, len(configs.Policies))
+		for _, policy := range configs.Policies {
+			policies = append(policies, policy)
+		}
+		definitionData["policies"] = policies
+	}
+
+	if configs.DeploymentEnvironments != nil {
+		deploymentEnvironments := make([]interface{}, 0, len(configs.DeploymentEnvironments))
+		for _, environment := range configs.DeploymentEnvironments {
+			deploymentEnvironment := map[string]interface{}{"deploymentEnvironment": environment.DeploymentEnvironment}
+			if environment.DeploymentVhost != "" {
+				deploymentEnvironment["deploymentVhost"] = environment.DeploymentVhost
+			}
+			if environment.DisplayOnDevportal != nil {
+				deploymentEnvironment["displayOnDevportal"] = toJSONDocument(environment.DisplayOnDevportal)
+			}
+			deploymentEnvironments = append(deploymentEnvironments, deploymentEnvironment)
+		}
+		setProjectFileData(files, "deployment_environments", deploymentEnvironments)
+	}
+
+	if configs.Certs != nil {
+		certs := make([]interface{}, 0, len(configs.Certs))
+		for _, cert := range configs.Certs {
+			certs = append(certs, map[string]interface{}{"alias": cert.Alias, "endpoint": cert.HostName})
+		}
+		setProjectFileData(files, ProjectFileEndpointCertificates, certs)
+	}
+	if configs.MutualSslCerts != nil {
+		certs := make([]interface{}, 0, len(configs.MutualSslCerts))
+		for _, cert := range configs.MutualSslCerts {
+			clientCert := map[string]interface{}{"alias": cert.Alias, "tierName": cert.TierName}
+			if cert.KeyType != "" {
+				clientCert["keyType"] = cert.KeyType
+			}
+			certs = append(certs, clientCert)
+		}
+		setProjectFileData(files, ProjectFileClientCertificates, certs)
+	}
+	return nil
+}
+
+// projectFileData returns the data of a project file, adding the file if it does not exist
+func projectFileData(files map[string]map[string]interface{}, fileName, fileType string) map[string]interface{} {
+	if files[fileName] == nil {
+		files[fileName] = map[string]interface{}{"type": fileType}
+	}
+	data, ok := files[fileName]["data"].(map[string]interface{})
+	if !ok {
+		data = make(map[string]interface{})
+		files[fileName]["data"] = data
+	}
+	return data
+}
+
+// setProjectFileData replaces the data of a project file, adding the file if it does not exist
+func setProjectFileData(files map[string]map[string]interface{}, fileName string, data interface{}) {
+	if files[fileName] == nil {
+		files[fileName] = map[string]interface{}{"type": fileName[strings.LastIndex(fileName, "/")+1:]}
+	}
+	files[fileName]["data"] = data
+}
+
+// withEndpointSecurityOf returns a new endpoint configuration with the endpoint security of the current configuration,
+// since the routing policy replaces the endpoints but not their security
+func withEndpointSecurityOf(current, endpointConfig map[string]interface{}) map[string]interface{} {
+	if security, ok := current["endpoint_security"]; ok {
+		endpointConfig["endpoint_security"] = security
+	}
+	return endpointConfig
+}
+
+// setEndpoint sets an endpoint of the endpoint configuration if it is given in the params
+func setEndpoint(endpointConfig map[string]interface{}, key string, endpoint *params.EndpointParams) {
+	if endpoint != nil {
+		endpointConfig[key] = toJSONDocument(endpoint)
+	}
+}
+
+// setEndpointList sets a list of endpoints of the endpoint configuration if it is given in the params
+func setEndpointList(endpointConfig map[string]interface{}, key string, endpoints []params.EndpointParams) {
+	if endpoints != nil {
+		endpointConfig[key] = toJSONDocument(endpoints)
+	}
+}
+
+// endpointSecurityToJSON returns the security of an endpoint as it is exported by the server. The type is upper case
+// and the password and the client secret are left out as the server does not export them
+func endpointSecurityToJSON(security *params.SecurityParams) map[string]interface{} {
+	document, _ := toJSONDocument(security).(map[string]interface{})
+	delete(document, "password")
+	delete(document, "clientSecret")
+	if security.Enabled == nil {
+		document["enabled"] = true
+	}
+	document["type"] = strings.ToUpper(security.Type)
+	return document
+}
+
+// toJSONDocument converts a params value to a generic JSON document
+func toJSONDocument(value interface{}) interface{} {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var document interface{}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil
+	}
+	return document
+}
+
+func stringOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -20,6 +20,7 @@ package impl
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strconv"
 
@@ -82,6 +83,24 @@ func exportAPI(name, version, revisionNum, provider, format, publisherEndpoint,
 // Exported API will be written to a zip file
 func WriteToZip(exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLocationPath string,
 	runningExportApiCommand bool, resp *resty.Response) {
+	exportedFinalZip, err := writeAPIToZip(exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLocationPath,
+		resp)
+	if err != nil {
+		utils.HandleErrorAndExit("Error writing the exported API", err)
+	}
+
+	// Output the final zip file location.
+	if runningExportApiCommand {
+		fmt.Fprintln(utils.MessageWriter(), "Successfully exported API!")
+		fmt.Fprintln(utils.MessageWriter(), "Find the exported API at "+exportedFinalZip)
+	}
+}
+
+// writeAPIToZip writes the exported API in the response to a zip file with the api_meta.yaml file
+// @return path to the zip file
+// @return error
+func writeAPIToZip(exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLocationPath string,
+	resp *resty.Response) (string, error) {
 	zipFilename := exportAPIName + "_" + exportAPIVersion
 	if exportAPIRevisionNumber != "" {
 		zipFilename += "_" + utils.GetRevisionNamFromRevisionNum(exportAPIRevisionNumber)
@@ -90,12 +109,13 @@ func WriteToZip(exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLoc
 	// Writes the REST API response to a temporary zip file
 	tempZipFile, err := utils.WriteResponseToTempZip(zipFilename, resp)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating the temporary zip file to store the exported API", err)
+		return "", fmt.Errorf("error creating the temporary zip file to store the exported API: %v", err)
 	}
+	defer os.RemoveAll(filepath.Dir(tempZipFile))
 
 	err = utils.CreateDirIfNotExist(zipLocationPath)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating dir to store zip archive: "+zipLocationPath, err)
+		return "", fmt.Errorf("error creating dir to store zip archive %s: %v", zipLocationPath, err)
 	}
 	exportedFinalZip := filepath.Join(zipLocationPath, zipFilename)
 
@@ -106,12 +126,7 @@ func WriteToZip(exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLoc
 	}
 	err = IncludeMetaFileToZip(tempZipFile, exportedFinalZip, utils.MetaFileAPI, metaData)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating the final zip archive with api_meta.yaml file", err)
-	}
-
-	// Output the final zip file location.
-	if runningExportApiCommand {
-		fmt.Println("Successfully exported API!")
-		fmt.Println("Find the exported API at " + exportedFinalZip)
+		return "", fmt.Errorf("error creating the final zip archive with api_meta.yaml file: %v", err)
 	}
+	return exportedFinalZip, nil
 }
@@ -99,7 +99,7 @@ func WriteAPIProductToZip(exportAPIProductName, exportAPIProductVersion, zipLoca
 	}
 
 	if runningExportAPIProductCommand {
-		fmt.Println("Successfully exported API Product!")
-		fmt.Println("Find the exported API Product at " + exportedFinalZip)
+		fmt.Fprintln(utils.MessageWriter(), "Successfully exported API Product!")
+		fmt.Fprintln(utils.MessageWriter(), "Find the exported API Product at "+exportedFinalZip)
 	}
 }
@@ -20,209 +20,372 @@ package impl
 
 import (
 	"fmt"
-	"github.com/spf13/cast"
-	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
-	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 	"net/http"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
-var apiExportDir string
-var apiListOffset int //from which index of API, the APIs will be fetched from APIM server
-var count int32       // size of API list to be exported or number of  APIs left to be exported from last iteration
-var apis []utils.API
-var exportRelatedFilesPath string
-var exportAPIsFormat string
+// ExportAPIsSummary is the summary report of the export apis command
+type ExportAPIsSummary struct {
+	Total             int                 `json:"total"`
+	Succeeded         int                 `json:"succeeded"`
+	Failed            int                 `json:"failed"`
+	Pending           int                 `json:"pending"`
+	ExportedInThisRun int                 `json:"exportedInThisRun"`
+	Files             int                 `json:"files"`
+	FailedAPIs        []ExportAPIsFailure `json:"failedApis,omitempty"`
+	ExportDirectory   string              `json:"exportDirectory"`
+	ManifestFile      string              `json:"manifestFile"`
+}
+
+// ExportAPIsFailure is an API which could not be exported
+type ExportAPIsFailure struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Provider string `json:"provider"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error"`
+}
 
-var startingApiIndexFromList int
-var mainConfigFilePath string
+// apisExporter exports the APIs of a tenant and records the progress in the manifest
+type apisExporter struct {
+	credential     credentials.Credential
+	environment    string
+	format         string
+	preserveStatus bool
+	allRevisions   bool
+	apiExportDir   string
+	manifestPath   string
+	manifest       *utils.MigrationApisExportManifest
+	// guards the manifest and the console output while the APIs are exported in parallel
+	lock sync.Mutex
+	// the credential store is not safe for concurrent use
+	tokenLock sync.Mutex
+}
 
-//  Prepare resumption of previous-halted export-apis operation
-func PrepareResumption(credential credentials.Credential, exportRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
-	var lastSuceededAPI utils.API
-	lastSuceededAPI = utils.ReadLastSucceededAPIFileData(exportRelatedFilesPath)
-	var migrationApisExportMetadata utils.MigrationApisExportMetadata
-	err := migrationApisExportMetadata.ReadMigrationApisExportMetadataFile(filepath.Join(exportRelatedFilesPath,
-		utils.MigrationAPIsExportMetadataFileName))
+// PrepareStartFromBeginning deletes the previously exported APIs and the manifest, so that the APIs are exported from
+// the beginning
+// @param exportRelatedFilesPath : Path to the directory of the exported APIs of the tenant
+// @return error
+func PrepareStartFromBeginning(exportRelatedFilesPath string) error {
+	fmt.Fprintln(utils.MessageWriter(), "Cleaning all the previously exported APIs of the given target tenant, in the given environment if "+
+		"any, and prepare to export APIs from beginning")
+	if err := utils.RemoveDirectoryIfExists(filepath.Join(exportRelatedFilesPath, utils.ExportedApisDirName)); err != nil {
+		return err
+	}
+	return utils.RemoveFileIfExists(filepath.Join(exportRelatedFilesPath, utils.MigrationAPIsExportManifestFileName))
+}
+
+// ExportAPIs exports all the APIs of a tenant. The status, the checksum of the archives and the error of each API is
+// recorded in the manifest (migration-apis-export-manifest.yaml) after each API is exported. If the manifest of a
+// previous export exists, only the APIs which are not yet exported are exported. The failed APIs are exported again
+// only if retryFailed is set.
+// @param credential : Credential of the environment
+// @param exportRelatedFilesPath : Path to the directory of the exported APIs of the tenant where the manifest is kept
+// @param apiExportDir : Path to the directory to write the exported APIs
+// @param cmdExportEnvironment : Environment to export the APIs from
+// @param cmdResourceTenantDomain : Tenant of the APIs
+// @param cmdUsername : User who exports the APIs
+// @param exportAPIsFormat : File format of the exported APIs (json or yaml)
+// @param exportAPIPreserveStatus : Whether the status of the APIs should be preserved
+// @param exportAllRevisions : Whether the working copy and all the revisions should be exported instead of only the
+// deployed revisions
+// @param retryFailed : Whether only the APIs failed in the previous exports should be exported
+// @param concurrency : Maximum number of APIs to export at the same time
+// @return summary of the export
+// @return error if the APIs cannot be listed or the manifest cannot be read or written
+func ExportAPIs(credential credentials.Credential, exportRelatedFilesPath, apiExportDir, cmdExportEnvironment,
+	cmdResourceTenantDomain, cmdUsername, exportAPIsFormat string, exportAPIPreserveStatus, exportAllRevisions,
+	retryFailed bool, concurrency int) (*ExportAPIsSummary, error) {
+	exporter := &apisExporter{
+		credential:     credential,
+		environment:    cmdExportEnvironment,
+		format:         exportAPIsFormat,
+		preserveStatus: exportAPIPreserveStatus,
+		allRevisions:   exportAllRevisions,
+		apiExportDir:   apiExportDir,
+		manifestPath:   filepath.Join(exportRelatedFilesPath, utils.MigrationAPIsExportManifestFileName),
+	}
+	err := exporter.loadManifest(cmdResourceTenantDomain, cmdUsername)
 	if err != nil {
-		utils.HandleErrorAndExit("Error loading metadata for resume from"+filepath.Join(exportRelatedFilesPath,
-			utils.MigrationAPIsExportMetadataFileName), err)
+		return nil, err
+	}
+	if retryFailed && !utils.IsFileExist(exporter.manifestPath) {
+		return nil, utils.NewValidationError("there is no previous export to retry in " + exportRelatedFilesPath)
 	}
-	apis = migrationApisExportMetadata.ApiListToExport
-	apiListOffset = migrationApisExportMetadata.ApiListOffset
-	startingApiIndexFromList = getLastSuceededApiIndex(lastSuceededAPI) + 1
 
-	//find count of APIs left to be exported
-	count = int32(len(apis) - startingApiIndexFromList)
+	if !exporter.manifest.ListingCompleted {
+		if err := exporter.listAPIs(cmdResourceTenantDomain); err != nil {
+			return nil, err
+		}
+	}
+	exporter.verifyExportedAPIs()
+
+	statusToExport := utils.MigrationStatusPending
+	if retryFailed {
+		statusToExport = utils.MigrationStatusFailed
+	}
+	var entries []*utils.MigrationApiExportEntry
+	for _, entry := range exporter.manifest.APIs {
+		if entry.Status == statusToExport {
+			entries = append(entries, entry)
+		}
+	}
 
-	if count == 0 {
-		//last iteration had been completed successfully but operation had halted at that point.
-		//So get the next set of APIs for next iteration
-		apiListOffset += utils.MaxAPIsToExportOnce
-		startingApiIndexFromList = 0
-		count, apis = getAPIList(credential, cmdExportEnvironment, cmdResourceTenantDomain)
-		if len(apis) > 0 {
-			utils.WriteMigrationApisExportMetadataFile(apis, cmdResourceTenantDomain, cmdUsername,
-				exportRelatedFilesPath, apiListOffset)
+	if len(entries) == 0 {
+		if retryFailed {
+			fmt.Fprintln(utils.MessageWriter(), "No failed APIs to retry..!")
 		} else {
-			fmt.Println("Command: export apis execution completed !")
+			fmt.Fprintln(utils.MessageWriter(), "No APIs available to be exported..!")
 		}
+	} else {
+		fmt.Fprintln(utils.MessageWriter(), "Exporting "+strconv.Itoa(len(entries))+" of "+strconv.Itoa(len(exporter.manifest.APIs))+
+			" APIs...")
+	}
+	exported, err := exporter.exportAPIs(entries, concurrency)
+	if err != nil {
+		return nil, err
 	}
+	return exporter.summary(exported), nil
 }
 
-// Delete directories where the APIs are exported, reset the indexes, get first API list and write the
-// migration-apis-export-metadata.yaml file
-func PrepareStartFromBeginning(credential credentials.Credential, exportRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
-	fmt.Println("Cleaning all the previously exported APIs of the given target tenant, in the given environment if " +
-		"any, and prepare to export APIs from beginning")
-	//cleaning existing old files (if exists) related to exportation
-	if err := utils.RemoveDirectoryIfExists(filepath.Join(exportRelatedFilesPath, utils.ExportedApisDirName)); err != nil {
-		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
-			"exportation", err)
+// loadManifest reads the manifest of the previous export if it exists. Otherwise a new manifest is created.
+func (e *apisExporter) loadManifest(tenantDomain, username string) error {
+	now := time.Now().Format(time.RFC3339)
+	if !utils.IsFileExist(e.manifestPath) {
+		e.manifest = &utils.MigrationApisExportManifest{
+			Environment:    e.environment,
+			User:           username,
+			OnTenant:       tenantDomain,
+			Format:         e.format,
+			PreserveStatus: e.preserveStatus,
+			AllRevisions:   e.allRevisions,
+			StartedTime:    now,
+		}
+		return nil
 	}
-	if err := utils.RemoveFileIfExists(filepath.Join(exportRelatedFilesPath, utils.MigrationAPIsExportMetadataFileName)); err != nil {
-		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
-			"exportation", err)
+
+	manifest, err := utils.ReadMigrationApisExportManifest(e.manifestPath)
+	if err != nil {
+		return fmt.Errorf("error reading the export manifest %s: %v", e.manifestPath, err)
 	}
-	if err := utils.RemoveFileIfExists(filepath.Join(exportRelatedFilesPath, utils.LastSucceededApiFileName)); err != nil {
-		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
-			"exportation", err)
+	if manifest.Environment != e.environment || manifest.OnTenant != tenantDomain ||
+		!strings.EqualFold(manifest.Format, e.format) || manifest.PreserveStatus != e.preserveStatus ||
+		manifest.AllRevisions != e.allRevisions {
+		return utils.NewValidationError("the APIs were previously exported with different options (see " +
+			e.manifestPath + "). Use --force to export the APIs from the beginning")
 	}
-
-	apiListOffset = 0
-	startingApiIndexFromList = 0
-	count, apis = getAPIList(credential, cmdExportEnvironment, cmdResourceTenantDomain)
-	//write  migration-apis-export-metadata.yaml file
-	utils.WriteMigrationApisExportMetadataFile(apis, cmdResourceTenantDomain, cmdUsername, exportRelatedFilesPath,
-		apiListOffset)
+	fmt.Fprintln(utils.MessageWriter(), "Resuming the previous export recorded in "+e.manifestPath)
+	e.manifest = manifest
+	return nil
 }
 
-// get the index of the finally (successfully) exported API from the list of APIs listed in migration-apis-export-metadata.yaml
-func getLastSuceededApiIndex(lastSuceededApi utils.API) int {
-	for i := 0; i < len(apis); i++ {
-		if (apis[i].Name == lastSuceededApi.Name) &&
-			(apis[i].Provider == lastSuceededApi.Provider) &&
-			(apis[i].Version == lastSuceededApi.Version) {
-			return i
-		}
+// saveManifest writes the manifest. The lock should be held while the APIs are exported in parallel.
+func (e *apisExporter) saveManifest() error {
+	e.manifest.UpdatedTime = time.Now().Format(time.RFC3339)
+	if err := utils.WriteMigrationApisExportManifest(e.manifest, e.manifestPath); err != nil {
+		return fmt.Errorf("error writing the export manifest %s: %v", e.manifestPath, err)
 	}
-	return -1
+	return nil
+}
+
+// getAccessToken returns an access token of the environment
+func (e *apisExporter) getAccessToken() (string, error) {
+	e.tokenLock.Lock()
+	defer e.tokenLock.Unlock()
+	return credentials.GetOAuthAccessToken(e.credential, e.environment)
 }
 
-// Get the list of APIs from the defined offset index, upto the limit of constant value utils.MaxAPIsToExportOnce
-func getAPIList(credential credentials.Credential, cmdExportEnvironment, cmdResourceTenantDomain string) (count int32, apis []utils.API) {
-	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, cmdExportEnvironment)
-	if preCommandErr == nil {
-		apiListEndpoint := utils.GetApiListEndpointOfEnv(cmdExportEnvironment, utils.MainConfigFilePath)
-		apiListEndpoint += "?limit=" + strconv.Itoa(utils.MaxAPIsToExportOnce) + "&offset=" + strconv.Itoa(apiListOffset)
-		if cmdResourceTenantDomain != "" {
-			apiListEndpoint += "&tenantDomain=" + cmdResourceTenantDomain
+// listAPIs adds the APIs of the tenant which are not yet in the manifest as pending APIs. The APIs are listed page by
+// page and the manifest is saved after each page.
+func (e *apisExporter) listAPIs(tenantDomain string) error {
+	listedAPIs := make(map[string]bool)
+	for _, entry := range e.manifest.APIs {
+		listedAPIs[entry.ID] = true
+	}
+	for offset := 0; ; offset += utils.MaxAPIsToExportOnce {
+		accessToken, err := e.getAccessToken()
+		if err != nil {
+			return err
 		}
-		count, apis, err := GetAPIList(accessToken, apiListEndpoint, "", "")
-		if err == nil {
-			return count, apis
-		} else {
-			utils.HandleErrorAndExit(utils.LogPrefixError+"Getting List of APIs.", utils.GetHttpErrorResponse(err))
+		apiListEndpoint := utils.GetApiListEndpointOfEnv(e.environment, utils.MainConfigFilePath)
+		apiListEndpoint += "?limit=" + strconv.Itoa(utils.MaxAPIsToExportOnce) + "&offset=" + strconv.Itoa(offset)
+		if tenantDomain != "" {
+			apiListEndpoint += "&tenantDomain=" + tenantDomain
+		}
+		_, apis, err := GetAPIList(accessToken, apiListEndpoint, "", "")
+		if err != nil {
+			return fmt.Errorf("error getting the list of APIs: %v", err)
+		}
+		utils.Logln(utils.LogPrefixInfo+"Found", len(apis), "APIs beginning with the offset #"+strconv.Itoa(offset))
+		for _, api := range apis {
+			if listedAPIs[api.ID] {
+				continue
+			}
+			listedAPIs[api.ID] = true
+			e.manifest.APIs = append(e.manifest.APIs, &utils.MigrationApiExportEntry{
+				ID:       api.ID,
+				Name:     api.Name,
+				Version:  api.Version,
+				Provider: api.Provider,
+				Status:   utils.MigrationStatusPending,
+			})
+		}
+		if len(apis) < utils.MaxAPIsToExportOnce {
+			break
+		}
+		if err := e.saveManifest(); err != nil {
+			return err
 		}
-	} else {
-		utils.HandleErrorAndExit(utils.LogPrefixError+"Error in getting access token for user while getting "+
-			"the list of APIs: ", preCommandErr)
 	}
-	return 0, nil
+	e.manifest.ListingCompleted = true
+	return e.saveManifest()
 }
 
-// Get the revisions associated with the api
-func getRevisionsListForAPI(accessToken, cmdExportEnvironment string, api utils.API,
-	exportAllRevisions bool) (count int32, revisions []utils.Revisions, err error) {
-	var query string
-	if !exportAllRevisions {
-		query = "deployed:true"
+// verifyExportedAPIs marks the exported APIs of which the archives are missing or modified after the export as
+// pending, so that they are exported again
+func (e *apisExporter) verifyExportedAPIs() {
+	for _, entry := range e.manifest.APIs {
+		if entry.Status != utils.MigrationStatusSucceeded {
+			continue
+		}
+		for _, file := range entry.Files {
+			checksum, err := utils.GetFileChecksum(filepath.Join(e.apiExportDir, file.File))
+			if err != nil || checksum != file.Checksum {
+				utils.Logln(utils.LogPrefixWarning + file.File + " is missing or modified, exporting " + entry.Name +
+					" " + entry.Version + " again")
+				entry.Status = utils.MigrationStatusPending
+				entry.Files = nil
+				break
+			}
+		}
 	}
-	return GetRevisionListFromEnv(accessToken, cmdExportEnvironment, api.Name, api.Version, api.Provider, query)
 }
 
-// Do the API exportation
-func ExportAPIs(credential credentials.Credential, exportRelatedFilesPath, cmdExportEnvironment, cmdResourceTenantDomain,
-	exportAPIsFormat, cmdUsername, apiExportDir string, exportAPIPreserveStatus, runningExportApiCommand, exportAllRevisions bool) {
-	if count == 0 {
-		fmt.Println("No APIs available to be exported..!")
-	} else {
-		var counterSuceededAPIs = 0
-		for count > 0 {
-			utils.Logln(utils.LogPrefixInfo+"Found ", count, "of APIs to be exported in the iteration beginning with the offset #"+
-				strconv.Itoa(apiListOffset)+". Maximum limit of APIs exported in single iteration is "+
-				strconv.Itoa(utils.MaxAPIsToExportOnce))
-			accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, cmdExportEnvironment)
-			if preCommandErr == nil {
-				for i := startingApiIndexFromList; i < len(apis); i++ {
-					if exportAllRevisions {
-						//Export the working copy of the api
-						exportAPIandWriteToZip(apis[i], "", accessToken, cmdExportEnvironment, apiExportDir,
-							exportRelatedFilesPath, exportAPIsFormat, exportAPIPreserveStatus, runningExportApiCommand)
-						counterSuceededAPIs++
-					}
-					revisionCount, revisions, err := getRevisionsListForAPI(accessToken, cmdExportEnvironment, apis[i],
-						exportAllRevisions)
-					if err != nil {
-						fmt.Println("An error occurred while getting the revisions list for API " + apis[i].Version +
-							"_" + apis[i].Version, err)
-					} else if revisionCount > 0 {
-						for j := 0; j < len(revisions); j++ {
-							exportApiRevision := utils.GetRevisionNumFromRevisionName(revisions[j].RevisionNumber)
-							exportAPIandWriteToZip(apis[i], exportApiRevision, accessToken, cmdExportEnvironment,
-								apiExportDir, exportRelatedFilesPath, exportAPIsFormat, exportAPIPreserveStatus,
-								runningExportApiCommand)
-							counterSuceededAPIs++
-						}
-					}
-				}
-			} else {
-				// error getting OAuth tokens
-				fmt.Println("Error getting OAuth Tokens : " + preCommandErr.Error())
-			}
-			fmt.Println("Batch of " + cast.ToString(count) + " APIs exported successfully..!")
+// exportAPIs exports the given APIs using a pool of at most concurrency workers. The manifest is saved after each
+// API is completed. Returns the number of APIs exported successfully.
+func (e *apisExporter) exportAPIs(entries []*utils.MigrationApiExportEntry, concurrency int) (int, error) {
+	if concurrency < 1 {
+		concurrency = utils.DefaultExportAPIsConcurrency
+	}
+	completed, exported := 0, 0
+	var saveErr error
+	RunInParallel(len(entries), concurrency, func(i int) {
+		entry := entries[i]
+		files, err := e.exportAPI(entry)
 
-			apiListOffset += utils.MaxAPIsToExportOnce
-			count, apis = getAPIList(credential, cmdExportEnvironment, cmdResourceTenantDomain)
-			startingApiIndexFromList = 0
-			if len(apis) > 0 {
-				utils.WriteMigrationApisExportMetadataFile(apis, cmdResourceTenantDomain, cmdUsername,
-					exportRelatedFilesPath, apiListOffset)
-			}
+		e.lock.Lock()
+		defer e.lock.Unlock()
+		completed++
+		progress := "[" + strconv.Itoa(completed) + "/" + strconv.Itoa(len(entries)) + "] "
+		entry.Attempts++
+		if err != nil {
+			entry.Status = utils.MigrationStatusFailed
+			entry.Error = err.Error()
+			entry.Files = nil
+			fmt.Fprintln(utils.MessageWriter(), progress+"Failed to export "+entry.Name+" "+entry.Version+" of provider "+
+				entry.Provider+": "+err.Error())
+		} else {
+			entry.Status = utils.MigrationStatusSucceeded
+			entry.Error = ""
+			entry.Files = files
+			entry.ExportedTime = time.Now().Format(time.RFC3339)
+			exported++
+			fmt.Fprintln(utils.MessageWriter(), progress+"Exported "+entry.Name+" "+entry.Version+" of provider "+
+				entry.Provider+" ("+strconv.Itoa(len(files))+" archive(s))")
 		}
-		fmt.Println("\nTotal number of APIs exported: " + cast.ToString(counterSuceededAPIs))
-		fmt.Println("API export path: " + apiExportDir)
-		fmt.Println("\nCommand: export-apis execution completed !")
-	}
+		if err := e.saveManifest(); err != nil && saveErr == nil {
+			saveErr = err
+		}
+	})
+	return exported, saveErr
 }
 
-//Export the API and archive to zip format
-func exportAPIandWriteToZip(api utils.API, revisionNumber, accessToken, cmdExportEnvironment, apiExportDir,
-	exportRelatedFilesPath, exportAPIsFormat string, exportAPIPreserveStatus, runningExportApiCommand bool) {
+// exportAPI exports the working copy (if all the revisions are exported) and the revisions of an API
+func (e *apisExporter) exportAPI(entry *utils.MigrationApiExportEntry) ([]utils.MigrationApiExportedFile, error) {
+	accessToken, err := e.getAccessToken()
+	if err != nil {
+		return nil, err
+	}
 
-	exportAPIName := api.Name
-	exportAPIVersion := api.Version
-	exportApiProvider := api.Provider
-	var exportApiRevision string
-	if revisionNumber != "" {
-		exportApiRevision = utils.GetRevisionNumFromRevisionName(revisionNumber)
+	var revisionNumbers []string
+	if e.allRevisions {
+		// the working copy
+		revisionNumbers = append(revisionNumbers, "")
 	}
-	resp, err := ExportAPIFromEnv(accessToken, exportAPIName, exportAPIVersion, exportApiRevision,
-		exportApiProvider, exportAPIsFormat, cmdExportEnvironment, exportAPIPreserveStatus, false)
+	revisionListEndpoint := utils.AppendSlashToString(utils.GetApiListEndpointOfEnv(e.environment,
+		utils.MainConfigFilePath)) + entry.ID + "/revisions"
+	if !e.allRevisions {
+		revisionListEndpoint += "?query=deployed:true"
+	}
+	_, revisions, err := GetRevisionsList(accessToken, revisionListEndpoint)
 	if err != nil {
-		utils.HandleErrorAndExit("Error exporting", err)
+		return nil, fmt.Errorf("error getting the revisions: %v", err)
+	}
+	for _, revision := range revisions {
+		revisionNumbers = append(revisionNumbers, utils.GetRevisionNumFromRevisionName(revision.RevisionNumber))
 	}
 
-	if resp.StatusCode() == http.StatusOK {
-		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
-		WriteToZip(exportAPIName, exportAPIVersion, exportApiRevision, apiExportDir, runningExportApiCommand, resp)
-		//write on last-succeeded-api.log
-		utils.WriteLastSuceededAPIFileData(exportRelatedFilesPath, api)
-	} else {
-		fmt.Println("Error exporting API:", exportAPIName, "-", exportAPIVersion, " of Provider:", exportApiProvider)
-		utils.PrintErrorResponseAndExit(resp)
+	files := []utils.MigrationApiExportedFile{}
+	for _, revisionNumber := range revisionNumbers {
+		resp, err := ExportAPIFromEnv(accessToken, entry.Name, entry.Version, revisionNumber, entry.Provider, e.format,
+			e.environment, e.preserveStatus, false)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return nil, utils.NewHttpStatusError(resp)
+		}
+		zipFile, err := writeAPIToZip(entry.Name, entry.Version, revisionNumber, e.apiExportDir, resp)
+		if err != nil {
+			return nil, err
+		}
+		checksum, err := utils.GetFileChecksum(zipFile)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, utils.MigrationApiExportedFile{
+			Revision: revisionNumber,
+			File:     filepath.Base(zipFile),
+			Checksum: checksum,
+		})
+	}
+	return files, nil
+}
+
+// summary returns the summary of the APIs in the manifest
+func (e *apisExporter) summary(exportedInThisRun int) *ExportAPIsSummary {
+	summary := &ExportAPIsSummary{
+		Total:             len(e.manifest.APIs),
+		ExportedInThisRun: exportedInThisRun,
+		ExportDirectory:   e.apiExportDir,
+		ManifestFile:      e.manifestPath,
+	}
+	for _, entry := range e.manifest.APIs {
+		switch entry.Status {
+		case utils.MigrationStatusSucceeded:
+			summary.Succeeded++
+			summary.Files += len(entry.Files)
+		case utils.MigrationStatusFailed:
+			summary.Failed++
+			summary.FailedAPIs = append(summary.FailedAPIs, ExportAPIsFailure{
+				Name:     entry.Name,
+				Version:  entry.Version,
+				Provider: entry.Provider,
+				Attempts: entry.Attempts,
+				Error:    entry.Error,
+			})
+		default:
+			summary.Pending++
+		}
 	}
+	return summary
 }
 
 // Create the required directory structure to save the exported APIs
@@ -0,0 +1,110 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func newTestAPIsExporter(t *testing.T) (*apisExporter, string) {
+	dir, err := ioutil.TempDir("", "apictl-export-apis")
+	assert.Nil(t, err)
+	exporter := &apisExporter{
+		environment:  "production",
+		format:       "YAML",
+		allRevisions: true,
+		apiExportDir: dir,
+		manifestPath: filepath.Join(dir, utils.MigrationAPIsExportManifestFileName),
+	}
+	return exporter, dir
+}
+
+func TestExportAPIsManifestResume(t *testing.T) {
+	exporter, dir := newTestAPIsExporter(t)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, exporter.loadManifest("wso2.com", "admin"))
+	exporter.manifest.ListingCompleted = true
+	exporter.manifest.APIs = []*utils.MigrationApiExportEntry{
+		{ID: "1", Name: "Pizza Shack API", Version: "1.0.0", Provider: "admin",
+			Status: utils.MigrationStatusPending},
+		{ID: "2", Name: "PhoneVerification", Version: "2.0.0", Provider: "admin",
+			Status: utils.MigrationStatusFailed, Attempts: 1, Error: "500 Internal Server Error"},
+	}
+	assert.Nil(t, exporter.saveManifest())
+
+	resumed := &apisExporter{environment: "production", format: "yaml", allRevisions: true,
+		manifestPath: exporter.manifestPath}
+	assert.Nil(t, resumed.loadManifest("wso2.com", "admin"))
+	assert.True(t, resumed.manifest.ListingCompleted)
+	assert.Equal(t, 2, len(resumed.manifest.APIs))
+	// names with spaces are preserved
+	assert.Equal(t, "Pizza Shack API", resumed.manifest.APIs[0].Name)
+	assert.Equal(t, "500 Internal Server Error", resumed.manifest.APIs[1].Error)
+
+	// resuming with different options is not allowed
+	different := &apisExporter{environment: "production", format: "JSON", allRevisions: true,
+		manifestPath: exporter.manifestPath}
+	err := different.loadManifest("wso2.com", "admin")
+	assert.NotNil(t, err)
+	assert.Equal(t, utils.ExitCodeValidationError, utils.GetExitCode(err))
+}
+
+func TestExportAPIsVerifyExportedAPIs(t *testing.T) {
+	exporter, dir := newTestAPIsExporter(t)
+	defer os.RemoveAll(dir)
+
+	archive := filepath.Join(dir, "PizzaShackAPI_1.0.0.zip")
+	assert.Nil(t, ioutil.WriteFile(archive, []byte("exported"), 0644))
+	checksum, err := utils.GetFileChecksum(archive)
+	assert.Nil(t, err)
+
+	assert.Nil(t, exporter.loadManifest("", "admin"))
+	exporter.manifest.APIs = []*utils.MigrationApiExportEntry{
+		{ID: "1", Name: "PizzaShackAPI", Version: "1.0.0", Status: utils.MigrationStatusSucceeded,
+			Files: []utils.MigrationApiExportedFile{{File: "PizzaShackAPI_1.0.0.zip", Checksum: checksum}}},
+		{ID: "2", Name: "Modified", Version: "1.0.0", Status: utils.MigrationStatusSucceeded,
+			Files: []utils.MigrationApiExportedFile{{File: "PizzaShackAPI_1.0.0.zip", Checksum: "invalid"}}},
+		{ID: "3", Name: "Missing", Version: "1.0.0", Status: utils.MigrationStatusSucceeded,
+			Files: []utils.MigrationApiExportedFile{{File: "Missing_1.0.0.zip", Checksum: checksum}}},
+		{ID: "4", Name: "Failed", Version: "1.0.0", Provider: "admin", Status: utils.MigrationStatusFailed,
+			Attempts: 2, Error: "404 Not Found"},
+	}
+	exporter.verifyExportedAPIs()
+	assert.Equal(t, utils.MigrationStatusSucceeded, exporter.manifest.APIs[0].Status)
+	assert.Equal(t, utils.MigrationStatusPending, exporter.manifest.APIs[1].Status)
+	assert.Equal(t, utils.MigrationStatusPending, exporter.manifest.APIs[2].Status)
+	assert.Nil(t, exporter.manifest.APIs[2].Files)
+
+	summary := exporter.summary(1)
+	assert.Equal(t, 4, summary.Total)
+	assert.Equal(t, 1, summary.Succeeded)
+	assert.Equal(t, 1, summary.Files)
+	assert.Equal(t, 2, summary.Pending)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, 1, summary.ExportedInThisRun)
+	assert.Equal(t, []ExportAPIsFailure{{Name: "Failed", Version: "1.0.0", Provider: "admin", Attempts: 2,
+		Error: "404 Not Found"}}, summary.FailedAPIs)
+}
@@ -21,7 +21,6 @@ package impl
 import (
 	"fmt"
 	"io"
-	"os"
 	"text/template"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
@@ -107,7 +106,7 @@ func PrintRevisions(revisions []utils.Revisions, format string) {
 		format = defaultRevisionTableFormat
 	}
 	// create revision Context with standard output
-	revisionContext := formatter.NewContext(os.Stdout, format)
+	revisionContext := formatter.NewContext(utils.MessageWriter(), format)
 
 	// create a new renderer function which iterate collection
 	renderer := func(w io.Writer, t *template.Template) error {
@@ -135,6 +134,6 @@ func PrintRevisions(revisions []utils.Revisions, format string) {
 
 	// execute context
 	if err := revisionContext.Write(renderer, revisionTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.MessageWriter(), "Error executing template:", err.Error())
 	}
 }
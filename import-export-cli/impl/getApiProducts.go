@@ -21,7 +21,6 @@ package impl
 import (
 	"fmt"
 	"io"
-	"os"
 	"text/template"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
@@ -96,7 +95,7 @@ func PrintAPIProducts(apiProducts []utils.APIProduct, format string) {
 		format = defaultApiProductTableFormat
 	}
 	// create API Product context with standard output
-	apiProductContext := formatter.NewContext(os.Stdout, format)
+	apiProductContext := formatter.NewContext(utils.MessageWriter(), format)
 
 	// create a new renderer function which iterate collection
 	renderer := func(w io.Writer, t *template.Template) error {
@@ -120,7 +119,7 @@ func PrintAPIProducts(apiProducts []utils.APIProduct, format string) {
 
 	// execute context
 	if err := apiProductContext.Write(renderer, apiProductTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.MessageWriter(), "Error executing template:", err.Error())
 	}
 }
 
@@ -21,7 +21,6 @@ package impl
 import (
 	"fmt"
 	"io"
-	"os"
 	"text/template"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
@@ -111,7 +110,7 @@ func PrintAPIs(apis []utils.API, format string) {
 		format = defaultApiTableFormat
 	}
 	// create api context with standard output
-	apiContext := formatter.NewContext(os.Stdout, format)
+	apiContext := formatter.NewContext(utils.MessageWriter(), format)
 
 	// create a new renderer function which iterate collection
 	renderer := func(w io.Writer, t *template.Template) error {
@@ -136,6 +135,6 @@ func PrintAPIs(apis []utils.API, format string) {
 
 	// execute context
 	if err := apiContext.Write(renderer, apiTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.MessageWriter(), "Error executing template:", err.Error())
 	}
 }
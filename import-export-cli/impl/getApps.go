@@ -22,7 +22,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 	"text/template"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
@@ -114,7 +113,7 @@ func PrintApps(apps []utils.Application, format string) {
 		format = defaultAppTableFormat
 	}
 	// create new app context with standard output
-	appContext := formatter.NewContext(os.Stdout, format)
+	appContext := formatter.NewContext(utils.MessageWriter(), format)
 
 	// create a new renderer function which iterate collection of apps
 	renderer := func(w io.Writer, t *template.Template) error {
@@ -139,6 +138,6 @@ func PrintApps(apps []utils.Application, format string) {
 
 	// execute context
 	if err := appContext.Write(renderer, appTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.MessageWriter(), "Error executing template:", err.Error())
 	}
 }
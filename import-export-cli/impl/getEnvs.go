@@ -21,7 +21,7 @@ package impl
 import (
 	"fmt"
 	"io"
-	"os"
+	"strings"
 	"text/template"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
@@ -60,10 +60,18 @@ func newEndpointFromEnvEndpoints(name string, e utils.EnvEndpoints) *endpoints {
 		publisherEndpoint:    e.PublisherEndpoint,
 		registrationEndpoint: e.RegistrationEndpoint,
 		tokenEndpoint:        e.TokenEndpoint,
-		miManagementEndpoint: e.MiManagementEndpoint,
+		miManagementEndpoint: getMiManagementEndpoints(e),
 	}
 }
 
+// getMiManagementEndpoints returns the endpoints of all the nodes when the environment has a Micro Integrator cluster
+func getMiManagementEndpoints(e utils.EnvEndpoints) string {
+	if len(e.MiManagementNodes) > 0 {
+		return strings.Join(e.MiManagementNodes, ",")
+	}
+	return e.MiManagementEndpoint
+}
+
 // Name of endpoint
 func (e endpoints) Name() string {
 	return e.name
@@ -116,7 +124,7 @@ func PrintEnvs(envData map[string]utils.EnvEndpoints, format, defaulEnvsTableFor
 	}
 
 	// create api context with standard output
-	envsContext := formatter.NewContext(os.Stdout, format)
+	envsContext := formatter.NewContext(utils.MessageWriter(), format)
 
 	// create a new renderer function which iterate collection
 	renderer := func(w io.Writer, t *template.Template) error {
@@ -143,6 +151,6 @@ func PrintEnvs(envData map[string]utils.EnvEndpoints, format, defaulEnvsTableFor
 
 	// execute context
 	if err := envsContext.Write(renderer, envsTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.MessageWriter(), "Error executing template:", err.Error())
 	}
 }
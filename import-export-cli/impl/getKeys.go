@@ -111,7 +111,7 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 
 				if accessToken != "" {
 					// Access Token generated successfully.
-					fmt.Println(token)
+					fmt.Fprintln(utils.MessageWriter(), token)
 				} else {
 					utils.HandleErrorAndExit("Error while generating token: ", err)
 				}
@@ -122,7 +122,7 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 					utils.HandleErrorAndExit("Error occurred while generating CLI application keys.", err)
 				}
 				// Access Token generated successfully.
-				fmt.Println(keygenResponse.Token.AccessToken)
+				fmt.Fprintln(utils.MessageWriter(), keygenResponse.Token.AccessToken)
 			}
 		} else {
 			utils.HandleErrorAndExit("Error while retrieving the CLI application:", err)
@@ -160,7 +160,7 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 		token, err := getNewToken(appKey, scopes)
 		if token != "" {
 			// Access Token generated successfully.
-			fmt.Println(token)
+			fmt.Fprintln(utils.MessageWriter(), token)
 		} else {
 			utils.HandleErrorAndExit("Error while generating token: ", err)
 		}
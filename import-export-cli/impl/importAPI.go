@@ -30,8 +30,6 @@ import (
 	"strconv"
 	"strings"
 
-	jsoniter "github.com/json-iterator/go"
-
 	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
 
 	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
@@ -114,30 +112,23 @@ func resolveYamlOrJSON(filename string) (string, []byte, error) {
 	return "", nil, fmt.Errorf("%s was not found as a YAML or JSON", filename)
 }
 
-// Substitutes environment variables in the project files.
+// Substitutes environment variables in the project files. Only the files given in the envSubstitution section of
+// the meta file of the project (or utils.EnvReplaceFilePaths if not given) are substituted.
 func replaceEnvVariables(apiFilePath string) error {
-	for _, replacePath := range utils.EnvReplaceFilePaths {
-		absFile := filepath.Join(apiFilePath, replacePath)
-		// check if the path exists. If exists, proceed with processing. Otherwise, continue with the next items
-		if fi, err := os.Stat(absFile); err != nil {
-			if !os.IsNotExist(err) {
-				return err
-			}
-		} else {
-			switch mode := fi.Mode(); {
-			case mode.IsDir():
-				utils.Logln(utils.LogPrefixInfo+"Substituting env variables of files in folder path: ", absFile)
-				err = utils.EnvSubstituteInFolder(absFile)
-			case mode.IsRegular():
-				utils.Logln(utils.LogPrefixInfo+"Substituting env of file: ", absFile)
-				err = utils.EnvSubstituteInFile(absFile)
-			}
-			if err != nil {
-				return err
-			}
+	var config *utils.EnvSubstitutionConfig
+	for _, metaFile := range []string{utils.MetaFileAPI, utils.MetaFileAPIProduct} {
+		metaFilePath := filepath.Join(apiFilePath, metaFile)
+		if _, err := os.Stat(metaFilePath); err != nil {
+			continue
+		}
+		metaData, err := LoadMetaInfoFromFile(metaFilePath)
+		if err != nil {
+			return errors.New("Error reading " + metaFile + ": " + err.Error())
 		}
+		config = metaData.EnvSubstitution
+		break
 	}
-	return nil
+	return utils.EnvSubstituteInProject(apiFilePath, config)
 }
 
 // importAPI imports an API to the API manager
@@ -151,28 +142,27 @@ func importAPI(endpoint, filePath, accessToken string, extraParams map[string]st
 	}
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully imported API.")
 		return nil
 	} else {
 		// We have an HTTP error
-		fmt.Println("Error importing API.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp)
-		return errors.New(resp.Status())
+		return errors.New(resp.Status() + ":<" + string(resp.Body()) + ">")
 	}
 }
 
 // ImportAPIToEnv function is used with import-api command
 func ImportAPIToEnv(accessOAuthToken, importEnvironment, importPath, apiParamsPath string, importAPIUpdate,
-	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool) error {
+	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments,
+	importAPISkipValidation bool) error {
 	publisherEndpoint := utils.GetPublisherEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
 	return ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath, importAPIUpdate,
-		preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments)
+		preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments,
+		importAPISkipValidation)
 }
 
 // ImportAPI function is used with import-api command
 func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath string, importAPIUpdate,
-	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool) error {
+	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments,
+	importAPISkipValidation bool) error {
 	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedApisDirName)
 	resolvedAPIFilePath, err := resolveImportFilePath(importPath, exportDirectory)
 	if err != nil {
@@ -204,6 +194,15 @@ func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPat
 		return err
 	}
 
+	if !importAPISkipValidation {
+		// validate the project before the params move it into the source archive to catch the errors before
+		// uploading it
+		err := validateProjectBeforeImport(apiFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
 	if apiParamsPath != "" {
 		//Reading params file of the API and add configurations into temp artifact
 		err := handleCustomizedParameters(apiFilePath, apiParamsPath, importEnvironment)
@@ -356,7 +355,7 @@ func handleCustomizedParameters(importPath, paramsPath, importEnvironment string
 // Process env params and create the intermediate_params.yaml file to pass to the server
 func handleEnvParams(tempDirectory string, destDirectory string, environmentParams *params.Environment) error {
 	// read api params from external parameters file
-	envParamsJson, err := jsoniter.Marshal(environmentParams.Config)
+	envParamsJson, err := json.Marshal(environmentParams.Config)
 	if err != nil {
 		return err
 	}
@@ -21,7 +21,6 @@ package impl
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -80,31 +79,27 @@ func importAPIProduct(endpoint, filePath, accessToken string, extraParams map[st
 
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully imported API Product.")
 		return nil
 	} else {
 		// We have an HTTP error
-		fmt.Println("Error importing API Product.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp)
-		return errors.New(resp.Status())
+		return errors.New(resp.Status() + ":<" + string(resp.Body()) + ">")
 	}
 }
 
 // ImportAPIProductToEnv function is used with import-api-product command
 func ImportAPIProductToEnv(accessOAuthToken, importEnvironment, importPath, apiProductParamsPath string, importAPIs, importAPIsUpdate,
 	importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup, rotateRevision,
-	skipDeployments bool) error {
+	skipDeployments, skipValidation bool) error {
 	publisherEndpoint := utils.GetPublisherEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
 	return ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiProductParamsPath, importAPIs,
 		importAPIsUpdate, importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup, rotateRevision,
-		skipDeployments)
+		skipDeployments, skipValidation)
 }
 
 // ImportAPIProduct function is used with import-api-product command
 func ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiProductParamsPath string, importAPIs, importAPIsUpdate,
 	importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup,
-	rotateRevision, skipDeployments bool) error {
+	rotateRevision, skipDeployments, skipValidation bool) error {
 	var exportDirectory = filepath.Join(utils.ExportDirectory, utils.ExportedApiProductsDirName)
 
 	resolvedAPIProductFilePath, err := resolveImportAPIProductFilePath(importPath, exportDirectory)
@@ -143,6 +138,15 @@ func ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, im
 		return err
 	}
 
+	if !skipValidation {
+		// validate the project before the params move it into the source archive to catch the errors before
+		// uploading it
+		err := validateProjectBeforeImport(apiProductFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
 	if apiProductParamsPath != "" {
 		// Reading params file of the API Product and add configurations into temp artifact
 		err := handleCustomizedParameters(apiProductFilePath, apiProductParamsPath, importEnvironment)
@@ -0,0 +1,373 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// matches the archive of a revision of an exported API (eg: PizzaShackAPI_1.0.0_Revision-2.zip)
+var revisionArchiveRegex = regexp.MustCompile(`^(.+)_Revision-(\d+)\.zip$`)
+
+// ImportAPIsSummary is the summary report of the import apis command
+type ImportAPIsSummary struct {
+	Total             int                 `json:"total"`
+	Succeeded         int                 `json:"succeeded"`
+	Failed            int                 `json:"failed"`
+	Pending           int                 `json:"pending"`
+	ImportedInThisRun int                 `json:"importedInThisRun"`
+	FailedArchives    []ImportAPIsFailure `json:"failedArchives,omitempty"`
+	ManifestFile      string              `json:"manifestFile"`
+}
+
+// ImportAPIsFailure is an archive which could not be imported
+type ImportAPIsFailure struct {
+	File     string `json:"file"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error"`
+}
+
+// ImportAPIsOptions are the options used to import each API. They have the same meaning as the flags of import api.
+type ImportAPIsOptions struct {
+	ParamsPath       string
+	Update           bool
+	PreserveProvider bool
+	RotateRevision   bool
+	SkipDeployments  bool
+	SkipValidation   bool
+}
+
+// apisImporter imports the exported APIs of a tenant and records the progress in the manifest
+type apisImporter struct {
+	credential   credentials.Credential
+	environment  string
+	apisDir      string
+	options      ImportAPIsOptions
+	manifestPath string
+	manifest     *utils.MigrationApisImportManifest
+	// guards the manifest and the console output while the APIs are imported in parallel
+	lock sync.Mutex
+	// the credential store is not safe for concurrent use
+	tokenLock sync.Mutex
+}
+
+// GetImportAPIsManifestPath returns the path to the manifest of importing the APIs in the given directory to the
+// given environment
+func GetImportAPIsManifestPath(sourceDir, importEnvironment string) string {
+	return filepath.Join(sourceDir, utils.MigrationAPIsImportManifestFilePrefix+importEnvironment+".yaml")
+}
+
+// ImportAPIs imports all the API archives exported by export apis. The archives of the same API (the working copy and
+// the revisions) are imported one after the other in the order of the revisions while the different APIs are imported
+// in parallel. The archives imported after the first archive of an API always update the API. The status, the
+// checksum and the error of each archive is recorded in the manifest after each archive is imported. If the manifest
+// of a previous import exists, only the archives which are not yet imported (or which are changed after importing)
+// are imported. The failed archives are imported again only if retryFailed is set.
+// @param credential : Credential of the environment
+// @param importEnvironment : Environment to import the APIs to
+// @param sourceDir : Directory of the exported APIs of a tenant (or the apis directory inside it)
+// @param options : Options used to import each API
+// @param retryFailed : Whether only the archives failed in the previous imports should be imported
+// @param concurrency : Maximum number of APIs to import at the same time
+// @return summary of the import
+// @return error if the archives cannot be read or the manifest cannot be read or written
+func ImportAPIs(credential credentials.Credential, importEnvironment, sourceDir string, options ImportAPIsOptions,
+	retryFailed bool, concurrency int) (*ImportAPIsSummary, error) {
+	apisDir := sourceDir
+	if utils.IsFileExist(filepath.Join(sourceDir, utils.ExportedApisDirName)) {
+		apisDir = filepath.Join(sourceDir, utils.ExportedApisDirName)
+	}
+	importer := &apisImporter{
+		credential:   credential,
+		environment:  importEnvironment,
+		apisDir:      apisDir,
+		options:      options,
+		manifestPath: GetImportAPIsManifestPath(sourceDir, importEnvironment),
+	}
+	if retryFailed && !utils.IsFileExist(importer.manifestPath) {
+		return nil, utils.NewValidationError("there is no previous import to " + importEnvironment + " to retry in " +
+			sourceDir)
+	}
+	if err := importer.loadManifest(); err != nil {
+		return nil, err
+	}
+	if err := importer.syncArchives(); err != nil {
+		return nil, err
+	}
+	if err := importer.saveManifest(); err != nil {
+		return nil, err
+	}
+
+	statusToImport := utils.MigrationStatusPending
+	if retryFailed {
+		statusToImport = utils.MigrationStatusFailed
+	}
+	groups := importer.groupArchivesByAPI(statusToImport)
+	archiveCount := 0
+	for _, group := range groups {
+		archiveCount += len(group.entries)
+	}
+
+	if archiveCount == 0 {
+		if retryFailed {
+			fmt.Fprintln(utils.MessageWriter(), "No failed APIs to retry..!")
+		} else {
+			fmt.Fprintln(utils.MessageWriter(), "No APIs available to be imported..!")
+		}
+	} else {
+		fmt.Fprintln(utils.MessageWriter(), "Importing "+strconv.Itoa(archiveCount)+" of "+
+			strconv.Itoa(len(importer.manifest.Archives))+" API archives...")
+	}
+	if concurrency < 1 {
+		concurrency = utils.DefaultImportAPIsConcurrency
+	}
+	imported, err := importer.importAPIs(groups, archiveCount, concurrency)
+	if err != nil {
+		return nil, err
+	}
+	return importer.summary(imported), nil
+}
+
+// loadManifest reads the manifest of the previous import if it exists. Otherwise a new manifest is created.
+func (i *apisImporter) loadManifest() error {
+	if !utils.IsFileExist(i.manifestPath) {
+		i.manifest = &utils.MigrationApisImportManifest{
+			Environment:     i.environment,
+			SourceDirectory: i.apisDir,
+			StartedTime:     time.Now().Format(time.RFC3339),
+		}
+		return nil
+	}
+	manifest, err := utils.ReadMigrationApisImportManifest(i.manifestPath)
+	if err != nil {
+		return fmt.Errorf("error reading the import manifest %s: %v", i.manifestPath, err)
+	}
+	fmt.Fprintln(utils.MessageWriter(), "Resuming the previous import recorded in "+i.manifestPath)
+	i.manifest = manifest
+	return nil
+}
+
+// saveManifest writes the manifest. The lock should be held while the APIs are imported in parallel.
+func (i *apisImporter) saveManifest() error {
+	i.manifest.UpdatedTime = time.Now().Format(time.RFC3339)
+	if err := utils.WriteMigrationApisImportManifest(i.manifest, i.manifestPath); err != nil {
+		return fmt.Errorf("error writing the import manifest %s: %v", i.manifestPath, err)
+	}
+	return nil
+}
+
+// getAccessToken returns an access token of the environment
+func (i *apisImporter) getAccessToken() (string, error) {
+	i.tokenLock.Lock()
+	defer i.tokenLock.Unlock()
+	return credentials.GetOAuthAccessToken(i.credential, i.environment)
+}
+
+// syncArchives updates the manifest with the archives in the directory. New archives and the archives changed after
+// they were imported are added as pending and the archives which no longer exist are removed.
+func (i *apisImporter) syncArchives() error {
+	files, err := ioutil.ReadDir(i.apisDir)
+	if err != nil {
+		return err
+	}
+	entries := make(map[string]*utils.MigrationApiImportEntry)
+	for _, entry := range i.manifest.Archives {
+		entries[entry.File] = entry
+	}
+
+	var archives []*utils.MigrationApiImportEntry
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".zip") {
+			continue
+		}
+		checksum, err := utils.GetFileChecksum(filepath.Join(i.apisDir, file.Name()))
+		if err != nil {
+			return err
+		}
+		entry, ok := entries[file.Name()]
+		if !ok {
+			entry = &utils.MigrationApiImportEntry{File: file.Name(), Status: utils.MigrationStatusPending}
+		} else if entry.Checksum != checksum && entry.Status == utils.MigrationStatusSucceeded {
+			utils.Logln(utils.LogPrefixInfo + file.Name() + " is changed after it was imported, importing it again")
+			entry.Status = utils.MigrationStatusPending
+		}
+		entry.Checksum = checksum
+		archives = append(archives, entry)
+	}
+	if len(archives) == 0 {
+		return errors.New("no API archives found in " + i.apisDir)
+	}
+	sortImportArchives(archives)
+	i.manifest.Archives = archives
+	return nil
+}
+
+// apiArchiveGroup is the archives of a single API
+type apiArchiveGroup struct {
+	// whether an archive of the API is already imported, so that the API exists in the environment
+	imported bool
+	entries  []*utils.MigrationApiImportEntry
+}
+
+// groupArchivesByAPI returns the archives with the given status grouped by the API in the order they should be
+// imported
+func (i *apisImporter) groupArchivesByAPI(status string) []*apiArchiveGroup {
+	var groups []*apiArchiveGroup
+	groupOfAPI := make(map[string]*apiArchiveGroup)
+	for _, entry := range i.manifest.Archives {
+		apiName, _ := parseImportArchiveName(entry.File)
+		group, ok := groupOfAPI[apiName]
+		if !ok {
+			group = &apiArchiveGroup{}
+			groupOfAPI[apiName] = group
+			groups = append(groups, group)
+		}
+		if entry.Status == utils.MigrationStatusSucceeded {
+			group.imported = true
+		} else if entry.Status == status {
+			group.entries = append(group.entries, entry)
+		}
+	}
+
+	var groupsToImport []*apiArchiveGroup
+	for _, group := range groups {
+		if len(group.entries) > 0 {
+			groupsToImport = append(groupsToImport, group)
+		}
+	}
+	return groupsToImport
+}
+
+// importAPIs imports the groups of archives using a pool of at most concurrency workers. The manifest is saved after
+// each archive is imported. Returns the number of archives imported successfully.
+func (i *apisImporter) importAPIs(groups []*apiArchiveGroup, archiveCount, concurrency int) (int, error) {
+	completed, imported := 0, 0
+	var saveErr error
+	RunInParallel(len(groups), concurrency, func(g int) {
+		group := groups[g]
+		var failedArchive string
+		for _, entry := range group.entries {
+			var err error
+			if failedArchive != "" {
+				// the revisions should be imported in order
+				err = errors.New("not imported as " + failedArchive + " failed to import")
+			} else {
+				err = i.importAPI(entry, group.imported)
+			}
+
+			i.lock.Lock()
+			completed++
+			progress := "[" + strconv.Itoa(completed) + "/" + strconv.Itoa(archiveCount) + "] "
+			if err != nil {
+				if failedArchive == "" {
+					entry.Attempts++
+					failedArchive = entry.File
+				}
+				entry.Status = utils.MigrationStatusFailed
+				entry.Error = err.Error()
+				fmt.Fprintln(utils.MessageWriter(), progress+"Failed to import "+entry.File+": "+err.Error())
+			} else {
+				entry.Attempts++
+				entry.Status = utils.MigrationStatusSucceeded
+				entry.Error = ""
+				entry.ImportedTime = time.Now().Format(time.RFC3339)
+				group.imported = true
+				imported++
+				fmt.Fprintln(utils.MessageWriter(), progress+"Imported "+entry.File)
+			}
+			if err := i.saveManifest(); err != nil && saveErr == nil {
+				saveErr = err
+			}
+			i.lock.Unlock()
+		}
+	})
+	return imported, saveErr
+}
+
+// importAPI imports a single archive. An API which is already imported is always updated.
+func (i *apisImporter) importAPI(entry *utils.MigrationApiImportEntry, apiImported bool) error {
+	accessToken, err := i.getAccessToken()
+	if err != nil {
+		return err
+	}
+	publisherEndpoint := utils.GetPublisherEndpointOfEnv(i.environment, utils.MainConfigFilePath)
+	return ImportAPI(accessToken, publisherEndpoint, i.environment, filepath.Join(i.apisDir, entry.File),
+		i.options.ParamsPath, i.options.Update || apiImported, i.options.PreserveProvider, false,
+		i.options.RotateRevision, i.options.SkipDeployments, i.options.SkipValidation)
+}
+
+// summary returns the summary of the archives in the manifest
+func (i *apisImporter) summary(importedInThisRun int) *ImportAPIsSummary {
+	summary := &ImportAPIsSummary{
+		Total:             len(i.manifest.Archives),
+		ImportedInThisRun: importedInThisRun,
+		ManifestFile:      i.manifestPath,
+	}
+	for _, entry := range i.manifest.Archives {
+		switch entry.Status {
+		case utils.MigrationStatusSucceeded:
+			summary.Succeeded++
+		case utils.MigrationStatusFailed:
+			summary.Failed++
+			summary.FailedArchives = append(summary.FailedArchives, ImportAPIsFailure{
+				File:     entry.File,
+				Attempts: entry.Attempts,
+				Error:    entry.Error,
+			})
+		default:
+			summary.Pending++
+		}
+	}
+	return summary
+}
+
+// parseImportArchiveName returns the name of the API (<name>_<version>) and the revision number of an archive
+// exported by export apis. The revision number of the working copy is 0.
+func parseImportArchiveName(fileName string) (string, int) {
+	if match := revisionArchiveRegex.FindStringSubmatch(fileName); match != nil {
+		revision, _ := strconv.Atoi(match[2])
+		return match[1], revision
+	}
+	return strings.TrimSuffix(fileName, ".zip"), 0
+}
+
+// sortImportArchives sorts the archives by the API and then by the revision, so that the working copy of an API is
+// imported first followed by the revisions in order
+func sortImportArchives(archives []*utils.MigrationApiImportEntry) {
+	sort.SliceStable(archives, func(a, b int) bool {
+		apiA, revisionA := parseImportArchiveName(archives[a].File)
+		apiB, revisionB := parseImportArchiveName(archives[b].File)
+		if apiA != apiB {
+			return apiA < apiB
+		}
+		return revisionA < revisionB
+	})
+}
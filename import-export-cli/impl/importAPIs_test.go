@@ -0,0 +1,94 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestParseImportArchiveName(t *testing.T) {
+	api, revision := parseImportArchiveName("Pizza Shack API_1.0.0_Revision-12.zip")
+	assert.Equal(t, "Pizza Shack API_1.0.0", api)
+	assert.Equal(t, 12, revision)
+
+	api, revision = parseImportArchiveName("PizzaShackAPI_1.0.0.zip")
+	assert.Equal(t, "PizzaShackAPI_1.0.0", api)
+	assert.Equal(t, 0, revision)
+}
+
+func TestImportAPIsSyncAndGroupArchives(t *testing.T) {
+	sourceDir, err := ioutil.TempDir("", "apictl-import-apis")
+	assert.Nil(t, err)
+	defer os.RemoveAll(sourceDir)
+	apisDir := filepath.Join(sourceDir, utils.ExportedApisDirName)
+	assert.Nil(t, os.MkdirAll(apisDir, os.ModePerm))
+	for _, file := range []string{"PizzaShackAPI_1.0.0_Revision-10.zip", "PizzaShackAPI_1.0.0_Revision-2.zip",
+		"PizzaShackAPI_1.0.0.zip", "Phone_2.0.0_Revision-1.zip", "Leasing_1.0.0_Revision-1.zip", "notes.txt"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(apisDir, file), []byte(file), 0644))
+	}
+	importer := &apisImporter{environment: "dev", apisDir: apisDir,
+		manifestPath: GetImportAPIsManifestPath(sourceDir, "dev")}
+	assert.Nil(t, importer.loadManifest())
+	assert.Nil(t, importer.syncArchives())
+
+	var files []string
+	for _, entry := range importer.manifest.Archives {
+		files = append(files, entry.File)
+	}
+	assert.Equal(t, []string{"Leasing_1.0.0_Revision-1.zip", "Phone_2.0.0_Revision-1.zip", "PizzaShackAPI_1.0.0.zip",
+		"PizzaShackAPI_1.0.0_Revision-2.zip", "PizzaShackAPI_1.0.0_Revision-10.zip"}, files)
+
+	// the working copy of PizzaShackAPI and Leasing were imported in a previous run, Phone failed
+	importer.manifest.Archives[0].Status = utils.MigrationStatusSucceeded
+	importer.manifest.Archives[1].Status = utils.MigrationStatusFailed
+	importer.manifest.Archives[2].Status = utils.MigrationStatusSucceeded
+	assert.Nil(t, importer.saveManifest())
+
+	// a modified archive is imported again on resume
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(apisDir, "Leasing_1.0.0_Revision-1.zip"), []byte("changed"), 0644))
+	resumed := &apisImporter{environment: "dev", apisDir: apisDir, manifestPath: importer.manifestPath}
+	assert.Nil(t, resumed.loadManifest())
+	assert.Nil(t, resumed.syncArchives())
+	assert.Equal(t, utils.MigrationStatusPending, resumed.manifest.Archives[0].Status)
+
+	groups := resumed.groupArchivesByAPI(utils.MigrationStatusPending)
+	assert.Equal(t, 2, len(groups))
+	assert.False(t, groups[0].imported)
+	assert.Equal(t, "Leasing_1.0.0_Revision-1.zip", groups[0].entries[0].File)
+	// the revisions of PizzaShackAPI update the API imported in the previous run
+	assert.True(t, groups[1].imported)
+	assert.Equal(t, 2, len(groups[1].entries))
+	assert.Equal(t, "PizzaShackAPI_1.0.0_Revision-2.zip", groups[1].entries[0].File)
+
+	failedGroups := resumed.groupArchivesByAPI(utils.MigrationStatusFailed)
+	assert.Equal(t, 1, len(failedGroups))
+	assert.Equal(t, "Phone_2.0.0_Revision-1.zip", failedGroups[0].entries[0].File)
+
+	summary := resumed.summary(0)
+	assert.Equal(t, 5, summary.Total)
+	assert.Equal(t, 1, summary.Succeeded)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, 3, summary.Pending)
+}
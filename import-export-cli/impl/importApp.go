@@ -21,8 +21,7 @@ package impl
 import (
 	"bytes"
 	"errors"
-	"fmt"
-	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"os"
@@ -44,11 +43,12 @@ import (
 // @param skipSubscriptions: Skip importing subscriptions
 // @param skipKeys: skip importing keys of application
 // @param skipCleanup: skip cleaning up temporary files created during the operation
+// @param skipValidation: skip validating the application project before importing it
 func ImportApplicationToEnv(accessToken, environment, filename, appOwner string, updateApplication, preserveOwner,
-	skipSubscriptions, skipKeys, skipCleanup bool) (*http.Response, error) {
+	skipSubscriptions, skipKeys, skipCleanup, skipValidation bool) (*http.Response, error) {
 	devportalApplicationsEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath)
 	return ImportApplication(accessToken, devportalApplicationsEndpoint, filename, appOwner, updateApplication, preserveOwner,
-		skipSubscriptions, skipKeys, skipCleanup)
+		skipSubscriptions, skipKeys, skipCleanup, skipValidation)
 }
 
 // ImportApplication function is used with import-app command
@@ -61,8 +61,9 @@ func ImportApplicationToEnv(accessToken, environment, filename, appOwner string,
 // @param skipSubscriptions: Skip importing subscriptions
 // @param skipKeys: skip importing keys of application
 // @param skipCleanup: skip cleaning up temporary files created during the operation
+// @param skipValidation: skip validating the application project before importing it
 func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, appOwner string, updateApplication, preserveOwner,
-	skipSubscriptions, skipKeys, skipCleanup bool) (*http.Response, error) {
+	skipSubscriptions, skipKeys, skipCleanup, skipValidation bool) (*http.Response, error) {
 
 	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedAppsDirName)
 	devportalApplicationsEndpoint = utils.AppendSlashToString(devportalApplicationsEndpoint)
@@ -79,6 +80,13 @@ func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, app
 		utils.HandleErrorAndExit("Error creating request.", err)
 	}
 
+	if !skipValidation {
+		err = validateApplicationBeforeImport(applicationFilePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// If applicationFilePath contains a directory, zip it. Otherwise, leave it as it is.
 	applicationFilePath, err, cleanupFunc := utils.CreateZipFileFromProject(applicationFilePath, skipCleanup)
 	if err != nil {
@@ -99,17 +107,24 @@ func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, app
 
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully imported Application.")
 		return nil, nil
 	} else {
 		// We have an HTTP error
-		fmt.Println("Error importing Application.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp)
-		return nil, errors.New(resp.Status())
+		return nil, errors.New(resp.Status() + ":<" + string(resp.Body()) + ">")
 	}
 }
 
+// validateApplicationBeforeImport validates an application project, which can be a directory or a zip archive,
+// before importing it
+func validateApplicationBeforeImport(applicationFilePath string) error {
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(applicationFilePath)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(filepath.Dir(tmpPath))
+	return validateProjectBeforeImport(tmpPath)
+}
+
 // resolveApplicationImportFilePath resolves the archive/directory for import
 // First will resolve in given path, if not found will try to load from exported directory
 func resolveApplicationImportFilePath(file, defaultExportDirectory string) (string, error) {
@@ -136,11 +151,17 @@ func resolveApplicationImportFilePath(file, defaultExportDirectory string) (stri
 // Returns the formed http request and errors
 func NewAppFileUploadRequest(uri string, params map[string]string, paramName, path,
 	accessToken string) (*resty.Response, error) {
-	file, err := os.Open(path)
+	// the secret references of the project are resolved in memory so that the secrets are never written to the disk
+	content, err := utils.ResolveSecretReferencesInZip(path)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+	if content == nil {
+		content, err = ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -148,7 +169,7 @@ func NewAppFileUploadRequest(uri string, params map[string]string, paramName, pa
 	if err != nil {
 		return nil, err
 	}
-	_, err = io.Copy(part, file)
+	_, err = part.Write(content)
 
 	for key, val := range params {
 		_ = writer.WriteField(key, val)
@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/Jeffail/gabs"
 	"github.com/go-openapi/loads"
@@ -51,29 +52,26 @@ var dirs = []string{
 	utils.InitProjectLibs,
 }
 
+// Types of the definitions used to initialize an API project
+const (
+	InitDefinitionTypeOAS      = "oas"
+	InitDefinitionTypeGraphQL  = "graphql"
+	InitDefinitionTypeWSDL     = "wsdl"
+	InitDefinitionTypeAsyncAPI = "asyncapi"
+)
+
 // InitAPIProject function is used to initlialize an API Project
-func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, initCmdApiDefinitionPath string, isAdvertiseOnly bool) error {
-	var dir string
+// @param initCmdDefinitionType : Type of the definition (oas, graphql, wsdl or asyncapi) used to populate the API
+// @param initCmdDefinitionPath : Path or URL of the definition. An empty API is created if not given
+func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdDefinitionType, initCmdDefinitionPath,
+	initCmdApiDefinitionPath string, isAdvertiseOnly bool) error {
 	swaggerSavePath := filepath.Join(initCmdOutputDir, filepath.FromSlash(utils.InitProjectDefinitionsSwagger))
 
-	if initCmdOutputDir != "" {
-		err := os.MkdirAll(initCmdOutputDir, os.ModePerm)
-		if err != nil {
-			return err
-		}
-		p, err := filepath.Abs(initCmdOutputDir)
-		if err != nil {
-			return err
-		}
-		dir = p
-	} else {
-		pwd, err := os.Getwd()
-		if err != nil {
-			return err
-		}
-		dir = pwd
+	dir, err := createProjectDirectory(initCmdOutputDir)
+	if err != nil {
+		return err
 	}
-	fmt.Println("Initializing a new WSO2 API Manager project in", dir)
+	fmt.Fprintln(utils.MessageWriter(), "Initializing a new WSO2 API Manager project in", dir)
 
 	definitionFile, err := loadDefaultSpec()
 
@@ -93,27 +91,36 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 		return err
 	}
 
-	// Use the swagger definition to populate the API definition and save the swagger file separately inside the project
-	if initCmdSwaggerPath != "" {
-		// Load the swagger file from the provided path
-		doc, err := loadSwagger(initCmdSwaggerPath)
-		if err != nil {
-			return err
-		}
-		// We use swagger2 loader. It works fine for now
-		// Since we don't use 3.0 specific details its ok
-		// otherwise please use v2.openAPI3 loaders
-		err = v2.Swagger2Populate(def, doc)
-		if err != nil {
-			return err
-		}
+	// the WSDL is written after merging the API definition since its file name contains the name and the version
+	var wsdlContent []byte
 
-		// Convert and write the swagger definition as yaml
-		yamlSwagger, err := utils.JsonToYaml(doc.Raw())
+	// Use the definition to populate the API definition and save the definition file separately inside the project
+	if initCmdDefinitionPath != "" {
+		content, err := readAPIDefinitionDocument(initCmdDefinitionPath)
 		if err != nil {
 			return err
 		}
-		err = ioutil.WriteFile(swaggerSavePath, yamlSwagger, os.ModePerm)
+		switch initCmdDefinitionType {
+		case InitDefinitionTypeGraphQL:
+			def.Name = filepath.Base(dir)
+			err = initFromGraphQL(def, initCmdOutputDir, content)
+		case InitDefinitionTypeWSDL:
+			err = initFromWSDL(def, content)
+			wsdlContent = content
+		case InitDefinitionTypeAsyncAPI:
+			err = initFromAsyncAPI(def, initCmdOutputDir, content)
+		default:
+			var jsonContent []byte
+			jsonContent, err = utils.YamlToJson(content)
+			if err != nil {
+				return err
+			}
+			if v2.IsOpenAPI3(jsonContent) {
+				err = initFromOpenAPI3(def, initCmdOutputDir, content, jsonContent)
+			} else {
+				err = initFromSwagger2(def, initCmdDefinitionPath, swaggerSavePath)
+			}
+		}
 		if err != nil {
 			return err
 		}
@@ -168,6 +175,13 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 		definitionFile.Data = tmpDef.Data
 	}
 
+	if wsdlContent != nil {
+		err = writeWSDL(&definitionFile.Data, initCmdOutputDir, wsdlContent)
+		if err != nil {
+			return err
+		}
+	}
+
 	apiData, err := yaml2.Marshal(definitionFile)
 	if err != nil {
 		return err
@@ -184,10 +198,7 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 	// Populate the deployment environments configuration and write it to the project directory
 	// if the API is not an advertise only API
 	if !isAdvertiseOnly {
-		apimProjDeploymentEnvironmentsFilePath := filepath.Join(initCmdOutputDir, utils.DeploymentEnvFile)
-		utils.Logln(utils.LogPrefixInfo + "Writing " + apimProjDeploymentEnvironmentsFilePath)
-		deploymentEnvironments, _ := box.Get("/init/default_deployment_environments.yaml")
-		err = ioutil.WriteFile(apimProjDeploymentEnvironmentsFilePath, deploymentEnvironments, os.ModePerm)
+		err = writeDefaultDeploymentEnvironments(initCmdOutputDir)
 		if err != nil {
 			return err
 		}
@@ -202,32 +213,59 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 		return err
 	}
 
-	// Create the metaData struct using details from definition
+	// Create the metaData struct using details from definition and write the api_meta.yaml file to the project
+	// directory
 	metaData := utils.MetaData{
 		Name:    definitionFile.Data.Name,
 		Version: definitionFile.Data.Version,
 	}
+	err = writeMetaData(filepath.Join(initCmdOutputDir, filepath.FromSlash(utils.MetaFileAPI)), metaData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(utils.MessageWriter(), "Project initialized")
+	fmt.Fprintln(utils.MessageWriter(), "Open README file to learn more")
+	return nil
+}
+
+// createProjectDirectory creates the directory of a project and returns its absolute path. The working directory is
+// used if the directory is not given
+func createProjectDirectory(projectDir string) (string, error) {
+	if projectDir == "" {
+		return os.Getwd()
+	}
+	err := os.MkdirAll(projectDir, os.ModePerm)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(projectDir)
+}
+
+// writeMetaData writes the meta data of a project (eg: api_meta.yaml) as yaml
+func writeMetaData(metaDataPath string, metaData utils.MetaData) error {
 	marshaledData, err := jsoniter.Marshal(metaData)
 	if err != nil {
 		return err
 	}
 	jsonMetaData, err := gabs.ParseJSON(marshaledData)
-	metaDataContent, err := utils.JsonToYaml(jsonMetaData.Bytes())
 	if err != nil {
 		return err
 	}
-
-	// Write the api_meta.yaml file to the project directory
-	apiMetaDataPath := filepath.Join(initCmdOutputDir, filepath.FromSlash(utils.MetaFileAPI))
-	utils.Logln(utils.LogPrefixInfo + "Writing " + apiMetaDataPath)
-	err = ioutil.WriteFile(apiMetaDataPath, metaDataContent, os.ModePerm)
+	metaDataContent, err := utils.JsonToYaml(jsonMetaData.Bytes())
 	if err != nil {
 		return err
 	}
+	utils.Logln(utils.LogPrefixInfo + "Writing " + metaDataPath)
+	return ioutil.WriteFile(metaDataPath, metaDataContent, os.ModePerm)
+}
 
-	fmt.Println("Project initialized")
-	fmt.Println("Open README file to learn more")
-	return nil
+// writeDefaultDeploymentEnvironments writes the default deployment environments configuration to a project
+func writeDefaultDeploymentEnvironments(projectDir string) error {
+	deploymentEnvironmentsPath := filepath.Join(projectDir, utils.DeploymentEnvFile)
+	utils.Logln(utils.LogPrefixInfo + "Writing " + deploymentEnvironmentsPath)
+	deploymentEnvironments, _ := box.Get("/init/default_deployment_environments.yaml")
+	return ioutil.WriteFile(deploymentEnvironmentsPath, deploymentEnvironments, os.ModePerm)
 }
 
 // loadDefaultSpec loads the API definition
@@ -257,6 +295,139 @@ func createDirectories(name string) error {
 	return nil
 }
 
+// initFromSwagger2 populates the API definition using a swagger 2 definition and writes it as yaml to the project
+func initFromSwagger2(def *v2.APIDTODefinition, swaggerPath, swaggerSavePath string) error {
+	// Load the swagger file from the provided path
+	doc, err := loadSwagger(swaggerPath)
+	if err != nil {
+		return err
+	}
+	err = v2.Swagger2Populate(def, doc)
+	if err != nil {
+		return err
+	}
+
+	// Convert and write the swagger definition as yaml
+	yamlSwagger, err := utils.JsonToYaml(doc.Raw())
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(swaggerSavePath, yamlSwagger, os.ModePerm)
+}
+
+// initFromOpenAPI3 populates the API definition using an OpenAPI 3.0 or 3.1 definition and writes the definition
+// to the project as it is in its original format (yaml or json)
+func initFromOpenAPI3(def *v2.APIDTODefinition, projectDir string, content, jsonContent []byte) error {
+	document, err := v2.LoadOpenAPI3Document(jsonContent)
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Loaded OpenAPI " + document.OpenAPI + " definition")
+	err = v2.OpenAPI3Populate(def, document)
+	if err != nil {
+		return err
+	}
+
+	definitionPath := filepath.Join(projectDir, filepath.FromSlash(utils.InitProjectDefinitionsSwagger))
+	if json.Valid(content) {
+		definitionPath = filepath.Join(projectDir, filepath.FromSlash(utils.InitProjectSwaggerJson))
+	}
+	utils.Logln(utils.LogPrefixInfo + "Writing " + definitionPath)
+	return ioutil.WriteFile(definitionPath, content, os.ModePerm)
+}
+
+// initFromGraphQL populates the operations of a GraphQL API using its SDL schema and writes the schema to the project
+func initFromGraphQL(def *v2.APIDTODefinition, projectDir string, content []byte) error {
+	err := v2.GraphQLPopulate(def, string(content))
+	if err != nil {
+		return err
+	}
+	schemaPath := filepath.Join(projectDir, filepath.FromSlash(utils.InitProjectGraphQLSchema))
+	utils.Logln(utils.LogPrefixInfo + "Writing " + schemaPath)
+	return ioutil.WriteFile(schemaPath, content, os.ModePerm)
+}
+
+// initFromWSDL populates a pass-through SOAP API using a WSDL document or a zip archive of WSDL documents
+func initFromWSDL(def *v2.APIDTODefinition, content []byte) error {
+	var document *v2.WSDLDocument
+	var err error
+	wsdlType := v2.WSDLTypeFile
+	if v2.IsWSDLArchive(content) {
+		wsdlType = v2.WSDLTypeArchive
+		document, err = v2.LoadWSDLArchive(content)
+	} else {
+		document, err = v2.LoadWSDLDocument(content)
+	}
+	if err != nil {
+		return err
+	}
+	return v2.WSDLPopulate(def, document, wsdlType)
+}
+
+// writeWSDL writes the WSDL document or the archive as WSDL/<name>-<version>.wsdl (or .zip) and the swagger
+// definition of the pass-through operations of the API to the project
+func writeWSDL(def *v2.APIDTODefinition, projectDir string, content []byte) error {
+	wsdlDir := filepath.Join(projectDir, utils.InitProjectWSDL)
+	err := os.MkdirAll(wsdlDir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	extension := ".wsdl"
+	if v2.IsWSDLArchive(content) {
+		extension = ".zip"
+	}
+	wsdlPath := filepath.Join(wsdlDir, def.Name+"-"+def.Version+extension)
+	utils.Logln(utils.LogPrefixInfo + "Writing " + wsdlPath)
+	err = ioutil.WriteFile(wsdlPath, content, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	swagger, err := yaml2.Marshal(v2.SOAPPassthroughSwagger(def))
+	if err != nil {
+		return err
+	}
+	swaggerPath := filepath.Join(projectDir, filepath.FromSlash(utils.InitProjectDefinitionsSwagger))
+	utils.Logln(utils.LogPrefixInfo + "Writing " + swaggerPath)
+	return ioutil.WriteFile(swaggerPath, swagger, os.ModePerm)
+}
+
+// initFromAsyncAPI populates a WebSocket, SSE, WebSub or other asynchronous API using an AsyncAPI 2.x definition and
+// writes the definition to the project as it is in its original format (yaml or json)
+func initFromAsyncAPI(def *v2.APIDTODefinition, projectDir string, content []byte) error {
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return err
+	}
+	if !v2.IsAsyncAPI(jsonContent) {
+		return errors.New("the definition is not an AsyncAPI definition")
+	}
+	document, err := v2.LoadAsyncAPIDocument(jsonContent)
+	if err != nil {
+		return err
+	}
+	err = v2.AsyncAPIPopulate(def, document)
+	if err != nil {
+		return err
+	}
+
+	definitionPath := filepath.Join(projectDir, filepath.FromSlash(utils.InitProjectAsyncAPIYaml))
+	if json.Valid(content) {
+		definitionPath = filepath.Join(projectDir, filepath.FromSlash(utils.InitProjectAsyncAPIJson))
+	}
+	utils.Logln(utils.LogPrefixInfo + "Writing " + definitionPath)
+	return ioutil.WriteFile(definitionPath, content, os.ModePerm)
+}
+
+// readAPIDefinitionDocument reads a definition from a file or a URL
+func readAPIDefinitionDocument(path string) ([]byte, error) {
+	utils.Logln(utils.LogPrefixInfo + "Reading definition from " + path)
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return utils.ReadFromUrl(path)
+	}
+	return ioutil.ReadFile(path)
+}
+
 // loadSwagger will Load the swagger definition from swaggerDoc
 // Swagger2.0/OpenAPI3.0 specs are supported
 func loadSwagger(swaggerDoc string) (*loads.Document, error) {
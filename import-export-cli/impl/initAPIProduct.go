@@ -0,0 +1,255 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/box"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Version of the API Products initialized by apictl
+const initAPIProductVersion = "1.0.0"
+
+// Directory of the API projects used by an API Product inside the API Product project
+const initProjectDependentAPIs = "APIs"
+
+// InitAPIProductProject initializes an API Product project using the operations of the given API projects. The
+// API projects are copied to the APIs directory of the project so that they can be imported with the API Product
+// @param initCmdOutputDir : Path of the API Product project. Its name is used as the name of the API Product
+// @param initCmdContext : Context of the API Product. /<name in lowercase> is used if empty
+// @param apiProjectPaths : Paths of the API projects of which the operations are included in the API Product
+// @param operationSelectors : Operations in the format <API name>:<VERB> <target>. All the operations of an API are
+// included if none of its operations are given
+// @return error
+func InitAPIProductProject(initCmdOutputDir, initCmdContext string, apiProjectPaths, operationSelectors []string) error {
+	if len(apiProjectPaths) == 0 {
+		return errors.New("at least one API project is required to initialize an API Product")
+	}
+	dir, err := createProjectDirectory(initCmdOutputDir)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(utils.MessageWriter(), "Initializing a new WSO2 API Manager API Product project in", dir)
+
+	defaultData, ok := box.Get("/init/default_api_product.yaml")
+	if !ok {
+		return errors.New("Error while retrieving default_api_product.yaml")
+	}
+	definitionFile := &v2.APIProductDefinitionFile{}
+	err = yaml.Unmarshal(defaultData, definitionFile)
+	if err != nil {
+		return err
+	}
+
+	name := strings.ReplaceAll(filepath.Base(dir), " ", "")
+	context := initCmdContext
+	if context == "" {
+		context = "/" + strings.ToLower(name)
+	}
+	apiProduct := &v2.APIProductDefinition{
+		ID: v2.ProductID{
+			ProviderName:   definitionFile.Data.Provider,
+			APIProductName: name,
+			Version:        initAPIProductVersion,
+		},
+		Context: context,
+	}
+
+	selectedOperations, err := parseOperationSelectors(operationSelectors)
+	if err != nil {
+		return err
+	}
+	apiDirectories := make(map[string]string)
+	for _, apiProjectPath := range apiProjectPaths {
+		api, err := readAPIProjectDefinition(apiProjectPath)
+		if err != nil {
+			return errors.New("Error reading the API project " + apiProjectPath + ": " + err.Error())
+		}
+		apiDirectory := api.Data.Name + "-" + api.Data.Version
+		if _, ok := apiDirectories[apiDirectory]; ok {
+			return errors.New(api.Data.Name + " " + api.Data.Version + " is given more than once")
+		}
+		apiDirectories[apiDirectory] = apiProjectPath
+
+		resources, err := productResourcesOfAPI(apiProduct, api, selectedOperations[api.Data.Name])
+		if err != nil {
+			return err
+		}
+		apiProduct.ProductResources = append(apiProduct.ProductResources, resources...)
+		delete(selectedOperations, api.Data.Name)
+	}
+	for apiName := range selectedOperations {
+		return errors.New("operations are given for " + apiName + " which is not one of the given API projects")
+	}
+
+	definitionFile.Data.Name = apiProduct.ID.APIProductName
+	definitionFile.Data.Context = apiProduct.Context
+	definitionFile.Data.APIs = apiProduct.APIs()
+
+	for _, projectDir := range []string{utils.InitProjectDocs, utils.InitProjectImage, initProjectDependentAPIs} {
+		err = os.MkdirAll(filepath.Join(initCmdOutputDir, projectDir), os.ModePerm)
+		if err != nil {
+			return err
+		}
+	}
+	for apiDirectory, apiProjectPath := range apiDirectories {
+		dependentAPIPath := filepath.Join(initCmdOutputDir, initProjectDependentAPIs, apiDirectory)
+		utils.Logln(utils.LogPrefixInfo + "Copying " + apiProjectPath + " to " + dependentAPIPath)
+		err = utils.CopyDir(apiProjectPath, dependentAPIPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	apiProductData, err := yaml.Marshal(definitionFile)
+	if err != nil {
+		return err
+	}
+	apiProductPath := filepath.Join(initCmdOutputDir, utils.APIProductDefinitionFileYaml)
+	utils.Logln(utils.LogPrefixInfo + "Writing " + apiProductPath)
+	err = ioutil.WriteFile(apiProductPath, apiProductData, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	err = writeDefaultDeploymentEnvironments(initCmdOutputDir)
+	if err != nil {
+		return err
+	}
+	metaData := utils.MetaData{
+		Name:    apiProduct.ID.APIProductName,
+		Version: apiProduct.ID.Version,
+	}
+	err = writeMetaData(filepath.Join(initCmdOutputDir, utils.MetaFileAPIProduct), metaData)
+	if err != nil {
+		return err
+	}
+	err = ScaffoldParams(filepath.Join(initCmdOutputDir, utils.ParamFileAPIProduct))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(utils.MessageWriter(), "Project initialized")
+	return nil
+}
+
+// parseOperationSelectors groups the operations given in the format <API name>:<VERB> <target> by the API names
+func parseOperationSelectors(operationSelectors []string) (map[string][]string, error) {
+	selectedOperations := make(map[string][]string)
+	for _, selector := range operationSelectors {
+		separator := strings.Index(selector, ":")
+		fields := strings.Fields(selector[separator+1:])
+		if separator <= 0 || len(fields) != 2 {
+			return nil, errors.New("invalid operation " + selector + ", the format is <API name>:<VERB> <target>")
+		}
+		apiName := strings.TrimSpace(selector[:separator])
+		selectedOperations[apiName] = append(selectedOperations[apiName], strings.ToUpper(fields[0])+" "+fields[1])
+	}
+	return selectedOperations, nil
+}
+
+// readAPIProjectDefinition reads the api.yaml (or api.json) of an API project
+func readAPIProjectDefinition(apiProjectPath string) (*v2.APIDefinitionFile, error) {
+	_, jsonContent, err := resolveYamlOrJSON(filepath.Join(apiProjectPath, "api"))
+	if err != nil {
+		return nil, err
+	}
+	api := &v2.APIDefinitionFile{}
+	err = json.Unmarshal(jsonContent, api)
+	if err != nil {
+		return nil, err
+	}
+	if api.Data.Name == "" || api.Data.Version == "" {
+		return nil, errors.New("the name and the version of the API are required")
+	}
+	return api, nil
+}
+
+// productResourcesOfAPI returns the product resources of the selected operations of an API or of all the operations
+// if none of the operations are selected
+func productResourcesOfAPI(apiProduct *v2.APIProductDefinition, api *v2.APIDefinitionFile,
+	selectedOperations []string) ([]v2.APIProductResource, error) {
+	operationsContent, err := json.Marshal(api.Data.Operations)
+	if err != nil {
+		return nil, err
+	}
+	var operations []v2.APIOperation
+	err = json.Unmarshal(operationsContent, &operations)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool)
+	for _, operation := range selectedOperations {
+		selected[operation] = true
+	}
+	apiIdentifier := v2.ID{
+		ProviderName: api.Data.Provider,
+		APIName:      api.Data.Name,
+		Version:      api.Data.Version,
+	}
+	var resources []v2.APIProductResource
+	for _, operation := range operations {
+		key := strings.ToUpper(operation.Verb) + " " + operation.Target
+		if len(selected) > 0 && !selected[key] {
+			continue
+		}
+		delete(selected, key)
+		authType, throttlingPolicy := operation.AuthType, operation.ThrottlingPolicy
+		if authType == "" {
+			authType = v2.OperationAuthTypeDefault
+		}
+		if throttlingPolicy == "" {
+			throttlingPolicy = v2.OperationThrottlingDefault
+		}
+		resources = append(resources, v2.APIProductResource{
+			APIProductName: api.Data.Name,
+			APIProductId:   api.Data.ID,
+			APIIdentifier:  apiIdentifier,
+			APIProductIdentifier: v2.ID{
+				ProviderName: apiProduct.ID.ProviderName,
+				APIName:      apiProduct.ID.APIProductName,
+				Version:      apiProduct.ID.Version,
+			},
+			URITemplate: v2.URITemplates{
+				URITemplate:    operation.Target,
+				HTTPVerb:       strings.ToUpper(operation.Verb),
+				AuthType:       authType,
+				ThrottlingTier: throttlingPolicy,
+			},
+		})
+	}
+	for operation := range selected {
+		return nil, errors.New("operation " + operation + " is not an operation of " + api.Data.Name)
+	}
+	if len(resources) == 0 {
+		return nil, errors.New(api.Data.Name + " does not have any operations")
+	}
+	return resources, nil
+}
@@ -0,0 +1,92 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+)
+
+const testInitAPIProject = `type: api
+version: v4.0.0
+data:
+  name: PizzaShackAPI
+  version: 1.0.0
+  provider: admin
+  operations:
+    - target: /menu
+      verb: GET
+      authType: Any
+      throttlingPolicy: Gold
+    - target: /order
+      verb: POST
+`
+
+func readTestInitAPIProject(t *testing.T) *v2.APIDefinitionFile {
+	dir, err := ioutil.TempDir("", "init-api-product")
+	assert.Nil(t, err, "err should be nil")
+	defer os.RemoveAll(dir)
+	err = ioutil.WriteFile(filepath.Join(dir, "api.yaml"), []byte(testInitAPIProject), os.ModePerm)
+	assert.Nil(t, err, "err should be nil")
+	api, err := readAPIProjectDefinition(dir)
+	assert.Nil(t, err, "err should be nil")
+	return api
+}
+
+func TestProductResourcesOfAPI(t *testing.T) {
+	api := readTestInitAPIProject(t)
+	apiProduct := &v2.APIProductDefinition{ID: v2.ProductID{ProviderName: "admin", APIProductName: "PizzaProduct",
+		Version: "1.0.0"}}
+
+	resources, err := productResourcesOfAPI(apiProduct, api, nil)
+	assert.Nil(t, err, "err should be nil")
+	assert.Len(t, resources, 2, "should include all the operations")
+	assert.Equal(t, v2.ID{ProviderName: "admin", APIName: "PizzaShackAPI", Version: "1.0.0"}, resources[0].APIIdentifier)
+	assert.Equal(t, "PizzaProduct", resources[0].APIProductIdentifier.APIName)
+	assert.Equal(t, "Any", resources[0].URITemplate.AuthType)
+	assert.Equal(t, "Gold", resources[0].URITemplate.ThrottlingTier)
+	assert.Equal(t, v2.OperationAuthTypeDefault, resources[1].URITemplate.AuthType, "should use the default auth type")
+
+	selectedOperations, err := parseOperationSelectors([]string{"PizzaShackAPI:post /order"})
+	assert.Nil(t, err, "err should be nil")
+	resources, err = productResourcesOfAPI(apiProduct, api, selectedOperations["PizzaShackAPI"])
+	assert.Nil(t, err, "err should be nil")
+	assert.Len(t, resources, 1, "should include only the selected operations")
+	assert.Equal(t, "/order", resources[0].URITemplate.URITemplate)
+
+	apiProduct.ProductResources = resources
+	assert.Equal(t, []v2.APIProductAPI{{Name: "PizzaShackAPI", Version: "1.0.0", Provider: "admin",
+		Operations: []v2.APIOperation{{Target: "/order", Verb: "POST", AuthType: v2.OperationAuthTypeDefault,
+			ThrottlingPolicy: v2.OperationThrottlingDefault, Scopes: []string{}}}}}, apiProduct.APIs())
+
+	_, err = productResourcesOfAPI(apiProduct, api, []string{"DELETE /order"})
+	assert.NotNil(t, err, "should fail for an operation which is not in the API")
+}
+
+func TestParseOperationSelectorsInvalid(t *testing.T) {
+	for _, selector := range []string{"GET /menu", ":GET /menu", "PizzaShackAPI:/menu"} {
+		_, err := parseOperationSelectors([]string{selector})
+		assert.NotNil(t, err, "should fail for "+selector)
+	}
+}
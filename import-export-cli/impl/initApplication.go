@@ -0,0 +1,172 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/box"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// InitApplicationProject initializes an Application project with the given subscriptions
+// @param initCmdOutputDir : Path of the Application project. Its name is used as the name of the Application
+// @param owner : Owner of the Application. The owner in the default Application is used if empty
+// @param throttlingPolicy : Application level throttling policy. Unlimited is used if empty
+// @param tokenType : Type of the tokens (JWT or OAUTH) generated for the keys of the Application
+// @param subscriptions : Subscriptions in the format [<provider>/]<API name>:<version>[:<throttling policy>]
+// @param keys : Settings of the keys added to the params, which are generated when the project is deployed
+// @return error
+func InitApplicationProject(initCmdOutputDir, owner, throttlingPolicy, tokenType string, subscriptions []string,
+	keys []params.ApplicationKeyParams) error {
+	dir, err := createProjectDirectory(initCmdOutputDir)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(utils.MessageWriter(), "Initializing a new WSO2 API Manager Application project in", dir)
+
+	defaultData, ok := box.Get("/init/default_application.yaml")
+	if !ok {
+		return errors.New("Error while retrieving default_application.yaml")
+	}
+	definitionFile := &v2.ApplicationDefinitionFile{}
+	err = yaml.Unmarshal(defaultData, definitionFile)
+	if err != nil {
+		return err
+	}
+
+	applicationInfo := &definitionFile.Data.ApplicationInfo
+	applicationInfo.Name = filepath.Base(dir)
+	if owner != "" {
+		applicationInfo.Owner = owner
+	}
+	if throttlingPolicy != "" {
+		applicationInfo.ThrottlingPolicy = throttlingPolicy
+	}
+	if tokenType != "" {
+		applicationInfo.TokenType = strings.ToUpper(tokenType)
+	}
+
+	subscribed := make(map[v2.ID]bool)
+	for _, subscription := range subscriptions {
+		applicationSubscription, err := parseApplicationSubscription(subscription)
+		if err != nil {
+			return err
+		}
+		if subscribed[applicationSubscription.APIIdentifier] {
+			return utils.NewValidationError("subscription " + subscription + " is given more than once")
+		}
+		subscribed[applicationSubscription.APIIdentifier] = true
+		definitionFile.Data.SubscribedAPIs = append(definitionFile.Data.SubscribedAPIs, *applicationSubscription)
+	}
+
+	applicationData, err := yaml.Marshal(definitionFile)
+	if err != nil {
+		return err
+	}
+	applicationPath := filepath.Join(initCmdOutputDir, utils.ApplicationDefinitionFileYaml)
+	utils.Logln(utils.LogPrefixInfo + "Writing " + applicationPath)
+	err = ioutil.WriteFile(applicationPath, applicationData, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	metaData := utils.MetaData{
+		Name:  applicationInfo.Name,
+		Owner: applicationInfo.Owner,
+	}
+	err = writeMetaData(filepath.Join(initCmdOutputDir, utils.MetaFileApplication), metaData)
+	if err != nil {
+		return err
+	}
+	paramsPath := filepath.Join(initCmdOutputDir, utils.ParamFileApplication)
+	err = ScaffoldParams(paramsPath)
+	if err != nil {
+		return err
+	}
+	if len(keys) != 0 {
+		err = appendApplicationKeyParams(paramsPath, keys)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(utils.MessageWriter(), "Project initialized")
+	return nil
+}
+
+// appendApplicationKeyParams appends the settings of the keys to the deploy section of the scaffolded params file
+func appendApplicationKeyParams(paramsPath string, keys []params.ApplicationKeyParams) error {
+	keysData, err := yaml.Marshal(struct {
+		Keys []params.ApplicationKeyParams `yaml:"keys"`
+	}{keys})
+	if err != nil {
+		return err
+	}
+	var keysSection strings.Builder
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(string(keysData), "\n"), "\n") {
+		keysSection.WriteString("  " + line)
+	}
+	keysSection.WriteString("\n")
+
+	paramsFile, err := os.OpenFile(paramsPath, os.O_APPEND|os.O_WRONLY, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer paramsFile.Close()
+	_, err = paramsFile.WriteString(keysSection.String())
+	return err
+}
+
+// parseApplicationSubscription parses a subscription given in the format
+// [<provider>/]<API name>:<version>[:<throttling policy>]. The provider defaults to admin and the throttling policy
+// defaults to Unlimited
+func parseApplicationSubscription(subscription string) (*v2.ApplicationSubscription, error) {
+	invalidSubscriptionError := utils.NewValidationError("invalid subscription " + subscription +
+		", the format is [<provider>/]<API name>:<version>[:<throttling policy>]")
+	parts := strings.Split(subscription, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[1] == "" {
+		return nil, invalidSubscriptionError
+	}
+	provider, apiName := "admin", parts[0]
+	if api := strings.Split(parts[0], "/"); len(api) == 2 {
+		provider, apiName = api[0], api[1]
+	} else if len(api) > 2 {
+		return nil, invalidSubscriptionError
+	}
+	if provider == "" || apiName == "" {
+		return nil, invalidSubscriptionError
+	}
+	applicationSubscription := &v2.ApplicationSubscription{
+		APIIdentifier:    v2.ID{ProviderName: provider, APIName: apiName, Version: parts[1]},
+		ThrottlingPolicy: v2.OperationThrottlingDefault,
+	}
+	if len(parts) == 3 && parts[2] != "" {
+		applicationSubscription.ThrottlingPolicy = parts[2]
+	}
+	return applicationSubscription, nil
+}
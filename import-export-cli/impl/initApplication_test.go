@@ -0,0 +1,74 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/box"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestParseApplicationSubscription(t *testing.T) {
+	subscription, err := parseApplicationSubscription("PizzaShackAPI:1.0.0")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, v2.ID{ProviderName: "admin", APIName: "PizzaShackAPI", Version: "1.0.0"}, subscription.APIIdentifier)
+	assert.Equal(t, v2.OperationThrottlingDefault, subscription.ThrottlingPolicy)
+
+	subscription, err = parseApplicationSubscription("devs/LeasingProduct:1.0.0:Gold")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, v2.ID{ProviderName: "devs", APIName: "LeasingProduct", Version: "1.0.0"}, subscription.APIIdentifier)
+	assert.Equal(t, "Gold", subscription.ThrottlingPolicy)
+
+	_, err = parseApplicationSubscription("PizzaShackAPI")
+	assert.NotNil(t, err, "should fail without the version")
+
+	for _, invalidSubscription := range []string{":1.0.0", "PizzaShackAPI:", "admin/:1.0.0", "/PizzaShackAPI:1.0.0",
+		"/:1.0.0", "admin/devs/PizzaShackAPI:1.0.0", "PizzaShackAPI:1.0.0:Gold:extra"} {
+		_, err = parseApplicationSubscription(invalidSubscription)
+		assert.NotNil(t, err, "should fail for "+invalidSubscription)
+	}
+}
+
+func TestAppendApplicationKeyParams(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-init-app")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	paramsPath := filepath.Join(dir, utils.ParamFileApplication)
+	tmpl, _ := box.Get("/init/application_params.tmpl")
+	assert.Nil(t, ioutil.WriteFile(paramsPath, tmpl, 0644))
+
+	keys := []params.ApplicationKeyParams{
+		{KeyType: "PRODUCTION", KeyManager: utils.DefaultKeyManager, GrantTypes: []string{"client_credentials"}},
+		{KeyType: "SANDBOX", KeyManager: "Okta", GrantTypes: []string{"password", "authorization_code"},
+			CallbackURL: "https://localhost/callback"},
+	}
+	assert.Nil(t, appendApplicationKeyParams(paramsPath, keys))
+
+	applicationParams, err := params.LoadApplicationParamsFromFile(paramsPath)
+	assert.Nil(t, err)
+	assert.Equal(t, keys, applicationParams.Deploy.Keys)
+	assert.True(t, applicationParams.Deploy.Import.SkipKeys, "the scaffolded import params should be kept")
+}
@@ -0,0 +1,273 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// wildcard of a lint rule path which matches all the items of a list or all the values of an object
+const lintPathWildcard = "*"
+
+// LintRuleset is a set of lint rules applied by the validate command
+//
+// rules:
+//   - name: api-name-pascal-case
+//     description: API names should be in PascalCase
+//     severity: warning
+//     path: data.name
+//     pattern: ^[A-Z][A-Za-z0-9]*$
+type LintRuleset struct {
+	Rules []LintRule `yaml:"rules"`
+}
+
+// LintRule checks the values of a field of a project file
+type LintRule struct {
+	// Name of the rule shown in the findings
+	Name string `yaml:"name"`
+	// Description of the rule shown as the message of the findings
+	Description string `yaml:"description"`
+	// Severity of the findings (error or warning). Defaults to warning
+	Severity string `yaml:"severity"`
+	// ProjectTypes are the definition files (api, api_product or application) of the projects the rule applies to.
+	// The rule applies to all the projects if empty
+	ProjectTypes []string `yaml:"projectTypes"`
+	// File is the project file (without the extension) the rule applies to. Defaults to the definition file
+	// (eg: api). Use Definitions/swagger to check the swagger definition
+	File string `yaml:"file"`
+	// Path is the dot separated path of the field (eg: data.tags). Use * to match all the items of a list or an object
+	Path string `yaml:"path"`
+	// Required makes the field mandatory
+	Required bool `yaml:"required"`
+	// Pattern is a regular expression which the value (or each item of a list) should match
+	Pattern string `yaml:"pattern"`
+	// Enum is the list of allowed values of the value (or each item of a list)
+	Enum []string `yaml:"enum"`
+	// Contains is the list of values the list should contain
+	Contains []string `yaml:"contains"`
+
+	pattern *regexp.Regexp
+}
+
+// lintValue is a value of a project file matched by the path of a lint rule
+type lintValue struct {
+	path  []string
+	value interface{}
+}
+
+// LoadLintRuleset reads a lint ruleset from a YAML file
+// @param path : Path to the ruleset file
+// @return the ruleset
+// @return error if the file cannot be read or a rule is invalid
+func LoadLintRuleset(path string) (*LintRuleset, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ruleset := &LintRuleset{}
+	if err := yaml.UnmarshalStrict(content, ruleset); err != nil {
+		return nil, fmt.Errorf("invalid lint ruleset %s: %v", path, err)
+	}
+	for i := range ruleset.Rules {
+		if err := ruleset.Rules[i].init(); err != nil {
+			return nil, fmt.Errorf("invalid lint rule %d in %s: %v", i+1, path, err)
+		}
+	}
+	return ruleset, nil
+}
+
+// init validates the rule and compiles the pattern
+func (r *LintRule) init() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if r.Path == "" {
+		return errors.New("path of " + r.Name + " is required")
+	}
+	if r.Severity == "" {
+		r.Severity = ValidationSeverityWarning
+	}
+	if r.Severity != ValidationSeverityError && r.Severity != ValidationSeverityWarning {
+		return errors.New("severity of " + r.Name + " should be " + ValidationSeverityError + " or " +
+			ValidationSeverityWarning)
+	}
+	if r.Pattern != "" {
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return fmt.Errorf("pattern of %s is invalid: %v", r.Name, err)
+		}
+		r.pattern = pattern
+	}
+	return nil
+}
+
+// apply applies the rules of the ruleset to a project
+func (ruleset *LintRuleset) apply(projectPath, definitionFileName string) ([]ValidationFinding, error) {
+	var findings []ValidationFinding
+	files := make(map[string]*projectFile)
+	for i := range ruleset.Rules {
+		rule := &ruleset.Rules[i]
+		if len(rule.ProjectTypes) > 0 && !containsString(rule.ProjectTypes, definitionFileName) {
+			continue
+		}
+		if err := rule.init(); err != nil {
+			return nil, err
+		}
+
+		fileName := rule.File
+		if fileName == "" {
+			fileName = definitionFileName
+		}
+		file, ok := files[fileName]
+		if !ok {
+			var err error
+			file, _, err = readProjectFileForValidation(projectPath, filepath.FromSlash(fileName))
+			if err != nil {
+				return nil, err
+			}
+			if file != nil {
+				file.name = filepath.ToSlash(filepath.Join(filepath.Dir(fileName), file.name))
+			}
+			files[fileName] = file
+		}
+		// syntax errors of the files are reported by the schema validation
+		if file == nil {
+			continue
+		}
+		var document interface{}
+		if err := json.Unmarshal(file.jsonContent, &document); err != nil {
+			continue
+		}
+		findings = append(findings, rule.check(file, document)...)
+	}
+	return findings, nil
+}
+
+// check applies the rule to a project file
+func (r *LintRule) check(file *projectFile, document interface{}) []ValidationFinding {
+	var findings []ValidationFinding
+	addFinding := func(path []string, message string) {
+		if r.Description != "" {
+			message = r.Description + " (" + message + ")"
+		}
+		findings = append(findings, ValidationFinding{
+			File:     file.name,
+			Line:     utils.FindYAMLLine(file.content, path),
+			Path:     strings.Join(path, "."),
+			Rule:     r.Name,
+			Severity: r.Severity,
+			Message:  message,
+		})
+	}
+
+	path := strings.Split(r.Path, ".")
+	values := collectLintValues(document, nil, path)
+	if len(values) == 0 {
+		if r.Required {
+			addFinding(path, "field is required")
+		}
+		return findings
+	}
+	for _, value := range values {
+		if value.value == nil {
+			if r.Required {
+				addFinding(value.path, "field is required")
+			}
+			continue
+		}
+		items, isList := value.value.([]interface{})
+		if !isList {
+			items = []interface{}{value.value}
+		}
+		if r.Required && isList && len(items) == 0 {
+			addFinding(value.path, "at least one value is required")
+		}
+		for _, expected := range r.Contains {
+			if !containsLintValue(items, expected) {
+				addFinding(value.path, "should contain "+expected)
+			}
+		}
+		for i, item := range items {
+			itemPath := value.path
+			if isList {
+				itemPath = append(append([]string{}, value.path...), strconv.Itoa(i))
+			}
+			text := fmt.Sprint(item)
+			if r.pattern != nil && !r.pattern.MatchString(text) {
+				addFinding(itemPath, "'"+text+"' does not match "+r.Pattern)
+			}
+			if len(r.Enum) > 0 && !containsString(r.Enum, text) {
+				addFinding(itemPath, "'"+text+"' should be one of "+strings.Join(r.Enum, ", "))
+			}
+		}
+	}
+	return findings
+}
+
+// collectLintValues returns the values of the document matching the remaining keys of a path
+func collectLintValues(document interface{}, currentPath, remaining []string) []lintValue {
+	if len(remaining) == 0 {
+		return []lintValue{{path: currentPath, value: document}}
+	}
+	key := remaining[0]
+	childPath := func(child string) []string {
+		return append(append([]string{}, currentPath...), child)
+	}
+
+	var values []lintValue
+	switch node := document.(type) {
+	case map[string]interface{}:
+		if key == lintPathWildcard {
+			for child, value := range node {
+				values = append(values, collectLintValues(value, childPath(child), remaining[1:])...)
+			}
+		} else if value, ok := node[key]; ok {
+			values = append(values, collectLintValues(value, childPath(key), remaining[1:])...)
+		}
+	case []interface{}:
+		if key == lintPathWildcard {
+			for i, value := range node {
+				values = append(values, collectLintValues(value, childPath(strconv.Itoa(i)), remaining[1:])...)
+			}
+		} else if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(node) {
+			values = append(values, collectLintValues(node[index], childPath(key), remaining[1:])...)
+		}
+	}
+	return values
+}
+
+// containsLintValue returns whether the list contains a value with the given string representation
+func containsLintValue(items []interface{}, expected string) bool {
+	for _, item := range items {
+		if fmt.Sprint(item) == expected {
+			return true
+		}
+	}
+	return false
+}
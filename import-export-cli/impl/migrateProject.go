@@ -0,0 +1,483 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Versions of API Manager between which the projects can be migrated
+const (
+	ProjectVersion320 = "3.2.0"
+	ProjectVersion400 = "4.0.0"
+)
+
+// Types of the projects which are migrated. These are also the types of their definition files (eg: type: api).
+const (
+	migrationProjectTypeAPI         = "api"
+	migrationProjectTypeAPIProduct  = "api_product"
+	migrationProjectTypeApplication = "application"
+)
+
+// MigrationNote is a transformation of a project which could not be done without losing or changing information. The
+// migrated project should be reviewed for each note before importing it.
+type MigrationNote struct {
+	File    string `json:"file"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// String returns the note in the format file: path: message
+func (n MigrationNote) String() string {
+	if n.Path == "" {
+		return n.File + ": " + n.Message
+	}
+	return n.File + ": " + n.Path + ": " + n.Message
+}
+
+// MigratedProject is a project migrated by MigrateProjects with the notes of its lossy transformations
+type MigratedProject struct {
+	Path  string          `json:"path"`
+	Type  string          `json:"type"`
+	Notes []MigrationNote `json:"notes"`
+}
+
+// projectMigration migrates the projects of a version of API Manager to another version
+type projectMigration struct {
+	from string
+	to   string
+	// detect returns the type of the project in the directory or an empty string if it is not a project of the version
+	detect func(projectPath string) (string, error)
+	// migrate migrates a project of the given type in place
+	migrate func(m *projectMigrator, projectType string) error
+}
+
+// projectMigrations are the supported migrations. Projects are migrated between versions which are not adjacent by
+// applying the migrations in between one after the other.
+var projectMigrations = []projectMigration{
+	{from: ProjectVersion320, to: ProjectVersion400, detect: detectProjectType320, migrate: migrateProjectTo400},
+}
+
+// projectMigrator keeps the state of a project being migrated
+type projectMigrator struct {
+	// path of the (temporary copy of the) project
+	projectPath string
+	// path of a nested project (eg: an API of an API Product) relative to the migrated project, used in the notes
+	prefix string
+	notes  *[]MigrationNote
+}
+
+// note records a lossy transformation of a file of the project
+func (m *projectMigrator) note(file, path, message string) {
+	*m.notes = append(*m.notes, MigrationNote{File: filepath.ToSlash(filepath.Join(m.prefix, file)), Path: path,
+		Message: message})
+}
+
+// nested returns a migrator of a project inside the project (eg: an API of an API Product)
+func (m *projectMigrator) nested(dir string) *projectMigrator {
+	return &projectMigrator{
+		projectPath: filepath.Join(m.projectPath, dir),
+		prefix:      filepath.Join(m.prefix, dir),
+		notes:       m.notes,
+	}
+}
+
+// path returns the absolute path of a file of the project
+func (m *projectMigrator) path(elements ...string) string {
+	return filepath.Join(append([]string{m.projectPath}, elements...)...)
+}
+
+// MigrateProjects migrates API, API Product and Application projects exported from a version of API Manager so that
+// they can be imported to another version, without connecting to any of the environments
+// @param path : Path to a project, a directory of projects or an exported archive
+// @param fromVersion : Version of API Manager the projects are exported from
+// @param toVersion : Version of API Manager the projects are migrated to
+// @param dryRun : Report the lossy transformations without changing the projects
+// @return the migrated projects with the notes of the lossy transformations
+// @return error
+func MigrateProjects(path, fromVersion, toVersion string, dryRun bool) ([]MigratedProject, error) {
+	migrations, err := findProjectMigrations(fromVersion, toVersion)
+	if err != nil {
+		return nil, err
+	}
+	// the projects are replaced through their parent directories, which requires a path without a trailing separator
+	// or a relative path such as "."
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(path); err == nil && !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".zip") {
+		return migrateArchive(path, migrations, dryRun)
+	}
+	return migrateProjectsOfDir(path, migrations, dryRun)
+}
+
+// migrateProjectsOfDir migrates a project or a directory of projects in place
+func migrateProjectsOfDir(path string, migrations []projectMigration, dryRun bool) ([]MigratedProject, error) {
+	projectPaths, projectTypes, err := findProjectsToMigrate(path, migrations[0])
+	if err != nil {
+		return nil, err
+	}
+	var migrated []MigratedProject
+	for i, projectPath := range projectPaths {
+		utils.Logln(utils.LogPrefixInfo + "Migrating the " + projectTypes[i] + " project " + projectPath)
+		project := MigratedProject{Path: projectPath, Type: projectTypes[i], Notes: []MigrationNote{}}
+		err = migrateProject(projectPath, projectTypes[i], migrations, &project.Notes, dryRun)
+		if err != nil {
+			return migrated, errors.New("Error while migrating " + projectPath + ": " + err.Error())
+		}
+		migrated = append(migrated, project)
+	}
+	return migrated, nil
+}
+
+// migrateArchive migrates the projects of an exported archive. The archive is extracted to a staging directory where
+// the projects are migrated, and it is replaced with an archive of the migrated projects unless it is a dry run.
+func migrateArchive(archivePath string, migrations []projectMigration, dryRun bool) ([]MigratedProject, error) {
+	extractedPath, err := utils.GetTempCloneFromDirOrZip(archivePath)
+	if err != nil {
+		return nil, errors.New("Error while extracting " + archivePath + ": " + err.Error())
+	}
+	defer os.RemoveAll(filepath.Dir(extractedPath))
+
+	migrated, err := migrateProjectsOfDir(extractedPath, migrations, dryRun)
+	// the extracted projects are removed with the staging directory, so they are reported with the path of the archive
+	for i := range migrated {
+		migrated[i].Path = archivePath + strings.TrimPrefix(migrated[i].Path, extractedPath)
+	}
+	if err != nil {
+		return migrated, errors.New(strings.Replace(err.Error(), extractedPath, archivePath, -1))
+	}
+	if dryRun {
+		return migrated, nil
+	}
+	return migrated, replaceProject(extractedPath, archivePath, utils.Zip)
+}
+
+// findProjectMigrations returns the migrations to apply to migrate the projects between the given versions
+func findProjectMigrations(fromVersion, toVersion string) ([]projectMigration, error) {
+	var migrations []projectMigration
+	version := fromVersion
+	for version != toVersion {
+		found := false
+		for _, migration := range projectMigrations {
+			if migration.from == version {
+				migrations = append(migrations, migration)
+				version = migration.to
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+	if version != toVersion || len(migrations) == 0 {
+		var supported []string
+		for _, migration := range projectMigrations {
+			supported = append(supported, migration.from+" to "+migration.to)
+		}
+		return nil, utils.NewValidationError("migrating projects from " + fromVersion + " to " + toVersion +
+			" is not supported. Supported migrations: " + strings.Join(supported, ", "))
+	}
+	return migrations, nil
+}
+
+// findProjectsToMigrate returns the projects of the path with their types. The path can be a project or a directory of
+// projects.
+func findProjectsToMigrate(path string, migration projectMigration) ([]string, []string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.IsDir() {
+		return nil, nil, fmt.Errorf("looking for a directory or a zip archive, found %s", info.Name())
+	}
+	projectType, err := migration.detect(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if projectType != "" {
+		return []string{path}, []string{projectType}, nil
+	}
+
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var projectPaths, projectTypes []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		projectPath := filepath.Join(path, entry.Name())
+		projectType, err := migration.detect(projectPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if projectType != "" {
+			projectPaths = append(projectPaths, projectPath)
+			projectTypes = append(projectTypes, projectType)
+		}
+	}
+	if len(projectPaths) == 0 {
+		return nil, nil, utils.NewValidationError("no " + migration.from + " projects are found in " + path)
+	}
+	return projectPaths, projectTypes, nil
+}
+
+// migrateProject applies the migrations to a temporary copy of a project and replaces the project with the copy
+// unless it is a dry run. The project is left untouched if any of the migrations fails.
+func migrateProject(projectPath, projectType string, migrations []projectMigration, notes *[]MigrationNote,
+	dryRun bool) error {
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(filepath.Dir(tmpPath))
+
+	m := &projectMigrator{projectPath: tmpPath, notes: notes}
+	for _, migration := range migrations {
+		utils.Logln(utils.LogPrefixInfo + "Applying the migration from " + migration.from + " to " + migration.to)
+		err = migration.migrate(m, projectType)
+		if err != nil {
+			return err
+		}
+	}
+	if dryRun {
+		return nil
+	}
+
+	return replaceProject(tmpPath, projectPath, utils.CopyDir)
+}
+
+// replaceProject replaces a project or an archive with the migrated copy. The migrated copy is staged next to the
+// project with stage (copying a directory or zipping an archive) and the project is kept as a backup until the copy is
+// in place, so the project is restored if the replacement fails.
+func replaceProject(migratedPath, projectPath string, stage func(source, target string) error) error {
+	projectPath = filepath.Clean(projectPath)
+	parentPath := filepath.Dir(projectPath)
+	stagingPath := filepath.Join(parentPath, "."+filepath.Base(projectPath)+".migrating")
+	backupPath := filepath.Join(parentPath, "."+filepath.Base(projectPath)+".backup")
+	_ = os.RemoveAll(stagingPath)
+	err := stage(migratedPath, stagingPath)
+	if err != nil {
+		_ = os.RemoveAll(stagingPath)
+		return err
+	}
+	err = os.Rename(projectPath, backupPath)
+	if err != nil {
+		_ = os.RemoveAll(stagingPath)
+		return err
+	}
+	err = os.Rename(stagingPath, projectPath)
+	if err != nil {
+		if restoreErr := os.Rename(backupPath, projectPath); restoreErr != nil {
+			return errors.New(err.Error() + ". The project is kept in " + backupPath)
+		}
+		_ = os.RemoveAll(stagingPath)
+		return err
+	}
+	return os.RemoveAll(backupPath)
+}
+
+// readMigrationDocument reads a YAML or JSON file of a project given without the extension. A nil document is returned
+// if the file does not exist.
+func readMigrationDocument(filePathWithoutExtension string) (*migrationDocument, error) {
+	if !utils.IsFileExist(filePathWithoutExtension+".yaml") && !utils.IsFileExist(filePathWithoutExtension+".json") {
+		return nil, nil
+	}
+	filePath, content, err := resolveYamlOrJSON(filePathWithoutExtension)
+	if err != nil {
+		return nil, err
+	}
+	document := &migrationDocument{filePath: filePath}
+	err = json.Unmarshal(content, &document.fields)
+	if err != nil {
+		return nil, errors.New("invalid " + filepath.Base(filePath) + ": " + err.Error())
+	}
+	if document.fields == nil {
+		document.fields = map[string]interface{}{}
+	}
+	return document, nil
+}
+
+// writeMigratedFile writes the content of a migrated project file as YAML
+func writeMigratedFile(filePath string, content interface{}) error {
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Writing " + filePath)
+	return ioutil.WriteFile(filePath, data, os.ModePerm)
+}
+
+// migrationDocument is a file of a project being migrated. The fields are removed from the document as they are
+// migrated so that the fields left at the end can be reported as dropped.
+type migrationDocument struct {
+	filePath string
+	// prefix of the paths of the fields in the notes (eg: the name of a document of a list of documents)
+	pathPrefix string
+	fields     map[string]interface{}
+}
+
+// take removes a field from the document and returns its value
+func (d *migrationDocument) take(key string) interface{} {
+	value := d.fields[key]
+	delete(d.fields, key)
+	return value
+}
+
+// takeString removes a field from the document and returns its value as a string
+func (d *migrationDocument) takeString(key string) string {
+	return toMigrationString(d.take(key))
+}
+
+// takeBool removes a field from the document and returns whether its value is true
+func (d *migrationDocument) takeBool(key string) bool {
+	switch value := d.take(key).(type) {
+	case bool:
+		return value
+	case string:
+		return strings.EqualFold(value, "true")
+	}
+	return false
+}
+
+// takeInt removes a field from the document and returns its value as an integer
+func (d *migrationDocument) takeInt(key string) int {
+	switch value := d.take(key).(type) {
+	case float64:
+		return int(value)
+	case string:
+		var number int
+		_, _ = fmt.Sscan(value, &number)
+		return number
+	}
+	return 0
+}
+
+// takeMap removes a field from the document and returns its value if it is an object
+func (d *migrationDocument) takeMap(key string) map[string]interface{} {
+	value, _ := d.take(key).(map[string]interface{})
+	return value
+}
+
+// takeList removes a field from the document and returns its value as a list of strings. Comma separated strings,
+// lists of strings and lists of objects with names (eg: availableTiers) are supported.
+func (d *migrationDocument) takeList(key string) []string {
+	return toStringList(d.take(key))
+}
+
+// drop removes fields from the document without reporting them
+func (d *migrationDocument) drop(keys ...string) {
+	for _, key := range keys {
+		delete(d.fields, key)
+	}
+}
+
+// reportDropped records a note for each field left in the document which has a value
+func (d *migrationDocument) reportDropped(m *projectMigrator, file, toVersion string) {
+	var keys []string
+	for key, value := range d.fields {
+		if !isEmptyMigrationValue(value) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		m.note(file, d.pathPrefix+key, "not supported by API Manager "+toVersion+", removed")
+	}
+}
+
+// toMigrationString converts a value of a document to a string. An empty string is returned for null.
+func toMigrationString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+	return fmt.Sprint(value)
+}
+
+// toStringList converts a comma separated string, a list of strings or a list of objects with names to a list of
+// strings
+func toStringList(value interface{}) []string {
+	var list []string
+	switch value := value.(type) {
+	case string:
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			switch item := item.(type) {
+			case string:
+				list = append(list, item)
+			case map[string]interface{}:
+				if name, ok := item["name"].(string); ok && name != "" {
+					list = append(list, name)
+				}
+			}
+		}
+	}
+	return list
+}
+
+// isEmptyMigrationValue returns whether a value of a document is empty (null, "", false, 0, [] or {})
+func isEmptyMigrationValue(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case bool:
+		return !value
+	case float64:
+		return value == 0
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
+
+// convertMigratedData converts a generic document to the given definition (eg: APIDTODefinition) so that the fields
+// of the migrated file are written in the order of the definition
+func convertMigratedData(data map[string]interface{}, definition interface{}) error {
+	content, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, definition)
+}
@@ -0,0 +1,907 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Version of the project files of API Manager 4.0.0
+const projectFileVersion400 = "v4.0.0"
+
+// Directory of the definitions of the projects exported from API Manager 3.x
+const legacyMetaInformation = "Meta-information"
+
+// Files and directories of the documents of the projects exported from API Manager 3.x
+const (
+	legacyDocsFile           = "docs"
+	legacyDocsInlineContents = "InlineContents"
+	legacyDocsFileContents   = "FileContents"
+)
+
+// Names (without the extension) of the files of the 4.0.0 projects written by the migration
+const (
+	migrationDeploymentEnvironmentsFile = "deployment_environments"
+	migrationDocumentFile               = "document"
+	migrationEndpointCertificatesFile   = "endpoint_certificates"
+	migrationClientCertificatesFile     = "client_certificates"
+)
+
+// Definitions of the 3.x API projects which are moved from Meta-information to Definitions
+var legacyAPIDefinitions = []string{"swagger.yaml", "swagger.json", "schema.graphql"}
+
+// Fields of the 3.x definitions which are generated by the server or derived from the other fields. These are removed
+// without any notes.
+var legacyDerivedFields = []string{"lastUpdated", "createdTime", "rating", "isLatest", "productId", "tenantDomain",
+	"environmentList", "availableSubscriptionLevelPolicies", "apiHeaderChanged", "apiResourcePatternsChanged",
+	"documents", "isPublishedDefaultVersion", "productionUrl", "sandboxUrl", "endpoints", "apiOwner", "redirectURL"}
+
+// projectFile400 is a file of a 4.0.0 project which is not an API, API Product or Application definition
+type projectFile400 struct {
+	Type    string      `yaml:"type"`
+	Version string      `yaml:"version"`
+	Data    interface{} `yaml:"data"`
+}
+
+// document400 is the data of a document.yaml of a 4.0.0 project
+type document400 struct {
+	Name          string `yaml:"name"`
+	Type          string `yaml:"type,omitempty"`
+	Summary       string `yaml:"summary,omitempty"`
+	SourceType    string `yaml:"sourceType,omitempty"`
+	SourceURL     string `yaml:"sourceUrl,omitempty"`
+	FileName      string `yaml:"fileName,omitempty"`
+	OtherTypeName string `yaml:"otherTypeName,omitempty"`
+	Visibility    string `yaml:"visibility,omitempty"`
+}
+
+// detectProjectType320 returns the type of a project exported from API Manager 3.2.0 or an empty string if the
+// directory is not such a project
+func detectProjectType320(projectPath string) (string, error) {
+	definition, err := readMigrationDocument(filepath.Join(projectPath, legacyMetaInformation, "api"))
+	if err != nil {
+		return "", err
+	}
+	if definition != nil {
+		if id, ok := definition.fields["id"].(map[string]interface{}); ok && id["apiProductName"] != nil {
+			return migrationProjectTypeAPIProduct, nil
+		}
+		return migrationProjectTypeAPI, nil
+	}
+	application, err := findLegacyApplicationDocument(projectPath)
+	if err != nil || application == nil {
+		return "", err
+	}
+	return migrationProjectTypeApplication, nil
+}
+
+// findLegacyApplicationDocument returns the definition of an Application exported from API Manager 3.x. It is a JSON
+// or YAML file at the root of the project with the subscriber of the Application.
+func findLegacyApplicationDocument(projectPath string) (*migrationDocument, error) {
+	entries, err := ioutil.ReadDir(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		extension := filepath.Ext(entry.Name())
+		if entry.IsDir() || (extension != ".json" && extension != ".yaml") {
+			continue
+		}
+		document, err := readMigrationDocument(filepath.Join(projectPath, strings.TrimSuffix(entry.Name(), extension)))
+		if err != nil {
+			// not a definition of an Application
+			continue
+		}
+		if document != nil && document.fields["subscriber"] != nil {
+			return document, nil
+		}
+	}
+	return nil, nil
+}
+
+// migrateProjectTo400 migrates a project exported from API Manager 3.2.0 to the format of API Manager 4.0.0
+func migrateProjectTo400(m *projectMigrator, projectType string) error {
+	switch projectType {
+	case migrationProjectTypeAPI:
+		return migrateAPIProjectTo400(m)
+	case migrationProjectTypeAPIProduct:
+		return migrateAPIProductProjectTo400(m)
+	case migrationProjectTypeApplication:
+		return migrateApplicationProjectTo400(m)
+	}
+	return errors.New("unknown project type " + projectType)
+}
+
+// migrateAPIProjectTo400 migrates an API project. Meta-information/api.yaml is converted to api.yaml, the definitions
+// are moved to Definitions and the deployment environments are written to deployment_environments.yaml.
+func migrateAPIProjectTo400(m *projectMigrator) error {
+	legacy, err := readMigrationDocument(m.path(legacyMetaInformation, "api"))
+	if err != nil {
+		return err
+	}
+	if legacy == nil {
+		return errors.New(legacyMetaInformation + "/api.yaml is not found")
+	}
+	legacyFile := legacyMetaInformation + "/" + filepath.Base(legacy.filePath)
+
+	err = moveLegacyDefinitions(m)
+	if err != nil {
+		return err
+	}
+	data := sharedDefinitionFieldsTo400(m, legacy, legacyFile)
+	id := legacy.takeMap("id")
+	name, version := toMigrationString(id["apiName"]), toMigrationString(id["version"])
+	data["name"] = name
+	data["version"] = version
+	data["provider"] = toMigrationString(id["providerName"])
+	data["id"] = legacy.takeString("uuid")
+	data["type"] = strings.ToUpper(legacy.takeString("type"))
+	data["context"] = contextTo400(legacy.takeString("context"), legacy.takeString("contextTemplate"), version)
+	data["lifeCycleStatus"] = strings.ToUpper(legacy.takeString("status"))
+	data["isDefaultVersion"] = legacy.takeBool("isDefaultVersion")
+	data["enableSchemaValidation"] = legacy.takeBool("enableSchemaValidation")
+	data["apiThrottlingPolicy"] = legacy.takeString("apiLevelPolicy")
+	data["destinationStatsEnabled"] = legacy.takeString("destinationStatsEnabled")
+	data["keyManagers"] = legacy.takeList("keyManagers")
+	data["wsdlUrl"] = legacy.takeString("wsdlUrl")
+	data["wsdlInfo"] = wsdlInfoTo400(m)
+	data["endpointImplementationType"] = strings.ToUpper(legacy.takeString("implementation"))
+	data["endpointConfig"], err = endpointConfigTo400(m, legacy, legacyFile)
+	if err != nil {
+		return err
+	}
+	data["mediationPolicies"] = mediationPoliciesTo400(m, legacy, legacyFile)
+	data["operations"] = operationsTo400(legacy)
+	if legacy.takeBool("advertiseOnly") {
+		data["advertiseInfo"] = map[string]interface{}{
+			"advertised":           true,
+			"originalDevPortalUrl": legacy.takeString("redirectURL"),
+			"apiOwner":             legacy.takeString("apiOwner"),
+			"vendor":               "WSO2",
+		}
+	}
+	if labels := legacy.takeList("gatewayLabels"); len(labels) > 0 {
+		m.note(legacyFile, "gatewayLabels", "microgateway labels ("+strings.Join(labels, ", ")+
+			") are not supported by API Manager 4.0.0, removed")
+	}
+	environments := legacy.takeList("environments")
+	legacy.drop(legacyDerivedFields...)
+	legacy.reportDropped(m, legacyFile, ProjectVersion400)
+
+	api := v2.APIDefinitionFile{Type: migrationProjectTypeAPI, ApimVersion: projectFileVersion400}
+	err = convertMigratedData(data, &api.Data)
+	if err != nil {
+		return err
+	}
+	err = writeMigratedFile(m.path(utils.APIDefinitionFileYaml), api)
+	if err != nil {
+		return err
+	}
+	err = writeDeploymentEnvironmentsTo400(m, environments)
+	if err != nil {
+		return err
+	}
+	if !utils.IsFileExist(m.path(utils.MetaFileAPI)) {
+		err = writeMetaData(m.path(utils.MetaFileAPI), utils.MetaData{Name: name, Version: version})
+		if err != nil {
+			return err
+		}
+	}
+	err = migrateDocsTo400(m)
+	if err != nil {
+		return err
+	}
+	err = migrateCertificatesTo400(m)
+	if err != nil {
+		return err
+	}
+	err = migrateParamsTo400(m, utils.ParamFileAPI)
+	if err != nil {
+		return err
+	}
+	return removeLegacyMetaInformation(m, filepath.Base(legacy.filePath))
+}
+
+// migrateAPIProductProjectTo400 migrates an API Product project and the API projects in its APIs directory. The
+// product resources are grouped by their APIs into the apis of api_product.yaml.
+func migrateAPIProductProjectTo400(m *projectMigrator) error {
+	legacy, err := readMigrationDocument(m.path(legacyMetaInformation, "api"))
+	if err != nil {
+		return err
+	}
+	if legacy == nil {
+		return errors.New(legacyMetaInformation + "/api.yaml is not found")
+	}
+	legacyFile := legacyMetaInformation + "/" + filepath.Base(legacy.filePath)
+
+	if utils.IsFileExist(m.path(initProjectDependentAPIs)) {
+		entries, err := ioutil.ReadDir(m.path(initProjectDependentAPIs))
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			apiMigrator := m.nested(filepath.Join(initProjectDependentAPIs, entry.Name()))
+			projectType, err := detectProjectType320(apiMigrator.projectPath)
+			if err != nil {
+				return err
+			}
+			if projectType == migrationProjectTypeAPI {
+				utils.Logln(utils.LogPrefixInfo + "Migrating the API project " + apiMigrator.prefix)
+				err = migrateAPIProjectTo400(apiMigrator)
+				if err != nil {
+					return errors.New(apiMigrator.prefix + ": " + err.Error())
+				}
+			}
+		}
+	}
+
+	err = moveLegacyDefinitions(m)
+	if err != nil {
+		return err
+	}
+	data := sharedDefinitionFieldsTo400(m, legacy, legacyFile)
+	id := legacy.takeMap("id")
+	name, version := toMigrationString(id["apiProductName"]), toMigrationString(id["version"])
+	data["name"] = name
+	data["provider"] = toMigrationString(id["providerName"])
+	data["id"] = legacy.takeString("uuid")
+	data["context"] = contextTo400(legacy.takeString("context"), legacy.takeString("contextTemplate"), version)
+	data["state"] = strings.ToUpper(legacy.takeString("state"))
+	data["apis"], err = productAPIsTo400(legacy.take("productResources"))
+	if err != nil {
+		return err
+	}
+	environments := legacy.takeList("environments")
+	legacy.drop(legacyDerivedFields...)
+	legacy.drop("type")
+	legacy.reportDropped(m, legacyFile, ProjectVersion400)
+
+	apiProduct := v2.APIProductDefinitionFile{Type: migrationProjectTypeAPIProduct, ApimVersion: projectFileVersion400}
+	err = convertMigratedData(data, &apiProduct.Data)
+	if err != nil {
+		return err
+	}
+	err = writeMigratedFile(m.path(utils.APIProductDefinitionFileYaml), apiProduct)
+	if err != nil {
+		return err
+	}
+	err = writeDeploymentEnvironmentsTo400(m, environments)
+	if err != nil {
+		return err
+	}
+	if !utils.IsFileExist(m.path(utils.MetaFileAPIProduct)) {
+		err = writeMetaData(m.path(utils.MetaFileAPIProduct), utils.MetaData{Name: name, Version: version})
+		if err != nil {
+			return err
+		}
+	}
+	err = migrateDocsTo400(m)
+	if err != nil {
+		return err
+	}
+	err = migrateParamsTo400(m, utils.ParamFileAPIProduct)
+	if err != nil {
+		return err
+	}
+	return removeLegacyMetaInformation(m, filepath.Base(legacy.filePath))
+}
+
+// migrateApplicationProjectTo400 migrates an Application project. The exported Application is converted to
+// application.yaml. The keys of the Application are not migrated.
+func migrateApplicationProjectTo400(m *projectMigrator) error {
+	legacy, err := findLegacyApplicationDocument(m.projectPath)
+	if err != nil {
+		return err
+	}
+	if legacy == nil {
+		return errors.New("the exported Application is not found")
+	}
+	legacyFile := filepath.Base(legacy.filePath)
+
+	subscriber := legacy.takeMap("subscriber")
+	data := map[string]interface{}{
+		"name":             legacy.takeString("name"),
+		"description":      legacy.takeString("description"),
+		"throttlingPolicy": legacy.takeString("tier"),
+		"tokenType":        strings.ToUpper(legacy.takeString("tokenType")),
+		"owner":            toMigrationString(subscriber["name"]),
+		"groups":           legacy.takeList("groupId"),
+		"attributes":       toStringMap(legacy.takeMap("applicationAttributes")),
+	}
+	application := v2.ApplicationDefinitionFile{Type: migrationProjectTypeApplication, ApimVersion: projectFileVersion400}
+	err = convertMigratedData(data, &application.Data.ApplicationInfo)
+	if err != nil {
+		return err
+	}
+
+	subscriptions, _ := legacy.take("subscribedAPIs").([]interface{})
+	application.Data.SubscribedAPIs = []v2.ApplicationSubscription{}
+	for _, item := range subscriptions {
+		subscription, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		apiID, _ := subscription["apiId"].(map[string]interface{})
+		apiName := toMigrationString(apiID["apiName"])
+		if apiName == "" {
+			apiName = toMigrationString(apiID["apiProductName"])
+		}
+		throttlingPolicy := toMigrationString(subscription["throttlingPolicy"])
+		if throttlingPolicy == "" {
+			throttlingPolicy = toMigrationString(subscription["tier"])
+		}
+		application.Data.SubscribedAPIs = append(application.Data.SubscribedAPIs, v2.ApplicationSubscription{
+			APIIdentifier: v2.ID{
+				ProviderName: toMigrationString(apiID["providerName"]),
+				APIName:      apiName,
+				Version:      toMigrationString(apiID["version"]),
+			},
+			ThrottlingPolicy: throttlingPolicy,
+		})
+	}
+	if keys := legacy.take("keys"); !isEmptyMigrationValue(keys) {
+		m.note(legacyFile, "keys", "the keys of the Application are not migrated, generate them after importing "+
+			"the Application")
+	}
+	legacy.drop("id", "uuid", "status", "createdTime", "lastUpdatedTime", "subscriptionCount")
+	legacy.reportDropped(m, legacyFile, ProjectVersion400)
+
+	err = writeMigratedFile(m.path(utils.ApplicationDefinitionFileYaml), application)
+	if err != nil {
+		return err
+	}
+	if !utils.IsFileExist(m.path(utils.MetaFileApplication)) {
+		err = writeMetaData(m.path(utils.MetaFileApplication), utils.MetaData{
+			Name:  application.Data.ApplicationInfo.Name,
+			Owner: application.Data.ApplicationInfo.Owner,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return os.Remove(legacy.filePath)
+}
+
+// sharedDefinitionFieldsTo400 migrates the fields which are common to the definitions of the APIs and the API Products
+func sharedDefinitionFieldsTo400(m *projectMigrator, legacy *migrationDocument, file string) map[string]interface{} {
+	var transports []string
+	for _, transport := range legacy.takeList("transports") {
+		transports = append(transports, strings.ToLower(transport))
+	}
+	data := map[string]interface{}{
+		"description":                  legacy.takeString("description"),
+		"tags":                         legacy.takeList("tags"),
+		"policies":                     legacy.takeList("availableTiers"),
+		"transport":                    transports,
+		"securityScheme":               legacy.takeList("apiSecurity"),
+		"authorizationHeader":          legacy.takeString("authorizationHeader"),
+		"corsConfiguration":            legacy.take("corsConfiguration"),
+		"responseCachingEnabled":       strings.EqualFold(legacy.takeString("responseCache"), "Enabled"),
+		"cacheTimeout":                 legacy.takeInt("cacheTimeout"),
+		"enableStore":                  legacy.takeBool("enableStore"),
+		"categories":                   legacy.takeList("apiCategories"),
+		"visibleRoles":                 legacy.takeList("visibleRoles"),
+		"visibleTenants":               legacy.takeList("visibleTenants"),
+		"subscriptionAvailability":     strings.ToUpper(legacy.takeString("subscriptionAvailability")),
+		"subscriptionAvailableTenants": legacy.takeList("subscriptionAvailableTenants"),
+		"accessControlRoles":           legacy.takeList("accessControlRoles"),
+		"additionalProperties":         toStringMap(legacy.takeMap("additionalProperties")),
+	}
+
+	visibility := strings.ToUpper(legacy.takeString("visibility"))
+	if visibility == "CONTROLLED" {
+		m.note(file, "visibility", "controlled visibility is not supported by API Manager 4.0.0, changed to PUBLIC")
+		visibility = "PUBLIC"
+	}
+	data["visibility"] = visibility
+
+	switch accessControl := strings.ToUpper(legacy.takeString("accessControl")); accessControl {
+	case "ALL":
+		data["accessControl"] = "NONE"
+	default:
+		data["accessControl"] = accessControl
+	}
+
+	businessInformation := map[string]interface{}{}
+	for _, field := range []string{"businessOwner", "businessOwnerEmail", "technicalOwner", "technicalOwnerEmail"} {
+		if value := legacy.takeString(field); value != "" {
+			businessInformation[field] = value
+		}
+	}
+	if len(businessInformation) > 0 {
+		data["businessInformation"] = businessInformation
+	}
+
+	monetizationEnabled := legacy.takeBool("isMonetizationEnabled")
+	monetizationProperties := legacy.takeMap("monetizationProperties")
+	if monetizationEnabled || len(monetizationProperties) > 0 {
+		data["monetization"] = map[string]interface{}{
+			"enabled":    monetizationEnabled,
+			"properties": toStringMap(monetizationProperties),
+		}
+	}
+
+	legacyScopes, _ := legacy.take("scopes").([]interface{})
+	var scopes []interface{}
+	for _, item := range legacyScopes {
+		legacyScope, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		scope := v2.APIScope{}
+		scope.Scope.Name = toMigrationString(legacyScope["key"])
+		scope.Scope.DisplayName = toMigrationString(legacyScope["name"])
+		scope.Scope.Description = toMigrationString(legacyScope["description"])
+		scope.Scope.Bindings = toStringList(legacyScope["roles"])
+		if scope.Scope.Name == "" {
+			scope.Scope.Name = scope.Scope.DisplayName
+		}
+		if scope.Scope.Bindings == nil {
+			scope.Scope.Bindings = []string{}
+		}
+		scopes = append(scopes, scope)
+	}
+	data["scopes"] = scopes
+	return data
+}
+
+// contextTo400 returns the context of an API or an API Product without the version and the tenant domain. The
+// context of a 3.x definition includes the version unless the context template has the version in the middle.
+func contextTo400(context, contextTemplate, version string) string {
+	if contextTemplate != "" {
+		context = contextTemplate
+	} else if version != "" {
+		context = strings.TrimSuffix(context, "/"+version)
+	}
+	context = strings.TrimSuffix(context, "/{version}")
+	if strings.HasPrefix(context, "/t/") {
+		if parts := strings.SplitN(context, "/", 4); len(parts) == 4 {
+			context = "/" + parts[3]
+		}
+	}
+	return context
+}
+
+// wsdlInfoTo400 returns the WSDL info of an API based on the WSDL file or the WSDL archive in the project
+func wsdlInfoTo400(m *projectMigrator) interface{} {
+	entries, err := ioutil.ReadDir(m.path(utils.InitProjectWSDL))
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".zip":
+			return map[string]interface{}{"type": v2.WSDLTypeArchive}
+		case ".wsdl":
+			return map[string]interface{}{"type": v2.WSDLTypeFile}
+		}
+	}
+	return nil
+}
+
+// endpointConfigTo400 converts the endpoint configuration of an API from a JSON string to an object. The endpoint
+// security configured by the endpointSecured, endpointAuthDigest and endpointUTUsername fields is moved to the
+// production and sandbox endpoint security of the endpoint configuration.
+func endpointConfigTo400(m *projectMigrator, legacy *migrationDocument, file string) (map[string]interface{},
+	error) {
+	var config map[string]interface{}
+	switch value := legacy.take("endpointConfig").(type) {
+	case string:
+		if value != "" {
+			if err := json.Unmarshal([]byte(value), &config); err != nil {
+				return nil, errors.New("invalid endpointConfig: " + err.Error())
+			}
+		}
+	case map[string]interface{}:
+		config = value
+	}
+
+	secured := legacy.takeBool("endpointSecured")
+	securityType := "BASIC"
+	if legacy.takeBool("endpointAuthDigest") {
+		securityType = "DIGEST"
+	}
+	username := legacy.takeString("endpointUTUsername")
+	if config == nil {
+		if secured {
+			m.note(file, "endpointSecured", "the API has no endpoints, the endpoint security is removed")
+		}
+		return nil, nil
+	}
+	if _, ok := config["endpoint_security"]; !ok && secured {
+		security := make(map[string]interface{})
+		for _, keyType := range []string{"production", "sandbox"} {
+			security[keyType] = map[string]interface{}{
+				"enabled":  true,
+				"type":     securityType,
+				"username": username,
+				"password": "",
+			}
+		}
+		config["endpoint_security"] = security
+	}
+	if security, ok := config["endpoint_security"].(map[string]interface{}); ok {
+		for _, keyType := range []string{"production", "sandbox"} {
+			endpointSecurity, ok := security[keyType].(map[string]interface{})
+			if ok && endpointSecurity["enabled"] == true && toMigrationString(endpointSecurity["password"]) == "" {
+				m.note(file, "endpointConfig.endpoint_security."+keyType, "the password is not exported, "+
+					"provide it with the security of the params file")
+			}
+		}
+	}
+	return config, nil
+}
+
+// mediationPoliciesTo400 converts the in, out and fault sequences of an API to mediation policies. Sequences which are
+// not in the Custom directories of the project are shared sequences of the environment.
+func mediationPoliciesTo400(m *projectMigrator, legacy *migrationDocument, file string) []interface{} {
+	var policies []interface{}
+	for _, sequence := range []struct {
+		field             string
+		policyType        string
+		sequenceDirectory string
+	}{
+		{field: "inSequence", policyType: "IN", sequenceDirectory: utils.InitProjectSequencesIn},
+		{field: "outSequence", policyType: "OUT", sequenceDirectory: utils.InitProjectSequencesOut},
+		{field: "faultSequence", policyType: "FAULT", sequenceDirectory: utils.InitProjectSequencesFault},
+	} {
+		name := legacy.takeString(sequence.field)
+		if name == "" {
+			continue
+		}
+		custom := utils.IsFileExist(m.path(sequence.sequenceDirectory, "Custom", name+".xml"))
+		if !custom && !utils.IsFileExist(m.path(sequence.sequenceDirectory, name+".xml")) {
+			m.note(file, sequence.field, "the shared sequence "+name+" is not in the project, it should be "+
+				"available in the environment")
+		}
+		policies = append(policies, map[string]interface{}{
+			"name":   name,
+			"type":   sequence.policyType,
+			"shared": !custom,
+		})
+	}
+	return policies
+}
+
+// operationsTo400 converts the URI templates of an API to operations. An API without URI templates gets the
+// operations from its definition when it is imported.
+func operationsTo400(legacy *migrationDocument) []interface{} {
+	templates, _ := legacy.take("uriTemplates").([]interface{})
+	var operations []interface{}
+	for _, item := range templates {
+		template, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		operation := v2.APIOperation{
+			Target:           toMigrationString(template["uriTemplate"]),
+			Verb:             strings.ToUpper(toMigrationString(template["httpVerb"])),
+			AuthType:         toMigrationString(template["authType"]),
+			ThrottlingPolicy: toMigrationString(template["throttlingTier"]),
+			Scopes:           []string{},
+		}
+		if operation.Target == "" || operation.Verb == "" {
+			continue
+		}
+		scopes, _ := template["scopes"].([]interface{})
+		if scope, ok := template["scope"].(map[string]interface{}); ok {
+			scopes = append(scopes, scope)
+		}
+		for _, scope := range scopes {
+			if scope, ok := scope.(map[string]interface{}); ok && toMigrationString(scope["key"]) != "" {
+				operation.Scopes = append(operation.Scopes, toMigrationString(scope["key"]))
+			}
+		}
+		operations = append(operations, operation)
+	}
+	return operations
+}
+
+// productAPIsTo400 groups the product resources of an API Product by their APIs
+func productAPIsTo400(productResources interface{}) ([]v2.APIProductAPI, error) {
+	content, err := json.Marshal(productResources)
+	if err != nil {
+		return nil, err
+	}
+	apiProduct := &v2.APIProductDefinition{}
+	if productResources != nil {
+		err = json.Unmarshal(content, &apiProduct.ProductResources)
+		if err != nil {
+			return nil, errors.New("invalid productResources: " + err.Error())
+		}
+	}
+	return apiProduct.APIs(), nil
+}
+
+// moveLegacyDefinitions moves the swagger and the GraphQL schema of a project from Meta-information to Definitions
+func moveLegacyDefinitions(m *projectMigrator) error {
+	for _, definition := range legacyAPIDefinitions {
+		legacyPath := m.path(legacyMetaInformation, definition)
+		if !utils.IsFileExist(legacyPath) {
+			continue
+		}
+		err := os.MkdirAll(m.path(utils.InitProjectDefinitions), os.ModePerm)
+		if err != nil {
+			return err
+		}
+		utils.Logln(utils.LogPrefixInfo + "Moving " + definition + " to " + utils.InitProjectDefinitions)
+		err = os.Rename(legacyPath, m.path(utils.InitProjectDefinitions, definition))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeLegacyMetaInformation removes the Meta-information directory of a project once its files are migrated. A note
+// is recorded for each of the other files of the directory.
+func removeLegacyMetaInformation(m *projectMigrator, definitionFile string) error {
+	entries, err := ioutil.ReadDir(m.path(legacyMetaInformation))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Name() != definitionFile {
+			m.note(legacyMetaInformation+"/"+entry.Name(), "", "not used by API Manager 4.0.0, removed")
+		}
+	}
+	return os.RemoveAll(m.path(legacyMetaInformation))
+}
+
+// writeDeploymentEnvironmentsTo400 writes the gateway environments of an API or an API Product to
+// deployment_environments.yaml
+func writeDeploymentEnvironmentsTo400(m *projectMigrator, environments []string) error {
+	deploymentEnvironments := []yaml.MapSlice{}
+	for _, environment := range environments {
+		deploymentEnvironments = append(deploymentEnvironments, yaml.MapSlice{
+			{Key: "displayOnDevportal", Value: true},
+			{Key: "deploymentEnvironment", Value: environment},
+		})
+	}
+	return writeMigratedFile(m.path(migrationDeploymentEnvironmentsFile+".yaml"), projectFile400{
+		Type:    migrationDeploymentEnvironmentsFile,
+		Version: projectFileVersion400,
+		Data:    deploymentEnvironments,
+	})
+}
+
+// migrateDocsTo400 moves each document listed in Docs/docs.json to a directory with its document.yaml and its inline
+// or file content
+func migrateDocsTo400(m *projectMigrator) error {
+	docsPath := m.path(utils.InitProjectDocs, legacyDocsFile)
+	if !utils.IsFileExist(docsPath+".json") && !utils.IsFileExist(docsPath+".yaml") {
+		return nil
+	}
+	filePath, content, err := resolveYamlOrJSON(docsPath)
+	if err != nil {
+		return err
+	}
+	docsFile := utils.InitProjectDocs + "/" + filepath.Base(filePath)
+	var docs []map[string]interface{}
+	err = json.Unmarshal(content, &docs)
+	if err != nil {
+		return errors.New("invalid " + docsFile + ": " + err.Error())
+	}
+
+	for i, doc := range docs {
+		legacy := &migrationDocument{filePath: filePath, fields: doc}
+		document := document400{
+			Name:          legacy.takeString("name"),
+			Type:          legacy.takeString("type"),
+			Summary:       legacy.takeString("summary"),
+			SourceType:    strings.ToUpper(legacy.takeString("sourceType")),
+			SourceURL:     legacy.takeString("sourceUrl"),
+			OtherTypeName: legacy.takeString("otherTypeName"),
+			Visibility:    legacy.takeString("visibility"),
+		}
+		if document.Name == "" {
+			m.note(docsFile, "", "the document at index "+strconv.Itoa(i)+" has no name, removed")
+			continue
+		}
+		legacy.pathPrefix = document.Name + "."
+		documentDir := m.path(utils.InitProjectDocs, document.Name)
+		err = os.MkdirAll(documentDir, os.ModePerm)
+		if err != nil {
+			return err
+		}
+		switch document.SourceType {
+		case "INLINE", "MARKDOWN":
+			err = moveMigratedFile(m.path(utils.InitProjectDocs, legacyDocsInlineContents, document.Name),
+				filepath.Join(documentDir, document.Name))
+		case "FILE":
+			document.FileName = filepath.Base(legacy.takeString("filePath"))
+			err = moveMigratedFile(m.path(utils.InitProjectDocs, legacyDocsFileContents, document.FileName),
+				filepath.Join(documentDir, document.FileName))
+		}
+		if err != nil {
+			return err
+		}
+		legacy.drop("id", "lastUpdated", "filePath")
+		legacy.reportDropped(m, docsFile, ProjectVersion400)
+		err = writeMigratedFile(filepath.Join(documentDir, migrationDocumentFile+".yaml"), projectFile400{
+			Type:    migrationDocumentFile,
+			Version: projectFileVersion400,
+			Data:    document,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, contentsDir := range []string{legacyDocsInlineContents, legacyDocsFileContents} {
+		entries, _ := ioutil.ReadDir(m.path(utils.InitProjectDocs, contentsDir))
+		for _, entry := range entries {
+			m.note(utils.InitProjectDocs+"/"+contentsDir+"/"+entry.Name(), "",
+				"not the content of any of the documents, removed")
+		}
+		err = os.RemoveAll(m.path(utils.InitProjectDocs, contentsDir))
+		if err != nil {
+			return err
+		}
+	}
+	return os.Remove(filePath)
+}
+
+// moveMigratedFile moves a file of a project if it exists
+func moveMigratedFile(source, destination string) error {
+	if !utils.IsFileExist(source) {
+		return nil
+	}
+	return os.Rename(source, destination)
+}
+
+// migrateCertificatesTo400 adds the type and the version to the endpoint certificates and the client certificates
+// files of an API
+func migrateCertificatesTo400(m *projectMigrator) error {
+	for _, certificates := range []struct {
+		directory string
+		file      string
+	}{
+		{directory: utils.InitProjectEndpointCertificates, file: migrationEndpointCertificatesFile},
+		{directory: utils.InitProjectClientCertificates, file: migrationClientCertificatesFile},
+	} {
+		certificatesPath := m.path(certificates.directory, certificates.file)
+		if !utils.IsFileExist(certificatesPath+".json") && !utils.IsFileExist(certificatesPath+".yaml") {
+			continue
+		}
+		filePath, content, err := resolveYamlOrJSON(certificatesPath)
+		if err != nil {
+			return err
+		}
+		var data []interface{}
+		if json.Unmarshal(content, &data) != nil {
+			// already has the type and the version
+			continue
+		}
+		err = os.Remove(filePath)
+		if err != nil {
+			return err
+		}
+		err = writeMigratedFile(certificatesPath+".yaml", projectFile400{
+			Type:    certificates.file,
+			Version: projectFileVersion400,
+			Data:    data,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateParamsTo400 moves the configurations of each environment of a params file of API Manager 3.x under configs.
+// The gateway environments are converted to deployment environments and the endpoint security is used for both the
+// production and the sandbox endpoints.
+func migrateParamsTo400(m *projectMigrator, fileName string) error {
+	content, err := ioutil.ReadFile(m.path(fileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var params yaml.MapSlice
+	err = yaml.Unmarshal(content, &params)
+	if err != nil {
+		return errors.New("invalid " + fileName + ": " + err.Error())
+	}
+
+	migrated := false
+	for i := range params {
+		environments, ok := params[i].Value.([]interface{})
+		if params[i].Key != "environments" || !ok {
+			continue
+		}
+		for j, item := range environments {
+			environment, ok := item.(yaml.MapSlice)
+			if !ok || mapSliceHasKey(environment, "configs") {
+				continue
+			}
+			var migratedEnvironment, configs yaml.MapSlice
+			for _, field := range environment {
+				switch field.Key {
+				case "name":
+					migratedEnvironment = append(migratedEnvironment, field)
+				case "gatewayEnvironments":
+					var deploymentEnvironments []yaml.MapSlice
+					for _, gatewayEnvironment := range toStringList(field.Value) {
+						deploymentEnvironments = append(deploymentEnvironments, yaml.MapSlice{
+							{Key: "displayOnDevportal", Value: true},
+							{Key: "deploymentEnvironment", Value: gatewayEnvironment},
+						})
+					}
+					configs = append(configs, yaml.MapItem{Key: "deploymentEnvironments", Value: deploymentEnvironments})
+				case "security":
+					security, ok := field.Value.(yaml.MapSlice)
+					if ok && !mapSliceHasKey(security, "production") && !mapSliceHasKey(security, "sandbox") {
+						field.Value = yaml.MapSlice{{Key: "production", Value: security}, {Key: "sandbox", Value: security}}
+					}
+					configs = append(configs, field)
+				default:
+					configs = append(configs, field)
+				}
+			}
+			environments[j] = append(migratedEnvironment, yaml.MapItem{Key: "configs", Value: configs})
+			migrated = true
+		}
+	}
+	if !migrated {
+		return nil
+	}
+	if bytes.Contains(content, []byte("#")) {
+		m.note(fileName, "", "the comments are not preserved")
+	}
+	return writeMigratedFile(m.path(fileName), params)
+}
+
+// mapSliceHasKey returns whether a YAML mapping has the given key
+func mapSliceHasKey(mapSlice yaml.MapSlice, key string) bool {
+	for _, item := range mapSlice {
+		if item.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// toStringMap converts the values of an object to strings
+func toStringMap(object map[string]interface{}) map[string]string {
+	if len(object) == 0 {
+		return nil
+	}
+	stringMap := make(map[string]string)
+	for key, value := range object {
+		stringMap[key] = toMigrationString(value)
+	}
+	return stringMap
+}
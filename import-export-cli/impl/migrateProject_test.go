@@ -0,0 +1,321 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const migrateTestLegacyAPIYaml = `id:
+  providerName: admin
+  apiName: PizzaShackAPI
+  version: 1.0.0
+uuid: 84cf3db1-b4dc-4aa9-a927-1d4e7d19576c
+type: HTTP
+context: /pizzashack/1.0.0
+contextTemplate: /pizzashack/{version}
+status: PUBLISHED
+visibility: restricted
+visibleRoles: admin,publisher
+transports: http,https
+apiSecurity: oauth2,oauth_basic_auth_api_key_mandatory
+availableTiers:
+  - name: Unlimited
+    displayName: Unlimited
+responseCache: Disabled
+cacheTimeout: 300
+implementation: ENDPOINT
+endpointSecured: true
+endpointUTUsername: backend
+endpointConfig: '{"endpoint_type":"http","production_endpoints":{"url":"https:\/\/localhost:9443\/pizzashack"}}'
+inSequence: addHeader
+uriTemplates:
+  - uriTemplate: /menu
+    httpVerb: get
+    authType: Any
+    throttlingTier: Unlimited
+    scopes:
+      - key: menu_read
+scopes:
+  - key: menu_read
+    name: Menu Read
+    roles: admin
+accessControl: all
+subscriptionAvailability: all_tenants
+environments:
+  - Production and Sandbox
+gatewayLabels:
+  - name: edge
+lastUpdated: May 13, 2020 10:18:31 AM
+`
+
+const migrateTestLegacyParams = `environments:
+  - name: dev
+    endpoints:
+      production:
+        url: https://dev.pizzashack.com
+    security:
+      enabled: true
+      type: basic
+      username: admin
+      password: admin
+    gatewayEnvironments:
+      - Production and Sandbox
+`
+
+const migrateTestLegacyApplication = `{"name": "PizzaApp", "tier": "Unlimited", "tokenType": "jwt", "groupId": "",
+"subscriber": {"name": "admin"}, "keys": [{"consumerKey": "key"}], "status": "APPROVED",
+"subscribedAPIs": [{"apiId": {"providerName": "admin", "apiName": "PizzaShackAPI", "version": "1.0.0"},
+"tier": "Gold"}]}`
+
+func writeMigrationTestAPIProject(t *testing.T) string {
+	return writeValidationProject(t, map[string]string{
+		"Meta-information/api.yaml":                        migrateTestLegacyAPIYaml,
+		"Meta-information/swagger.yaml":                    validateTestSwaggerYaml,
+		"Sequences/in-sequence/Custom/addHeader.xml":       "<sequence name=\"addHeader\"/>",
+		"Docs/docs.json":                                   `[{"name": "Guide", "type": "HOWTO", "sourceType": "INLINE"}]`,
+		"Docs/InlineContents/Guide":                        "Order a pizza",
+		"Endpoint-certificates/endpoint_certificates.json": `[{"alias": "pizza", "certificate": "MIIB"}]`,
+		"api_params.yaml":                                  migrateTestLegacyParams,
+	})
+}
+
+func TestMigrateAPIProjectTo400(t *testing.T) {
+	addValidationSchemas(t)
+	project := writeMigrationTestAPIProject(t)
+	defer os.RemoveAll(project)
+
+	migrated, err := MigrateProjects(project, ProjectVersion320, ProjectVersion400, false)
+	assert.Nil(t, err)
+	assert.Len(t, migrated, 1)
+	assert.Equal(t, migrationProjectTypeAPI, migrated[0].Type)
+	assert.Equal(t, []MigrationNote{
+		{File: "Meta-information/api.yaml", Path: "endpointConfig.endpoint_security.production",
+			Message: "the password is not exported, provide it with the security of the params file"},
+		{File: "Meta-information/api.yaml", Path: "endpointConfig.endpoint_security.sandbox",
+			Message: "the password is not exported, provide it with the security of the params file"},
+		{File: "Meta-information/api.yaml", Path: "gatewayLabels",
+			Message: "microgateway labels (edge) are not supported by API Manager 4.0.0, removed"},
+	}, migrated[0].Notes)
+
+	assert.False(t, utils.IsFileExist(filepath.Join(project, legacyMetaInformation)))
+	assert.True(t, utils.IsFileExist(filepath.Join(project, "Definitions", "swagger.yaml")))
+	assert.True(t, utils.IsFileExist(filepath.Join(project, "Docs", "Guide", "Guide")))
+	assert.True(t, utils.IsFileExist(filepath.Join(project, "Docs", "Guide", "document.yaml")))
+
+	content, err := ioutil.ReadFile(filepath.Join(project, "api.yaml"))
+	assert.Nil(t, err)
+	api := v2.APIDefinitionFile{}
+	assert.Nil(t, yaml.Unmarshal(content, &api))
+	assert.Equal(t, "api", api.Type)
+	assert.Equal(t, "v4.0.0", api.ApimVersion)
+	assert.Equal(t, "PizzaShackAPI", api.Data.Name)
+	assert.Equal(t, "/pizzashack", api.Data.Context)
+	assert.Equal(t, "RESTRICTED", api.Data.Visibility)
+	assert.Equal(t, []string{"admin", "publisher"}, api.Data.VisibleRoles)
+	assert.Equal(t, []string{"Unlimited"}, api.Data.Policies)
+	assert.Equal(t, "NONE", api.Data.AccessControl)
+	assert.Equal(t, "ALL_TENANTS", api.Data.SubscriptionAvailability)
+	assert.Len(t, api.Data.MediationPolicies, 1)
+	assert.Len(t, api.Data.Operations, 1)
+	endpointConfig := api.Data.EndpointConfig.(map[interface{}]interface{})
+	assert.Equal(t, "https://localhost:9443/pizzashack",
+		endpointConfig["production_endpoints"].(map[interface{}]interface{})["url"])
+	assert.NotNil(t, endpointConfig["endpoint_security"], "should move the endpoint security")
+
+	deploymentEnvironments, err := ReadProjectFileAsJSON(project, "deployment_environments")
+	assert.Nil(t, err)
+	assert.Equal(t, "Production and Sandbox",
+		deploymentEnvironments["data"].([]interface{})[0].(map[string]interface{})["deploymentEnvironment"])
+
+	certificates, err := ReadProjectFileAsJSON(filepath.Join(project, "Endpoint-certificates"), "endpoint_certificates")
+	assert.Nil(t, err)
+	assert.Equal(t, "endpoint_certificates", certificates["type"])
+
+	params, err := ioutil.ReadFile(filepath.Join(project, utils.ParamFileAPI))
+	assert.Nil(t, err)
+	assert.Contains(t, string(params), "configs:")
+	assert.Contains(t, string(params), "deploymentEnvironment: Production and Sandbox")
+
+	findings, err := ValidateProject(project, nil)
+	assert.Nil(t, err)
+	assert.False(t, HasValidationErrors(findings), "migrated project should be valid")
+}
+
+func TestMigrateProjectsDryRun(t *testing.T) {
+	project := writeMigrationTestAPIProject(t)
+	defer os.RemoveAll(project)
+
+	migrated, err := MigrateProjects(project, ProjectVersion320, ProjectVersion400, true)
+	assert.Nil(t, err)
+	assert.Len(t, migrated[0].Notes, 3)
+	assert.True(t, utils.IsFileExist(filepath.Join(project, legacyMetaInformation, "api.yaml")))
+	assert.False(t, utils.IsFileExist(filepath.Join(project, "api.yaml")), "should not change the project")
+}
+
+func TestMigrateProjectWithTrailingSeparator(t *testing.T) {
+	project := writeMigrationTestAPIProject(t)
+	defer os.RemoveAll(project)
+
+	migrated, err := MigrateProjects(project+string(os.PathSeparator), ProjectVersion320, ProjectVersion400, false)
+	assert.Nil(t, err)
+	assert.Len(t, migrated, 1)
+	assert.True(t, utils.IsFileExist(filepath.Join(project, "api.yaml")))
+	assert.False(t, utils.IsFileExist(filepath.Join(project, legacyMetaInformation)))
+	assertNoMigrationLeftovers(t, project)
+}
+
+func TestMigrateProjectInWorkingDirectory(t *testing.T) {
+	project := writeMigrationTestAPIProject(t)
+	defer os.RemoveAll(project)
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(project))
+	defer os.Chdir(wd)
+
+	migrated, err := MigrateProjects(".", ProjectVersion320, ProjectVersion400, false)
+	assert.Nil(t, err)
+	assert.Len(t, migrated, 1)
+	assert.True(t, utils.IsFileExist(filepath.Join(project, "api.yaml")))
+	assert.False(t, utils.IsFileExist(filepath.Join(project, legacyMetaInformation)))
+	assertNoMigrationLeftovers(t, project)
+}
+
+func TestMigrateProjectArchive(t *testing.T) {
+	project := writeMigrationTestAPIProject(t)
+	defer os.RemoveAll(project)
+	archive := project + ".zip"
+	assert.Nil(t, utils.Zip(project, archive))
+	defer os.Remove(archive)
+
+	migrated, err := MigrateProjects(archive, ProjectVersion320, ProjectVersion400, true)
+	assert.Nil(t, err)
+	assert.Len(t, migrated, 1)
+	assert.Equal(t, archive, migrated[0].Path)
+	assert.Len(t, migrated[0].Notes, 3)
+
+	migrated, err = MigrateProjects(archive, ProjectVersion320, ProjectVersion400, false)
+	assert.Nil(t, err)
+	assert.Len(t, migrated, 1)
+	assert.False(t, utils.IsFileExist(filepath.Join(filepath.Dir(archive), "."+filepath.Base(archive)+".migrating")))
+	assert.False(t, utils.IsFileExist(filepath.Join(filepath.Dir(archive), "."+filepath.Base(archive)+".backup")))
+
+	extracted, err := utils.GetTempCloneFromDirOrZip(archive)
+	assert.Nil(t, err)
+	defer os.RemoveAll(filepath.Dir(extracted))
+	assert.Equal(t, filepath.Base(project), filepath.Base(extracted))
+	assert.True(t, utils.IsFileExist(filepath.Join(extracted, "api.yaml")))
+	assert.False(t, utils.IsFileExist(filepath.Join(extracted, legacyMetaInformation)))
+
+	_, err = MigrateProjects(archive, ProjectVersion320, ProjectVersion400, false)
+	assert.NotNil(t, err, "should fail if the archive is already migrated")
+}
+
+func assertNoMigrationLeftovers(t *testing.T, project string) {
+	entries, err := ioutil.ReadDir(filepath.Dir(project))
+	assert.Nil(t, err)
+	for _, entry := range entries {
+		assert.NotContains(t, entry.Name(), filepath.Base(project)+".", "should not leave the staging or the backup")
+	}
+	entries, err = ioutil.ReadDir(project)
+	assert.Nil(t, err)
+	for _, entry := range entries {
+		assert.NotContains(t, entry.Name(), ".migrating", "should not stage inside the project")
+	}
+}
+
+func TestMigrateAPIProductProjectTo400(t *testing.T) {
+	addValidationSchemas(t)
+	dir, err := ioutil.TempDir("", "apictl-migrate")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	project := filepath.Join(dir, "MyProduct-1.0.0")
+	assert.Nil(t, utils.CopyDir(filepath.Join("..", "cmd", "testdata", "MyProduct-1.0.0"), project))
+
+	migrated, err := MigrateProjects(dir, ProjectVersion320, ProjectVersion400, false)
+	assert.Nil(t, err)
+	assert.Len(t, migrated, 1)
+	assert.Equal(t, migrationProjectTypeAPIProduct, migrated[0].Type)
+
+	content, err := ioutil.ReadFile(filepath.Join(project, "api_product.yaml"))
+	assert.Nil(t, err)
+	apiProduct := v2.APIProductDefinitionFile{}
+	assert.Nil(t, yaml.Unmarshal(content, &apiProduct))
+	assert.Equal(t, "MyProduct", apiProduct.Data.Name)
+	assert.Equal(t, "/myproduct", apiProduct.Data.Context)
+	assert.Len(t, apiProduct.Data.APIs, 2)
+	assert.Equal(t, "PizzaShackAPI", apiProduct.Data.APIs[0].Name)
+
+	for _, path := range []string{project, filepath.Join(project, "APIs", "PizzaShackAPI-1.0.0"),
+		filepath.Join(project, "APIs", "SwaggerPetstore-1.0.5")} {
+		findings, err := ValidateProject(path, nil)
+		assert.Nil(t, err)
+		assert.False(t, HasValidationErrors(findings), path+" should be valid")
+	}
+}
+
+func TestMigrateApplicationProjectTo400(t *testing.T) {
+	project := writeValidationProject(t, map[string]string{"admin_PizzaApp.json": migrateTestLegacyApplication})
+	defer os.RemoveAll(project)
+
+	migrated, err := MigrateProjects(project, ProjectVersion320, ProjectVersion400, false)
+	assert.Nil(t, err)
+	assert.Equal(t, migrationProjectTypeApplication, migrated[0].Type)
+	assert.Equal(t, []MigrationNote{{File: "admin_PizzaApp.json", Path: "keys",
+		Message: "the keys of the Application are not migrated, generate them after importing the Application"}},
+		migrated[0].Notes)
+	assert.False(t, utils.IsFileExist(filepath.Join(project, "admin_PizzaApp.json")))
+
+	content, err := ioutil.ReadFile(filepath.Join(project, "application.yaml"))
+	assert.Nil(t, err)
+	application := v2.ApplicationDefinitionFile{}
+	assert.Nil(t, yaml.Unmarshal(content, &application))
+	assert.Equal(t, "PizzaApp", application.Data.ApplicationInfo.Name)
+	assert.Equal(t, "admin", application.Data.ApplicationInfo.Owner)
+	assert.Equal(t, "JWT", application.Data.ApplicationInfo.TokenType)
+	assert.Equal(t, []v2.ApplicationSubscription{{APIIdentifier: v2.ID{ProviderName: "admin",
+		APIName: "PizzaShackAPI", Version: "1.0.0"}, ThrottlingPolicy: "Gold"}}, application.Data.SubscribedAPIs)
+}
+
+func TestMigrateProjectsErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-migrate")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = MigrateProjects(dir, ProjectVersion400, ProjectVersion320, false)
+	assert.NotNil(t, err, "should fail for an unsupported migration")
+	_, err = MigrateProjects(dir, ProjectVersion320, ProjectVersion400, false)
+	assert.NotNil(t, err, "should fail if there are no projects")
+}
+
+func TestContextTo400(t *testing.T) {
+	assert.Equal(t, "/pizzashack", contextTo400("/pizzashack/1.0.0", "/pizzashack/{version}", "1.0.0"))
+	assert.Equal(t, "/pizzashack", contextTo400("/pizzashack/1.0.0", "", "1.0.0"))
+	assert.Equal(t, "/{version}/pizzashack", contextTo400("/1.0.0/pizzashack", "/{version}/pizzashack", "1.0.0"))
+	assert.Equal(t, "/pizzashack", contextTo400("/t/wso2.com/pizzashack/1.0.0", "/t/wso2.com/pizzashack/{version}",
+		"1.0.0"))
+}
@@ -0,0 +1,136 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// VolatileDefinitionFields are the fields of api.yaml, api_product.yaml and application.yaml which are generated by the
+// server and change without any user modification. These are ignored when comparing two project definitions.
+var VolatileDefinitionFields = []string{
+	"data.id",
+	"data.applicationId",
+	"data.createdTime",
+	"data.lastUpdatedTime",
+	"data.lastUpdatedTimestamp",
+	"data.workflowStatus",
+}
+
+// ExportAPIToTempDir exports the current state of an API from an environment and extracts it to a temporary directory
+// @param accessToken : Access Token for the resource
+// @param environment : Environment where the API is located
+// @param name : Name of the API
+// @param version : Version of the API
+// @param provider : Provider of the API
+// @return the path to the extracted API project or an empty string if the API does not exist in the environment
+// @return error
+func ExportAPIToTempDir(accessToken, environment, name, version, provider string) (string, error) {
+	resp, err := ExportAPIFromEnv(accessToken, name, version, "", provider, utils.DefaultExportFormat, environment,
+		true, false)
+	if err != nil {
+		return "", err
+	}
+	return extractExportedProject(name+"_"+version+utils.ZipFileSuffix, resp)
+}
+
+// ExportAPIProductToTempDir exports the current state of an API Product from an environment and extracts it to a
+// temporary directory
+// @param accessToken : Access Token for the resource
+// @param environment : Environment where the API Product is located
+// @param name : Name of the API Product
+// @param version : Version of the API Product
+// @param provider : Provider of the API Product
+// @return the path to the extracted API Product project or an empty string if it does not exist in the environment
+// @return error
+func ExportAPIProductToTempDir(accessToken, environment, name, version, provider string) (string, error) {
+	resp, err := ExportAPIProductFromEnv(accessToken, name, version, "", provider, utils.DefaultExportFormat,
+		environment, false)
+	if err != nil {
+		return "", err
+	}
+	return extractExportedProject(name+"_"+version+utils.ZipFileSuffix, resp)
+}
+
+// ExportAppToTempDir exports the current state of an Application from an environment and extracts it to a temporary
+// directory
+// @param accessToken : Access Token for the resource
+// @param environment : Environment where the Application is located
+// @param name : Name of the Application
+// @param owner : Owner of the Application
+// @return the path to the extracted Application project or an empty string if it does not exist in the environment
+// @return error
+func ExportAppToTempDir(accessToken, environment, name, owner string) (string, error) {
+	resp, err := ExportAppFromEnv(accessToken, name, owner, utils.DefaultExportFormat, environment, false)
+	if err != nil {
+		return "", err
+	}
+	return extractExportedProject(replaceUserStoreDomainDelimiter(owner)+"_"+name+utils.ZipFileSuffix, resp)
+}
+
+// extractExportedProject writes an export response to a temporary zip file and extracts it.
+// Returns an empty path if the response says the resource was not found.
+func extractExportedProject(zipFileName string, resp *resty.Response) (string, error) {
+	if resp.StatusCode() == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", errors.New(resp.Status() + ": " + string(resp.Body()))
+	}
+	tempZipFile, err := utils.WriteResponseToTempZip(zipFileName, resp)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = os.RemoveAll(filepath.Dir(tempZipFile))
+	}()
+	return utils.GetTempCloneFromDirOrZip(tempZipFile)
+}
+
+// ReadProjectFileAsJSON reads a YAML or JSON file of a project given without the extension (eg: api, api_product,
+// deployment_environments) and returns the content as a generic JSON document. Server generated volatile fields are
+// removed from the returned document. A nil document is returned if the file does not exist.
+func ReadProjectFileAsJSON(projectPath, fileNameWithoutExtension string) (map[string]interface{}, error) {
+	filePath := filepath.Join(projectPath, fileNameWithoutExtension)
+	if !utils.IsFileExist(filePath+".yaml") && !utils.IsFileExist(filePath+".json") {
+		return nil, nil
+	}
+	_, content, err := resolveYamlOrJSON(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseProjectFileContent(content)
+}
+
+// ParseProjectFileContent parses the JSON content of a project file and removes the server generated volatile fields
+func ParseProjectFileContent(content []byte) (map[string]interface{}, error) {
+	document := map[string]interface{}{}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	utils.RemoveFieldsFromJSON(document, VolatileDefinitionFields)
+	return document, nil
+}
@@ -0,0 +1,381 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/box"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Severities of the validation findings
+const (
+	ValidationSeverityError   = "error"
+	ValidationSeverityWarning = "warning"
+)
+
+// Names of the built in validation rules
+const (
+	validationRuleSyntax       = "syntax"
+	validationRuleSchema       = "schema"
+	validationRuleUnknownField = "unknown-field"
+	validationRuleOperations   = "operations"
+)
+
+// File names (without extension) of the project files which are validated
+const (
+	validationDefinitionFileAPI         = "api"
+	validationDefinitionFileAPIProduct  = "api_product"
+	validationDefinitionFileApplication = "application"
+	validationDeploymentEnvironments    = "deployment_environments"
+	validationSwaggerFile               = "swagger"
+)
+
+// API types of which the operations are defined by a swagger or an OAS3 definition
+var apiTypesWithSwagger = []string{"", "HTTP", "SOAPTOREST", "SOAP"}
+
+// HTTP methods which can be used as operations in a swagger or an OAS3 definition
+var swaggerOperationVerbs = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// matches the line number in yaml parsing errors (eg: yaml: line 4: mapping values are not allowed in this context)
+var yamlErrorLineRegex = regexp.MustCompile(`line (\d+)`)
+
+// ValidationFinding is an issue found while validating a project
+type ValidationFinding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// String returns the finding in the format file:line: severity: [rule] path: message
+func (f ValidationFinding) String() string {
+	location := f.File
+	if f.Line > 0 {
+		location += ":" + strconv.Itoa(f.Line)
+	}
+	message := f.Message
+	if f.Path != "" {
+		message = f.Path + ": " + message
+	}
+	return location + ": " + f.Severity + ": [" + f.Rule + "] " + message
+}
+
+// projectFile is a YAML or JSON file of a project
+type projectFile struct {
+	// name of the file relative to the project (eg: api.yaml)
+	name string
+	// original content of the file used to locate the line numbers
+	content []byte
+	// content of the file converted to JSON
+	jsonContent []byte
+}
+
+// ValidateProject validates an API, API Product or Application project against the schemas of the project files,
+// cross-checks the operations of an API with its swagger or OAS3 definition and applies the given lint rules
+// @param projectPath : Path to the project directory
+// @param ruleset : Lint rules to apply on the project. Can be nil
+// @return the findings sorted by the file and the line
+// @return error if the project cannot be read
+func ValidateProject(projectPath string, ruleset *LintRuleset) ([]ValidationFinding, error) {
+	definitionFileName, err := getProjectDefinitionFileName(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	definition, findings, err := readProjectFileForValidation(projectPath, definitionFileName)
+	if err != nil || definition == nil {
+		return findings, err
+	}
+
+	schemaFindings, err := validateWithSchema(definition, definitionFileName)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, schemaFindings...)
+
+	if definitionFileName != validationDefinitionFileApplication {
+		deploymentEnvs, syntaxFindings, err := readProjectFileForValidation(projectPath,
+			validationDeploymentEnvironments)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, syntaxFindings...)
+		if deploymentEnvs != nil {
+			schemaFindings, err := validateWithSchema(deploymentEnvs, validationDeploymentEnvironments)
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, schemaFindings...)
+		}
+	}
+
+	if definitionFileName == validationDefinitionFileAPI {
+		operationFindings, err := validateAPIOperations(projectPath, definition)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, operationFindings...)
+	}
+
+	if ruleset != nil {
+		lintFindings, err := ruleset.apply(projectPath, definitionFileName)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, lintFindings...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+	return findings, nil
+}
+
+// HasValidationErrors returns whether any of the findings is an error
+func HasValidationErrors(findings []ValidationFinding) bool {
+	for _, finding := range findings {
+		if finding.Severity == ValidationSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// validateProjectBeforeImport validates a project before importing it. The warnings are logged and the errors are
+// returned as a single validation error.
+func validateProjectBeforeImport(projectPath string) error {
+	utils.Logln(utils.LogPrefixInfo + "Validating the project " + projectPath)
+	findings, err := ValidateProject(projectPath, nil)
+	if err != nil {
+		return err
+	}
+	var errorMessages []string
+	for _, finding := range findings {
+		if finding.Severity == ValidationSeverityError {
+			errorMessages = append(errorMessages, finding.String())
+		} else {
+			utils.Logln(utils.LogPrefixWarning + finding.String())
+		}
+	}
+	if len(errorMessages) > 0 {
+		return utils.NewValidationError("project validation failed (use --skip-validation to skip):\n" +
+			strings.Join(errorMessages, "\n"))
+	}
+	return nil
+}
+
+// getProjectDefinitionFileName returns the name of the definition file of the project (without the extension)
+func getProjectDefinitionFileName(projectPath string) (string, error) {
+	for _, fileName := range []string{validationDefinitionFileAPI, validationDefinitionFileAPIProduct,
+		validationDefinitionFileApplication} {
+		if findProjectFile(projectPath, fileName) != "" {
+			return fileName, nil
+		}
+	}
+	return "", errors.New(projectPath + " is not a valid project. api.yaml, api_product.yaml or application.yaml " +
+		"is not found")
+}
+
+// findProjectFile returns the name of the YAML or JSON file (given without the extension) relative to the project.
+// Returns an empty string if the file does not exist.
+func findProjectFile(projectPath, fileNameWithoutExtension string) string {
+	for _, extension := range []string{".yaml", ".yml", ".json"} {
+		if utils.IsFileExist(filepath.Join(projectPath, fileNameWithoutExtension+extension)) {
+			return filepath.ToSlash(fileNameWithoutExtension + extension)
+		}
+	}
+	return ""
+}
+
+// readProjectFileForValidation reads a YAML or JSON file (given without the extension) of a project. A nil file is
+// returned if the file does not exist or if it cannot be parsed. Parsing failures are returned as findings.
+func readProjectFileForValidation(projectPath, fileNameWithoutExtension string) (*projectFile, []ValidationFinding,
+	error) {
+	name := findProjectFile(projectPath, fileNameWithoutExtension)
+	if name == "" {
+		return nil, nil, nil
+	}
+	content, err := ioutil.ReadFile(filepath.Join(projectPath, filepath.FromSlash(name)))
+	if err != nil {
+		return nil, nil, err
+	}
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		finding := ValidationFinding{File: name, Rule: validationRuleSyntax, Severity: ValidationSeverityError,
+			Message: err.Error()}
+		if match := yamlErrorLineRegex.FindStringSubmatch(err.Error()); match != nil {
+			finding.Line, _ = strconv.Atoi(match[1])
+		}
+		return nil, []ValidationFinding{finding}, nil
+	}
+	return &projectFile{name: name, content: content, jsonContent: jsonContent}, nil, nil
+}
+
+// validateWithSchema validates a project file against the schema of the given name. Unknown fields are reported as
+// warnings since they are ignored by the server.
+func validateWithSchema(file *projectFile, schemaName string) ([]ValidationFinding, error) {
+	schema, ok := box.Get("/schemas/" + schemaName + ".json")
+	if !ok {
+		return nil, errors.New("schema of " + schemaName + " is not found")
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema),
+		gojsonschema.NewBytesLoader(file.jsonContent))
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []ValidationFinding
+	for _, resultError := range result.Errors() {
+		// the first element of the context is the root
+		path := strings.Split(resultError.Context().String("\x00"), "\x00")[1:]
+		finding := ValidationFinding{
+			File:     file.name,
+			Rule:     validationRuleSchema,
+			Severity: ValidationSeverityError,
+			Message:  resultError.Description(),
+		}
+		if resultError.Type() == "additional_property_not_allowed" {
+			path = append(path, fmt.Sprint(resultError.Details()["property"]))
+			finding.Rule = validationRuleUnknownField
+			finding.Severity = ValidationSeverityWarning
+			finding.Message = "unknown field, it may be misspelled"
+		}
+		finding.Line = utils.FindYAMLLine(file.content, path)
+		finding.Path = strings.Join(path, ".")
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// validateAPIOperations cross-checks the operations of api.yaml with the paths of the swagger or the OAS3 definition.
+// Operations which are not in the definition are errors while the operations of the definition which are not in
+// api.yaml are warnings.
+func validateAPIOperations(projectPath string, definition *projectFile) ([]ValidationFinding, error) {
+	var api struct {
+		Data struct {
+			Type       string `json:"type"`
+			Operations []struct {
+				Target string `json:"target"`
+				Verb   string `json:"verb"`
+			} `json:"operations"`
+		} `json:"data"`
+	}
+	// type mismatches are already reported by the schema validation
+	_ = json.Unmarshal(definition.jsonContent, &api)
+	if !containsString(apiTypesWithSwagger, strings.ToUpper(api.Data.Type)) {
+		return nil, nil
+	}
+
+	definitionsPath := filepath.Join(projectPath, utils.InitProjectDefinitions)
+	swagger, findings, err := readProjectFileForValidation(definitionsPath, validationSwaggerFile)
+	if err != nil || findings != nil {
+		for i := range findings {
+			findings[i].File = utils.InitProjectDefinitions + "/" + findings[i].File
+		}
+		return findings, err
+	}
+	if swagger == nil {
+		return []ValidationFinding{{
+			File:     definition.name,
+			Rule:     validationRuleOperations,
+			Severity: ValidationSeverityError,
+			Message:  utils.InitProjectDefinitions + "/swagger.yaml is not found",
+		}}, nil
+	}
+	swagger.name = utils.InitProjectDefinitions + "/" + swagger.name
+
+	var swaggerDoc struct {
+		Paths map[string]map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(swagger.jsonContent, &swaggerDoc); err != nil {
+		return []ValidationFinding{{File: swagger.name, Rule: validationRuleSyntax, Severity: ValidationSeverityError,
+			Message: "invalid paths: " + err.Error()}}, nil
+	}
+	swaggerOperations := make(map[string]bool)
+	for target, pathItem := range swaggerDoc.Paths {
+		for verb := range pathItem {
+			if containsString(swaggerOperationVerbs, strings.ToLower(verb)) {
+				swaggerOperations[strings.ToUpper(verb)+" "+target] = true
+			}
+		}
+	}
+
+	apiOperations := make(map[string]bool)
+	for i, operation := range api.Data.Operations {
+		key := strings.ToUpper(operation.Verb) + " " + operation.Target
+		apiOperations[key] = true
+		if !swaggerOperations[key] {
+			path := []string{"data", "operations", strconv.Itoa(i)}
+			findings = append(findings, ValidationFinding{
+				File:     definition.name,
+				Line:     utils.FindYAMLLine(definition.content, path),
+				Path:     strings.Join(path, "."),
+				Rule:     validationRuleOperations,
+				Severity: ValidationSeverityError,
+				Message:  "operation " + key + " is not defined in " + swagger.name,
+			})
+		}
+	}
+	// an API without operations gets the operations from the definition
+	if len(api.Data.Operations) == 0 {
+		return findings, nil
+	}
+	for target, pathItem := range swaggerDoc.Paths {
+		for verb := range pathItem {
+			key := strings.ToUpper(verb) + " " + target
+			if swaggerOperations[key] && !apiOperations[key] {
+				path := []string{"paths", target, verb}
+				findings = append(findings, ValidationFinding{
+					File:     swagger.name,
+					Line:     utils.FindYAMLLine(swagger.content, path),
+					Path:     strings.Join(path, "."),
+					Rule:     validationRuleOperations,
+					Severity: ValidationSeverityWarning,
+					Message:  "operation " + key + " is not defined in " + definition.name,
+				})
+			}
+		}
+	}
+	return findings, nil
+}
+
+// containsString returns whether the slice contains the given element
+func containsString(slice []string, element string) bool {
+	for _, item := range slice {
+		if item == element {
+			return true
+		}
+	}
+	return false
+}
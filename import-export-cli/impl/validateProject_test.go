@@ -0,0 +1,322 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/box"
+)
+
+const validateTestAPIYaml = `type: api
+data:
+  name: PizzaShackAPI
+  context: /pizzashack
+  version: 1.0.0
+  lifeCycleStatus: PUBLISHED
+  type: HTTP
+  tags:
+    - pizza
+  operations:
+    - target: /menu
+      verb: GET
+    - target: /order
+      verb: POST
+`
+
+const validateTestSwaggerYaml = `openapi: 3.0.1
+paths:
+  /menu:
+    get: {}
+  /order:
+    post: {}
+`
+
+// addValidationSchemas adds the schemas to the box since the generated resources are not available in the tests
+func addValidationSchemas(t *testing.T) {
+	for _, name := range []string{"api", "api_product", "application", "deployment_environments"} {
+		content, err := ioutil.ReadFile(filepath.Join("..", "box", "resources", "schemas", name+".json"))
+		assert.Nil(t, err)
+		box.Add("/schemas/"+name+".json", content)
+	}
+}
+
+func writeValidationProject(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "apictl-validate")
+	assert.Nil(t, err)
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(path, []byte(content), os.ModePerm))
+	}
+	return dir
+}
+
+func TestValidateProjectValidAPI(t *testing.T) {
+	addValidationSchemas(t)
+	project := writeValidationProject(t, map[string]string{
+		"api.yaml":                 validateTestAPIYaml,
+		"Definitions/swagger.yaml": validateTestSwaggerYaml,
+		"deployment_environments.yaml": "type: deployment_environments\ndata:\n" +
+			"  - deploymentEnvironment: Default\n    displayOnDevportal: true\n",
+	})
+	defer os.RemoveAll(project)
+
+	findings, err := ValidateProject(project, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, findings)
+	assert.Nil(t, validateProjectBeforeImport(project))
+}
+
+func TestValidateProjectSchemaErrors(t *testing.T) {
+	addValidationSchemas(t)
+	project := writeValidationProject(t, map[string]string{
+		"api.yaml": "type: api\ndata:\n  name: PizzaShackAPI\n  context: /pizzashack\n  version: 1.0.0\n" +
+			"  lifeCycleStatus: LIVE\n  contxt: /typo\n",
+		"Definitions/swagger.yaml": validateTestSwaggerYaml,
+	})
+	defer os.RemoveAll(project)
+
+	findings, err := ValidateProject(project, nil)
+	assert.Nil(t, err)
+	assert.True(t, HasValidationErrors(findings))
+	assert.Equal(t, 2, len(findings))
+
+	assert.Equal(t, "api.yaml", findings[0].File)
+	assert.Equal(t, 6, findings[0].Line)
+	assert.Equal(t, "data.lifeCycleStatus", findings[0].Path)
+	assert.Equal(t, ValidationSeverityError, findings[0].Severity)
+
+	assert.Equal(t, 7, findings[1].Line)
+	assert.Equal(t, "data.contxt", findings[1].Path)
+	assert.Equal(t, validationRuleUnknownField, findings[1].Rule)
+	assert.Equal(t, ValidationSeverityWarning, findings[1].Severity)
+
+	err = validateProjectBeforeImport(project)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "api.yaml:6: error: [schema] data.lifeCycleStatus")
+}
+
+// newValidationTestServer returns a server which counts the projects uploaded to it
+func newValidationTestServer(uploads *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*uploads++
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestImportAPIProductValidatesProject(t *testing.T) {
+	addValidationSchemas(t)
+	project := writeValidationProject(t, map[string]string{
+		"api_product.yaml": "type: api_product\ndata:\n  name: LeasingAPIProduct\n  context: /leasing\n" +
+			"  apis: []\n  state: LIVE\n",
+	})
+	defer os.RemoveAll(project)
+	uploads := 0
+	server := newValidationTestServer(&uploads)
+	defer server.Close()
+
+	err := ImportAPIProduct("access-token", server.URL, "dev", project, "", false, false, false, true, false,
+		false, false, false)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "api_product.yaml:6: error: [schema] data.state")
+	assert.Equal(t, 0, uploads, "should not upload an invalid API Product project")
+
+	err = ImportAPIProduct("access-token", server.URL, "dev", project, "", false, false, false, true, false,
+		false, false, true)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, uploads, "should upload the project when the validation is skipped")
+}
+
+func TestImportAPIWithParamsValidatesProject(t *testing.T) {
+	addValidationSchemas(t)
+	project := writeValidationProject(t, map[string]string{
+		"api.yaml":                 validateTestAPIYaml,
+		"Definitions/swagger.yaml": validateTestSwaggerYaml,
+	})
+	defer os.RemoveAll(project)
+	paramsFile := filepath.Join(project, "..", filepath.Base(project)+"_params.yaml")
+	assert.Nil(t, ioutil.WriteFile(paramsFile, []byte("environments:\n  - name: dev\n    configs:\n"+
+		"      endpoints:\n        production:\n          url: https://dev.pizzashack\n"), os.ModePerm))
+	defer os.Remove(paramsFile)
+	uploads := 0
+	server := newValidationTestServer(&uploads)
+	defer server.Close()
+
+	err := ImportAPI("access-token", server.URL, "dev", project, paramsFile, false, false, false, false, false,
+		false)
+	assert.Nil(t, err, "should validate the project before the params are applied")
+	assert.Equal(t, 1, uploads)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project, "api.yaml"),
+		[]byte(validateTestAPIYaml+"  lifeCycleStatus: LIVE\n"), os.ModePerm))
+	err = ImportAPI("access-token", server.URL, "dev", project, paramsFile, false, false, false, false, false,
+		false)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "data.lifeCycleStatus")
+	assert.Equal(t, 1, uploads, "should not upload an invalid API project")
+}
+
+func TestImportAPIProductWithParamsValidatesProject(t *testing.T) {
+	addValidationSchemas(t)
+	project := writeValidationProject(t, map[string]string{
+		"api_product.yaml": "type: api_product\ndata:\n  name: LeasingAPIProduct\n  context: /leasing\n" +
+			"  apis:\n    - name: PizzaShackAPI\n",
+	})
+	defer os.RemoveAll(project)
+	paramsFile := filepath.Join(project, "..", filepath.Base(project)+"_params.yaml")
+	assert.Nil(t, ioutil.WriteFile(paramsFile, []byte("environments:\n  - name: dev\n    configs:\n"+
+		"      policies:\n        - Gold\n"), os.ModePerm))
+	defer os.Remove(paramsFile)
+	uploads := 0
+	server := newValidationTestServer(&uploads)
+	defer server.Close()
+
+	err := ImportAPIProduct("access-token", server.URL, "dev", project, paramsFile, false, false, false, true,
+		false, false, false, false)
+	assert.Nil(t, err, "should validate the project before the params are applied")
+	assert.Equal(t, 1, uploads)
+}
+
+func TestImportApplicationValidatesProject(t *testing.T) {
+	addValidationSchemas(t)
+	project := writeValidationProject(t, map[string]string{
+		"application.yaml": "type: application\ndata:\n  subscribedAPIs: []\n",
+	})
+	defer os.RemoveAll(project)
+	uploads := 0
+	server := newValidationTestServer(&uploads)
+	defer server.Close()
+
+	_, err := ImportApplication("access-token", server.URL, project, "admin", false, true, true, true, false, false)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "application.yaml")
+	assert.Contains(t, err.Error(), "applicationInfo")
+	assert.Equal(t, 0, uploads, "should not upload an invalid Application project")
+
+	_, err = ImportApplication("access-token", server.URL, project, "admin", false, true, true, true, false, true)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, uploads, "should upload the project when the validation is skipped")
+}
+
+func TestValidateProjectSyntaxError(t *testing.T) {
+	addValidationSchemas(t)
+	project := writeValidationProject(t, map[string]string{
+		"api.yaml": "type: api\ndata:\n  name: PizzaShackAPI\n   context: /pizzashack\n",
+	})
+	defer os.RemoveAll(project)
+
+	findings, err := ValidateProject(project, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(findings))
+	assert.Equal(t, validationRuleSyntax, findings[0].Rule)
+	assert.Equal(t, 4, findings[0].Line)
+}
+
+func TestValidateProjectOperations(t *testing.T) {
+	addValidationSchemas(t)
+	project := writeValidationProject(t, map[string]string{
+		"api.yaml":                 validateTestAPIYaml + "    - target: /menu\n      verb: DELETE\n",
+		"Definitions/swagger.yaml": validateTestSwaggerYaml + "  /customers:\n    get: {}\n",
+	})
+	defer os.RemoveAll(project)
+
+	findings, err := ValidateProject(project, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(findings))
+
+	assert.Equal(t, "Definitions/swagger.yaml", findings[0].File)
+	assert.Equal(t, 8, findings[0].Line)
+	assert.Equal(t, ValidationSeverityWarning, findings[0].Severity)
+	assert.Contains(t, findings[0].Message, "GET /customers")
+
+	assert.Equal(t, "api.yaml", findings[1].File)
+	assert.Equal(t, 15, findings[1].Line)
+	assert.Equal(t, validationRuleOperations, findings[1].Rule)
+	assert.Equal(t, ValidationSeverityError, findings[1].Severity)
+	assert.Contains(t, findings[1].Message, "DELETE /menu")
+}
+
+func TestValidateProjectLintRules(t *testing.T) {
+	addValidationSchemas(t)
+	project := writeValidationProject(t, map[string]string{
+		"api.yaml":                 validateTestAPIYaml,
+		"Definitions/swagger.yaml": validateTestSwaggerYaml,
+		"lint-rules.yaml": `rules:
+  - name: api-name-suffix
+    severity: error
+    projectTypes: [api]
+    path: data.name
+    pattern: API$
+  - name: required-tags
+    description: APIs should be tagged with the team
+    path: data.tags
+    required: true
+    contains: [team-pizza]
+  - name: operation-verbs
+    path: data.operations.*.verb
+    enum: [GET]
+  - name: oas3
+    file: Definitions/swagger
+    path: openapi
+    pattern: ^3\.
+  - name: product-only
+    projectTypes: [api_product]
+    path: data.apis
+    required: true
+`,
+	})
+	defer os.RemoveAll(project)
+
+	ruleset, err := LoadLintRuleset(filepath.Join(project, "lint-rules.yaml"))
+	assert.Nil(t, err)
+	findings, err := ValidateProject(project, ruleset)
+	assert.Nil(t, err)
+	assert.False(t, HasValidationErrors(findings))
+	assert.Equal(t, 2, len(findings))
+
+	assert.Equal(t, "required-tags", findings[0].Rule)
+	assert.Equal(t, 8, findings[0].Line)
+	assert.Equal(t, "APIs should be tagged with the team (should contain team-pizza)", findings[0].Message)
+
+	assert.Equal(t, "operation-verbs", findings[1].Rule)
+	assert.Equal(t, 14, findings[1].Line)
+	assert.Equal(t, "data.operations.1.verb", findings[1].Path)
+}
+
+func TestLoadLintRulesetInvalid(t *testing.T) {
+	project := writeValidationProject(t, map[string]string{
+		"no-path.yaml":      "rules:\n  - name: no-path\n",
+		"bad-pattern.yaml":  "rules:\n  - name: bad-pattern\n    path: data.name\n    pattern: '['\n",
+		"bad-severity.yaml": "rules:\n  - name: bad-severity\n    path: data.name\n    severity: fatal\n",
+		"unknown-key.yaml":  "rules:\n  - name: unknown-key\n    path: data.name\n    regex: a\n",
+	})
+	defer os.RemoveAll(project)
+
+	for _, file := range []string{"no-path.yaml", "bad-pattern.yaml", "bad-severity.yaml", "unknown-key.yaml"} {
+		_, err := LoadLintRuleset(filepath.Join(project, file))
+		assert.NotNil(t, err, file)
+	}
+}
@@ -0,0 +1,579 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// wildcard of a workspace path pattern which matches any number of directories
+const workspaceRecursiveWildcard = "**"
+
+// project types that can be declared in the workspace manifest
+var workspaceProjectTypes = map[string]string{
+	"api":         utils.ProjectTypeApi,
+	"api_product": utils.ProjectTypeApiProduct,
+	"application": utils.ProjectTypeApplication,
+}
+
+// projects are deployed by type in this order: APIs, then API Products and then Applications
+var workspaceProjectTypeRanks = map[string]int{
+	utils.ProjectTypeApi:         0,
+	utils.ProjectTypeApiProduct:  1,
+	utils.ProjectTypeApplication: 2,
+}
+
+// params file of a project when it is not declared in the workspace manifest
+var workspaceDefaultParamsFiles = map[string]string{
+	utils.ProjectTypeApi:         utils.ParamFileAPI,
+	utils.ProjectTypeApiProduct:  utils.ParamFileAPIProduct,
+	utils.ProjectTypeApplication: utils.ParamFileApplication,
+}
+
+// definition file (without the extension) of each project type
+var workspaceDefinitionFiles = map[string]string{
+	utils.ProjectTypeApi:         "api",
+	utils.ProjectTypeApiProduct:  "api_product",
+	utils.ProjectTypeApplication: "application",
+}
+
+// Workspace is the apictl.yaml manifest in the root of a multi-project repository
+//
+//	projects:
+//	  - path: apis/*
+//	    type: api
+//	  - path: products/PizzaProduct
+//	    params: params/api_product_params.yaml
+//	    deployOrder: 1
+//	    dependsOn:
+//	      - apis/PizzaShackAPI
+//	ignore:
+//	  - drafts
+//	environments:
+//	  prod: production
+type Workspace struct {
+	// Projects declares the projects of the workspace. A directory belongs to the first entry matching it
+	Projects []WorkspaceProjectEntry `yaml:"projects"`
+	// Ignore is the list of path patterns which are never treated as projects or project changes
+	Ignore []string `yaml:"ignore"`
+	// Environments maps aliases to the names of the environments added with add env
+	Environments map[string]string `yaml:"environments"`
+
+	// BasePath is the directory of the manifest. All the paths of the manifest are relative to it
+	BasePath string `yaml:"-"`
+}
+
+// WorkspaceProjectEntry declares one or more projects of a workspace
+type WorkspaceProjectEntry struct {
+	// Path is a slash separated path pattern of the project directories. * matches within a directory name and **
+	// matches any number of directories
+	Path string `yaml:"path"`
+	// Type of the projects (api, api_product or application). Detected from the params or definition file if empty
+	Type string `yaml:"type"`
+	// Params is the path of the params file relative to the project directory. Defaults to <type>_params.yaml
+	Params string `yaml:"params"`
+	// DeployOrder orders the projects of the same type. Projects with a lower deploy order are deployed first
+	DeployOrder int `yaml:"deployOrder"`
+	// DependsOn is the list of paths of the projects which should be deployed before these projects
+	DependsOn []string `yaml:"dependsOn"`
+}
+
+// WorkspaceProject is a project of a workspace discovered from the manifest
+type WorkspaceProject struct {
+	// Path is the absolute path of the project directory
+	Path string
+	// RelativePath is the slash separated path of the project directory relative to the workspace
+	RelativePath string
+	// Type is the project type (utils.ProjectTypeApi, ..)
+	Type string
+	// ParamsFile is the absolute path of the params file of the project. The file may not exist
+	ParamsFile string
+	// DeployOrder is the deploy order declared for the project
+	DeployOrder int
+	// DependsOn is the list of relative paths of the projects which should be deployed before this project. It
+	// includes the declared dependencies and the APIs of an API Product or the subscriptions of an Application
+	DependsOn []string
+}
+
+// workspaceDefinition holds the fields of the project definitions used to link the projects of a workspace
+type workspaceDefinition struct {
+	Data struct {
+		Name           string                       `json:"name"`
+		Version        string                       `json:"version"`
+		APIs           []v2.APIProductAPI           `json:"apis"`
+		SubscribedAPIs []v2.ApplicationSubscription `json:"subscribedAPIs"`
+	} `json:"data"`
+}
+
+// LoadWorkspace reads a workspace manifest
+// @param manifestPath : Path to the apictl.yaml file
+// @return the workspace
+// @return error if the file cannot be read or the manifest is invalid
+func LoadWorkspace(manifestPath string) (*Workspace, error) {
+	content, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	workspace := &Workspace{}
+	if err := yaml.UnmarshalStrict(content, workspace); err != nil {
+		return nil, fmt.Errorf("invalid workspace manifest %s: %v", manifestPath, err)
+	}
+	basePath, err := filepath.Abs(filepath.Dir(manifestPath))
+	if err != nil {
+		return nil, err
+	}
+	workspace.BasePath = basePath
+	if err := workspace.init(); err != nil {
+		return nil, fmt.Errorf("invalid workspace manifest %s: %v", manifestPath, err)
+	}
+	return workspace, nil
+}
+
+// FindWorkspace looks for a workspace manifest in the given directory and its parents
+// @param dir : Directory to start looking from
+// @return the workspace or nil if there is no manifest
+// @return error if the manifest cannot be read
+func FindWorkspace(dir string) (*Workspace, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		manifestPath := filepath.Join(dir, utils.WorkspaceManifestFile)
+		if utils.IsFileExist(manifestPath) {
+			return LoadWorkspace(manifestPath)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// init validates the manifest and normalizes the declared paths
+func (w *Workspace) init() error {
+	if len(w.Projects) == 0 {
+		return errors.New("no projects are declared")
+	}
+	for i := range w.Projects {
+		entry := &w.Projects[i]
+		if entry.Path == "" {
+			return fmt.Errorf("path of project %d is required", i+1)
+		}
+		entry.Path = cleanWorkspacePath(entry.Path)
+		if err := validateWorkspacePattern(entry.Path); err != nil {
+			return err
+		}
+		if _, ok := workspaceProjectTypes[entry.Type]; entry.Type != "" && !ok {
+			return errors.New("type of " + entry.Path + " should be api, api_product or application")
+		}
+		for j, dependency := range entry.DependsOn {
+			entry.DependsOn[j] = cleanWorkspacePath(dependency)
+		}
+	}
+	for i, pattern := range w.Ignore {
+		w.Ignore[i] = cleanWorkspacePath(pattern)
+		if err := validateWorkspacePattern(w.Ignore[i]); err != nil {
+			return err
+		}
+	}
+	for alias, environment := range w.Environments {
+		if environment == "" {
+			return errors.New("environment of the alias " + alias + " is required")
+		}
+	}
+	return nil
+}
+
+// ResolveEnvironment returns the environment name of an alias declared in the manifest. Any other name is returned as
+// it is
+func (w *Workspace) ResolveEnvironment(name string) string {
+	if environment, ok := w.Environments[name]; ok {
+		return environment
+	}
+	return name
+}
+
+// IsIgnored returns whether a slash separated path relative to the workspace, or any of its parents, is ignored
+func (w *Workspace) IsIgnored(relativePath string) bool {
+	segments := strings.Split(cleanWorkspacePath(relativePath), "/")
+	for i := range segments {
+		parent := strings.Join(segments[:i+1], "/")
+		if segments[i] == ".git" || parent == utils.WorkspaceManifestFile {
+			return true
+		}
+		for _, pattern := range w.Ignore {
+			if matchWorkspacePattern(pattern, parent) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DiscoverProjects finds the projects declared in the manifest and links their dependencies
+// @return the projects in the order they should be deployed
+// @return error if a declared project is not found or the dependencies are invalid
+func (w *Workspace) DiscoverProjects() ([]*WorkspaceProject, error) {
+	var projects []*WorkspaceProject
+	matched := make([]bool, len(w.Projects))
+	err := filepath.Walk(w.BasePath, func(dirPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() || dirPath == w.BasePath {
+			return nil
+		}
+		relativePath := filepath.ToSlash(strings.TrimPrefix(dirPath, w.BasePath+string(os.PathSeparator)))
+		if w.IsIgnored(relativePath) {
+			return filepath.SkipDir
+		}
+		for i, entry := range w.Projects {
+			if !matchWorkspacePattern(entry.Path, relativePath) {
+				continue
+			}
+			project := newWorkspaceProject(entry, dirPath, relativePath)
+			if project == nil {
+				continue
+			}
+			matched[i] = true
+			projects = append(projects, project)
+			// projects are not nested, so the directories of a project (eg: APIs of an API Product) are skipped
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range w.Projects {
+		if !matched[i] && !strings.ContainsAny(entry.Path, "*?[") {
+			return nil, utils.NewValidationError("project " + entry.Path + " declared in " +
+				utils.WorkspaceManifestFile + " is not found or is not an API, API Product or Application project")
+		}
+	}
+	if err := linkWorkspaceProjects(projects); err != nil {
+		return nil, err
+	}
+	var ordered []*WorkspaceProject
+	for _, stage := range WorkspaceDeployStages(projects) {
+		ordered = append(ordered, stage...)
+	}
+	return ordered, nil
+}
+
+// FindWorkspaceProject returns the project containing the given path
+// @param projects : Projects of the workspace
+// @param absolutePath : Absolute path of a project directory or a file in it
+// @return the project or nil if the path does not belong to a project
+func FindWorkspaceProject(projects []*WorkspaceProject, absolutePath string) *WorkspaceProject {
+	for _, project := range projects {
+		if absolutePath == project.Path || strings.HasPrefix(absolutePath, project.Path+string(os.PathSeparator)) {
+			return project
+		}
+	}
+	return nil
+}
+
+// WorkspaceDeployStages groups projects into the stages they should be deployed in. The projects of a stage have the
+// same type and do not depend on each other, so they can be deployed in parallel. Only the dependencies between the
+// given projects are considered as the other projects are not deployed.
+// @param projects : Projects to deploy
+// @return the stages in the order they should be deployed
+func WorkspaceDeployStages(projects []*WorkspaceProject) [][]*WorkspaceProject {
+	byPath := make(map[string]*WorkspaceProject)
+	for _, project := range projects {
+		byPath[project.RelativePath] = project
+	}
+	levels := make(map[string]int)
+	var levelOf func(project *WorkspaceProject, visiting map[string]bool) int
+	levelOf = func(project *WorkspaceProject, visiting map[string]bool) int {
+		if level, ok := levels[project.RelativePath]; ok {
+			return level
+		}
+		visiting[project.RelativePath] = true
+		level := 0
+		for _, dependencyPath := range project.DependsOn {
+			dependency := byPath[dependencyPath]
+			// dependencies of an earlier type or deploy order are already deployed in an earlier stage
+			if dependency == nil || visiting[dependencyPath] || dependency.Type != project.Type ||
+				dependency.DeployOrder != project.DeployOrder {
+				continue
+			}
+			if dependencyLevel := levelOf(dependency, visiting) + 1; dependencyLevel > level {
+				level = dependencyLevel
+			}
+		}
+		delete(visiting, project.RelativePath)
+		levels[project.RelativePath] = level
+		return level
+	}
+
+	sorted := append([]*WorkspaceProject{}, projects...)
+	for _, project := range sorted {
+		levelOf(project, map[string]bool{})
+	}
+	stageKey := func(project *WorkspaceProject) []int {
+		return []int{workspaceProjectTypeRanks[project.Type], project.DeployOrder, levels[project.RelativePath]}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		keyI, keyJ := stageKey(sorted[i]), stageKey(sorted[j])
+		for k := range keyI {
+			if keyI[k] != keyJ[k] {
+				return keyI[k] < keyJ[k]
+			}
+		}
+		return sorted[i].RelativePath < sorted[j].RelativePath
+	})
+
+	var stages [][]*WorkspaceProject
+	for i, project := range sorted {
+		if i == 0 || fmt.Sprint(stageKey(sorted[i-1])) != fmt.Sprint(stageKey(project)) {
+			stages = append(stages, nil)
+		}
+		stages[len(stages)-1] = append(stages[len(stages)-1], project)
+	}
+	return stages
+}
+
+// newWorkspaceProject creates a project of a directory matched by a manifest entry
+// Returns nil if the directory does not have the files of a project
+func newWorkspaceProject(entry WorkspaceProjectEntry, dirPath, relativePath string) *WorkspaceProject {
+	paramsFile := filepath.FromSlash(entry.Params)
+	if paramsFile != "" && !filepath.IsAbs(paramsFile) {
+		paramsFile = filepath.Join(dirPath, paramsFile)
+	}
+	projectType := detectWorkspaceProjectType(dirPath)
+	if projectType == utils.ProjectTypeNone && (paramsFile == "" || !utils.IsFileExist(paramsFile)) {
+		return nil
+	}
+	// the declared type takes precedence over the detected type
+	if entry.Type != "" {
+		projectType = workspaceProjectTypes[entry.Type]
+	}
+	if projectType == utils.ProjectTypeNone {
+		return nil
+	}
+	if paramsFile == "" {
+		paramsFile = filepath.Join(dirPath, workspaceDefaultParamsFiles[projectType])
+	}
+	// the dependencies of an entry matching several projects may include one of the projects itself
+	var dependsOn []string
+	for _, dependency := range entry.DependsOn {
+		if dependency != relativePath {
+			dependsOn = append(dependsOn, dependency)
+		}
+	}
+	return &WorkspaceProject{
+		Path:         dirPath,
+		RelativePath: relativePath,
+		Type:         projectType,
+		ParamsFile:   paramsFile,
+		DeployOrder:  entry.DeployOrder,
+		DependsOn:    dependsOn,
+	}
+}
+
+// detectWorkspaceProjectType detects the type of a project from its params file or its definition file
+func detectWorkspaceProjectType(dirPath string) string {
+	for _, projectType := range []string{utils.ProjectTypeApi, utils.ProjectTypeApiProduct,
+		utils.ProjectTypeApplication} {
+		if utils.IsFileExist(filepath.Join(dirPath, workspaceDefaultParamsFiles[projectType])) {
+			return projectType
+		}
+	}
+	for _, projectType := range []string{utils.ProjectTypeApi, utils.ProjectTypeApiProduct,
+		utils.ProjectTypeApplication} {
+		definitionFile := filepath.Join(dirPath, workspaceDefinitionFiles[projectType])
+		if utils.IsFileExist(definitionFile+".yaml") || utils.IsFileExist(definitionFile+".json") {
+			return projectType
+		}
+	}
+	return utils.ProjectTypeNone
+}
+
+// linkWorkspaceProjects adds the APIs of the API Products and the subscriptions of the Applications to the
+// dependencies of the projects and validates all the dependencies
+func linkWorkspaceProjects(projects []*WorkspaceProject) error {
+	byPath := make(map[string]*WorkspaceProject)
+	byIdentifier := make(map[string]*WorkspaceProject)
+	definitions := make(map[string]*workspaceDefinition)
+	for _, project := range projects {
+		byPath[project.RelativePath] = project
+		definition, err := readWorkspaceDefinition(project)
+		if err != nil {
+			return err
+		}
+		if definition != nil {
+			definitions[project.RelativePath] = definition
+			byIdentifier[workspaceIdentifier(project.Type, definition.Data.Name, definition.Data.Version)] = project
+		}
+	}
+
+	for _, project := range projects {
+		definition := definitions[project.RelativePath]
+		if definition == nil {
+			continue
+		}
+		var dependencies []*WorkspaceProject
+		for _, api := range definition.Data.APIs {
+			dependencies = append(dependencies,
+				byIdentifier[workspaceIdentifier(utils.ProjectTypeApi, api.Name, api.Version)])
+		}
+		for _, subscription := range definition.Data.SubscribedAPIs {
+			id := subscription.APIIdentifier
+			dependencies = append(dependencies,
+				byIdentifier[workspaceIdentifier(utils.ProjectTypeApi, id.APIName, id.Version)],
+				byIdentifier[workspaceIdentifier(utils.ProjectTypeApiProduct, id.APIName, id.Version)])
+		}
+		for _, dependency := range dependencies {
+			if dependency != nil && !containsString(project.DependsOn, dependency.RelativePath) {
+				project.DependsOn = append(project.DependsOn, dependency.RelativePath)
+			}
+		}
+	}
+
+	for _, project := range projects {
+		for _, dependencyPath := range project.DependsOn {
+			dependency := byPath[dependencyPath]
+			if dependency == nil {
+				return utils.NewValidationError(project.RelativePath + " depends on " + dependencyPath +
+					" which is not a project of the workspace")
+			}
+			if workspaceProjectTypeRanks[dependency.Type] > workspaceProjectTypeRanks[project.Type] {
+				return utils.NewValidationError(project.RelativePath + " (" + project.Type + ") cannot depend on " +
+					dependencyPath + " (" + dependency.Type + ") as " + dependency.Type + "s are deployed after " +
+					project.Type + "s")
+			}
+			if dependency.Type == project.Type && dependency.DeployOrder > project.DeployOrder {
+				return utils.NewValidationError(project.RelativePath + " depends on " + dependencyPath +
+					" which has a later deploy order")
+			}
+		}
+	}
+	return checkWorkspaceDependencyCycles(projects, byPath)
+}
+
+// checkWorkspaceDependencyCycles returns an error if the dependencies of the projects have a cycle
+func checkWorkspaceDependencyCycles(projects []*WorkspaceProject, byPath map[string]*WorkspaceProject) error {
+	const visiting, visited = 1, 2
+	states := make(map[string]int)
+	var visit func(project *WorkspaceProject, chain []string) error
+	visit = func(project *WorkspaceProject, chain []string) error {
+		chain = append(chain, project.RelativePath)
+		switch states[project.RelativePath] {
+		case visiting:
+			return utils.NewValidationError("cyclic project dependency: " + strings.Join(chain, " -> "))
+		case visited:
+			return nil
+		}
+		states[project.RelativePath] = visiting
+		for _, dependencyPath := range project.DependsOn {
+			if err := visit(byPath[dependencyPath], chain); err != nil {
+				return err
+			}
+		}
+		states[project.RelativePath] = visited
+		return nil
+	}
+	for _, project := range projects {
+		if err := visit(project, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readWorkspaceDefinition reads the definition file of a project. Returns nil if the project has no definition file
+func readWorkspaceDefinition(project *WorkspaceProject) (*workspaceDefinition, error) {
+	definitionFile := filepath.Join(project.Path, workspaceDefinitionFiles[project.Type])
+	if !utils.IsFileExist(definitionFile+".yaml") && !utils.IsFileExist(definitionFile+".json") {
+		return nil, nil
+	}
+	fileName, content, err := resolveYamlOrJSON(definitionFile)
+	if err != nil {
+		return nil, err
+	}
+	definition := &workspaceDefinition{}
+	if err := json.Unmarshal(content, definition); err != nil {
+		return nil, fmt.Errorf("error while reading %s: %v", fileName, err)
+	}
+	return definition, nil
+}
+
+// workspaceIdentifier identifies a project of a workspace by its type, name and version
+func workspaceIdentifier(projectType, name, version string) string {
+	return projectType + ":" + name + ":" + version
+}
+
+// cleanWorkspacePath converts a path of the manifest to a clean slash separated relative path
+func cleanWorkspacePath(p string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(p)), "./")
+}
+
+// validateWorkspacePattern returns an error if a path pattern of the manifest is malformed
+func validateWorkspacePattern(pattern string) error {
+	if path.IsAbs(pattern) || pattern == ".." || strings.HasPrefix(pattern, "../") {
+		return errors.New("path " + pattern + " should be relative to the workspace")
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return errors.New("invalid path pattern " + pattern)
+		}
+	}
+	return nil
+}
+
+// matchWorkspacePattern returns whether a slash separated relative path matches a path pattern of the manifest
+func matchWorkspacePattern(pattern, relativePath string) bool {
+	return matchWorkspaceSegments(strings.Split(pattern, "/"), strings.Split(relativePath, "/"))
+}
+
+func matchWorkspaceSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == workspaceRecursiveWildcard {
+		for i := 0; i <= len(segments); i++ {
+			if matchWorkspaceSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+		return false
+	}
+	return matchWorkspaceSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,183 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const workspaceTestManifest = `projects:
+  - path: apis/*
+    dependsOn:
+      - apis/PizzaAPI
+  - path: products/**
+    type: api_product
+    params: params/product_params.yaml
+  - path: apps/ShopApp
+    deployOrder: 2
+ignore:
+  - drafts
+environments:
+  prod: production
+`
+
+func workspaceTestAPIYaml(name string) string {
+	return "type: api\nversion: v4.0.0\ndata:\n  name: " + name + "\n  version: 1.0.0\n"
+}
+
+func loadTestWorkspace(t *testing.T, files map[string]string) (*Workspace, string) {
+	dir := writeValidationProject(t, files)
+	workspace, err := LoadWorkspace(filepath.Join(dir, utils.WorkspaceManifestFile))
+	assert.Nil(t, err)
+	return workspace, dir
+}
+
+func workspaceProjectPaths(projects []*WorkspaceProject) []string {
+	var paths []string
+	for _, project := range projects {
+		paths = append(paths, project.RelativePath)
+	}
+	return paths
+}
+
+func TestWorkspaceDiscoverProjects(t *testing.T) {
+	workspace, dir := loadTestWorkspace(t, map[string]string{
+		utils.WorkspaceManifestFile:   workspaceTestManifest,
+		"apis/PizzaAPI/api.yaml":      workspaceTestAPIYaml("PizzaAPI"),
+		"apis/PetAPI/api_params.yaml": "environments: []\n",
+		"apis/docs/README.md":         "not a project",
+		"drafts/DraftAPI/api.yaml":    workspaceTestAPIYaml("DraftAPI"),
+		"products/retail/PizzaProduct/api_product.yaml": "type: api_product\ndata:\n  name: PizzaProduct\n" +
+			"  apis:\n    - name: PizzaAPI\n      version: 1.0.0\n",
+		"products/retail/PizzaProduct/APIs/PizzaAPI/api.yaml": workspaceTestAPIYaml("PizzaAPI"),
+		"apps/ShopApp/application.yaml": "type: application\ndata:\n  applicationInfo:\n    name: ShopApp\n" +
+			"  subscribedAPIs:\n    - apiId:\n        apiName: PizzaProduct\n        version: \"\"\n",
+	})
+	defer os.RemoveAll(dir)
+
+	projects, err := workspace.DiscoverProjects()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"apis/PizzaAPI", "apis/PetAPI", "products/retail/PizzaProduct", "apps/ShopApp"},
+		workspaceProjectPaths(projects))
+
+	pizzaAPI, petAPI, product, app := projects[0], projects[1], projects[2], projects[3]
+	assert.Equal(t, utils.ProjectTypeApi, pizzaAPI.Type)
+	assert.Equal(t, filepath.Join(dir, "apis", "PizzaAPI", utils.ParamFileAPI), pizzaAPI.ParamsFile)
+	assert.Equal(t, utils.ProjectTypeApi, petAPI.Type)
+	assert.Equal(t, []string{"apis/PizzaAPI"}, petAPI.DependsOn)
+	assert.Equal(t, utils.ProjectTypeApiProduct, product.Type)
+	assert.Equal(t, filepath.Join(product.Path, "params", "product_params.yaml"), product.ParamsFile)
+	assert.Equal(t, []string{"apis/PizzaAPI"}, product.DependsOn)
+	assert.Equal(t, utils.ProjectTypeApplication, app.Type)
+	assert.Equal(t, 2, app.DeployOrder)
+	assert.Equal(t, []string{"products/retail/PizzaProduct"}, app.DependsOn)
+
+	assert.Equal(t, product, FindWorkspaceProject(projects, filepath.Join(product.Path, "APIs", "PizzaAPI")))
+	assert.Nil(t, FindWorkspaceProject(projects, filepath.Join(dir, "apis", "PizzaAPIv2")))
+}
+
+func TestWorkspaceDeployStages(t *testing.T) {
+	projects := []*WorkspaceProject{
+		{RelativePath: "apps/ShopApp", Type: utils.ProjectTypeApplication, DependsOn: []string{"apis/PizzaAPI"}},
+		{RelativePath: "apis/PetAPI", Type: utils.ProjectTypeApi, DependsOn: []string{"apis/PizzaAPI"}},
+		{RelativePath: "apis/OrderAPI", Type: utils.ProjectTypeApi, DeployOrder: 1},
+		{RelativePath: "apis/PizzaAPI", Type: utils.ProjectTypeApi},
+		{RelativePath: "apis/StockAPI", Type: utils.ProjectTypeApi},
+	}
+	stages := WorkspaceDeployStages(projects)
+	var stagePaths [][]string
+	for _, stage := range stages {
+		stagePaths = append(stagePaths, workspaceProjectPaths(stage))
+	}
+	assert.Equal(t, [][]string{
+		{"apis/PizzaAPI", "apis/StockAPI"},
+		{"apis/PetAPI"},
+		{"apis/OrderAPI"},
+		{"apps/ShopApp"},
+	}, stagePaths)
+
+	// dependencies which are not deployed do not add stages
+	stages = WorkspaceDeployStages(projects[1:3])
+	assert.Equal(t, 2, len(stages))
+}
+
+func TestWorkspaceInvalidDependencies(t *testing.T) {
+	tests := map[string]string{
+		"unknown":         "projects:\n  - path: apis/*\n    dependsOn: [apis/MissingAPI]\n",
+		"cyclic":          "projects:\n  - path: apis/*\n    dependsOn: [apis/PizzaAPI, apis/PetAPI]\n",
+		"later type":      "projects:\n  - path: apis/*\n    dependsOn: [apps/ShopApp]\n  - path: apps/*\n",
+		"later order":     "projects:\n  - path: apis/PizzaAPI\n    dependsOn: [apis/PetAPI]\n  - path: apis/PetAPI\n    deployOrder: 1\n",
+		"missing literal": "projects:\n  - path: apis/PizzaAPI\n  - path: apis/OrderAPI\n",
+	}
+	for name, manifest := range tests {
+		workspace, dir := loadTestWorkspace(t, map[string]string{
+			utils.WorkspaceManifestFile:     manifest,
+			"apis/PizzaAPI/api.yaml":        workspaceTestAPIYaml("PizzaAPI"),
+			"apis/PetAPI/api.yaml":          workspaceTestAPIYaml("PetAPI"),
+			"apps/ShopApp/application.yaml": "type: application\n",
+		})
+		_, err := workspace.DiscoverProjects()
+		assert.NotNil(t, err, name)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestLoadWorkspaceInvalidManifest(t *testing.T) {
+	tests := map[string]string{
+		"no projects":   "ignore: [drafts]\n",
+		"no path":       "projects:\n  - type: api\n",
+		"invalid type":  "projects:\n  - path: apis/*\n    type: policy\n",
+		"outside":       "projects:\n  - path: ../apis/*\n",
+		"bad pattern":   "projects:\n  - path: apis/[\n",
+		"unknown field": "projects:\n  - path: apis/*\n    order: 1\n",
+		"empty alias":   "projects:\n  - path: apis/*\nenvironments:\n  prod: \"\"\n",
+	}
+	for name, manifest := range tests {
+		dir := writeValidationProject(t, map[string]string{utils.WorkspaceManifestFile: manifest})
+		_, err := LoadWorkspace(filepath.Join(dir, utils.WorkspaceManifestFile))
+		assert.NotNil(t, err, name)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestFindWorkspace(t *testing.T) {
+	dir := writeValidationProject(t, map[string]string{
+		utils.WorkspaceManifestFile: workspaceTestManifest,
+		"apis/PizzaAPI/api.yaml":    workspaceTestAPIYaml("PizzaAPI"),
+	})
+	defer os.RemoveAll(dir)
+
+	workspace, err := FindWorkspace(filepath.Join(dir, "apis", "PizzaAPI"))
+	assert.Nil(t, err)
+	assert.NotNil(t, workspace)
+	assert.Equal(t, dir, workspace.BasePath)
+	assert.Equal(t, "production", workspace.ResolveEnvironment("prod"))
+	assert.Equal(t, "dev", workspace.ResolveEnvironment("dev"))
+	assert.True(t, workspace.IsIgnored("drafts/DraftAPI/api.yaml"))
+	assert.True(t, workspace.IsIgnored(utils.WorkspaceManifestFile))
+	assert.False(t, workspace.IsIgnored("apis/PizzaAPI/api.yaml"))
+
+	workspace, err = FindWorkspace(os.TempDir())
+	assert.Nil(t, err)
+	assert.Nil(t, workspace)
+}
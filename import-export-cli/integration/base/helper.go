@@ -64,6 +64,15 @@ func Execute(t *testing.T, args ...string) (string, error) {
 	return string(output), err
 }
 
+// GetExitCode : Get the exit code of an apictl command from the error returned by Execute.
+//
+func GetExitCode(err error) int {
+	if exitError, ok := err.(*exec.ExitError); ok {
+		return exitError.ExitCode()
+	}
+	return 0
+}
+
 // GetRowsFromTableResponse : Parse tabular apictl output to retrieve an array of rows.
 // This friendly format aids in asserting results during testing via simple string comparison.
 //
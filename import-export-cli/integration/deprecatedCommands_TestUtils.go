@@ -295,13 +295,10 @@ func validateGetKeysFailure(t *testing.T, args *testutils.ApiGetKeyTestArgs) {
 	base.SetupEnv(t, args.Apim.GetEnvName(), args.Apim.GetApimURL(), args.Apim.GetTokenURL())
 	base.Login(t, args.Apim.GetEnvName(), args.CtlUser.Username, args.CtlUser.Password)
 
-	var err error
-	var result string
-
-	result, err = getKeys(t, args.Api.Provider, args.Api.Name, args.Api.Version, args.Apim.GetEnvName())
+	_, err := getKeys(t, args.Api.Provider, args.Api.Name, args.Api.Version, args.Apim.GetEnvName())
 
 	assert.NotNil(t, err, "Expected error was not returned")
-	assert.Contains(t, base.GetValueOfUniformResponse(result), "Exit status 1")
+	assert.NotEqual(t, 0, base.GetExitCode(err), "Expected the command to exit with an error code")
 }
 
 func validateGetKeys(t *testing.T, args *testutils.ApiGetKeyTestArgs) {
@@ -428,10 +428,10 @@ func ValidateAPIImportFailure(t *testing.T, args *ApiImportExportTestArgs) {
 	base.Login(t, args.DestAPIM.GetEnvName(), args.CtlUser.Username, args.CtlUser.Password)
 
 	// importAPIPreserveProviderFailure is used to eleminate cleaning the API after importing
-	result, err := importAPIPreserveProviderFailure(t, args.SrcAPIM.GetEnvName(), args.Api, args.DestAPIM)
+	_, err := importAPIPreserveProviderFailure(t, args.SrcAPIM.GetEnvName(), args.Api, args.DestAPIM)
 
 	assert.NotNil(t, err, "Expected error was not returned")
-	assert.Contains(t, base.GetValueOfUniformResponse(result), "Exit status 1")
+	assert.NotEqual(t, 0, base.GetExitCode(err), "Expected the command to exit with an error code")
 }
 
 // ValidateAPIsEqual : Validate if two APIs are equal while ignoring unique fields
@@ -101,17 +101,16 @@ func ValidateGetKeysFailure(t *testing.T, args *ApiGetKeyTestArgs) {
 	base.Login(t, args.Apim.GetEnvName(), args.CtlUser.Username, args.CtlUser.Password)
 
 	var err error
-	var result string
 	if args.Api != nil {
-		result, err = GetKeys(t, args.Api.Provider, args.Api.Name, args.Api.Version, args.Apim.GetEnvName())
+		_, err = GetKeys(t, args.Api.Provider, args.Api.Name, args.Api.Version, args.Apim.GetEnvName())
 	}
 
 	if args.ApiProduct != nil {
-		result, err = GetKeys(t, args.ApiProduct.Provider, args.ApiProduct.Name, utils.DefaultApiProductVersion, args.Apim.GetEnvName())
+		_, err = GetKeys(t, args.ApiProduct.Provider, args.ApiProduct.Name, utils.DefaultApiProductVersion, args.Apim.GetEnvName())
 	}
 
 	assert.NotNil(t, err, "Expected error was not returned")
-	assert.Contains(t, base.GetValueOfUniformResponse(result), "Exit status 1")
+	assert.NotEqual(t, 0, base.GetExitCode(err), "Expected the command to exit with an error code")
 }
 
 func ValidateGetKeys(t *testing.T, args *ApiGetKeyTestArgs) {
@@ -0,0 +1,300 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	miArtifactStateActive   = "active"
+	miArtifactStateInactive = "inactive"
+
+	miStateEndpoints         = "endpoints"
+	miStateMessageProcessors = "messageProcessors"
+	miStateProxyServices     = "proxyServices"
+	miStateLoggers           = "loggers"
+)
+
+var miLogLevels = []string{"OFF", "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+// MIOperationalState is the desired state of the artifacts and the loggers of a micro integrator. The artifacts are
+// mapped to active or inactive by name
+type MIOperationalState struct {
+	Endpoints         map[string]string        `json:"endpoints,omitempty"`
+	MessageProcessors map[string]string        `json:"messageProcessors,omitempty"`
+	ProxyServices     map[string]string        `json:"proxyServices,omitempty"`
+	Loggers           map[string]MILoggerState `json:"loggers,omitempty"`
+}
+
+// MILoggerState is the desired state of a logger. A logger which does not exist is added if the class is given
+type MILoggerState struct {
+	Level string `json:"level"`
+	Class string `json:"class,omitempty"`
+}
+
+// MIStateChange is a change required to bring an artifact or a logger of a micro integrator to the desired state
+type MIStateChange struct {
+	ArtifactType string `json:"artifactType"`
+	Name         string `json:"name"`
+	// Type is added for a new logger and modified otherwise
+	Type    string `json:"type"`
+	Before  string `json:"before,omitempty"`
+	After   string `json:"after"`
+	Class   string `json:"class,omitempty"`
+	Applied bool   `json:"applied"`
+	Error   string `json:"error,omitempty"`
+}
+
+// LoadMIOperationalState reads and validates the desired state of a micro integrator from a YAML file
+// @param filePath : Path to the file
+// @return the desired state
+// @return error if the file is not valid
+func LoadMIOperationalState(filePath string) (*MIOperationalState, error) {
+	data, err := utils.LoadYamlAsJson(filePath)
+	if err != nil {
+		return nil, err
+	}
+	state := &MIOperationalState{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(state); err != nil {
+		return nil, errors.New(filePath + " is not a valid state file. " + err.Error())
+	}
+	for artifactType, artifacts := range map[string]map[string]string{
+		miStateEndpoints:         state.Endpoints,
+		miStateMessageProcessors: state.MessageProcessors,
+		miStateProxyServices:     state.ProxyServices,
+	} {
+		for name, artifactState := range artifacts {
+			if artifactState != miArtifactStateActive && artifactState != miArtifactStateInactive {
+				return nil, fmt.Errorf("invalid state '%s' of %s/%s, expected '%s' or '%s'", artifactState,
+					artifactType, name, miArtifactStateActive, miArtifactStateInactive)
+			}
+		}
+	}
+	for name, logger := range state.Loggers {
+		if !isValidMILogLevel(logger.Level) {
+			return nil, fmt.Errorf("invalid level '%s' of %s/%s, expected one of %s", logger.Level, miStateLoggers,
+				name, strings.Join(miLogLevels, ", "))
+		}
+	}
+	return state, nil
+}
+
+func isValidMILogLevel(level string) bool {
+	for _, validLevel := range miLogLevels {
+		if strings.EqualFold(level, validLevel) {
+			return true
+		}
+	}
+	return false
+}
+
+// PlanMIOperationalState compares the desired state with the micro integrator in a given environment
+// @param env : Environment of the micro integrator
+// @param state : Desired state
+// @return the changes required, sorted by the artifact type and the name
+// @return error if any artifact or logger in the desired state cannot be read, in which case nothing should be applied
+func PlanMIOperationalState(env string, state *MIOperationalState) ([]MIStateChange, error) {
+	current, err := readMIOperationalState(env, state)
+	if err != nil {
+		return nil, err
+	}
+	return planMIStateChanges(current, state)
+}
+
+// readMIOperationalState reads the current state of the artifacts in the desired state and of all the loggers from
+// the micro integrator in a given environment
+func readMIOperationalState(env string, state *MIOperationalState) (*MIOperationalState, error) {
+	current := &MIOperationalState{
+		Endpoints:         make(map[string]string),
+		MessageProcessors: make(map[string]string),
+		ProxyServices:     make(map[string]string),
+		Loggers:           make(map[string]MILoggerState),
+	}
+	for _, name := range sortedKeys(state.Endpoints) {
+		endpoint, err := GetEndpoint(env, name)
+		if err != nil {
+			return nil, errors.New("unable to read endpoint " + name + ". " + err.Error())
+		}
+		current.Endpoints[name] = toMIArtifactState(endpoint.Active)
+	}
+	for _, name := range sortedKeys(state.MessageProcessors) {
+		processor, err := GetMessageProcessor(env, name)
+		if err != nil {
+			return nil, errors.New("unable to read message processor " + name + ". " + err.Error())
+		}
+		current.MessageProcessors[name] = toMIArtifactState(processor.Status == miArtifactStateActive)
+	}
+	for _, name := range sortedKeys(state.ProxyServices) {
+		proxy, err := GetProxyService(env, name)
+		if err != nil {
+			return nil, errors.New("unable to read proxy service " + name + ". " + err.Error())
+		}
+		current.ProxyServices[name] = toMIArtifactState(proxy.IsRunning)
+	}
+	if len(state.Loggers) == 0 {
+		return current, nil
+	}
+	loggerList, err := GetLoggerList(env)
+	if err != nil {
+		return nil, errors.New("unable to read the loggers. " + err.Error())
+	}
+	for _, logger := range loggerList.Loggers {
+		current.Loggers[logger.LoggerName] = MILoggerState{Level: logger.LogLevel}
+	}
+	return current, nil
+}
+
+func toMIArtifactState(active bool) string {
+	if active {
+		return miArtifactStateActive
+	}
+	return miArtifactStateInactive
+}
+
+// planMIStateChanges compares the desired state with the current state of a micro integrator
+// @param current : Current state of the artifacts in the desired state and of all the loggers
+// @param desired : Desired state
+// @return the changes required, sorted by the artifact type and the name
+// @return error if an artifact does not exist, or a logger does not exist and its class is not given
+func planMIStateChanges(current, desired *MIOperationalState) ([]MIStateChange, error) {
+	changes := []MIStateChange{}
+	for _, artifacts := range []struct {
+		artifactType     string
+		current, desired map[string]string
+	}{
+		{miStateEndpoints, current.Endpoints, desired.Endpoints},
+		{miStateMessageProcessors, current.MessageProcessors, desired.MessageProcessors},
+		{miStateProxyServices, current.ProxyServices, desired.ProxyServices},
+	} {
+		for _, name := range sortedKeys(artifacts.desired) {
+			currentState, exists := artifacts.current[name]
+			if !exists {
+				return nil, errors.New(artifacts.artifactType + "/" + name + " does not exist")
+			}
+			changes = appendArtifactStateChange(changes, artifacts.artifactType, name, currentState,
+				artifacts.desired[name])
+		}
+	}
+	for _, name := range sortedKeys(desired.Loggers) {
+		logger := desired.Loggers[name]
+		level := strings.ToUpper(logger.Level)
+		currentLogger, exists := current.Loggers[name]
+		if !exists {
+			if logger.Class == "" {
+				return nil, errors.New("logger " + name + " does not exist. The class of the logger is required " +
+					"to add it")
+			}
+			changes = append(changes, MIStateChange{ArtifactType: miStateLoggers, Name: name,
+				Type: utils.DiffTypeAdded, After: level, Class: logger.Class})
+		} else if !strings.EqualFold(currentLogger.Level, level) {
+			changes = append(changes, MIStateChange{ArtifactType: miStateLoggers, Name: name,
+				Type: utils.DiffTypeModified, Before: currentLogger.Level, After: level})
+		}
+	}
+	return changes, nil
+}
+
+func appendArtifactStateChange(changes []MIStateChange, artifactType, name, currentState,
+	desiredState string) []MIStateChange {
+	if currentState == desiredState {
+		return changes
+	}
+	return append(changes, MIStateChange{ArtifactType: artifactType, Name: name, Type: utils.DiffTypeModified,
+		Before: currentState, After: desiredState})
+}
+
+// ApplyMIStateChanges applies the changes planned by PlanMIOperationalState to the micro integrator in a given
+// environment. A failed change does not stop the others and is marked with the error
+// @param env : Environment of the micro integrator
+// @param changes : Changes to be applied
+// @return the number of changes failed
+func ApplyMIStateChanges(env string, changes []MIStateChange) int {
+	failed := 0
+	for i := range changes {
+		change := &changes[i]
+		utils.Logln(utils.LogPrefixInfo + "applying " + change.ArtifactType + "/" + change.Name)
+		if _, err := applyMIStateChange(env, change); err != nil {
+			change.Error = err.Error()
+			failed++
+			continue
+		}
+		change.Applied = true
+	}
+	return failed
+}
+
+func applyMIStateChange(env string, change *MIStateChange) (interface{}, error) {
+	active := change.After == miArtifactStateActive
+	switch change.ArtifactType {
+	case miStateEndpoints:
+		if active {
+			return ActivateEndpoint(env, change.Name)
+		}
+		return DeactivateEndpoint(env, change.Name)
+	case miStateMessageProcessors:
+		if active {
+			return ActivateMessageProcessor(env, change.Name)
+		}
+		return DeactivateMessageProcessor(env, change.Name)
+	case miStateProxyServices:
+		if active {
+			return ActivateProxy(env, change.Name)
+		}
+		return DeactivateProxy(env, change.Name)
+	case miStateLoggers:
+		return AddMILogger(env, change.Name, change.Class, change.After)
+	}
+	return nil, errors.New("unknown artifact type " + change.ArtifactType)
+}
+
+// PrintMIStateChanges prints the changes planned or applied to a micro integrator
+func PrintMIStateChanges(changes []MIStateChange, dryRun bool) {
+	if len(changes) == 0 {
+		fmt.Println("No changes to apply")
+		return
+	}
+	applied := 0
+	for _, change := range changes {
+		artifact := change.ArtifactType + "/" + change.Name
+		if change.Type == utils.DiffTypeAdded {
+			fmt.Println("+ " + artifact + ": " + change.After + " (" + change.Class + ")")
+		} else {
+			fmt.Println("~ " + artifact + ": " + change.Before + " => " + change.After)
+		}
+		if change.Error != "" {
+			fmt.Println("\t" + utils.LogPrefixError + change.Error)
+		}
+		if change.Applied {
+			applied++
+		}
+	}
+	if dryRun {
+		fmt.Printf("Changes to apply: %d\n", len(changes))
+	} else {
+		fmt.Printf("Changes applied: %d of %d\n", applied, len(changes))
+	}
+}
@@ -0,0 +1,199 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestPlanMIStateChanges(t *testing.T) {
+	current := &MIOperationalState{
+		Endpoints:         map[string]string{"GrandOakEndpoint": "active", "PineValleyEndpoint": "inactive"},
+		MessageProcessors: map[string]string{"OrderProcessor": "active", "RetryProcessor": "inactive"},
+		ProxyServices:     map[string]string{"StockQuoteProxy": "active", "LegacyProxy": "inactive"},
+		Loggers: map[string]MILoggerState{
+			"org-apache-synapse": {Level: "INFO"},
+			"org-apache-axis2":   {Level: "WARN"},
+		},
+	}
+	tests := []struct {
+		name    string
+		desired MIOperationalState
+		changes []MIStateChange
+		err     string
+	}{
+		{
+			name: "unchanged",
+			desired: MIOperationalState{
+				Endpoints:         map[string]string{"GrandOakEndpoint": "active"},
+				MessageProcessors: map[string]string{"RetryProcessor": "inactive"},
+				ProxyServices:     map[string]string{"StockQuoteProxy": "active"},
+				Loggers:           map[string]MILoggerState{"org-apache-synapse": {Level: "info"}},
+			},
+			changes: []MIStateChange{},
+		},
+		{
+			name: "endpoints",
+			desired: MIOperationalState{
+				Endpoints: map[string]string{"PineValleyEndpoint": "active", "GrandOakEndpoint": "inactive"},
+			},
+			changes: []MIStateChange{
+				{ArtifactType: "endpoints", Name: "GrandOakEndpoint", Type: utils.DiffTypeModified,
+					Before: "active", After: "inactive"},
+				{ArtifactType: "endpoints", Name: "PineValleyEndpoint", Type: utils.DiffTypeModified,
+					Before: "inactive", After: "active"},
+			},
+		},
+		{
+			name: "message processors and proxy services",
+			desired: MIOperationalState{
+				MessageProcessors: map[string]string{"OrderProcessor": "inactive"},
+				ProxyServices:     map[string]string{"LegacyProxy": "active", "StockQuoteProxy": "active"},
+			},
+			changes: []MIStateChange{
+				{ArtifactType: "messageProcessors", Name: "OrderProcessor", Type: utils.DiffTypeModified,
+					Before: "active", After: "inactive"},
+				{ArtifactType: "proxyServices", Name: "LegacyProxy", Type: utils.DiffTypeModified,
+					Before: "inactive", After: "active"},
+			},
+		},
+		{
+			name: "loggers",
+			desired: MIOperationalState{
+				Loggers: map[string]MILoggerState{
+					"org-apache-axis2":   {Level: "debug"},
+					"org-apache-synapse": {Level: "INFO"},
+					"healthcare":         {Level: "DEBUG", Class: "org.wso2.healthcare"},
+				},
+			},
+			changes: []MIStateChange{
+				{ArtifactType: "loggers", Name: "healthcare", Type: utils.DiffTypeAdded, After: "DEBUG",
+					Class: "org.wso2.healthcare"},
+				{ArtifactType: "loggers", Name: "org-apache-axis2", Type: utils.DiffTypeModified, Before: "WARN",
+					After: "DEBUG"},
+			},
+		},
+		{
+			name: "missing logger without a class",
+			desired: MIOperationalState{
+				Loggers: map[string]MILoggerState{"healthcare": {Level: "DEBUG"}},
+			},
+			err: "logger healthcare does not exist. The class of the logger is required to add it",
+		},
+		{
+			name: "missing artifact",
+			desired: MIOperationalState{
+				ProxyServices: map[string]string{"OrderProxy": "active"},
+			},
+			err: "proxyServices/OrderProxy does not exist",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := planMIStateChanges(current, &test.desired)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.changes, changes)
+		})
+	}
+}
+
+func TestLoadMIOperationalState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-mi-state")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		content string
+		state   *MIOperationalState
+		err     string
+	}{
+		{
+			name: "valid",
+			content: `endpoints:
+  GrandOakEndpoint: inactive
+messageProcessors:
+  OrderProcessor: active
+proxyServices:
+  StockQuoteProxy: active
+loggers:
+  healthcare:
+    level: debug
+    class: org.wso2.healthcare
+`,
+			state: &MIOperationalState{
+				Endpoints:         map[string]string{"GrandOakEndpoint": "inactive"},
+				MessageProcessors: map[string]string{"OrderProcessor": "active"},
+				ProxyServices:     map[string]string{"StockQuoteProxy": "active"},
+				Loggers:           map[string]MILoggerState{"healthcare": {Level: "debug", Class: "org.wso2.healthcare"}},
+			},
+		},
+		{
+			name:    "unknown artifact type",
+			content: "sequences:\n  HealthcareSequence: active\n",
+			err:     "unknown field",
+		},
+		{
+			name:    "unknown logger field",
+			content: "loggers:\n  healthcare:\n    levl: DEBUG\n",
+			err:     "unknown field",
+		},
+		{
+			name:    "invalid artifact state",
+			content: "endpoints:\n  GrandOakEndpoint: enabled\n",
+			err:     "invalid state 'enabled' of endpoints/GrandOakEndpoint, expected 'active' or 'inactive'",
+		},
+		{
+			name:    "invalid log level",
+			content: "loggers:\n  healthcare:\n    level: VERBOSE\n",
+			err:     "invalid level 'VERBOSE' of loggers/healthcare",
+		},
+		{
+			name:    "invalid artifact state type",
+			content: "proxyServices:\n  StockQuoteProxy: true\n",
+			err:     "is not a valid state file",
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filePath := filepath.Join(dir, "state"+strconv.Itoa(i)+".yaml")
+			assert.Nil(t, ioutil.WriteFile(filePath, []byte(test.content), 0644))
+			state, err := LoadMIOperationalState(filePath)
+			if test.err != "" {
+				assert.Error(t, err)
+				if err != nil {
+					assert.Contains(t, err.Error(), test.err)
+				}
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.state, state)
+		})
+	}
+}
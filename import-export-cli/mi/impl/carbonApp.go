@@ -0,0 +1,420 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// descriptor of a CAR file which lists the artifacts of the Carbon App
+const carbonAppDescriptorFileName = "artifacts.xml"
+
+// descriptor of an artifact packed in a CAR file
+const carbonAppArtifactDescriptorFileName = "artifact.xml"
+
+const carbonAppFileExtension = ".car"
+
+// types of the artifacts of a CAR file which are listed as the artifacts of the deployed Carbon App
+var deployableCarbonAppArtifactTypes = map[string]bool{
+	"synapse/api":                true,
+	"synapse/proxy-service":      true,
+	"synapse/endpoint":           true,
+	"synapse/sequence":           true,
+	"synapse/local-entry":        true,
+	"synapse/message-store":      true,
+	"synapse/message-processors": true,
+	"synapse/inbound-endpoint":   true,
+	"synapse/task":               true,
+	"synapse/template":           true,
+	"service/dataservice":        true,
+}
+
+// CarbonApp is a Carbon App read from a CAR file
+type CarbonApp struct {
+	// Name of the Carbon App
+	Name string
+	// Version of the Carbon App
+	Version string
+	// Artifacts which are expected to be listed in the deployed Carbon App
+	Artifacts []string
+	// Content of the CAR file
+	Content []byte
+}
+
+// CarbonAppDeployOptions are the options to deploy or undeploy a Carbon App
+type CarbonAppDeployOptions struct {
+	// Force deploys the Carbon App even if the same or a newer version is deployed
+	Force bool
+	// Timeout is the maximum time to wait until a Carbon App is deployed or undeployed
+	Timeout time.Duration
+	// Interval is the time between two checks of the deployed Carbon Apps
+	Interval time.Duration
+}
+
+type carbonAppArtifacts struct {
+	Artifacts []carbonAppArtifact `xml:"artifact"`
+}
+
+type carbonAppArtifact struct {
+	Name         string                `xml:"name,attr"`
+	Version      string                `xml:"version,attr"`
+	Type         string                `xml:"type,attr"`
+	Dependencies []carbonAppDependency `xml:"dependency"`
+}
+
+type carbonAppDependency struct {
+	Artifact string `xml:"artifact,attr"`
+	Version  string `xml:"version,attr"`
+}
+
+// FileName returns the name of the CAR file of the Carbon App in the micro integrator
+func (app *CarbonApp) FileName() string {
+	return app.Name + "_" + app.Version + carbonAppFileExtension
+}
+
+// ReadCarbonApp reads the name, the version and the artifacts of a Carbon App from a CAR file
+// @param carFilePath : Path to the CAR file
+// @return the Carbon App
+// @return error if the file is not a valid CAR file
+func ReadCarbonApp(carFilePath string) (*CarbonApp, error) {
+	content, err := ioutil.ReadFile(carFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return parseCarbonApp(content)
+}
+
+func parseCarbonApp(content []byte) (*CarbonApp, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, errors.New("not a valid CAR file. " + err.Error())
+	}
+
+	var app *CarbonApp
+	var dependencies []carbonAppDependency
+	artifactTypes := make(map[string]string)
+	for _, file := range reader.File {
+		name := strings.TrimPrefix(file.Name, "/")
+		if name != carbonAppDescriptorFileName && path.Base(name) != carbonAppArtifactDescriptorFileName {
+			continue
+		}
+		descriptor, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var artifacts carbonAppArtifacts
+		if name == carbonAppDescriptorFileName {
+			if err = xml.Unmarshal(descriptor, &artifacts); err != nil {
+				return nil, errors.New("invalid " + carbonAppDescriptorFileName + " in the CAR file. " + err.Error())
+			}
+			for _, artifact := range artifacts.Artifacts {
+				if artifact.Type == "carbon/application" {
+					app = &CarbonApp{Name: artifact.Name, Version: artifact.Version, Content: content}
+					dependencies = artifact.Dependencies
+				}
+			}
+			continue
+		}
+		// the descriptor of an artifact has the artifact as the root element
+		var artifact carbonAppArtifact
+		if err = xml.Unmarshal(descriptor, &artifact); err == nil {
+			artifactTypes[artifact.Name] = artifact.Type
+		}
+	}
+	if app == nil || app.Name == "" {
+		return nil, errors.New("not a valid CAR file. " + carbonAppDescriptorFileName + " with a carbon/application " +
+			"artifact was not found")
+	}
+	for _, dependency := range dependencies {
+		if deployableCarbonAppArtifactTypes[artifactTypes[dependency.Artifact]] {
+			app.Artifacts = append(app.Artifacts, dependency.Artifact)
+		}
+	}
+	return app, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// DeployCarbonApp deploys a Carbon App in the micro integrator in a given environment
+// A deployed Carbon App with the same name is replaced if its version is older, while the same version is kept and a
+// newer version is not downgraded unless options.Force is set. A deployed Carbon App of which the CAR file was not
+// kept by SaveDeployedCarbonApp is not replaced either unless options.Force is set, as it cannot be rolled back to.
+// The command waits until the Carbon App and its artifacts are listed by the micro integrator. If the deployment
+// fails, the new Carbon App is removed and the previous version is deployed again from the CAR file kept when it was
+// deployed with apictl. The previous version is deployed again as well if removing it fails after it is undeployed
+// @param env : Environment of the micro integrator
+// @param app : Carbon App to be deployed
+// @param options : Options of the deployment
+// @return the status of the deployment
+// @return error if the Carbon App was not deployed
+func DeployCarbonApp(env string, app *CarbonApp, options CarbonAppDeployOptions) (string, error) {
+	var previous *CarbonApp
+	deployed, err := GetCompositeApp(env, app.Name)
+	if err == nil {
+		versionDiff := compareCarbonAppVersions(app.Version, deployed.Version)
+		if versionDiff == 0 && !options.Force {
+			return "Carbon App " + app.Name + " version " + app.Version + " is already deployed", nil
+		}
+		if versionDiff < 0 && !options.Force {
+			return "", utils.NewConflictError("Carbon App " + app.Name + " version " + deployed.Version +
+				" is deployed, which is newer than version " + app.Version + ". Use --force to downgrade it")
+		}
+		previous = getDeployedCarbonAppBackup(env, deployed.Name, deployed.Version)
+		if previous == nil && !options.Force {
+			return "", utils.NewConflictError("Carbon App " + app.Name + " version " + deployed.Version +
+				" was not deployed with " + utils.ProjectName + ", so it cannot be rolled back to if the deployment " +
+				"fails. Use --force to replace it without a rollback")
+		}
+		utils.Logln(utils.LogPrefixInfo + "undeploying Carbon App " + deployed.Name + " version " + deployed.Version)
+		if err = removeCarbonApp(env, deployed.Name, deployed.Version, options); err != nil {
+			return "", errors.New("unable to undeploy version " + deployed.Version + ". " + err.Error() + ". " +
+				restoreCarbonApp(env, deployed, previous, options))
+		}
+	}
+
+	utils.Logln(utils.LogPrefixInfo + "deploying Carbon App " + app.Name + " version " + app.Version)
+	err = addCarbonApp(env, app, options)
+	if err != nil {
+		return "", errors.New(err.Error() + ". " + rollbackCarbonApp(env, app, previous, options))
+	}
+	if deployed != nil {
+		return "Carbon App " + app.Name + " version " + app.Version + " deployed replacing version " +
+			deployed.Version, nil
+	}
+	return "Carbon App " + app.Name + " version " + app.Version + " deployed", nil
+}
+
+// UndeployCarbonApp undeploys a Carbon App from the micro integrator in a given environment and waits until it is
+// removed
+// @param env : Environment of the micro integrator
+// @param appName : Name of the Carbon App
+// @param options : Options of the undeployment
+// @return the status of the undeployment
+// @return error if the Carbon App was not undeployed
+func UndeployCarbonApp(env, appName string, options CarbonAppDeployOptions) (string, error) {
+	deployed, err := GetCompositeApp(env, appName)
+	if err != nil {
+		return "", utils.NewNotFoundError("Carbon App " + appName + " is not deployed. " + err.Error())
+	}
+	if err = removeCarbonApp(env, deployed.Name, deployed.Version, options); err != nil {
+		return "", err
+	}
+	return "Carbon App " + deployed.Name + " version " + deployed.Version + " undeployed", nil
+}
+
+// rollbackCarbonApp removes a Carbon App which failed to deploy and deploys the previous version if it is available
+// It returns the outcome of the rollback to be reported with the failure
+func rollbackCarbonApp(env string, failed, previous *CarbonApp, options CarbonAppDeployOptions) string {
+	if err := removeCarbonApp(env, failed.Name, failed.Version, options); err != nil {
+		utils.Logln(utils.LogPrefixWarning + "unable to remove the failed Carbon App: " + err.Error())
+	}
+	if previous == nil {
+		return "No previous version was restored"
+	}
+	utils.Logln(utils.LogPrefixInfo + "rolling back to Carbon App " + previous.Name + " version " + previous.Version)
+	if err := addCarbonApp(env, previous, options); err != nil {
+		return "Rolling back to version " + previous.Version + " failed. " + err.Error()
+	}
+	return "Rolled back to version " + previous.Version
+}
+
+// restoreCarbonApp deploys the previous version of a Carbon App again from its CAR file if removing it failed after
+// it was undeployed. It returns the outcome of the restore to be reported with the failure
+func restoreCarbonApp(env string, deployed *artifactutils.CompositeApp, previous *CarbonApp,
+	options CarbonAppDeployOptions) string {
+	if current, err := GetCompositeApp(env, deployed.Name); err == nil && current.Version == deployed.Version {
+		return "Version " + deployed.Version + " is still deployed"
+	}
+	if previous == nil {
+		return "Version " + deployed.Version + " is not deployed anymore and its CAR file is not available to " +
+			"restore it"
+	}
+	utils.Logln(utils.LogPrefixInfo + "restoring Carbon App " + previous.Name + " version " + previous.Version)
+	if err := addCarbonApp(env, previous, options); err != nil {
+		return "Restoring version " + previous.Version + " failed. " + err.Error()
+	}
+	return "Restored version " + previous.Version
+}
+
+// addCarbonApp uploads the CAR file of a Carbon App and waits until the Carbon App is listed with all its artifacts
+func addCarbonApp(env string, app *CarbonApp, options CarbonAppDeployOptions) error {
+	url := utils.GetMIManagementEndpointOfResource(utils.MiManagementCarbonAppResource, env, utils.MainConfigFilePath)
+	resp, err := invokePOSTFileRequestWithRetry(env, url, app.FileName(), app.Content)
+	if _, err = handleResponse(resp, err, env, url, "Message", "Error"); err != nil {
+		return err
+	}
+	return waitForCarbonApp(env, app.Name, options, "deployed",
+		func(deployed *artifactutils.CompositeApp, err error) bool {
+			return err == nil && deployed.Version == app.Version && containsCarbonAppArtifacts(deployed, app.Artifacts)
+		})
+}
+
+// removeCarbonApp deletes the CAR file of a Carbon App and waits until the Carbon App is not listed
+func removeCarbonApp(env, appName, version string, options CarbonAppDeployOptions) error {
+	url := utils.GetMIManagementEndpointOfResource(utils.MiManagementCarbonAppResource, env, utils.MainConfigFilePath)
+	resp, err := invokeDELETERequestWithRetry(url+"/"+appName+"_"+version, env)
+	if _, err = handleResponse(resp, err, env, url, "Message", "Error"); err != nil {
+		// a CAR file which was not deployed by apictl may not be named with the version
+		resp, err = invokeDELETERequestWithRetry(url+"/"+appName, env)
+		if _, err = handleResponse(resp, err, env, url, "Message", "Error"); err != nil {
+			return err
+		}
+	}
+	return waitForCarbonApp(env, appName, options, "undeployed",
+		func(deployed *artifactutils.CompositeApp, err error) bool {
+			return err != nil || deployed.Version != version
+		})
+}
+
+// waitForCarbonApp polls a Carbon App until done returns true or the timeout expires
+func waitForCarbonApp(env, appName string, options CarbonAppDeployOptions, state string,
+	done func(deployed *artifactutils.CompositeApp, err error) bool) error {
+	deadline := time.Now().Add(options.Timeout)
+	for {
+		if done(GetCompositeApp(env, appName)) {
+			return nil
+		}
+		if time.Now().Add(options.Interval).After(deadline) {
+			return fmt.Errorf("Carbon App %s was not %s within %s", appName, state, options.Timeout)
+		}
+		time.Sleep(options.Interval)
+	}
+}
+
+func containsCarbonAppArtifacts(deployed *artifactutils.CompositeApp, artifacts []string) bool {
+	deployedArtifacts := make(map[string]bool)
+	for _, artifact := range deployed.Artifacts {
+		deployedArtifacts[artifact.Name] = true
+	}
+	for _, artifact := range artifacts {
+		if !deployedArtifacts[artifact] {
+			return false
+		}
+	}
+	return true
+}
+
+// compareCarbonAppVersions compares two versions (eg: 1.0.0, 1.2.0-SNAPSHOT) part by part. Numeric parts are compared
+// as numbers and the other parts as strings. A qualifier such as SNAPSHOT marks a version older than the release
+// @return a negative number if v1 is older than v2, zero if they are equal and a positive number otherwise
+func compareCarbonAppVersions(v1, v2 string) int {
+	split := func(r rune) bool {
+		return r == '.' || r == '-' || r == '_'
+	}
+	parts1 := strings.FieldsFunc(v1, split)
+	parts2 := strings.FieldsFunc(v2, split)
+	for i := 0; i < len(parts1) && i < len(parts2); i++ {
+		n1, err1 := strconv.Atoi(parts1[i])
+		n2, err2 := strconv.Atoi(parts2[i])
+		switch {
+		case err1 == nil && err2 == nil:
+			if n1 != n2 {
+				return n1 - n2
+			}
+		case err1 == nil:
+			return 1
+		case err2 == nil:
+			return -1
+		default:
+			if c := strings.Compare(parts1[i], parts2[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	// a longer version is newer if it continues with a number (1.0.1 and 1.0) and older if it continues with a
+	// qualifier (1.0.0-SNAPSHOT and 1.0.0)
+	if len(parts1) > len(parts2) {
+		if _, err := strconv.Atoi(parts1[len(parts2)]); err != nil {
+			return -1
+		}
+		return 1
+	}
+	if len(parts2) > len(parts1) {
+		if _, err := strconv.Atoi(parts2[len(parts1)]); err != nil {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+// SaveDeployedCarbonApp keeps the CAR file of a Carbon App deployed in an environment to roll back a failed
+// deployment of a later version
+// @param env : Environment of the micro integrator
+// @param app : Deployed Carbon App
+// @return error
+func SaveDeployedCarbonApp(env string, app *CarbonApp) error {
+	backupPath := getDeployedCarbonAppBackupPath(env, app.Name)
+	if err := os.MkdirAll(filepath.Dir(backupPath), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(backupPath, app.Content, 0644)
+}
+
+// RemoveDeployedCarbonApp removes the CAR file kept for a Carbon App which was undeployed from an environment
+// @param env : Environment of the micro integrator
+// @param appName : Name of the Carbon App
+// @return error
+func RemoveDeployedCarbonApp(env, appName string) error {
+	err := os.Remove(getDeployedCarbonAppBackupPath(env, appName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// getDeployedCarbonAppBackup returns the CAR file kept for a Carbon App if it is of the deployed version
+func getDeployedCarbonAppBackup(env, appName, version string) *CarbonApp {
+	app, err := ReadCarbonApp(getDeployedCarbonAppBackupPath(env, appName))
+	if err != nil || app.Version != version {
+		utils.Logln(utils.LogPrefixWarning + "CAR file of Carbon App " + appName + " version " + version +
+			" is not available to roll back")
+		return nil
+	}
+	return app
+}
+
+// getDeployedCarbonAppBackupPath returns the path of the CAR file kept for a Carbon App. The nodes of a cluster share
+// the CAR files of the environment
+func getDeployedCarbonAppBackupPath(env, appName string) string {
+	if clusterEnv, ok := utils.GetClusterEnvOfMINode(env); ok {
+		env = clusterEnv
+	}
+	return filepath.Join(utils.DeployedCarbonAppsDirPath, env, appName+carbonAppFileExtension)
+}
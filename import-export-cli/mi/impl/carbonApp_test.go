@@ -0,0 +1,118 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const carbonAppTestDescriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<artifacts>
+    <artifact name="HealthcareCompositeExporter" version="1.0.0" type="carbon/application">
+        <dependency artifact="HealthcareAPI" version="1.0.0" include="true" serverRole="EnterpriseServiceBus"/>
+        <dependency artifact="GrandOakEndpoint" version="1.0.0" include="true" serverRole="EnterpriseServiceBus"/>
+        <dependency artifact="HealthcareRegistryResources" version="1.0.0" include="true" serverRole="EnterpriseServiceBus"/>
+        <dependency artifact="HealthcareConnector" version="1.0.0" include="true" serverRole="EnterpriseServiceBus"/>
+    </artifact>
+</artifacts>`
+
+func writeCarbonAppTestCAR(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range files {
+		file, err := writer.Create(name)
+		assert.Nil(t, err)
+		_, err = file.Write([]byte(content))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestParseCarbonApp(t *testing.T) {
+	content := writeCarbonAppTestCAR(t, map[string]string{
+		"artifacts.xml": carbonAppTestDescriptor,
+		"HealthcareAPI_1.0.0/artifact.xml": `<artifact name="HealthcareAPI" version="1.0.0" type="synapse/api">` +
+			`<file>HealthcareAPI-1.0.0.xml</file></artifact>`,
+		"GrandOakEndpoint_1.0.0/artifact.xml": `<artifact name="GrandOakEndpoint" version="1.0.0" ` +
+			`type="synapse/endpoint"/>`,
+		"HealthcareRegistryResources_1.0.0/artifact.xml": `<artifact name="HealthcareRegistryResources" ` +
+			`version="1.0.0" type="registry/resource"/>`,
+		"HealthcareConnector_1.0.0/artifact.xml": `<artifact name="HealthcareConnector" version="1.0.0" ` +
+			`type="synapse/lib"/>`,
+	})
+
+	app, err := parseCarbonApp(content)
+	assert.Nil(t, err)
+	assert.Equal(t, "HealthcareCompositeExporter", app.Name)
+	assert.Equal(t, "1.0.0", app.Version)
+	assert.Equal(t, "HealthcareCompositeExporter_1.0.0.car", app.FileName())
+	assert.Equal(t, []string{"HealthcareAPI", "GrandOakEndpoint"}, app.Artifacts,
+		"should list only the artifacts listed by the deployed Carbon App")
+	assert.Equal(t, content, app.Content)
+}
+
+func TestParseCarbonAppErrors(t *testing.T) {
+	_, err := parseCarbonApp([]byte("not a zip"))
+	assert.NotNil(t, err)
+
+	_, err = parseCarbonApp(writeCarbonAppTestCAR(t, map[string]string{"HealthcareAPI_1.0.0/artifact.xml": ""}))
+	assert.NotNil(t, err, "should require artifacts.xml")
+
+	_, err = parseCarbonApp(writeCarbonAppTestCAR(t, map[string]string{"artifacts.xml": "<artifacts"}))
+	assert.NotNil(t, err, "should fail for an invalid artifacts.xml")
+
+	_, err = parseCarbonApp(writeCarbonAppTestCAR(t, map[string]string{
+		"artifacts.xml": `<artifacts><artifact name="HealthcareAPI" version="1.0.0" type="synapse/api"/></artifacts>`,
+	}))
+	assert.NotNil(t, err, "should require a carbon/application artifact")
+}
+
+func TestCompareCarbonAppVersions(t *testing.T) {
+	tests := []struct {
+		v1, v2 string
+		want   int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "1.0.0-SNAPSHOT", 1},
+		{"1.0.0-SNAPSHOT", "1.0.0", -1},
+		{"1.0.0-SNAPSHOT", "1.0.0-SNAPSHOT", 0},
+		{"1.10", "1.9", 1},
+		{"1.9", "1.10", -1},
+		{"1.0.1", "1.0", 1},
+		{"1.0", "1.0.1", -1},
+		{"1.1.0-SNAPSHOT", "1.0.0", 1},
+		{"2.0.0-alpha", "2.0.0-beta", -1},
+		{"1.0.0.1", "1.0.0-SNAPSHOT", 1},
+	}
+	for _, test := range tests {
+		got := compareCarbonAppVersions(test.v1, test.v2)
+		switch {
+		case test.want == 0:
+			assert.Equal(t, 0, got, test.v1+" and "+test.v2)
+		case test.want < 0:
+			assert.Less(t, got, 0, test.v1+" should be older than "+test.v2)
+		default:
+			assert.Greater(t, got, 0, test.v1+" should be newer than "+test.v2)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	nodeHeader   = "NODE"
+	driftHeader  = "DRIFT"
+	resultHeader = "RESULT"
+
+	defaultNodeResultTableFormat = "table {{.Node}}\t{{.Result}}"
+	defaultNodeDetailsHeader     = "Node - %s\n"
+)
+
+// NodeResult is the outcome of invoking a node of a Micro Integrator cluster
+type NodeResult struct {
+	// Node that was invoked
+	Node utils.MINode
+	// Value returned by the node
+	Value interface{}
+	// Err returned by the node, if any
+	Err error
+}
+
+// InvokeNodes invokes f concurrently for each node of the Micro Integrator cluster in env
+// f receives the environment addressing the node, which can be used with any function of this package.
+// The results are returned in the order of the nodes and a failure of a node does not affect the others
+func InvokeNodes(env string, f func(nodeEnv string) (interface{}, error)) []NodeResult {
+	nodes, err := utils.GetMINodesOfEnv(env, utils.MainConfigFilePath)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting the nodes of environment "+env, err)
+	}
+	results := make([]NodeResult, len(nodes))
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node utils.MINode) {
+			defer wg.Done()
+			utils.Logln(utils.LogPrefixInfo + "invoking node " + node.Name)
+			value, err := f(node.Env)
+			results[i] = NodeResult{Node: node, Value: value, Err: err}
+		}(i, node)
+	}
+	wg.Wait()
+	return results
+}
+
+// PrintNodeResults prints the result of an action performed on each node of a Micro Integrator cluster
+func PrintNodeResults(results []NodeResult) {
+	var rows []map[string]interface{}
+	for _, result := range results {
+		row := map[string]interface{}{"Node": result.Node.Name, "Result": result.Value}
+		if result.Err != nil {
+			row["Result"] = utils.LogPrefixError + result.Err.Error()
+		}
+		rows = append(rows, row)
+	}
+	nodeResultTableHeaders := map[string]string{
+		"Node":   nodeHeader,
+		"Result": resultHeader,
+	}
+	writeNodeRows(rows, "", defaultNodeResultTableFormat, nodeResultTableHeaders)
+}
+
+// PrintNodeDetails prints the value returned by each node of a Micro Integrator cluster using printDetails, preceded by
+// the name of the node
+func PrintNodeDetails(results []NodeResult, printDetails func(value interface{}), printError func(err error)) {
+	for i, result := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf(defaultNodeDetailsHeader, result.Node.Name)
+		if result.Err != nil {
+			printError(result.Err)
+		} else {
+			printDetails(result.Value)
+		}
+	}
+}
+
+// printArtifactListOfNodes prints the artifacts listed by each node of a Micro Integrator cluster in a single table
+// Each row is an artifact of a node. The rows of an artifact are grouped together and the artifacts which are not
+// deployed in all the nodes or differ in any of the driftFields between the nodes are marked in the DRIFT column.
+// keyField is the field identifying an artifact and defaultFormat and headers are the ones used to print the list of
+// a single Micro Integrator. The errors of the nodes which could not be listed are printed after the table
+func printArtifactListOfNodes(results []NodeResult, format, defaultFormat string, headers map[string]string,
+	notFoundMessage, keyField string, driftFields ...string) {
+
+	rows, listedNodes := getArtifactRowsOfNodes(results, keyField, driftFields)
+	if len(rows) > 0 {
+		nodeHeaders := map[string]string{
+			"Node":  nodeHeader,
+			"Drift": driftHeader,
+		}
+		for field, header := range headers {
+			nodeHeaders[field] = header
+		}
+		nodeFormat := "table {{.Node}}\t" + strings.TrimPrefix(defaultFormat, "table ") + "\t{{.Drift}}"
+		writeNodeRows(rows, format, nodeFormat, nodeHeaders)
+	} else if listedNodes > 0 {
+		fmt.Println(notFoundMessage)
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Println(utils.LogPrefixError+"Node [ "+result.Node.Name+" ]", result.Err)
+		}
+	}
+}
+
+// getArtifactRowsOfNodes returns a row for each artifact listed by each node, grouped by the keyField of the artifacts
+// and marked with their drift in the Drift field, along with the number of nodes which were listed successfully
+func getArtifactRowsOfNodes(results []NodeResult, keyField string, driftFields []string) ([]map[string]interface{},
+	int) {
+	var keys []string
+	artifacts := make(map[string][]map[string]interface{})
+	listedNodes := 0
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		listedNodes++
+		for _, item := range getItemsOfArtifactList(result.Value) {
+			row := structToMap(item)
+			row["Node"] = result.Node.Name
+			key := fmt.Sprint(row[keyField])
+			if _, ok := artifacts[key]; !ok {
+				keys = append(keys, key)
+			}
+			artifacts[key] = append(artifacts[key], row)
+		}
+	}
+
+	var rows []map[string]interface{}
+	for _, key := range keys {
+		drift := getArtifactDrift(artifacts[key], results, listedNodes, driftFields)
+		for _, row := range artifacts[key] {
+			row["Drift"] = drift
+			rows = append(rows, row)
+		}
+	}
+	return rows, listedNodes
+}
+
+// getArtifactDrift describes how the rows of an artifact differ between the nodes which were listed successfully
+// An empty string is returned if the artifact is the same in all the nodes
+func getArtifactDrift(rows []map[string]interface{}, results []NodeResult, listedNodes int, driftFields []string) string {
+	var drift []string
+	if len(rows) < listedNodes {
+		var missingNodes []string
+		for _, result := range results {
+			if result.Err == nil && !containsNode(rows, result.Node.Name) {
+				missingNodes = append(missingNodes, result.Node.Name)
+			}
+		}
+		drift = append(drift, "missing in "+strings.Join(missingNodes, ","))
+	}
+	for _, field := range driftFields {
+		for _, row := range rows[1:] {
+			if fmt.Sprint(row[field]) != fmt.Sprint(rows[0][field]) {
+				drift = append(drift, strings.ToLower(field)+" differs")
+				break
+			}
+		}
+	}
+	return strings.Join(drift, ", ")
+}
+
+func containsNode(rows []map[string]interface{}, nodeName string) bool {
+	for _, row := range rows {
+		if row["Node"] == nodeName {
+			return true
+		}
+	}
+	return false
+}
+
+// getItemsOfArtifactList returns the artifacts of a list returned by a Get...List function, which is a pointer to a
+// struct holding the artifacts in a slice. A struct without a slice is a single artifact
+func getItemsOfArtifactList(list interface{}) []interface{} {
+	var items []interface{}
+	value := reflect.Indirect(reflect.ValueOf(list))
+	if value.Kind() != reflect.Struct {
+		return items
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() == reflect.Slice {
+			for j := 0; j < field.Len(); j++ {
+				items = append(items, field.Index(j).Interface())
+			}
+			return items
+		}
+	}
+	return append(items, value.Interface())
+}
+
+// structToMap converts an artifact to a map of its fields so that the node specific columns can be added to it while
+// the templates of the artifact keep working
+func structToMap(item interface{}) map[string]interface{} {
+	row := make(map[string]interface{})
+	value := reflect.Indirect(reflect.ValueOf(item))
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).PkgPath == "" {
+			row[value.Type().Field(i).Name] = value.Field(i).Interface()
+		}
+	}
+	return row
+}
+
+func writeNodeRows(rows []map[string]interface{}, format, defaultFormat string, headers map[string]string) {
+	nodeListContext := getContextWithFormat(format, defaultFormat)
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, row := range rows {
+			if err := t.Execute(w, row); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+	if err := nodeListContext.Write(renderer, headers); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
@@ -0,0 +1,149 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// clusterTestNodes are the nodes of a cluster used when the main config is not read
+var clusterTestNodes = []utils.MINode{
+	{Name: "mi1:9164", Env: "cluster#1"},
+	{Name: "mi2:9164", Env: "cluster#2"},
+	{Name: "mi3:9164", Env: "cluster#3"},
+}
+
+// captureStdout returns what f prints to the standard output
+func captureStdout(t *testing.T, f func()) string {
+	r, w, err := os.Pipe()
+	assert.Nil(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	f()
+	assert.Nil(t, w.Close())
+	out, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	return string(out)
+}
+
+func TestInvokeNodes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-mi-cluster")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	mainConfigFilePath := utils.MainConfigFilePath
+	defer func() { utils.MainConfigFilePath = mainConfigFilePath }()
+	utils.MainConfigFilePath = filepath.Join(dir, utils.MainConfigFileName)
+	assert.Nil(t, ioutil.WriteFile(utils.MainConfigFilePath, []byte(`environments:
+  cluster:
+    mi: https://mi1:9164
+    miNodes:
+    - https://mi1:9164
+    - https://mi2:9164
+    - https://mi3:9164
+`), 0644))
+
+	results := InvokeNodes("cluster", func(nodeEnv string) (interface{}, error) {
+		switch nodeEnv {
+		case "cluster#1":
+			// the results are kept in the order of the nodes even if the first node responds last
+			time.Sleep(50 * time.Millisecond)
+			return "first", nil
+		case "cluster#2":
+			return nil, errors.New("connection refused")
+		}
+		return "third", nil
+	})
+
+	assert.Equal(t, 3, len(results))
+	for i, result := range results {
+		assert.Equal(t, clusterTestNodes[i].Name, result.Node.Name)
+		assert.Equal(t, clusterTestNodes[i].Env, result.Node.Env)
+	}
+	assert.Equal(t, "first", results[0].Value)
+	assert.Nil(t, results[0].Err)
+	assert.Nil(t, results[1].Value)
+	assert.EqualError(t, results[1].Err, "connection refused")
+	assert.Equal(t, "third", results[2].Value, "a failed node should not affect the others")
+	assert.Nil(t, results[2].Err)
+}
+
+// endpointListTestResults returns the endpoints listed by a cluster where the endpoint B is missing in the second
+// node, the endpoint A is inactive in the second node and the third node fails
+func endpointListTestResults() []NodeResult {
+	return []NodeResult{
+		{Node: clusterTestNodes[0], Value: &artifactutils.EndpointList{Count: 2, Endpoints: []artifactutils.EndpointSummary{
+			{Name: "A", Type: "http", Active: true}, {Name: "B", Type: "http", Active: true}}}},
+		{Node: clusterTestNodes[1], Value: &artifactutils.EndpointList{Count: 1, Endpoints: []artifactutils.EndpointSummary{
+			{Name: "A", Type: "http", Active: false}}}},
+		{Node: clusterTestNodes[2], Err: errors.New("connection refused")},
+	}
+}
+
+func TestGetArtifactRowsOfNodes(t *testing.T) {
+	rows, listedNodes := getArtifactRowsOfNodes(endpointListTestResults(), "Name", []string{"Active"})
+	assert.Equal(t, 2, listedNodes)
+	assert.Equal(t, []map[string]interface{}{
+		{"Node": "mi1:9164", "Name": "A", "Type": "http", "Active": true, "Drift": "active differs"},
+		{"Node": "mi2:9164", "Name": "A", "Type": "http", "Active": false, "Drift": "active differs"},
+		{"Node": "mi1:9164", "Name": "B", "Type": "http", "Active": true, "Drift": "missing in mi2:9164"},
+	}, rows)
+
+	// the artifacts are the same in the nodes which were listed
+	results := endpointListTestResults()
+	results[1] = NodeResult{Node: clusterTestNodes[1], Err: errors.New("unauthorized")}
+	rows, listedNodes = getArtifactRowsOfNodes(results, "Name", []string{"Active"})
+	assert.Equal(t, 1, listedNodes)
+	for _, row := range rows {
+		assert.Equal(t, "", row["Drift"])
+	}
+
+	rows, listedNodes = getArtifactRowsOfNodes([]NodeResult{{Node: clusterTestNodes[0],
+		Value: &artifactutils.EndpointList{}}}, "Name", []string{"Active"})
+	assert.Empty(t, rows)
+	assert.Equal(t, 1, listedNodes)
+}
+
+func TestPrintEndpointListOfNodes(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintEndpointListOfNodes(endpointListTestResults(), "")
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Equal(t, 5, len(lines))
+	assert.Equal(t, []string{"NODE", "NAME", "TYPE", "ACTIVE", "DRIFT"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"mi1:9164", "A", "http", "true", "active", "differs"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"mi2:9164", "A", "http", "false", "active", "differs"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"mi1:9164", "B", "http", "true", "missing", "in", "mi2:9164"}, strings.Fields(lines[3]))
+	assert.Equal(t, utils.LogPrefixError+"Node [ mi3:9164 ] connection refused", lines[4])
+
+	out = captureStdout(t, func() {
+		PrintEndpointListOfNodes([]NodeResult{{Node: clusterTestNodes[0], Value: &artifactutils.EndpointList{}}}, "")
+	})
+	assert.Equal(t, "No Endpoints found\n", out)
+}
@@ -52,6 +52,9 @@ func unmarshalData(url string, params map[string]string, env string, model inter
 	resp, err := invokeGETRequestWithRetry(url, params, env)
 
 	if err != nil {
+		if isMINodeEnv(env) {
+			return nil, errors.New("Unable to connect to " + url + " " + err.Error())
+		}
 		utils.HandleErrorAndExit("Unable to connect to "+url, err)
 	}
 
@@ -67,6 +70,9 @@ func unmarshalData(url string, params map[string]string, env string, model inter
 		return response, nil
 	}
 	if resp.StatusCode() == http.StatusUnauthorized {
+		if isMINodeEnv(env) {
+			return nil, errors.New("Invalid credentials. " + resp.Status())
+		}
 		fmt.Println("Invalid credentials. Please login to the current Micro Integrator instance")
 		utils.HandleErrorAndExit("Execute 'apictl mi login --help' for more information", nil)
 	}
@@ -81,6 +87,9 @@ func downloadLogFileData(url string, params map[string]string, env string) ([]by
 	resp, err := invokeGETRequestWithRetry(url, params, env)
 
 	if err != nil {
+		if isMINodeEnv(env) {
+			return nil, errors.New("Unable to connect to " + url + " " + err.Error())
+		}
 		utils.HandleErrorAndExit("Unable to connect to "+url, err)
 	}
 
@@ -90,19 +99,28 @@ func downloadLogFileData(url string, params map[string]string, env string) ([]by
 		return resp.Body(), nil
 	}
 	if resp.StatusCode() == http.StatusUnauthorized {
+		if isMINodeEnv(env) {
+			return nil, errors.New("Invalid credentials. " + resp.Status())
+		}
 		fmt.Println("Invalid credentials. Please login to the current Micro Integrator instance")
 		utils.HandleErrorAndExit("Execute 'apictl mi login --help' for more information", nil)
 	}
 	return nil, errors.New(resp.Status())
 }
 
-func handleResponse(resp *resty.Response, err error, url, messageTag, errorTag string) (string, error) {
+func handleResponse(resp *resty.Response, err error, env, url, messageTag, errorTag string) (string, error) {
 	if err != nil {
+		if isMINodeEnv(env) {
+			return "", errors.New("Unable to connect to " + url + " " + err.Error())
+		}
 		utils.HandleErrorAndExit("Unable to connect to "+url, err)
 	}
 	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
 
 	if resp.StatusCode() == http.StatusUnauthorized {
+		if isMINodeEnv(env) {
+			return "", errors.New("Invalid credentials. " + resp.Status())
+		}
 		fmt.Println("Invalid credentials. Please login to the current Micro Integrator instance")
 		utils.HandleErrorAndExit("Execute 'apictl mi login --help' for more information", nil)
 	}
@@ -118,6 +136,9 @@ func handleResponse(resp *resty.Response, err error, url, messageTag, errorTag s
 
 func retryHTTPCall(attempts int, env string, f func(string) (*resty.Response, error)) (*resty.Response, error) {
 	cred, err := credentials.GetMICredentials(env)
+	if err != nil {
+		return nil, err
+	}
 	resp, err := f(cred.AccessToken)
 	if resp.StatusCode() == http.StatusUnauthorized {
 		if attempts--; attempts > 0 {
@@ -157,6 +178,14 @@ func invokePOSTRequestWithRetry(env, url string, body interface{}) (*resty.Respo
 	})
 }
 
+func invokePOSTFileRequestWithRetry(env, url, fileName string, content []byte) (*resty.Response, error) {
+	return retryHTTPCall(miHTTPRetryCount, env, func(accessToken string) (*resty.Response, error) {
+		headers := make(map[string]string)
+		headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+		return utils.InvokePOSTRequestWithFileContentAndQueryParams(nil, url, headers, "file", fileName, content)
+	})
+}
+
 func invokeDELETERequestWithRetry(url string, env string) (*resty.Response, error) {
 	return retryHTTPCall(miHTTPRetryCount, env, func(accessToken string) (*resty.Response, error) {
 		headers := make(map[string]string)
@@ -165,6 +194,13 @@ func invokeDELETERequestWithRetry(url string, env string) (*resty.Response, erro
 	})
 }
 
+// isMINodeEnv checks whether env addresses a node of a Micro Integrator cluster. Errors of a node are returned to the
+// caller instead of exiting so that the other nodes of the cluster are still processed
+func isMINodeEnv(env string) bool {
+	_, ok := utils.GetClusterEnvOfMINode(env)
+	return ok
+}
+
 func unmarshalJSONToStringMap(body []byte) map[string]string {
 	var data map[string]string
 	unmarshalError := json.Unmarshal(body, &data)
@@ -249,5 +285,5 @@ func updateArtifactState(url, artifactName, state, env string) (string, error) {
 		Status: state,
 	}
 	resp, err := invokePOSTRequestWithRetry(env, url, body)
-	return handleResponse(resp, err, url, "Message", "Error")
+	return handleResponse(resp, err, env, url, "Message", "Error")
 }
@@ -0,0 +1,166 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	apimImpl "github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Version of the generated API if the integration API is not versioned
+const defaultIntegrationAPIVersion = "1.0.0"
+
+// matches the variables of the uri-templates of the resources (eg: {category} of /doctors/{category})
+var integrationAPIVariableRegex = regexp.MustCompile(`{(\w+)}`)
+
+// ExportIntegrationAPIAsAPIMProject generates an API Manager project from an integration API deployed in the micro
+// integrator in a given environment
+// @param env : Environment of the micro integrator
+// @param apiName : Name of the integration API
+// @param exportDirectory : Directory in which the project is created as <name>-<version>
+// @return path of the project
+// @return error
+func ExportIntegrationAPIAsAPIMProject(env, apiName, exportDirectory string) (string, error) {
+	api, err := GetIntegrationAPI(env, apiName)
+	if err != nil {
+		return "", err
+	}
+	if api.Url == "" {
+		return "", errors.New("the URL of the API " + apiName + " is not available")
+	}
+	definition, err := json.Marshal(GenerateOpenAPIForIntegrationAPI(api))
+	if err != nil {
+		return "", err
+	}
+	definition, err = utils.JsonToYaml(definition)
+	if err != nil {
+		return "", err
+	}
+
+	version := api.Version
+	if version == "" {
+		version = defaultIntegrationAPIVersion
+	}
+	projectPath := filepath.Join(exportDirectory, api.Name+"-"+version)
+	if _, err := os.Stat(projectPath); err == nil {
+		return "", errors.New(projectPath + " already exists")
+	}
+
+	definitionFile, err := ioutil.TempFile("", "mi-api-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(definitionFile.Name())
+	_, err = definitionFile.Write(definition)
+	definitionFile.Close()
+	if err != nil {
+		return "", err
+	}
+
+	err = apimImpl.InitAPIProject(projectPath, "", apimImpl.InitDefinitionTypeOAS, definitionFile.Name(), "", false)
+	if err != nil {
+		_ = os.RemoveAll(projectPath)
+		return "", err
+	}
+	return projectPath, nil
+}
+
+// GenerateOpenAPIForIntegrationAPI generates an OpenAPI 3.0 definition from the resources of an integration API. The
+// URL of the API is used as the server, so it becomes the backend endpoint and its path becomes the context of the API
+func GenerateOpenAPIForIntegrationAPI(api *artifactutils.IntegrationAPI) map[string]interface{} {
+	version := api.Version
+	if version == "" {
+		version = defaultIntegrationAPIVersion
+	}
+	paths := make(map[string]map[string]interface{})
+	for _, resource := range api.Resources {
+		path, query := integrationAPIResourcePath(resource.Url)
+		if paths[path] == nil {
+			paths[path] = make(map[string]interface{})
+		}
+		parameters := integrationAPIParameters(path, "path")
+		parameters = append(parameters, integrationAPIParameters(query, "query")...)
+		// sort a copy as the resources belong to the caller
+		methods := append([]string{}, resource.Methods...)
+		sort.Strings(methods)
+		for _, method := range methods {
+			operation := map[string]interface{}{
+				"responses": map[string]interface{}{
+					"default": map[string]interface{}{"description": "Default response"},
+				},
+			}
+			if len(parameters) > 0 {
+				operation["parameters"] = parameters
+			}
+			paths[path][strings.ToLower(method)] = operation
+		}
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.1",
+		"info": map[string]interface{}{
+			"title":       api.Name,
+			"version":     version,
+			"description": fmt.Sprintf("Generated from the integration API %s of the micro integrator", api.Name),
+		},
+		"servers": []interface{}{map[string]interface{}{"url": api.Url}},
+		"paths":   paths,
+	}
+}
+
+// integrationAPIResourcePath returns the path and the query of the uri-template or the url-mapping of a resource.
+// A resource without a template or a mapping matches all the paths
+func integrationAPIResourcePath(resourceURL string) (string, string) {
+	path, query := resourceURL, ""
+	if index := strings.Index(resourceURL, "?"); index >= 0 {
+		path, query = resourceURL[:index], resourceURL[index+1:]
+	}
+	if path == "" {
+		path = "/*"
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path, query
+}
+
+// integrationAPIParameters returns the variables of a path or a query of a uri-template as OpenAPI parameters
+func integrationAPIParameters(template, in string) []interface{} {
+	var parameters []interface{}
+	for _, match := range integrationAPIVariableRegex.FindAllStringSubmatch(template, -1) {
+		parameters = append(parameters, map[string]interface{}{
+			"name":     match[1],
+			"in":       in,
+			"required": in == "path",
+			"schema":   map[string]interface{}{"type": "string"},
+		})
+	}
+	return parameters
+}
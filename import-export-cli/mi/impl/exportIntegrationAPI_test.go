@@ -0,0 +1,125 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+)
+
+func integrationAPITestOperation(parameters ...interface{}) map[string]interface{} {
+	operation := map[string]interface{}{
+		"responses": map[string]interface{}{
+			"default": map[string]interface{}{"description": "Default response"},
+		},
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+	return operation
+}
+
+func integrationAPITestParameter(name, in string) interface{} {
+	return map[string]interface{}{
+		"name":     name,
+		"in":       in,
+		"required": in == "path",
+		"schema":   map[string]interface{}{"type": "string"},
+	}
+}
+
+func TestGenerateOpenAPIForIntegrationAPI(t *testing.T) {
+	tests := []struct {
+		name    string
+		api     artifactutils.IntegrationAPI
+		version string
+		paths   map[string]map[string]interface{}
+	}{
+		{
+			name: "uri-template with path and query variables",
+			api: artifactutils.IntegrationAPI{Name: "HealthcareAPI", Version: "2.0.0", Resources: []artifactutils.Resource{
+				{Methods: []string{"POST", "GET"}, Url: "/doctors/{category}?hospital={hospital}"},
+			}},
+			version: "2.0.0",
+			paths: map[string]map[string]interface{}{
+				"/doctors/{category}": {
+					"get": integrationAPITestOperation(integrationAPITestParameter("category", "path"),
+						integrationAPITestParameter("hospital", "query")),
+					"post": integrationAPITestOperation(integrationAPITestParameter("category", "path"),
+						integrationAPITestParameter("hospital", "query")),
+				},
+			},
+		},
+		{
+			name: "url-mapping",
+			api: artifactutils.IntegrationAPI{Name: "HealthcareAPI", Version: "1.0.0", Resources: []artifactutils.Resource{
+				{Methods: []string{"GET"}, Url: "/*"},
+				{Methods: []string{"PUT"}, Url: "reports/*"},
+			}},
+			version: "1.0.0",
+			paths: map[string]map[string]interface{}{
+				"/*":         {"get": integrationAPITestOperation()},
+				"/reports/*": {"put": integrationAPITestOperation()},
+			},
+		},
+		{
+			name: "empty url",
+			api: artifactutils.IntegrationAPI{Name: "HealthcareAPI", Version: "1.0.0", Resources: []artifactutils.Resource{
+				{Methods: []string{"DELETE"}, Url: ""},
+			}},
+			version: "1.0.0",
+			paths: map[string]map[string]interface{}{
+				"/*": {"delete": integrationAPITestOperation()},
+			},
+		},
+		{
+			name: "unversioned API",
+			api: artifactutils.IntegrationAPI{Name: "HealthcareAPI", Resources: []artifactutils.Resource{
+				{Methods: []string{"GET"}, Url: "/doctors"},
+			}},
+			version: defaultIntegrationAPIVersion,
+			paths: map[string]map[string]interface{}{
+				"/doctors": {"get": integrationAPITestOperation()},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.api.Url = "http://localhost:8290/healthcare"
+			definition := GenerateOpenAPIForIntegrationAPI(&test.api)
+			assert.Equal(t, "3.0.1", definition["openapi"])
+			info := definition["info"].(map[string]interface{})
+			assert.Equal(t, "HealthcareAPI", info["title"])
+			assert.Equal(t, test.version, info["version"])
+			assert.Equal(t, []interface{}{map[string]interface{}{"url": "http://localhost:8290/healthcare"}},
+				definition["servers"])
+			assert.Equal(t, test.paths, definition["paths"])
+		})
+	}
+}
+
+func TestGenerateOpenAPIForIntegrationAPIKeepsResources(t *testing.T) {
+	api := &artifactutils.IntegrationAPI{Name: "HealthcareAPI", Resources: []artifactutils.Resource{
+		{Methods: []string{"POST", "GET", "DELETE"}, Url: "/doctors"},
+	}}
+	GenerateOpenAPIForIntegrationAPI(api)
+	assert.Equal(t, []string{"POST", "GET", "DELETE"}, api.Resources[0].Methods,
+		"should not reorder the methods of the API")
+}
@@ -0,0 +1,300 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Log levels of the micro integrator in the ascending order of severity
+var logLevels = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+// matches the first line of a log entry (eg: [2021-05-10 10:15:30,123]  INFO {org.apache.synapse.Foo} - message)
+var logEntryHeaderRegex = regexp.MustCompile(`^\[(\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2})[,.]?\d*\]\s+(TRACE|DEBUG|INFO|WARN|WARNING|ERROR|FATAL)\b`)
+
+// maximum length of the beginning of a log file used to detect that the file was rotated
+const logFileFingerprintLength = 256
+
+const bytesPerMB = 1024 * 1024
+
+// LogFollowOptions are the options to follow a log file of the micro integrator
+type LogFollowOptions struct {
+	// Since skips the log entries logged before this time if not zero
+	Since time.Time
+	// Pattern skips the lines which do not match it if not nil
+	Pattern *regexp.Regexp
+	// Level skips the log entries with a lower level than this if not empty
+	Level string
+	// Interval is the time between two polls of the log file
+	Interval time.Duration
+	// MaxSize stops following the log file when it is larger than this number of bytes if not zero, as the whole file
+	// is downloaded at every poll
+	MaxSize int64
+}
+
+// logFilter decides which lines of a log file are printed. The time and the level of a log entry are read from its
+// first line and the other lines of the entry (eg: a stack trace) are printed only if the first line is printed
+type logFilter struct {
+	options      LogFollowOptions
+	minLevel     int
+	includeEntry bool
+}
+
+// logTail reads the new complete lines of a log file from the successive downloads of the whole file
+type logTail struct {
+	offset      int
+	fingerprint []byte
+}
+
+// ParseLogLevel validates a log level of the micro integrator and returns it in the upper case
+// @param level : Log level (eg: warn)
+// @return the log level
+// @return error if it is not a valid log level
+func ParseLogLevel(level string) (string, error) {
+	level = strings.ToUpper(level)
+	if logLevelRank(level) < 0 {
+		return "", errors.New("invalid log level " + level + ". Log level should be one of " +
+			strings.Join(logLevels, ", "))
+	}
+	return level, nil
+}
+
+// ParseLogSince parses the time to start printing the logs from. The time can be a duration relative to now
+// (eg: 10m, 1h30m) or a local time in the format 2006-01-02 15:04:05, 2006-01-02T15:04:05 or 2006-01-02, or an RFC3339
+// time
+// @param since : Duration or time
+// @param now : Current time
+// @return the time
+// @return error if the value is neither a duration nor a time
+func ParseLogSince(since string, now time.Time) (time.Time, error) {
+	if duration, err := time.ParseDuration(since); err == nil {
+		return now.Add(-duration), nil
+	}
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, since, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("invalid time " + since + ". Use a duration (eg: 10m) or a time " +
+		"(eg: 2006-01-02 15:04:05)")
+}
+
+// FollowLogFile prints the lines of a log file created by the micro integrator in a given environment and keeps
+// printing the lines appended to it until the command is interrupted. The management API serves only whole log files,
+// so the file is downloaded at every poll and only the lines after the previously printed byte offset are printed.
+// If the log file is rotated, the remaining lines of the rotated file are printed before the lines of the new file.
+// Following stops when the log file grows larger than options.MaxSize, after printing its new lines.
+// @param env : Environment of the micro integrator
+// @param logFileName : Name of the log file (eg: wso2carbon.log)
+// @param options : Filters, the poll interval and the maximum size of the log file
+// @param out : Writer to print the lines into
+// @return error if the log file cannot be downloaded at the start or it is larger than the maximum size
+func FollowLogFile(env, logFileName string, options LogFollowOptions, out io.Writer) error {
+	content, err := fetchLogFile(env, logFileName)
+	if err != nil {
+		return err
+	}
+	if err = checkLogFileSize(logFileName, content, options.MaxSize); err != nil {
+		return err
+	}
+	tail := &logTail{}
+	filter := newLogFilter(options)
+	failing := false
+	for {
+		lines, rotated := tail.next(content)
+		if rotated {
+			printLogLines(out, filter, readRotatedLogFile(env, logFileName, tail))
+			fmt.Fprintln(os.Stderr, "Log file "+logFileName+" was rotated")
+			tail = &logTail{}
+			lines, _ = tail.next(content)
+		}
+		printLogLines(out, filter, lines)
+		if err = checkLogFileSize(logFileName, content, options.MaxSize); err != nil {
+			return err
+		}
+
+		time.Sleep(options.Interval)
+		latestContent, err := fetchLogFile(env, logFileName)
+		if err != nil {
+			// keep following as the micro integrator may be restarting
+			if !failing {
+				fmt.Fprintln(os.Stderr, "Error while reading the log file "+logFileName+". Retrying..", err)
+			}
+			failing = true
+			continue
+		}
+		if failing {
+			fmt.Fprintln(os.Stderr, "Reading the log file "+logFileName+" resumed")
+		}
+		failing = false
+		content = latestContent
+	}
+}
+
+// checkLogFileSize returns an error if a log file is larger than maxSize bytes, unless maxSize is zero
+func checkLogFileSize(logFileName string, content []byte, maxSize int64) error {
+	if maxSize <= 0 || int64(len(content)) <= maxSize {
+		return nil
+	}
+	return fmt.Errorf("log file %s is %.1f MB, which is larger than %.1f MB to be downloaded at every poll. "+
+		"Stopped following it. Download it without --follow instead, or increase --max-size", logFileName,
+		float64(len(content))/bytesPerMB, float64(maxSize)/bytesPerMB)
+}
+
+// fetchLogFile downloads a log file. Unlike downloadLogFileData, it does not exit if the micro integrator is not
+// reachable, so that following a log file survives a restart of the micro integrator
+func fetchLogFile(env, logFileName string) ([]byte, error) {
+	url := utils.GetMIManagementEndpointOfResource(utils.MiManagementLogResource, env, utils.MainConfigFilePath)
+	params := make(map[string]string)
+	params["file"] = logFileName
+	resp, err := invokeGETRequestWithRetry(url, params, env)
+	if err != nil {
+		return nil, err
+	}
+	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
+	if resp.StatusCode() == http.StatusUnauthorized {
+		fmt.Println("Invalid credentials. Please login to the current Micro Integrator instance")
+		utils.HandleErrorAndExit("Execute 'apictl mi login --help' for more information", nil)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(resp.Status())
+	}
+	return resp.Body(), nil
+}
+
+// readRotatedLogFile finds the file the followed log file was rotated into and returns its lines which were not
+// printed yet. The rotated file is identified by the beginning of the followed file (eg: wso2carbon-05-10-2021.log
+// for wso2carbon.log). Returns nil if the rotated file is not found.
+func readRotatedLogFile(env, logFileName string, tail *logTail) []string {
+	logFileList, err := GetLogFileList(env)
+	if err != nil {
+		return nil
+	}
+	stem := strings.TrimSuffix(logFileName, ".log")
+	for _, logFile := range FilterOnlyLogFiles(logFileList).LogFiles {
+		if logFile.FileName == logFileName || !strings.HasPrefix(logFile.FileName, stem) {
+			continue
+		}
+		content, err := fetchLogFile(env, logFile.FileName)
+		if err != nil || !tail.isSameFile(content) {
+			continue
+		}
+		lines, _ := tail.next(content)
+		// the rotated file is complete, so its last line is printed even if it does not end with a new line
+		if tail.offset < len(content) {
+			lines = append(lines, string(content[tail.offset:]))
+		}
+		return lines
+	}
+	return nil
+}
+
+// next returns the complete lines of the content after the lines returned previously. rotated is true if the content
+// is not a continuation of the previous content, which means the file was rotated. No lines are returned in that case.
+func (t *logTail) next(content []byte) (lines []string, rotated bool) {
+	if t.fingerprint != nil && !t.isSameFile(content) {
+		return nil, true
+	}
+	if t.fingerprint == nil || len(t.fingerprint) < logFileFingerprintLength {
+		t.fingerprint = logFileFingerprint(content)
+	}
+	end := bytes.LastIndexByte(content, '\n') + 1
+	if end <= t.offset {
+		return nil, false
+	}
+	newContent := strings.TrimSuffix(string(content[t.offset:end]), "\n")
+	t.offset = end
+	for _, line := range strings.Split(newContent, "\n") {
+		lines = append(lines, strings.TrimSuffix(line, "\r"))
+	}
+	return lines, false
+}
+
+// isSameFile returns whether the content is a continuation of the content read previously
+func (t *logTail) isSameFile(content []byte) bool {
+	return len(content) >= t.offset && bytes.HasPrefix(content, t.fingerprint)
+}
+
+// logFileFingerprint returns the beginning of a log file which is used to detect that the file was rotated
+func logFileFingerprint(content []byte) []byte {
+	if len(content) > logFileFingerprintLength {
+		content = content[:logFileFingerprintLength]
+	}
+	return append([]byte{}, content...)
+}
+
+func newLogFilter(options LogFollowOptions) *logFilter {
+	return &logFilter{
+		options:      options,
+		minLevel:     logLevelRank(options.Level),
+		includeEntry: true,
+	}
+}
+
+// include returns whether a line should be printed
+func (f *logFilter) include(line string) bool {
+	if match := logEntryHeaderRegex.FindStringSubmatch(line); match != nil {
+		f.includeEntry = f.minLevel <= logLevelRank(match[2])
+		if !f.options.Since.IsZero() {
+			entryTime, err := time.ParseInLocation("2006-01-02 15:04:05", strings.Replace(match[1], "T", " ", 1),
+				time.Local)
+			if err == nil && entryTime.Before(f.options.Since.Truncate(time.Second)) {
+				f.includeEntry = false
+			}
+		}
+	}
+	if !f.includeEntry {
+		return false
+	}
+	return f.options.Pattern == nil || f.options.Pattern.MatchString(line)
+}
+
+// printLogLines prints the lines included by the filter
+func printLogLines(out io.Writer, filter *logFilter, lines []string) {
+	for _, line := range lines {
+		if filter.include(line) {
+			fmt.Fprintln(out, line)
+		}
+	}
+}
+
+// logLevelRank returns the index of a log level in the ascending order of severity, or -1 if it is not a log level
+func logLevelRank(level string) int {
+	if level == "WARNING" {
+		level = "WARN"
+	}
+	for i, logLevel := range logLevels {
+		if logLevel == level {
+			return i
+		}
+	}
+	return -1
+}
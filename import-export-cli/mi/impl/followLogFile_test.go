@@ -0,0 +1,266 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogTailNext(t *testing.T) {
+	longHeader := strings.Repeat("#", logFileFingerprintLength) + "\n"
+	tests := []struct {
+		name     string
+		contents []string
+		lines    [][]string
+		rotated  []bool
+	}{
+		{
+			name:     "complete lines",
+			contents: []string{"a\nb\n", "a\nb\nc\nd\n"},
+			lines:    [][]string{{"a", "b"}, {"c", "d"}},
+			rotated:  []bool{false, false},
+		},
+		{
+			name:     "partial last line is returned when it is completed",
+			contents: []string{"a\nb", "a\nbc", "a\nbc\nd"},
+			lines:    [][]string{{"a"}, nil, {"bc"}},
+			rotated:  []bool{false, false, false},
+		},
+		{
+			name:     "windows line endings",
+			contents: []string{"a\r\nb\r\n"},
+			lines:    [][]string{{"a", "b"}},
+			rotated:  []bool{false},
+		},
+		{
+			name:     "empty file",
+			contents: []string{"", "a\n"},
+			lines:    [][]string{nil, {"a"}},
+			rotated:  []bool{false, false},
+		},
+		{
+			name:     "rotated into a shorter file",
+			contents: []string{"a\nb\nc\n", "a\n"},
+			lines:    [][]string{{"a", "b", "c"}, nil},
+			rotated:  []bool{false, true},
+		},
+		{
+			name:     "rotated into a file with another beginning",
+			contents: []string{"a\nb\n", "x\ny\nz\n"},
+			lines:    [][]string{{"a", "b"}, nil},
+			rotated:  []bool{false, true},
+		},
+		{
+			name:     "beginning of a short file grows into the fingerprint",
+			contents: []string{"a\n", "a\n" + longHeader, "b\n" + longHeader},
+			lines:    [][]string{{"a"}, {strings.TrimSuffix(longHeader, "\n")}, nil},
+			rotated:  []bool{false, false, true},
+		},
+		{
+			name:     "changes after the fingerprint are not a rotation",
+			contents: []string{longHeader + "a\n", longHeader + "a\nb\n"},
+			lines:    [][]string{{strings.TrimSuffix(longHeader, "\n"), "a"}, {"b"}},
+			rotated:  []bool{false, false},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tail := &logTail{}
+			for i, content := range test.contents {
+				lines, rotated := tail.next([]byte(content))
+				assert.Equal(t, test.lines[i], lines, "lines of content %d", i)
+				assert.Equal(t, test.rotated[i], rotated, "rotation of content %d", i)
+			}
+		})
+	}
+}
+
+func TestLogFilterInclude(t *testing.T) {
+	since := time.Date(2021, 5, 10, 10, 15, 30, 500000000, time.Local)
+	tests := []struct {
+		name     string
+		options  LogFollowOptions
+		lines    []string
+		included []string
+	}{
+		{
+			name: "no filters",
+			lines: []string{
+				"[2021-05-10 10:15:29,999] DEBUG {org.apache.synapse.Foo} - debug",
+				"plain line",
+			},
+			included: []string{
+				"[2021-05-10 10:15:29,999] DEBUG {org.apache.synapse.Foo} - debug",
+				"plain line",
+			},
+		},
+		{
+			name:    "since boundary",
+			options: LogFollowOptions{Since: since},
+			lines: []string{
+				"[2021-05-10 10:15:29,999]  INFO {org.apache.synapse.Foo} - before",
+				"[2021-05-10 10:15:30,100]  INFO {org.apache.synapse.Foo} - same second",
+				"[2021-05-10T10:15:31.000]  INFO {org.apache.synapse.Foo} - after",
+			},
+			included: []string{
+				"[2021-05-10 10:15:30,100]  INFO {org.apache.synapse.Foo} - same second",
+				"[2021-05-10T10:15:31.000]  INFO {org.apache.synapse.Foo} - after",
+			},
+		},
+		{
+			name:    "level",
+			options: LogFollowOptions{Level: "WARN"},
+			lines: []string{
+				"[2021-05-10 10:15:30,100]  INFO {org.apache.synapse.Foo} - info",
+				"[2021-05-10 10:15:30,200]  WARNING {org.apache.synapse.Foo} - warning",
+				"[2021-05-10 10:15:30,300] ERROR {org.apache.synapse.Foo} - error",
+			},
+			included: []string{
+				"[2021-05-10 10:15:30,200]  WARNING {org.apache.synapse.Foo} - warning",
+				"[2021-05-10 10:15:30,300] ERROR {org.apache.synapse.Foo} - error",
+			},
+		},
+		{
+			name:    "stack trace follows its entry",
+			options: LogFollowOptions{Level: "ERROR"},
+			lines: []string{
+				"[2021-05-10 10:15:30,100]  WARN {org.apache.synapse.Foo} - warning",
+				"java.lang.IllegalStateException: skipped",
+				"\tat org.apache.synapse.Foo.bar(Foo.java:10)",
+				"[2021-05-10 10:15:30,300] ERROR {org.apache.synapse.Foo} - error",
+				"java.lang.IllegalStateException: printed",
+				"\tat org.apache.synapse.Foo.bar(Foo.java:20)",
+			},
+			included: []string{
+				"[2021-05-10 10:15:30,300] ERROR {org.apache.synapse.Foo} - error",
+				"java.lang.IllegalStateException: printed",
+				"\tat org.apache.synapse.Foo.bar(Foo.java:20)",
+			},
+		},
+		{
+			name:    "stack trace of an entry before since",
+			options: LogFollowOptions{Since: since},
+			lines: []string{
+				"[2021-05-10 10:15:29,000] ERROR {org.apache.synapse.Foo} - old",
+				"\tat org.apache.synapse.Foo.bar(Foo.java:10)",
+				"[2021-05-10 10:15:31,000] ERROR {org.apache.synapse.Foo} - new",
+				"\tat org.apache.synapse.Foo.bar(Foo.java:20)",
+			},
+			included: []string{
+				"[2021-05-10 10:15:31,000] ERROR {org.apache.synapse.Foo} - new",
+				"\tat org.apache.synapse.Foo.bar(Foo.java:20)",
+			},
+		},
+		{
+			name:    "pattern is matched by each line",
+			options: LogFollowOptions{Level: "ERROR", Pattern: regexp.MustCompile("Foo.java")},
+			lines: []string{
+				"[2021-05-10 10:15:30,100]  INFO {org.apache.synapse.Foo} - info",
+				"\tat org.apache.synapse.Foo.bar(Foo.java:10)",
+				"[2021-05-10 10:15:30,300] ERROR {org.apache.synapse.Foo} - error",
+				"\tat org.apache.synapse.Foo.bar(Foo.java:20)",
+			},
+			included: []string{
+				"\tat org.apache.synapse.Foo.bar(Foo.java:20)",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := newLogFilter(test.options)
+			var included []string
+			for _, line := range test.lines {
+				if filter.include(line) {
+					included = append(included, line)
+				}
+			}
+			assert.Equal(t, test.included, included)
+		})
+	}
+}
+
+func TestParseLogSince(t *testing.T) {
+	now := time.Date(2021, 5, 10, 10, 15, 30, 0, time.Local)
+	tests := []struct {
+		since    string
+		expected time.Time
+		valid    bool
+	}{
+		{since: "10m", expected: now.Add(-10 * time.Minute), valid: true},
+		{since: "1h30m", expected: now.Add(-90 * time.Minute), valid: true},
+		{since: "2021-05-10T08:00:00Z", expected: time.Date(2021, 5, 10, 8, 0, 0, 0, time.UTC), valid: true},
+		{since: "2021-05-10 09:00:00", expected: time.Date(2021, 5, 10, 9, 0, 0, 0, time.Local), valid: true},
+		{since: "2021-05-10T09:00:00", expected: time.Date(2021, 5, 10, 9, 0, 0, 0, time.Local), valid: true},
+		{since: "2021-05-10", expected: time.Date(2021, 5, 10, 0, 0, 0, 0, time.Local), valid: true},
+		{since: "yesterday"},
+		{since: "2021-05-10 09:00"},
+	}
+	for _, test := range tests {
+		t.Run(test.since, func(t *testing.T) {
+			since, err := ParseLogSince(test.since, now)
+			if !test.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, test.expected.Equal(since), "expected %v, got %v", test.expected, since)
+		})
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected string
+		valid    bool
+	}{
+		{level: "warn", expected: "WARN", valid: true},
+		{level: "ERROR", expected: "ERROR", valid: true},
+		{level: "Trace", expected: "TRACE", valid: true},
+		{level: "warning", expected: "WARNING", valid: true},
+		{level: "verbose"},
+		{level: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.level, func(t *testing.T) {
+			level, err := ParseLogLevel(test.level)
+			if !test.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, level)
+		})
+	}
+}
+
+func TestCheckLogFileSize(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 3*bytesPerMB/2)
+	assert.Nil(t, checkLogFileSize("wso2carbon.log", content, 0), "the size should not be limited without a maximum")
+	assert.Nil(t, checkLogFileSize("wso2carbon.log", content, 2*bytesPerMB))
+	assert.Nil(t, checkLogFileSize("wso2carbon.log", content, int64(len(content))))
+	err := checkLogFileSize("wso2carbon.log", content, bytesPerMB)
+	assert.EqualError(t, err, "log file wso2carbon.log is 1.5 MB, which is larger than 1.0 MB to be downloaded at "+
+		"every poll. Stopped following it. Download it without --follow instead, or increase --max-size")
+}
@@ -39,6 +39,11 @@ const (
 		"{{range .Artifacts}}{{.Name}}\t{{.Type}}\n{{end}}"
 )
 
+var appListTableHeaders = map[string]string{
+	"Name":    nameHeader,
+	"Version": versionHeader,
+}
+
 // GetCompositeAppList returns a list of composite apps deployed in the micro integrator in a given environment
 func GetCompositeAppList(env string) (*artifactutils.CompositeAppList, error) {
 	resp, err := getArtifactList(utils.MiManagementCarbonAppResource, env, &artifactutils.CompositeAppList{})
@@ -63,10 +68,6 @@ func PrintCompositeAppList(appList *artifactutils.CompositeAppList, format strin
 			}
 			return nil
 		}
-		appListTableHeaders := map[string]string{
-			"Name":    nameHeader,
-			"Version": versionHeader,
-		}
 		if err := appListContext.Write(renderer, appListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -75,6 +76,11 @@ func PrintCompositeAppList(appList *artifactutils.CompositeAppList, format strin
 	}
 }
 
+// PrintCompositeAppListOfNodes print a list of composite apps deployed in each node of a Micro Integrator cluster
+func PrintCompositeAppListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultCompositeAppListTableFormat, appListTableHeaders, "No Composite Apps found", "Name", "Version")
+}
+
 // GetCompositeApp returns a information about a specific composite app deployed in the micro integrator in a given environment
 func GetCompositeApp(env, appname string) (*artifactutils.CompositeApp, error) {
 	resp, err := getArtifactInfo(utils.MiManagementCarbonAppResource, "carbonAppName", appname, env, &artifactutils.CompositeApp{})
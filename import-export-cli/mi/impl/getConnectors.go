@@ -29,6 +29,13 @@ import (
 
 const defaultConnectorListTableFormat = "table {{.Name}}\t{{.Status}}\t{{.Package}}\t{{.Description}}"
 
+var connectorListTableHeaders = map[string]string{
+	"Name":        nameHeader,
+	"Status":      statsHeader,
+	"Package":     packageHeader,
+	"Description": descriptionHeader,
+}
+
 // GetConnectorList returns a list of connector artifacts deployed in the micro integrator in a given environment
 func GetConnectorList(env string) (*artifactutils.ConnectorList, error) {
 	resp, err := getArtifactList(utils.MiManagementConnectorResource, env, &artifactutils.ConnectorList{})
@@ -53,12 +60,6 @@ func PrintConnectorList(connectorList *artifactutils.ConnectorList, format strin
 			}
 			return nil
 		}
-		connectorListTableHeaders := map[string]string{
-			"Name":        nameHeader,
-			"Status":      statsHeader,
-			"Package":     packageHeader,
-			"Description": descriptionHeader,
-		}
 		if err := connectorListContext.Write(renderer, connectorListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -66,3 +67,8 @@ func PrintConnectorList(connectorList *artifactutils.ConnectorList, format strin
 		fmt.Println("No Connectors found")
 	}
 }
+
+// PrintConnectorListOfNodes print a list of connectors deployed in each node of a Micro Integrator cluster
+func PrintConnectorListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultConnectorListTableFormat, connectorListTableHeaders, "No Connectors found", "Name", "Status")
+}
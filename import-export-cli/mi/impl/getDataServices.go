@@ -42,6 +42,12 @@ const (
 		"{{range .Queries}}{{.Id}}\t{{.Namespace}}\n{{end}}"
 )
 
+var dataserviceListTableHeaders = map[string]string{
+	"ServiceName": nameHeader,
+	"Wsdl11":      wsdl11Header,
+	"Wsdl20":      wsdl20Header,
+}
+
 // GetDataServiceList returns a list of data services deployed in the micro integrator in a given environment
 func GetDataServiceList(env string) (*artifactutils.DataServicesList, error) {
 	resp, err := getArtifactList(utils.MiManagementDataServiceResource, env, &artifactutils.DataServicesList{})
@@ -66,11 +72,6 @@ func PrintDataServiceList(dataServiceList *artifactutils.DataServicesList, forma
 			}
 			return nil
 		}
-		dataserviceListTableHeaders := map[string]string{
-			"ServiceName": nameHeader,
-			"Wsdl11":      wsdl11Header,
-			"Wsdl20":      wsdl20Header,
-		}
 		if err := dataserviceListContext.Write(renderer, dataserviceListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -79,6 +80,11 @@ func PrintDataServiceList(dataServiceList *artifactutils.DataServicesList, forma
 	}
 }
 
+// PrintDataServiceListOfNodes print a list of data services deployed in each node of a Micro Integrator cluster
+func PrintDataServiceListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultdataServiceListTableFormat, dataserviceListTableHeaders, "No Data Services found", "ServiceName")
+}
+
 // GetDataService returns information about a specific data service deployed in the micro integrator in a given environment
 func GetDataService(env, dataserviceName string) (*artifactutils.DataServiceInfo, error) {
 	resp, err := getArtifactInfo(utils.MiManagementDataServiceResource, "dataServiceName", dataserviceName, env, &artifactutils.DataServiceInfo{})
@@ -42,6 +42,12 @@ const (
 		"{{if .WsdlURI}}WSDL URI - {{.WsdlURI}}\n{{ end }}"
 )
 
+var endpointListTableHeaders = map[string]string{
+	"Name":   nameHeader,
+	"Type":   typeHeader,
+	"Active": activeHeader,
+}
+
 // GetEndpointList returns a list of endpoints
 func GetEndpointList(env string) (*artifactutils.EndpointList, error) {
 	resp, err := getArtifactList(utils.MiManagementEndpointResource, env, &artifactutils.EndpointList{})
@@ -66,11 +72,6 @@ func PrintEndpointList(endpointList *artifactutils.EndpointList, format string)
 			}
 			return nil
 		}
-		endpointListTableHeaders := map[string]string{
-			"Name":   nameHeader,
-			"Type":   typeHeader,
-			"Active": activeHeader,
-		}
 		if err := endpointListContext.Write(renderer, endpointListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -79,6 +80,11 @@ func PrintEndpointList(endpointList *artifactutils.EndpointList, format string)
 	}
 }
 
+// PrintEndpointListOfNodes print a list of endpoints deployed in each node of a Micro Integrator cluster
+func PrintEndpointListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultEndpointListTableFormat, endpointListTableHeaders, "No Endpoints found", "Name", "Active")
+}
+
 // GetEndpoint returns information about a specific endpoint
 func GetEndpoint(env, endpointName string) (*artifactutils.Endpoint, error) {
 	resp, err := getArtifactInfo(utils.MiManagementEndpointResource, "endpointName", endpointName, env, &artifactutils.Endpoint{})
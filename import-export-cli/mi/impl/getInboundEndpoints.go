@@ -41,6 +41,11 @@ const (
 		"{{range .Parameters}}{{.Name}}\t{{.Value}}\n{{end}}"
 )
 
+var inboundEPListTableHeaders = map[string]string{
+	"Name": nameHeader,
+	"Type": typeHeader,
+}
+
 // GetInboundEndpointList returns a list of inbound endpoints deployed in the micro integrator in a given environment
 func GetInboundEndpointList(env string) (*artifactutils.InboundEndpointList, error) {
 	resp, err := getArtifactList(utils.MiManagementInboundEndpointResource, env, &artifactutils.InboundEndpointList{})
@@ -65,10 +70,6 @@ func PrintInboundEndpointList(inboundEPList *artifactutils.InboundEndpointList,
 			}
 			return nil
 		}
-		inboundEPListTableHeaders := map[string]string{
-			"Name": nameHeader,
-			"Type": typeHeader,
-		}
 		if err := inboundEPListContext.Write(renderer, inboundEPListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -77,6 +78,11 @@ func PrintInboundEndpointList(inboundEPList *artifactutils.InboundEndpointList,
 	}
 }
 
+// PrintInboundEndpointListOfNodes print a list of inbound endpoints deployed in each node of a Micro Integrator cluster
+func PrintInboundEndpointListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultInboundEndpointListTableFormat, inboundEPListTableHeaders, "No Inbound Endpoints found", "Name", "Type")
+}
+
 // GetInboundEndpoint returns a information about a specific inbound endpoint deployed in the micro integrator in a given environment
 func GetInboundEndpoint(env, inboundEPName string) (*artifactutils.InboundEndpoint, error) {
 	resp, err := getArtifactInfo(utils.MiManagementInboundEndpointResource, "inboundEndpointName", inboundEPName, env,
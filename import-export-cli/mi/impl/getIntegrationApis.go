@@ -42,6 +42,11 @@ const (
 		"{{range .Resources}}{{.Url}}\t{{.Methods}}\n{{end}}"
 )
 
+var apiListTableHeaders = map[string]string{
+	"Name": nameHeader,
+	"Url":  urlHeader,
+}
+
 // GetIntegrationAPIList returns a list of apis deployed in the micro integrator in a given environment
 func GetIntegrationAPIList(env string) (*artifactutils.IntegrationAPIList, error) {
 	resp, err := getArtifactList(utils.MiManagementAPIResource, env, &artifactutils.IntegrationAPIList{})
@@ -66,10 +71,6 @@ func PrintIntegrationAPIList(apiList *artifactutils.IntegrationAPIList, format s
 			}
 			return nil
 		}
-		apiListTableHeaders := map[string]string{
-			"Name": nameHeader,
-			"Url":  urlHeader,
-		}
 		if err := apiListContext.Write(renderer, apiListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -78,6 +79,11 @@ func PrintIntegrationAPIList(apiList *artifactutils.IntegrationAPIList, format s
 	}
 }
 
+// PrintIntegrationAPIListOfNodes print a list of APIs deployed in each node of a Micro Integrator cluster
+func PrintIntegrationAPIListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultIntegrationAPIListTableFormat, apiListTableHeaders, "No APIs found", "Name")
+}
+
 // GetIntegrationAPI returns a information about a specific api deployed in the micro integrator in a given environment
 func GetIntegrationAPI(env, apiName string) (*artifactutils.IntegrationAPI, error) {
 	resp, err := getArtifactInfo(utils.MiManagementAPIResource, "apiName", apiName, env, &artifactutils.IntegrationAPI{})
@@ -34,6 +34,11 @@ const (
 		"Value - {{.Value}}"
 )
 
+var localEntryListTableHeaders = map[string]string{
+	"Name": nameHeader,
+	"Type": typeHeader,
+}
+
 // GetLocalEntryList returns a list of local entries deployed in the micro integrator in a given environment
 func GetLocalEntryList(env string) (*artifactutils.LocalEntryList, error) {
 	resp, err := getArtifactList(utils.MiManagementLocalEntrieResource, env, &artifactutils.LocalEntryList{})
@@ -58,10 +63,6 @@ func PrintLocalEntryList(localEntryList *artifactutils.LocalEntryList, format st
 			}
 			return nil
 		}
-		localEntryListTableHeaders := map[string]string{
-			"Name": nameHeader,
-			"Type": typeHeader,
-		}
 		if err := localEntryListContext.Write(renderer, localEntryListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -70,6 +71,11 @@ func PrintLocalEntryList(localEntryList *artifactutils.LocalEntryList, format st
 	}
 }
 
+// PrintLocalEntryListOfNodes print a list of local entries deployed in each node of a Micro Integrator cluster
+func PrintLocalEntryListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultLocalEntryListTableFormat, localEntryListTableHeaders, "No Local Entries found", "Name", "Type")
+}
+
 // GetLocalEntry returns a information about a specific local entry deployed in the micro integrator in a given environment
 func GetLocalEntry(env, localEntryName string) (*artifactutils.LocalEntryData, error) {
 	resp, err := getArtifactInfo(utils.MiManagementLocalEntrieResource, "name", localEntryName, env, &artifactutils.LocalEntryData{})
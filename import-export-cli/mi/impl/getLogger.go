@@ -29,6 +29,21 @@ const (
 	defaultLoggerTableFormat = "table {{.LoggerName}}\t{{.LogLevel}}\t{{.ComponentName}}"
 )
 
+var loggerInfoTableHeaders = map[string]string{
+	"LoggerName":    nameHeader,
+	"LogLevel":      loglevelHeader,
+	"ComponentName": componentHeader,
+}
+
+// GetLoggerList returns a list of loggers of the micro integrator in a given environment
+func GetLoggerList(env string) (*artifactutils.LoggerList, error) {
+	resp, err := getArtifactList(utils.MiManagementLoggingResource, env, &artifactutils.LoggerList{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*artifactutils.LoggerList), nil
+}
+
 // GetLoggerInfo returns information about a specific logger
 func GetLoggerInfo(env, loggerName string) (*artifactutils.Logger, error) {
 	resp, err := getArtifactInfo(utils.MiManagementLoggingResource, "loggerName", loggerName, env, &artifactutils.Logger{})
@@ -43,12 +58,12 @@ func PrintLoggerInfo(logger *artifactutils.Logger, format string) {
 	loggerContext := getContextWithFormat(format, defaultLoggerTableFormat)
 	renderer := getItemRendererEndsWithNewLine(logger)
 
-	loggerInfoTableHeaders := map[string]string{
-		"LoggerName":    nameHeader,
-		"LogLevel":      loglevelHeader,
-		"ComponentName": componentHeader,
-	}
 	if err := loggerContext.Write(renderer, loggerInfoTableHeaders); err != nil {
 		fmt.Println("Error executing template:", err.Error())
 	}
 }
+
+// PrintLoggerInfoOfNodes prints details about a logger in each node of a Micro Integrator cluster
+func PrintLoggerInfoOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultLoggerTableFormat, loggerInfoTableHeaders, "No Logger found", "LoggerName", "LogLevel")
+}
@@ -48,6 +48,12 @@ const (
 		"{{ end }}"
 )
 
+var messageProcessorListTableHeaders = map[string]string{
+	"Name":   nameHeader,
+	"Type":   typeHeader,
+	"Status": statusHeader,
+}
+
 // GetMessageProcessorList returns a list of message processors deployed in the micro integrator in a given environment
 func GetMessageProcessorList(env string) (*artifactutils.MessageProcessorList, error) {
 	resp, err := getArtifactList(utils.MiManagementMessageProcessorResource, env, &artifactutils.MessageProcessorList{})
@@ -72,11 +78,6 @@ func PrintMessageProcessorList(messageProcessorList *artifactutils.MessageProces
 			}
 			return nil
 		}
-		messageProcessorListTableHeaders := map[string]string{
-			"Name":   nameHeader,
-			"Type":   typeHeader,
-			"Status": statusHeader,
-		}
 		if err := messageProcessorListContext.Write(renderer, messageProcessorListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -85,6 +86,11 @@ func PrintMessageProcessorList(messageProcessorList *artifactutils.MessageProces
 	}
 }
 
+// PrintMessageProcessorListOfNodes print a list of message processors deployed in each node of a Micro Integrator cluster
+func PrintMessageProcessorListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultMessageProcessorListTableFormat, messageProcessorListTableHeaders, "No Message Processors found", "Name", "Status")
+}
+
 // GetMessageProcessor returns a information about a specific message processor deployed in the micro integrator in a given environment
 func GetMessageProcessor(env, messageProcessorName string) (*artifactutils.MessageProcessorData, error) {
 	resp, err := getArtifactInfo(utils.MiManagementMessageProcessorResource, "name", messageProcessorName, env, &artifactutils.MessageProcessorData{})
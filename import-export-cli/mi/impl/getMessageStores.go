@@ -48,6 +48,12 @@ const (
 		"{{ end }}"
 )
 
+var messageStoreListTableHeaders = map[string]string{
+	"Name": nameHeader,
+	"Type": typeHeader,
+	"Size": sizeHeader,
+}
+
 // GetMessageStoreList returns a list of message stores deployed in the micro integrator in a given environment
 func GetMessageStoreList(env string) (*artifactutils.MessageStoreList, error) {
 	resp, err := getArtifactList(utils.MiManagementMessageStoreResource, env, &artifactutils.MessageStoreList{})
@@ -72,11 +78,6 @@ func PrintMessageStoreList(messageStoreList *artifactutils.MessageStoreList, for
 			}
 			return nil
 		}
-		messageStoreListTableHeaders := map[string]string{
-			"Name": nameHeader,
-			"Type": typeHeader,
-			"Size": sizeHeader,
-		}
 		if err := messageStoreListContext.Write(renderer, messageStoreListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -85,6 +86,11 @@ func PrintMessageStoreList(messageStoreList *artifactutils.MessageStoreList, for
 	}
 }
 
+// PrintMessageStoreListOfNodes print a list of message stores deployed in each node of a Micro Integrator cluster
+func PrintMessageStoreListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultMessageStoreListTableFormat, messageStoreListTableHeaders, "No Message Stores found", "Name", "Type")
+}
+
 // GetMessageStore returns a information about a specific message store deployed in the micro integrator in a given environment
 func GetMessageStore(env, messageStoreName string) (*artifactutils.MessageStoreData, error) {
 	resp, err := getArtifactInfo(utils.MiManagementMessageStoreResource, "name", messageStoreName, env, &artifactutils.MessageStoreData{})
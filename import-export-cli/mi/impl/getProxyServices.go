@@ -38,6 +38,12 @@ const (
 		"Tracing - {{.Tracing}}"
 )
 
+var proxyListTableHeaders = map[string]string{
+	"Name":   nameHeader,
+	"Wsdl11": wsdl11Header,
+	"Wsdl20": wsdl20Header,
+}
+
 // GetProxyServiceList returns a list of proxy serives deployed in the micro integrator in a given environment
 func GetProxyServiceList(env string) (*artifactutils.ProxyServiceList, error) {
 	resp, err := getArtifactList(utils.MiManagementProxyServiceResource, env, &artifactutils.ProxyServiceList{})
@@ -62,11 +68,6 @@ func PrintProxyServiceList(proxyList *artifactutils.ProxyServiceList, format str
 			}
 			return nil
 		}
-		proxyListTableHeaders := map[string]string{
-			"Name":   nameHeader,
-			"Wsdl11": wsdl11Header,
-			"Wsdl20": wsdl20Header,
-		}
 		if err := proxyListContext.Write(renderer, proxyListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -75,6 +76,11 @@ func PrintProxyServiceList(proxyList *artifactutils.ProxyServiceList, format str
 	}
 }
 
+// PrintProxyServiceListOfNodes print a list of proxy services deployed in each node of a Micro Integrator cluster
+func PrintProxyServiceListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultProxyServiceListTableFormat, proxyListTableHeaders, "No Proxy Services found", "Name")
+}
+
 // GetProxyService returns a information about a specific proxy deployed in the micro integrator in a given environment
 func GetProxyService(env, proxyName string) (*artifactutils.Proxy, error) {
 	resp, err := getArtifactInfo(utils.MiManagementProxyServiceResource, "proxyServiceName", proxyName, env, &artifactutils.Proxy{})
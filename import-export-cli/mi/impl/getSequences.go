@@ -43,6 +43,12 @@ const (
 		"{{end}}"
 )
 
+var sequenceListTableHeaders = map[string]string{
+	"Name":    nameHeader,
+	"Stats":   statsHeader,
+	"Tracing": tracingHeader,
+}
+
 // GetSequenceList returns a list of sequences deployed in the micro integrator in a given environment
 func GetSequenceList(env string) (*artifactutils.SequenceList, error) {
 	resp, err := getArtifactList(utils.MiManagementSequenceResource, env, &artifactutils.SequenceList{})
@@ -67,11 +73,6 @@ func PrintSequenceList(sequenceList *artifactutils.SequenceList, format string)
 			}
 			return nil
 		}
-		sequenceListTableHeaders := map[string]string{
-			"Name":    nameHeader,
-			"Stats":   statsHeader,
-			"Tracing": tracingHeader,
-		}
 		if err := sequenceListContext.Write(renderer, sequenceListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -80,6 +81,11 @@ func PrintSequenceList(sequenceList *artifactutils.SequenceList, format string)
 	}
 }
 
+// PrintSequenceListOfNodes print a list of sequences deployed in each node of a Micro Integrator cluster
+func PrintSequenceListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultSequenceListTableFormat, sequenceListTableHeaders, "No Sequences found", "Name", "Stats", "Tracing")
+}
+
 // GetSequence returns a information about a specific sequence deployed in the micro integrator in a given environment
 func GetSequence(env, sequenceName string) (*artifactutils.Sequence, error) {
 	resp, err := getArtifactInfo(utils.MiManagementSequenceResource, "sequenceName", sequenceName, env, &artifactutils.Sequence{})
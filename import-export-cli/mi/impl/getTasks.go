@@ -41,6 +41,10 @@ const (
 		"{{end}}"
 )
 
+var taskListTableHeaders = map[string]string{
+	"Name": nameHeader,
+}
+
 // GetTaskList returns a list of Tasks deployed in the micro integrator in a given environment
 func GetTaskList(env string) (*artifactutils.TaskList, error) {
 	resp, err := getArtifactList(utils.MiManagementTaskResource, env, &artifactutils.TaskList{})
@@ -65,9 +69,6 @@ func PrintTaskList(taskList *artifactutils.TaskList, format string) {
 			}
 			return nil
 		}
-		taskListTableHeaders := map[string]string{
-			"Name": nameHeader,
-		}
 		if err := taskListContext.Write(renderer, taskListTableHeaders); err != nil {
 			fmt.Println("Error executing template:", err.Error())
 		}
@@ -76,6 +77,11 @@ func PrintTaskList(taskList *artifactutils.TaskList, format string) {
 	}
 }
 
+// PrintTaskListOfNodes print a list of tasks deployed in each node of a Micro Integrator cluster
+func PrintTaskListOfNodes(results []NodeResult, format string) {
+	printArtifactListOfNodes(results, format, defaultTaskListTableFormat, taskListTableHeaders, "No Tasks found", "Name")
+}
+
 // GetTask returns a information about a specific Task deployed in the micro integrator in a given environment
 func GetTask(env, taskName string) (*artifactutils.Task, error) {
 	resp, err := getArtifactInfo(utils.MiManagementTaskResource, "taskName", taskName, env, &artifactutils.Task{})
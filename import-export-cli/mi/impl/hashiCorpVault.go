@@ -32,5 +32,5 @@ func UpdateHashiCorpSecretID(env, secretID string) (interface{}, error) {
 
 func updateHarshiCorpSecret(env, url, body string) (string, error) {
 	resp, err := invokePOSTRequestWithRetry(env, url, body)
-	return handleResponse(resp, err, url, "Message", "Error")
+	return handleResponse(resp, err, env, url, "Message", "Error")
 }
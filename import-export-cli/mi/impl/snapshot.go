@@ -0,0 +1,464 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// MISnapshotVersion is the version of the format of the snapshot document
+const MISnapshotVersion = "v1"
+
+// MISnapshot is the runtime configuration of a micro integrator. The details of the artifacts are kept as returned by
+// the management API, by the type and the name of the artifact
+type MISnapshot struct {
+	Version     string                            `json:"version"`
+	Environment string                            `json:"environment"`
+	Endpoint    string                            `json:"endpoint"`
+	CreatedAt   string                            `json:"createdAt"`
+	Artifacts   map[string]map[string]interface{} `json:"artifacts"`
+	// Skipped are the artifact types which could not be read, with the reason
+	Skipped map[string]string `json:"skipped,omitempty"`
+}
+
+// MISnapshotDiff is a difference of an artifact between two snapshots
+type MISnapshotDiff struct {
+	ArtifactType string            `json:"artifactType"`
+	Name         string            `json:"name"`
+	Type         string            `json:"type"`
+	Diffs        []utils.FieldDiff `json:"diffs,omitempty"`
+}
+
+// snapshotResource reads an artifact type of a micro integrator. list returns the artifacts by name, with their
+// details if get is nil
+type snapshotResource struct {
+	name           string
+	list           func(env string) (map[string]interface{}, error)
+	get            func(env, name string) (interface{}, error)
+	volatileFields []string
+}
+
+var snapshotResources = []snapshotResource{
+	{
+		name: "apis",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetIntegrationAPIList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, api := range list.Apis {
+				artifacts[api.Name] = api
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetIntegrationAPI(env, name) },
+	},
+	{
+		name: "proxyServices",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetProxyServiceList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, proxy := range list.Proxies {
+				artifacts[proxy.Name] = proxy
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetProxyService(env, name) },
+	},
+	{
+		name: "endpoints",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetEndpointList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, endpoint := range list.Endpoints {
+				artifacts[endpoint.Name] = endpoint
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetEndpoint(env, name) },
+	},
+	{
+		name: "sequences",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetSequenceList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, sequence := range list.Sequences {
+				artifacts[sequence.Name] = sequence
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetSequence(env, name) },
+	},
+	{
+		name: "sequenceTemplates",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetTemplateList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, template := range list.SequenceTemplates {
+				artifacts[template.Name] = template
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetSequenceTemplate(env, name) },
+	},
+	{
+		name: "endpointTemplates",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetTemplateList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, template := range list.EndpointTemplates {
+				artifacts[template.Name] = template
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetEndpointTemplate(env, name) },
+	},
+	{
+		name: "tasks",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetTaskList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, task := range list.Tasks {
+				artifacts[task.Name] = task
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetTask(env, name) },
+	},
+	{
+		name: "messageStores",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetMessageStoreList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, store := range list.MessageStores {
+				artifacts[store.Name] = store
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetMessageStore(env, name) },
+		// the number of messages in a store changes at runtime
+		volatileFields: []string{"size"},
+	},
+	{
+		name: "messageProcessors",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetMessageProcessorList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, processor := range list.MessageProcessors {
+				artifacts[processor.Name] = processor
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetMessageProcessor(env, name) },
+	},
+	{
+		name: "inboundEndpoints",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetInboundEndpointList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, inboundEndpoint := range list.InboundEndpoints {
+				artifacts[inboundEndpoint.Name] = inboundEndpoint
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetInboundEndpoint(env, name) },
+	},
+	{
+		name: "localEntries",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetLocalEntryList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, localEntry := range list.LocalEntries {
+				artifacts[localEntry.Name] = localEntry
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetLocalEntry(env, name) },
+	},
+	{
+		name: "dataServices",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetDataServiceList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, dataService := range list.List {
+				artifacts[dataService.ServiceName] = dataService
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetDataService(env, name) },
+	},
+	{
+		name: "compositeApps",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetCompositeAppList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, app := range list.CompositeApps {
+				artifacts[app.Name] = app
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetCompositeApp(env, name) },
+	},
+	{
+		name: "connectors",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetConnectorList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, connector := range list.Connectors {
+				artifacts[connector.Name] = connector
+			}
+			return artifacts, nil
+		},
+	},
+	{
+		name: "loggers",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetLoggerList(env)
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, logger := range list.Loggers {
+				artifacts[logger.LoggerName] = logger
+			}
+			return artifacts, nil
+		},
+	},
+	{
+		name: "users",
+		list: func(env string) (map[string]interface{}, error) {
+			list, err := GetUserList(env, "", "")
+			if err != nil {
+				return nil, err
+			}
+			artifacts := make(map[string]interface{})
+			for _, user := range list.Users {
+				artifacts[user.UserId] = user
+			}
+			return artifacts, nil
+		},
+		get: func(env, name string) (interface{}, error) { return GetUserInfo(env, name) },
+	},
+}
+
+// TakeMISnapshot reads the details of all the artifacts, the loggers and the users of the micro integrator in a given
+// environment. An artifact type which cannot be read is recorded as skipped instead of failing the snapshot
+// @param env : Environment of the micro integrator
+// @return the snapshot
+// @return error
+func TakeMISnapshot(env string) (*MISnapshot, error) {
+	endpoint, err := utils.GetMIManagementEndpointOfEnv(env, utils.MainConfigFilePath)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &MISnapshot{
+		Version:     MISnapshotVersion,
+		Environment: env,
+		Endpoint:    endpoint,
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+		Artifacts:   make(map[string]map[string]interface{}),
+		Skipped:     make(map[string]string),
+	}
+	for _, resource := range snapshotResources {
+		utils.Logln(utils.LogPrefixInfo + "reading " + resource.name)
+		artifacts, err := readSnapshotResource(env, resource)
+		if err != nil {
+			utils.Logln(utils.LogPrefixWarning + "skipping " + resource.name + ": " + err.Error())
+			snapshot.Skipped[resource.name] = err.Error()
+			continue
+		}
+		snapshot.Artifacts[resource.name] = artifacts
+	}
+	return snapshot, nil
+}
+
+func readSnapshotResource(env string, resource snapshotResource) (map[string]interface{}, error) {
+	artifacts, err := resource.list(env)
+	if err != nil {
+		return nil, err
+	}
+	for name, artifact := range artifacts {
+		if resource.get != nil {
+			artifact, err = resource.get(env, name)
+			if err != nil {
+				return nil, errors.New("unable to read " + name + ". " + err.Error())
+			}
+		}
+		// the details are kept as plain values so that a snapshot read from a file is compared in the same way
+		value, err := toSnapshotValue(artifact)
+		if err != nil {
+			return nil, err
+		}
+		if details, ok := value.(map[string]interface{}); ok {
+			for _, field := range resource.volatileFields {
+				delete(details, field)
+			}
+		}
+		artifacts[name] = value
+	}
+	return artifacts, nil
+}
+
+func toSnapshotValue(artifact interface{}) (interface{}, error) {
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	return value, err
+}
+
+// WriteMISnapshot writes a snapshot as a YAML document to a file, or returns the document if the file is empty
+// @param snapshot : Snapshot to be written
+// @param filePath : Path to the file
+// @return the YAML document
+// @return error
+func WriteMISnapshot(snapshot *MISnapshot, filePath string) ([]byte, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	data, err = utils.JsonToYaml(data)
+	if err != nil {
+		return nil, err
+	}
+	if filePath != "" {
+		err = ioutil.WriteFile(filePath, data, 0644)
+	}
+	return data, err
+}
+
+// ReadMISnapshot reads a snapshot written by WriteMISnapshot
+// @param filePath : Path to the snapshot
+// @return the snapshot
+// @return error if the file is not a snapshot of a supported version
+func ReadMISnapshot(filePath string) (*MISnapshot, error) {
+	data, err := utils.LoadYamlAsJson(filePath)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &MISnapshot{}
+	if err = json.Unmarshal(data, snapshot); err != nil {
+		return nil, errors.New(filePath + " is not a valid snapshot. " + err.Error())
+	}
+	if snapshot.Version != MISnapshotVersion {
+		return nil, fmt.Errorf("%s is not a valid snapshot. Unsupported version '%s', expected '%s'", filePath,
+			snapshot.Version, MISnapshotVersion)
+	}
+	return snapshot, nil
+}
+
+// DiffMISnapshots compares the artifacts of two snapshots. The artifact types skipped by either snapshot are not
+// compared
+// @param before : Snapshot to compare from
+// @param after : Snapshot to compare to
+// @return the added, removed and modified artifacts, sorted by the artifact type and the name
+func DiffMISnapshots(before, after *MISnapshot) []MISnapshotDiff {
+	diffs := []MISnapshotDiff{}
+	for _, artifactType := range sortedKeys(before.Artifacts, after.Artifacts) {
+		if _, skipped := before.Skipped[artifactType]; skipped {
+			continue
+		}
+		if _, skipped := after.Skipped[artifactType]; skipped {
+			continue
+		}
+		beforeArtifacts := before.Artifacts[artifactType]
+		afterArtifacts := after.Artifacts[artifactType]
+		for _, name := range sortedKeys(beforeArtifacts, afterArtifacts) {
+			beforeArtifact, inBefore := beforeArtifacts[name]
+			afterArtifact, inAfter := afterArtifacts[name]
+			diff := MISnapshotDiff{ArtifactType: artifactType, Name: name}
+			if !inBefore {
+				diff.Type = utils.DiffTypeAdded
+			} else if !inAfter {
+				diff.Type = utils.DiffTypeRemoved
+			} else if diff.Diffs = utils.DiffValues(beforeArtifact, afterArtifact); len(diff.Diffs) > 0 {
+				diff.Type = utils.DiffTypeModified
+			} else {
+				continue
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+// sortedKeys returns the keys of all the given maps in the sorted order. The maps have to be keyed by strings
+func sortedKeys(maps ...interface{}) []string {
+	keySet := make(map[string]bool)
+	for _, m := range maps {
+		for _, key := range reflect.ValueOf(m).MapKeys() {
+			keySet[key.String()] = true
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
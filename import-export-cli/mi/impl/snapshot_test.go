@@ -0,0 +1,201 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestTakeMISnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-mi-snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	mainConfigFilePath := utils.MainConfigFilePath
+	defer func() { utils.MainConfigFilePath = mainConfigFilePath }()
+	utils.MainConfigFilePath = filepath.Join(dir, utils.MainConfigFileName)
+	assert.Nil(t, ioutil.WriteFile(utils.MainConfigFilePath, []byte("environments:\n  dev:\n    mi: https://mi:9164\n"),
+		0644))
+
+	resources := snapshotResources
+	defer func() { snapshotResources = resources }()
+	snapshotResources = []snapshotResource{
+		{
+			name: "endpoints",
+			list: func(env string) (map[string]interface{}, error) {
+				return map[string]interface{}{
+					"GrandOakEndpoint": artifactutils.EndpointSummary{Name: "GrandOakEndpoint", Type: "http",
+						Active: true},
+				}, nil
+			},
+		},
+		{
+			name: "messageStores",
+			list: func(env string) (map[string]interface{}, error) {
+				return map[string]interface{}{"OrderStore": nil}, nil
+			},
+			get: func(env, name string) (interface{}, error) {
+				return map[string]interface{}{"name": name, "type": "in-memory", "size": 12}, nil
+			},
+			volatileFields: []string{"size"},
+		},
+		{
+			name: "users",
+			list: func(env string) (map[string]interface{}, error) {
+				return nil, errors.New("403 Forbidden")
+			},
+		},
+		{
+			name: "sequences",
+			list: func(env string) (map[string]interface{}, error) {
+				return map[string]interface{}{"HealthcareSequence": nil}, nil
+			},
+			get: func(env, name string) (interface{}, error) {
+				return nil, errors.New("404 Not Found")
+			},
+		},
+	}
+
+	snapshot, err := TakeMISnapshot("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, MISnapshotVersion, snapshot.Version)
+	assert.Equal(t, "dev", snapshot.Environment)
+	assert.Equal(t, "https://mi:9164", snapshot.Endpoint)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"endpoints": {
+			"GrandOakEndpoint": map[string]interface{}{"name": "GrandOakEndpoint", "type": "http", "isActive": true},
+		},
+		"messageStores": {
+			"OrderStore": map[string]interface{}{"name": "OrderStore", "type": "in-memory"},
+		},
+	}, snapshot.Artifacts, "the details should be plain values without the volatile fields")
+	assert.Equal(t, map[string]string{
+		"users":     "403 Forbidden",
+		"sequences": "unable to read HealthcareSequence. 404 Not Found",
+	}, snapshot.Skipped)
+}
+
+func TestWriteAndReadMISnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-mi-snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	snapshot := &MISnapshot{
+		Version:     MISnapshotVersion,
+		Environment: "dev",
+		Endpoint:    "https://mi:9164",
+		CreatedAt:   "2021-01-01T00:00:00Z",
+		Artifacts: map[string]map[string]interface{}{
+			"endpoints": {"GrandOakEndpoint": map[string]interface{}{"type": "http", "isActive": true}},
+		},
+		Skipped: map[string]string{"users": "403 Forbidden"},
+	}
+	snapshotPath := filepath.Join(dir, "snapshot.yaml")
+	_, err = WriteMISnapshot(snapshot, snapshotPath)
+	assert.Nil(t, err)
+	read, err := ReadMISnapshot(snapshotPath)
+	assert.Nil(t, err)
+	assert.Equal(t, snapshot, read)
+
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "unsupported version",
+			content: "version: v2\nenvironment: dev\n",
+			err:     "Unsupported version 'v2', expected '" + MISnapshotVersion + "'",
+		},
+		{
+			name:    "missing version",
+			content: "environment: dev\n",
+			err:     "Unsupported version '', expected '" + MISnapshotVersion + "'",
+		},
+		{
+			name:    "invalid artifacts",
+			content: "version: v1\nartifacts: [endpoints]\n",
+			err:     "is not a valid snapshot",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Nil(t, ioutil.WriteFile(snapshotPath, []byte(test.content), 0644))
+			_, err := ReadMISnapshot(snapshotPath)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestDiffMISnapshots(t *testing.T) {
+	before := &MISnapshot{
+		Artifacts: map[string]map[string]interface{}{
+			"endpoints": {
+				"GrandOakEndpoint":   map[string]interface{}{"type": "http", "isActive": true},
+				"PineValleyEndpoint": map[string]interface{}{"type": "http", "isActive": true},
+				"StockQuoteEndpoint": map[string]interface{}{"type": "address", "isActive": true},
+			},
+			"sequences": {"HealthcareSequence": map[string]interface{}{"tracing": "disabled"}},
+			"users":     {"admin": map[string]interface{}{"isAdmin": true}},
+		},
+		// sequences which could not be read are not compared
+		Skipped: map[string]string{"sequences": "404 Not Found"},
+	}
+	after := &MISnapshot{
+		Artifacts: map[string]map[string]interface{}{
+			"apis": {"HealthcareAPI": map[string]interface{}{"context": "/healthcare"}},
+			"endpoints": {
+				"GrandOakEndpoint":   map[string]interface{}{"type": "http", "isActive": false},
+				"StockQuoteEndpoint": map[string]interface{}{"type": "address", "isActive": true},
+				"ChildEndpoint":      map[string]interface{}{"type": "http", "isActive": true},
+			},
+			"sequences": {"HealthcareSequence": map[string]interface{}{"tracing": "enabled"}},
+		},
+		// users which could not be read are not reported as removed
+		Skipped: map[string]string{"users": "403 Forbidden"},
+	}
+
+	assert.Equal(t, []MISnapshotDiff{
+		{ArtifactType: "apis", Name: "HealthcareAPI", Type: utils.DiffTypeAdded},
+		{ArtifactType: "endpoints", Name: "ChildEndpoint", Type: utils.DiffTypeAdded},
+		{ArtifactType: "endpoints", Name: "GrandOakEndpoint", Type: utils.DiffTypeModified, Diffs: []utils.FieldDiff{
+			{Path: "isActive", Type: utils.DiffTypeModified, Before: true, After: false},
+		}},
+		{ArtifactType: "endpoints", Name: "PineValleyEndpoint", Type: utils.DiffTypeRemoved},
+	}, DiffMISnapshots(before, after))
+
+	assert.Equal(t, []MISnapshotDiff{}, DiffMISnapshots(after, after))
+}
+
+func TestSortedKeys(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, sortedKeys(map[string]string{"c": "", "a": ""},
+		map[string]interface{}{"b": nil, "a": nil}))
+	assert.Equal(t, []string{"healthcare", "root"}, sortedKeys(map[string]MILoggerState{"root": {}, "healthcare": {}}))
+	assert.Equal(t, []string{"x"}, sortedKeys(map[string]bool{"x": true}, map[string]int(nil)))
+	assert.Empty(t, sortedKeys())
+	assert.Panics(t, func() { sortedKeys([]string{"a"}) }, "a value which is not a map should not be ignored")
+}
@@ -50,12 +50,12 @@ func DeleteMIUser(env, userName string) (interface{}, error) {
 
 func addNewMIUser(env, url string, body interface{}) (string, error) {
 	resp, err := invokePOSTRequestWithRetry(env, url, body)
-	return handleResponse(resp, err, url, "status", "Error")
+	return handleResponse(resp, err, env, url, "status", "Error")
 }
 
 func deleteMIUser(url, env string) (string, error) {
 	resp, err := invokeDELETERequestWithRetry(url, env)
-	return handleResponse(resp, err, url, "status", "Error")
+	return handleResponse(resp, err, env, url, "status", "Error")
 }
 
 func resolveIsAdmin(isAdminConsoleInput string) string {
@@ -44,6 +44,10 @@ func TestGetNonExistingAPIByName(t *testing.T) {
 	assert.Contains(t, response, "[ERROR]: Getting Information of "+apisCmd+" [ "+invalidAPIName+" ]  404 Not Found")
 }
 
+func TestExportAPIAsAPIMProject(t *testing.T) {
+	testutils.ValidateExportAPIAsAPIMProject(t, config, validAPIName)
+}
+
 func TestGetAPIsWithoutSettingUpEnv(t *testing.T) {
 	testutils.ExecGetCommandWithoutSettingEnv(t, apisCmd)
 }
@@ -19,10 +19,14 @@
 package testutils
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/integration/base"
 	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -59,3 +63,36 @@ func validateAPIEqual(t *testing.T, apisListFromCtl string, api *artifactutils.I
 	assert.Contains(t, apisListFromCtl, api.Url)
 	assert.Contains(t, apisListFromCtl, api.Version)
 }
+
+// ValidateExportAPIAsAPIMProject validate the API Manager project exported from the api with the api from the
+// Management API
+func ValidateExportAPIAsAPIMProject(t *testing.T, config *MiConfig, apiName string) {
+	t.Helper()
+	SetupAndLoginToMI(t, config)
+	exportDir, err := ioutil.TempDir("", "mi-export-api")
+	assert.Nil(t, err)
+	defer os.RemoveAll(exportDir)
+
+	output, err := base.Execute(t, "mi", "export", "api", apiName, "--as-apim-project", "-d", exportDir,
+		"-e", config.MIClient.GetEnvName(), "-k")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "Successfully exported the integration API "+apiName)
+
+	artifact := config.MIClient.GetArtifactFromAPI(utils.MiManagementAPIResource, getParamMap("apiName", apiName), &artifactutils.IntegrationAPI{})
+	api := artifact.(*artifactutils.IntegrationAPI)
+	version := api.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+	projectPath := filepath.Join(exportDir, api.Name+"-"+version)
+	apiDefinition, err := ioutil.ReadFile(filepath.Join(projectPath, utils.APIDefinitionFileYaml))
+	assert.Nil(t, err)
+	assert.Contains(t, string(apiDefinition), "name: "+api.Name)
+	assert.Contains(t, string(apiDefinition), "url: "+api.Url)
+	for _, resource := range api.Resources {
+		path := strings.SplitN(resource.Url, "?", 2)[0]
+		assert.Contains(t, string(apiDefinition), "target: "+path)
+	}
+	_, err = os.Stat(filepath.Join(projectPath, utils.InitProjectDefinitionsSwagger))
+	assert.Nil(t, err, "the OpenAPI definition should be in the project")
+}
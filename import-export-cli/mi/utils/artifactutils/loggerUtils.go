@@ -18,6 +18,11 @@
 
 package artifactutils
 
+type LoggerList struct {
+	Count   int32    `json:"count"`
+	Loggers []Logger `json:"list"`
+}
+
 type Logger struct {
 	LoggerName    string `json:"loggerName"`
 	ComponentName string `json:"componentName"`
@@ -19,11 +19,12 @@
 package artifactutils
 
 type Proxy struct {
-	Name    string `json:"name"`
-	Wsdl11  string `json:"wsdl1_1"`
-	Wsdl20  string `json:"wsdl2_0"`
-	Stats   string `json:"stats"`
-	Tracing string `json:"tracing"`
+	Name      string `json:"name"`
+	Wsdl11    string `json:"wsdl1_1"`
+	Wsdl20    string `json:"wsdl2_0"`
+	Stats     string `json:"stats"`
+	Tracing   string `json:"tracing"`
+	IsRunning bool   `json:"isRunning"`
 }
 
 type ProxyServiceList struct {
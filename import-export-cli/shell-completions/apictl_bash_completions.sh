@@ -401,6 +401,8 @@ _apictl_add_env()
     local_nonpersistent_flags+=("--token=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -424,6 +426,8 @@ _apictl_add_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -454,6 +458,8 @@ _apictl_add()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -493,11 +499,11 @@ _apictl_bundle()
     local_nonpersistent_flags+=("-s")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
-    must_have_one_flag+=("--source=")
-    must_have_one_flag+=("-s")
     must_have_one_noun=()
     noun_aliases=()
 }
@@ -552,6 +558,8 @@ _apictl_change-status_api()
     local_nonpersistent_flags+=("-v")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -583,6 +591,8 @@ _apictl_change-status_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -613,6 +623,99 @@ _apictl_change-status()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_credentials_help()
+{
+    last_command="apictl_credentials_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_credentials_migrate()
+{
+    last_command="apictl_credentials_migrate"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--to=")
+    two_word_flags+=("--to")
+    local_nonpersistent_flags+=("--to")
+    local_nonpersistent_flags+=("--to=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--to=")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_credentials()
+{
+    last_command="apictl_credentials"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("help")
+    commands+=("migrate")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -664,6 +767,8 @@ _apictl_delete_api()
     local_nonpersistent_flags+=("-v")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -715,6 +820,8 @@ _apictl_delete_api-product()
     local_nonpersistent_flags+=("-r")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -764,6 +871,8 @@ _apictl_delete_app()
     local_nonpersistent_flags+=("-o")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -791,6 +900,8 @@ _apictl_delete_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -823,6 +934,8 @@ _apictl_delete()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -830,9 +943,9 @@ _apictl_delete()
     noun_aliases=()
 }
 
-_apictl_export_api()
+_apictl_diff_api()
 {
-    last_command="apictl_export_api"
+    last_command="apictl_diff_api"
 
     command_aliases=()
 
@@ -850,34 +963,30 @@ _apictl_export_api()
     local_nonpersistent_flags+=("--environment")
     local_nonpersistent_flags+=("--environment=")
     local_nonpersistent_flags+=("-e")
-    flags+=("--format=")
-    two_word_flags+=("--format")
-    local_nonpersistent_flags+=("--format")
-    local_nonpersistent_flags+=("--format=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
-    flags+=("--latest")
-    local_nonpersistent_flags+=("--latest")
     flags+=("--name=")
     two_word_flags+=("--name")
     two_word_flags+=("-n")
     local_nonpersistent_flags+=("--name")
     local_nonpersistent_flags+=("--name=")
     local_nonpersistent_flags+=("-n")
-    flags+=("--preserve-status")
-    local_nonpersistent_flags+=("--preserve-status")
+    flags+=("--project=")
+    two_word_flags+=("--project")
+    local_nonpersistent_flags+=("--project")
+    local_nonpersistent_flags+=("--project=")
     flags+=("--provider=")
     two_word_flags+=("--provider")
     two_word_flags+=("-r")
     local_nonpersistent_flags+=("--provider")
     local_nonpersistent_flags+=("--provider=")
     local_nonpersistent_flags+=("-r")
-    flags+=("--rev=")
-    two_word_flags+=("--rev")
-    local_nonpersistent_flags+=("--rev")
-    local_nonpersistent_flags+=("--rev=")
+    flags+=("--to-environment=")
+    two_word_flags+=("--to-environment")
+    local_nonpersistent_flags+=("--to-environment")
+    local_nonpersistent_flags+=("--to-environment=")
     flags+=("--version=")
     two_word_flags+=("--version")
     two_word_flags+=("-v")
@@ -886,6 +995,8 @@ _apictl_export_api()
     local_nonpersistent_flags+=("-v")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -899,9 +1010,9 @@ _apictl_export_api()
     noun_aliases=()
 }
 
-_apictl_export_api-product()
+_apictl_diff_api-product()
 {
-    last_command="apictl_export_api-product"
+    last_command="apictl_diff_api-product"
 
     command_aliases=()
 
@@ -919,34 +1030,34 @@ _apictl_export_api-product()
     local_nonpersistent_flags+=("--environment")
     local_nonpersistent_flags+=("--environment=")
     local_nonpersistent_flags+=("-e")
-    flags+=("--format=")
-    two_word_flags+=("--format")
-    local_nonpersistent_flags+=("--format")
-    local_nonpersistent_flags+=("--format=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
-    flags+=("--latest")
-    local_nonpersistent_flags+=("--latest")
     flags+=("--name=")
     two_word_flags+=("--name")
     two_word_flags+=("-n")
     local_nonpersistent_flags+=("--name")
     local_nonpersistent_flags+=("--name=")
     local_nonpersistent_flags+=("-n")
+    flags+=("--project=")
+    two_word_flags+=("--project")
+    local_nonpersistent_flags+=("--project")
+    local_nonpersistent_flags+=("--project=")
     flags+=("--provider=")
     two_word_flags+=("--provider")
     two_word_flags+=("-r")
     local_nonpersistent_flags+=("--provider")
     local_nonpersistent_flags+=("--provider=")
     local_nonpersistent_flags+=("-r")
-    flags+=("--rev=")
-    two_word_flags+=("--rev")
-    local_nonpersistent_flags+=("--rev")
-    local_nonpersistent_flags+=("--rev=")
+    flags+=("--to-environment=")
+    two_word_flags+=("--to-environment")
+    local_nonpersistent_flags+=("--to-environment")
+    local_nonpersistent_flags+=("--to-environment=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -958,9 +1069,67 @@ _apictl_export_api-product()
     noun_aliases=()
 }
 
-_apictl_export_apis()
+_apictl_diff_help()
 {
-    last_command="apictl_export_apis"
+    last_command="apictl_diff_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_diff()
+{
+    last_command="apictl_diff"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("api")
+    commands+=("api-product")
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_export_api()
+{
+    last_command="apictl_export_api"
 
     command_aliases=()
 
@@ -972,15 +1141,12 @@ _apictl_export_apis()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--all")
-    local_nonpersistent_flags+=("--all")
     flags+=("--environment=")
     two_word_flags+=("--environment")
     two_word_flags+=("-e")
     local_nonpersistent_flags+=("--environment")
     local_nonpersistent_flags+=("--environment=")
     local_nonpersistent_flags+=("-e")
-    flags+=("--force")
     flags+=("--format=")
     two_word_flags+=("--format")
     local_nonpersistent_flags+=("--format")
@@ -989,22 +1155,52 @@ _apictl_export_apis()
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--latest")
+    local_nonpersistent_flags+=("--latest")
+    flags+=("--name=")
+    two_word_flags+=("--name")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name")
+    local_nonpersistent_flags+=("--name=")
+    local_nonpersistent_flags+=("-n")
     flags+=("--preserve-status")
     local_nonpersistent_flags+=("--preserve-status")
+    flags+=("--provider=")
+    two_word_flags+=("--provider")
+    two_word_flags+=("-r")
+    local_nonpersistent_flags+=("--provider")
+    local_nonpersistent_flags+=("--provider=")
+    local_nonpersistent_flags+=("-r")
+    flags+=("--rev=")
+    two_word_flags+=("--rev")
+    local_nonpersistent_flags+=("--rev")
+    local_nonpersistent_flags+=("--rev=")
+    flags+=("--version=")
+    two_word_flags+=("--version")
+    two_word_flags+=("-v")
+    local_nonpersistent_flags+=("--version")
+    local_nonpersistent_flags+=("--version=")
+    local_nonpersistent_flags+=("-v")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
     must_have_one_flag+=("--environment=")
     must_have_one_flag+=("-e")
+    must_have_one_flag+=("--name=")
+    must_have_one_flag+=("-n")
+    must_have_one_flag+=("--version=")
+    must_have_one_flag+=("-v")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_export_app()
+_apictl_export_api-product()
 {
-    last_command="apictl_export_app"
+    last_command="apictl_export_api-product"
 
     command_aliases=()
 
@@ -1030,22 +1226,28 @@ _apictl_export_app()
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--latest")
+    local_nonpersistent_flags+=("--latest")
     flags+=("--name=")
     two_word_flags+=("--name")
     two_word_flags+=("-n")
     local_nonpersistent_flags+=("--name")
     local_nonpersistent_flags+=("--name=")
     local_nonpersistent_flags+=("-n")
-    flags+=("--owner=")
-    two_word_flags+=("--owner")
-    two_word_flags+=("-o")
-    local_nonpersistent_flags+=("--owner")
-    local_nonpersistent_flags+=("--owner=")
-    local_nonpersistent_flags+=("-o")
-    flags+=("--with-keys")
-    local_nonpersistent_flags+=("--with-keys")
+    flags+=("--provider=")
+    two_word_flags+=("--provider")
+    two_word_flags+=("-r")
+    local_nonpersistent_flags+=("--provider")
+    local_nonpersistent_flags+=("--provider=")
+    local_nonpersistent_flags+=("-r")
+    flags+=("--rev=")
+    two_word_flags+=("--rev")
+    local_nonpersistent_flags+=("--rev")
+    local_nonpersistent_flags+=("--rev=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1053,13 +1255,122 @@ _apictl_export_app()
     must_have_one_flag+=("-e")
     must_have_one_flag+=("--name=")
     must_have_one_flag+=("-n")
-    must_have_one_flag+=("--owner=")
-    must_have_one_flag+=("-o")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_export_help()
+_apictl_export_apis()
+{
+    last_command="apictl_export_apis"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--all")
+    local_nonpersistent_flags+=("--all")
+    flags+=("--concurrency=")
+    two_word_flags+=("--concurrency")
+    local_nonpersistent_flags+=("--concurrency")
+    local_nonpersistent_flags+=("--concurrency=")
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--force")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--preserve-status")
+    local_nonpersistent_flags+=("--preserve-status")
+    flags+=("--retry-failed")
+    local_nonpersistent_flags+=("--retry-failed")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_export_app()
+{
+    last_command="apictl_export_app"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--name=")
+    two_word_flags+=("--name")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name")
+    local_nonpersistent_flags+=("--name=")
+    local_nonpersistent_flags+=("-n")
+    flags+=("--owner=")
+    two_word_flags+=("--owner")
+    two_word_flags+=("-o")
+    local_nonpersistent_flags+=("--owner")
+    local_nonpersistent_flags+=("--owner=")
+    local_nonpersistent_flags+=("-o")
+    flags+=("--with-keys")
+    local_nonpersistent_flags+=("--with-keys")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_flag+=("--name=")
+    must_have_one_flag+=("-n")
+    must_have_one_flag+=("--owner=")
+    must_have_one_flag+=("-o")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_export_help()
 {
     last_command="apictl_export_help"
 
@@ -1075,6 +1386,8 @@ _apictl_export_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1083,6 +1396,139 @@ _apictl_export_help()
     noun_aliases=()
 }
 
+_apictl_export_key-managers()
+{
+    last_command="apictl_export_key-managers"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--name=")
+    two_word_flags+=("--name")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name")
+    local_nonpersistent_flags+=("--name=")
+    local_nonpersistent_flags+=("-n")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_export_policies()
+{
+    last_command="apictl_export_policies"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--name=")
+    two_word_flags+=("--name")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name")
+    local_nonpersistent_flags+=("--name=")
+    local_nonpersistent_flags+=("-n")
+    flags+=("--type=")
+    two_word_flags+=("--type")
+    local_nonpersistent_flags+=("--type")
+    local_nonpersistent_flags+=("--type=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_export_scopes()
+{
+    last_command="apictl_export_scopes"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--name=")
+    two_word_flags+=("--name")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name")
+    local_nonpersistent_flags+=("--name=")
+    local_nonpersistent_flags+=("-n")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _apictl_export()
 {
     last_command="apictl_export"
@@ -1095,6 +1541,9 @@ _apictl_export()
     commands+=("apis")
     commands+=("app")
     commands+=("help")
+    commands+=("key-managers")
+    commands+=("policies")
+    commands+=("scopes")
 
     flags=()
     two_word_flags=()
@@ -1108,6 +1557,8 @@ _apictl_export()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1147,6 +1598,8 @@ _apictl_gen_deployment-dir()
     local_nonpersistent_flags+=("-s")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1172,6 +1625,8 @@ _apictl_gen_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1202,6 +1657,8 @@ _apictl_gen()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1257,6 +1714,8 @@ _apictl_get_api-product-revisions()
     local_nonpersistent_flags+=("-q")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1310,6 +1769,8 @@ _apictl_get_api-products()
     local_nonpersistent_flags+=("-q")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1373,6 +1834,8 @@ _apictl_get_api-revisions()
     local_nonpersistent_flags+=("-v")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1428,6 +1891,8 @@ _apictl_get_apis()
     local_nonpersistent_flags+=("-q")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1479,6 +1944,8 @@ _apictl_get_apps()
     local_nonpersistent_flags+=("-o")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1512,6 +1979,8 @@ _apictl_get_envs()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1535,6 +2004,8 @@ _apictl_get_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1593,6 +2064,8 @@ _apictl_get_keys()
     local_nonpersistent_flags+=("-v")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1632,6 +2105,8 @@ _apictl_get()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1655,6 +2130,8 @@ _apictl_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1705,10 +2182,20 @@ _apictl_import_api()
     local_nonpersistent_flags+=("--skip-cleanup")
     flags+=("--skip-deployments")
     local_nonpersistent_flags+=("--skip-deployments")
+    flags+=("--skip-validation")
+    local_nonpersistent_flags+=("--skip-validation")
+    flags+=("--substitution-mode=")
+    two_word_flags+=("--substitution-mode")
+    local_nonpersistent_flags+=("--substitution-mode")
+    local_nonpersistent_flags+=("--substitution-mode=")
+    flags+=("--substitution-report")
+    local_nonpersistent_flags+=("--substitution-report")
     flags+=("--update")
     local_nonpersistent_flags+=("--update")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1764,12 +2251,95 @@ _apictl_import_api-product()
     local_nonpersistent_flags+=("--skip-cleanup")
     flags+=("--skip-deployments")
     local_nonpersistent_flags+=("--skip-deployments")
+    flags+=("--skip-validation")
+    local_nonpersistent_flags+=("--skip-validation")
+    flags+=("--substitution-mode=")
+    two_word_flags+=("--substitution-mode")
+    local_nonpersistent_flags+=("--substitution-mode")
+    local_nonpersistent_flags+=("--substitution-mode=")
+    flags+=("--substitution-report")
+    local_nonpersistent_flags+=("--substitution-report")
     flags+=("--update-api-product")
     local_nonpersistent_flags+=("--update-api-product")
     flags+=("--update-apis")
     local_nonpersistent_flags+=("--update-apis")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_import_apis()
+{
+    last_command="apictl_import_apis"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--concurrency=")
+    two_word_flags+=("--concurrency")
+    local_nonpersistent_flags+=("--concurrency")
+    local_nonpersistent_flags+=("--concurrency=")
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
+    flags+=("--force")
+    local_nonpersistent_flags+=("--force")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--params=")
+    two_word_flags+=("--params")
+    local_nonpersistent_flags+=("--params")
+    local_nonpersistent_flags+=("--params=")
+    flags+=("--preserve-provider")
+    local_nonpersistent_flags+=("--preserve-provider")
+    flags+=("--retry-failed")
+    local_nonpersistent_flags+=("--retry-failed")
+    flags+=("--rotate-revision")
+    local_nonpersistent_flags+=("--rotate-revision")
+    flags+=("--skip-deployments")
+    local_nonpersistent_flags+=("--skip-deployments")
+    flags+=("--skip-validation")
+    local_nonpersistent_flags+=("--skip-validation")
+    flags+=("--substitution-mode=")
+    two_word_flags+=("--substitution-mode")
+    local_nonpersistent_flags+=("--substitution-mode")
+    local_nonpersistent_flags+=("--substitution-mode=")
+    flags+=("--substitution-report")
+    local_nonpersistent_flags+=("--substitution-report")
+    flags+=("--update")
+    local_nonpersistent_flags+=("--update")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1827,10 +2397,14 @@ _apictl_import_app()
     flags+=("-s")
     local_nonpersistent_flags+=("--skip-subscriptions")
     local_nonpersistent_flags+=("-s")
+    flags+=("--skip-validation")
+    local_nonpersistent_flags+=("--skip-validation")
     flags+=("--update")
     local_nonpersistent_flags+=("--update")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1858,6 +2432,8 @@ _apictl_import_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -1866,17 +2442,13 @@ _apictl_import_help()
     noun_aliases=()
 }
 
-_apictl_import()
+_apictl_import_key-managers()
 {
-    last_command="apictl_import"
+    last_command="apictl_import_key-managers"
 
     command_aliases=()
 
     commands=()
-    commands+=("api")
-    commands+=("api-product")
-    commands+=("app")
-    commands+=("help")
 
     flags=()
     two_word_flags=()
@@ -1884,22 +2456,46 @@ _apictl_import()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--params=")
+    two_word_flags+=("--params")
+    local_nonpersistent_flags+=("--params")
+    local_nonpersistent_flags+=("--params=")
+    flags+=("--update")
+    local_nonpersistent_flags+=("--update")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_init()
+_apictl_import_policies()
 {
-    last_command="apictl_init"
+    last_command="apictl_import_policies"
 
     command_aliases=()
 
@@ -1911,40 +2507,46 @@ _apictl_init()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--definition=")
-    two_word_flags+=("--definition")
-    two_word_flags+=("-d")
-    local_nonpersistent_flags+=("--definition")
-    local_nonpersistent_flags+=("--definition=")
-    local_nonpersistent_flags+=("-d")
-    flags+=("--force")
-    flags+=("-f")
-    local_nonpersistent_flags+=("--force")
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
     local_nonpersistent_flags+=("-f")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
-    flags+=("--initial-state=")
-    two_word_flags+=("--initial-state")
-    local_nonpersistent_flags+=("--initial-state")
-    local_nonpersistent_flags+=("--initial-state=")
-    flags+=("--oas=")
-    two_word_flags+=("--oas")
-    local_nonpersistent_flags+=("--oas")
-    local_nonpersistent_flags+=("--oas=")
+    flags+=("--params=")
+    two_word_flags+=("--params")
+    local_nonpersistent_flags+=("--params")
+    local_nonpersistent_flags+=("--params=")
+    flags+=("--update")
+    local_nonpersistent_flags+=("--update")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_k8s_add_api()
+_apictl_import_scopes()
 {
-    last_command="apictl_k8s_add_api"
+    last_command="apictl_import_scopes"
 
     command_aliases=()
 
@@ -1956,6 +2558,12 @@ _apictl_k8s_add_api()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
     flags+=("--file=")
     two_word_flags+=("--file")
     two_word_flags+=("-f")
@@ -1966,36 +2574,42 @@ _apictl_k8s_add_api()
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
-    flags+=("--name=")
-    two_word_flags+=("--name")
-    two_word_flags+=("-n")
-    local_nonpersistent_flags+=("--name")
-    local_nonpersistent_flags+=("--name=")
-    local_nonpersistent_flags+=("-n")
-    flags+=("--namespace=")
-    two_word_flags+=("--namespace")
-    local_nonpersistent_flags+=("--namespace")
-    local_nonpersistent_flags+=("--namespace=")
+    flags+=("--params=")
+    two_word_flags+=("--params")
+    local_nonpersistent_flags+=("--params")
+    local_nonpersistent_flags+=("--params=")
+    flags+=("--update")
+    local_nonpersistent_flags+=("--update")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
     must_have_one_flag+=("--file=")
     must_have_one_flag+=("-f")
-    must_have_one_flag+=("--name=")
-    must_have_one_flag+=("-n")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_k8s_add_help()
+_apictl_import()
 {
-    last_command="apictl_k8s_add_help"
+    last_command="apictl_import"
 
     command_aliases=()
 
     commands=()
+    commands+=("api")
+    commands+=("api-product")
+    commands+=("apis")
+    commands+=("app")
+    commands+=("help")
+    commands+=("key-managers")
+    commands+=("policies")
+    commands+=("scopes")
 
     flags=()
     two_word_flags=()
@@ -2003,25 +2617,28 @@ _apictl_k8s_add_help()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
     must_have_one_noun=()
-    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_k8s_add()
+_apictl_init_api-product()
 {
-    last_command="apictl_k8s_add"
+    last_command="apictl_init_api-product"
 
     command_aliases=()
 
     commands=()
-    commands+=("api")
-    commands+=("help")
 
     flags=()
     two_word_flags=()
@@ -2029,22 +2646,41 @@ _apictl_k8s_add()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--api=")
+    two_word_flags+=("--api")
+    local_nonpersistent_flags+=("--api")
+    local_nonpersistent_flags+=("--api=")
+    flags+=("--context=")
+    two_word_flags+=("--context")
+    local_nonpersistent_flags+=("--context")
+    local_nonpersistent_flags+=("--context=")
+    flags+=("--force")
+    flags+=("-f")
+    local_nonpersistent_flags+=("--force")
+    local_nonpersistent_flags+=("-f")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--operation=")
+    two_word_flags+=("--operation")
+    local_nonpersistent_flags+=("--operation")
+    local_nonpersistent_flags+=("--operation=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--api=")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_k8s_delete_api()
+_apictl_init_app()
 {
-    last_command="apictl_k8s_delete_api"
+    last_command="apictl_init_app"
 
     command_aliases=()
 
@@ -2056,30 +2692,60 @@ _apictl_k8s_delete_api()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--callback-url=")
+    two_word_flags+=("--callback-url")
+    local_nonpersistent_flags+=("--callback-url")
+    local_nonpersistent_flags+=("--callback-url=")
+    flags+=("--force")
+    flags+=("-f")
+    local_nonpersistent_flags+=("--force")
+    local_nonpersistent_flags+=("-f")
+    flags+=("--grant-type=")
+    two_word_flags+=("--grant-type")
+    local_nonpersistent_flags+=("--grant-type")
+    local_nonpersistent_flags+=("--grant-type=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
-    flags+=("--name=")
-    two_word_flags+=("--name")
-    two_word_flags+=("-n")
-    local_nonpersistent_flags+=("--name")
-    local_nonpersistent_flags+=("--name=")
-    local_nonpersistent_flags+=("-n")
+    flags+=("--key-manager=")
+    two_word_flags+=("--key-manager")
+    local_nonpersistent_flags+=("--key-manager")
+    local_nonpersistent_flags+=("--key-manager=")
+    flags+=("--key-type=")
+    two_word_flags+=("--key-type")
+    local_nonpersistent_flags+=("--key-type")
+    local_nonpersistent_flags+=("--key-type=")
+    flags+=("--owner=")
+    two_word_flags+=("--owner")
+    local_nonpersistent_flags+=("--owner")
+    local_nonpersistent_flags+=("--owner=")
+    flags+=("--subscription=")
+    two_word_flags+=("--subscription")
+    local_nonpersistent_flags+=("--subscription")
+    local_nonpersistent_flags+=("--subscription=")
+    flags+=("--throttling-policy=")
+    two_word_flags+=("--throttling-policy")
+    local_nonpersistent_flags+=("--throttling-policy")
+    local_nonpersistent_flags+=("--throttling-policy=")
+    flags+=("--token-type=")
+    two_word_flags+=("--token-type")
+    local_nonpersistent_flags+=("--token-type")
+    local_nonpersistent_flags+=("--token-type=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
-    must_have_one_flag+=("--name=")
-    must_have_one_flag+=("-n")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_k8s_delete_help()
+_apictl_init_help()
 {
-    last_command="apictl_k8s_delete_help"
+    last_command="apictl_init_help"
 
     command_aliases=()
 
@@ -2093,6 +2759,8 @@ _apictl_k8s_delete_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2101,14 +2769,15 @@ _apictl_k8s_delete_help()
     noun_aliases=()
 }
 
-_apictl_k8s_delete()
+_apictl_init()
 {
-    last_command="apictl_k8s_delete"
+    last_command="apictl_init"
 
     command_aliases=()
 
     commands=()
-    commands+=("api")
+    commands+=("api-product")
+    commands+=("app")
     commands+=("help")
 
     flags=()
@@ -2117,12 +2786,44 @@ _apictl_k8s_delete()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--asyncapi=")
+    two_word_flags+=("--asyncapi")
+    local_nonpersistent_flags+=("--asyncapi")
+    local_nonpersistent_flags+=("--asyncapi=")
+    flags+=("--definition=")
+    two_word_flags+=("--definition")
+    two_word_flags+=("-d")
+    local_nonpersistent_flags+=("--definition")
+    local_nonpersistent_flags+=("--definition=")
+    local_nonpersistent_flags+=("-d")
+    flags+=("--force")
+    flags+=("-f")
+    local_nonpersistent_flags+=("--force")
+    local_nonpersistent_flags+=("-f")
+    flags+=("--graphql=")
+    two_word_flags+=("--graphql")
+    local_nonpersistent_flags+=("--graphql")
+    local_nonpersistent_flags+=("--graphql=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--initial-state=")
+    two_word_flags+=("--initial-state")
+    local_nonpersistent_flags+=("--initial-state")
+    local_nonpersistent_flags+=("--initial-state=")
+    flags+=("--oas=")
+    two_word_flags+=("--oas")
+    local_nonpersistent_flags+=("--oas")
+    local_nonpersistent_flags+=("--oas=")
+    flags+=("--wsdl=")
+    two_word_flags+=("--wsdl")
+    local_nonpersistent_flags+=("--wsdl")
+    local_nonpersistent_flags+=("--wsdl=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2130,9 +2831,9 @@ _apictl_k8s_delete()
     noun_aliases=()
 }
 
-_apictl_k8s_gen_deployment-dir()
+_apictl_k8s_add_api()
 {
-    last_command="apictl_k8s_gen_deployment-dir"
+    last_command="apictl_k8s_add_api"
 
     command_aliases=()
 
@@ -2144,12 +2845,212 @@ _apictl_k8s_gen_deployment-dir()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--destination=")
-    two_word_flags+=("--destination")
-    two_word_flags+=("-d")
-    local_nonpersistent_flags+=("--destination")
-    local_nonpersistent_flags+=("--destination=")
-    local_nonpersistent_flags+=("-d")
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--name=")
+    two_word_flags+=("--name")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name")
+    local_nonpersistent_flags+=("--name=")
+    local_nonpersistent_flags+=("-n")
+    flags+=("--namespace=")
+    two_word_flags+=("--namespace")
+    local_nonpersistent_flags+=("--namespace")
+    local_nonpersistent_flags+=("--namespace=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
+    must_have_one_flag+=("--name=")
+    must_have_one_flag+=("-n")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_k8s_add_help()
+{
+    last_command="apictl_k8s_add_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_k8s_add()
+{
+    last_command="apictl_k8s_add"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("api")
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_k8s_delete_api()
+{
+    last_command="apictl_k8s_delete_api"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--name=")
+    two_word_flags+=("--name")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name")
+    local_nonpersistent_flags+=("--name=")
+    local_nonpersistent_flags+=("-n")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--name=")
+    must_have_one_flag+=("-n")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_k8s_delete_help()
+{
+    last_command="apictl_k8s_delete_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_k8s_delete()
+{
+    last_command="apictl_k8s_delete"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("api")
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_k8s_gen_deployment-dir()
+{
+    last_command="apictl_k8s_gen_deployment-dir"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--destination=")
+    two_word_flags+=("--destination")
+    two_word_flags+=("-d")
+    local_nonpersistent_flags+=("--destination")
+    local_nonpersistent_flags+=("--destination=")
+    local_nonpersistent_flags+=("-d")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
@@ -2162,6 +3063,8 @@ _apictl_k8s_gen_deployment-dir()
     local_nonpersistent_flags+=("-s")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2187,6 +3090,8 @@ _apictl_k8s_gen_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2217,6 +3122,8 @@ _apictl_k8s_gen()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2240,6 +3147,8 @@ _apictl_k8s_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2284,6 +3193,8 @@ _apictl_k8s_update_api()
     local_nonpersistent_flags+=("--namespace=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2311,6 +3222,8 @@ _apictl_k8s_update_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2341,6 +3254,8 @@ _apictl_k8s_update()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2373,6 +3288,8 @@ _apictl_k8s()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2414,6 +3331,8 @@ _apictl_login()
     local_nonpersistent_flags+=("-u")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2441,6 +3360,8 @@ _apictl_logout()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2474,6 +3395,8 @@ _apictl_mg_add_env()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2499,6 +3422,8 @@ _apictl_mg_add_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2529,6 +3454,8 @@ _apictl_mg_add()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2574,6 +3501,8 @@ _apictl_mg_deploy_api()
     local_nonpersistent_flags+=("--skip-cleanup")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2601,6 +3530,8 @@ _apictl_mg_deploy_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2631,6 +3562,8 @@ _apictl_mg_deploy()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2676,6 +3609,8 @@ _apictl_mg_get_apis()
     local_nonpersistent_flags+=("-q")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2701,6 +3636,8 @@ _apictl_mg_get_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2731,6 +3668,8 @@ _apictl_mg_get()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2754,6 +3693,8 @@ _apictl_mg_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2796,6 +3737,8 @@ _apictl_mg_login()
     local_nonpersistent_flags+=("-u")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2823,6 +3766,8 @@ _apictl_mg_logout()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2850,6 +3795,8 @@ _apictl_mg_remove_env()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2873,6 +3820,8 @@ _apictl_mg_remove_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2903,6 +3852,8 @@ _apictl_mg_remove()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2954,6 +3905,8 @@ _apictl_mg_undeploy_api()
     local_nonpersistent_flags+=("-t")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -2983,6 +3936,8 @@ _apictl_mg_undeploy_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3013,6 +3968,8 @@ _apictl_mg_undeploy()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3048,6 +4005,8 @@ _apictl_mg()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3081,6 +4040,8 @@ _apictl_mi_activate_endpoint()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3106,6 +4067,8 @@ _apictl_mi_activate_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3140,6 +4103,8 @@ _apictl_mi_activate_message-processor()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3175,6 +4140,8 @@ _apictl_mi_activate_proxy-service()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3208,6 +4175,8 @@ _apictl_mi_activate()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3231,6 +4200,8 @@ _apictl_mi_add_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3265,6 +4236,8 @@ _apictl_mi_add_log-level()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3300,6 +4273,8 @@ _apictl_mi_add_user()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3332,9 +4307,58 @@ _apictl_mi_add()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_apply()
+{
+    last_command="apictl_mi_apply"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--dry-run")
+    local_nonpersistent_flags+=("--dry-run")
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
     must_have_one_noun=()
     noun_aliases=()
 }
@@ -3365,6 +4389,8 @@ _apictl_mi_deactivate_endpoint()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3390,6 +4416,8 @@ _apictl_mi_deactivate_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3424,6 +4452,8 @@ _apictl_mi_deactivate_message-processor()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3459,6 +4489,8 @@ _apictl_mi_deactivate_proxy-service()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3492,6 +4524,8 @@ _apictl_mi_deactivate()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3515,6 +4549,8 @@ _apictl_mi_delete_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3549,6 +4585,8 @@ _apictl_mi_delete_user()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3580,6 +4618,8 @@ _apictl_mi_delete()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3587,9 +4627,9 @@ _apictl_mi_delete()
     noun_aliases=()
 }
 
-_apictl_mi_get_apis()
+_apictl_mi_deploy_capp()
 {
-    last_command="apictl_mi_get_apis"
+    last_command="apictl_mi_deploy_capp"
 
     command_aliases=()
 
@@ -3607,16 +4647,24 @@ _apictl_mi_get_apis()
     local_nonpersistent_flags+=("--environment")
     local_nonpersistent_flags+=("--environment=")
     local_nonpersistent_flags+=("-e")
-    flags+=("--format=")
-    two_word_flags+=("--format")
-    local_nonpersistent_flags+=("--format")
-    local_nonpersistent_flags+=("--format=")
+    flags+=("--force")
+    local_nonpersistent_flags+=("--force")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--interval=")
+    two_word_flags+=("--interval")
+    local_nonpersistent_flags+=("--interval")
+    local_nonpersistent_flags+=("--interval=")
+    flags+=("--timeout=")
+    two_word_flags+=("--timeout")
+    local_nonpersistent_flags+=("--timeout")
+    local_nonpersistent_flags+=("--timeout=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3626,9 +4674,9 @@ _apictl_mi_get_apis()
     noun_aliases=()
 }
 
-_apictl_mi_get_composite-apps()
+_apictl_mi_deploy_help()
 {
-    last_command="apictl_mi_get_composite-apps"
+    last_command="apictl_mi_deploy_help"
 
     command_aliases=()
 
@@ -3640,38 +4688,27 @@ _apictl_mi_get_composite-apps()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--environment=")
-    two_word_flags+=("--environment")
-    two_word_flags+=("-e")
-    local_nonpersistent_flags+=("--environment")
-    local_nonpersistent_flags+=("--environment=")
-    local_nonpersistent_flags+=("-e")
-    flags+=("--format=")
-    two_word_flags+=("--format")
-    local_nonpersistent_flags+=("--format")
-    local_nonpersistent_flags+=("--format=")
-    flags+=("--help")
-    flags+=("-h")
-    local_nonpersistent_flags+=("--help")
-    local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
-    must_have_one_flag+=("--environment=")
-    must_have_one_flag+=("-e")
     must_have_one_noun=()
+    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_mi_get_connectors()
+_apictl_mi_deploy()
 {
-    last_command="apictl_mi_get_connectors"
+    last_command="apictl_mi_deploy"
 
     command_aliases=()
 
     commands=()
+    commands+=("capp")
+    commands+=("help")
 
     flags=()
     two_word_flags=()
@@ -3679,34 +4716,24 @@ _apictl_mi_get_connectors()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--environment=")
-    two_word_flags+=("--environment")
-    two_word_flags+=("-e")
-    local_nonpersistent_flags+=("--environment")
-    local_nonpersistent_flags+=("--environment=")
-    local_nonpersistent_flags+=("-e")
-    flags+=("--format=")
-    two_word_flags+=("--format")
-    local_nonpersistent_flags+=("--format")
-    local_nonpersistent_flags+=("--format=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
-    must_have_one_flag+=("--environment=")
-    must_have_one_flag+=("-e")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_mi_get_data-services()
+_apictl_mi_export_api()
 {
-    last_command="apictl_mi_get_data-services"
+    last_command="apictl_mi_export_api"
 
     command_aliases=()
 
@@ -3718,22 +4745,28 @@ _apictl_mi_get_data-services()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--as-apim-project")
+    local_nonpersistent_flags+=("--as-apim-project")
+    flags+=("--destination=")
+    two_word_flags+=("--destination")
+    two_word_flags+=("-d")
+    local_nonpersistent_flags+=("--destination")
+    local_nonpersistent_flags+=("--destination=")
+    local_nonpersistent_flags+=("-d")
     flags+=("--environment=")
     two_word_flags+=("--environment")
     two_word_flags+=("-e")
     local_nonpersistent_flags+=("--environment")
     local_nonpersistent_flags+=("--environment=")
     local_nonpersistent_flags+=("-e")
-    flags+=("--format=")
-    two_word_flags+=("--format")
-    local_nonpersistent_flags+=("--format")
-    local_nonpersistent_flags+=("--format=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3743,9 +4776,9 @@ _apictl_mi_get_data-services()
     noun_aliases=()
 }
 
-_apictl_mi_get_endpoints()
+_apictl_mi_export_help()
 {
-    last_command="apictl_mi_get_endpoints"
+    last_command="apictl_mi_export_help"
 
     command_aliases=()
 
@@ -3757,38 +4790,27 @@ _apictl_mi_get_endpoints()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--environment=")
-    two_word_flags+=("--environment")
-    two_word_flags+=("-e")
-    local_nonpersistent_flags+=("--environment")
-    local_nonpersistent_flags+=("--environment=")
-    local_nonpersistent_flags+=("-e")
-    flags+=("--format=")
-    two_word_flags+=("--format")
-    local_nonpersistent_flags+=("--format")
-    local_nonpersistent_flags+=("--format=")
-    flags+=("--help")
-    flags+=("-h")
-    local_nonpersistent_flags+=("--help")
-    local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
-    must_have_one_flag+=("--environment=")
-    must_have_one_flag+=("-e")
     must_have_one_noun=()
+    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_mi_get_help()
+_apictl_mi_export()
 {
-    last_command="apictl_mi_get_help"
+    last_command="apictl_mi_export"
 
     command_aliases=()
 
     commands=()
+    commands+=("api")
+    commands+=("help")
 
     flags=()
     two_word_flags=()
@@ -3796,19 +4818,24 @@ _apictl_mi_get_help()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
     must_have_one_noun=()
-    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_mi_get_inbound-endpoints()
+_apictl_mi_get_apis()
 {
-    last_command="apictl_mi_get_inbound-endpoints"
+    last_command="apictl_mi_get_apis"
 
     command_aliases=()
 
@@ -3836,6 +4863,8 @@ _apictl_mi_get_inbound-endpoints()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3845,9 +4874,9 @@ _apictl_mi_get_inbound-endpoints()
     noun_aliases=()
 }
 
-_apictl_mi_get_local-entries()
+_apictl_mi_get_composite-apps()
 {
-    last_command="apictl_mi_get_local-entries"
+    last_command="apictl_mi_get_composite-apps"
 
     command_aliases=()
 
@@ -3875,6 +4904,8 @@ _apictl_mi_get_local-entries()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3884,9 +4915,9 @@ _apictl_mi_get_local-entries()
     noun_aliases=()
 }
 
-_apictl_mi_get_log-levels()
+_apictl_mi_get_connectors()
 {
-    last_command="apictl_mi_get_log-levels"
+    last_command="apictl_mi_get_connectors"
 
     command_aliases=()
 
@@ -3914,6 +4945,8 @@ _apictl_mi_get_log-levels()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3923,9 +4956,9 @@ _apictl_mi_get_log-levels()
     noun_aliases=()
 }
 
-_apictl_mi_get_logs()
+_apictl_mi_get_data-services()
 {
-    last_command="apictl_mi_get_logs"
+    last_command="apictl_mi_get_data-services"
 
     command_aliases=()
 
@@ -3951,14 +4984,10 @@ _apictl_mi_get_logs()
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
-    flags+=("--path=")
-    two_word_flags+=("--path")
-    two_word_flags+=("-p")
-    local_nonpersistent_flags+=("--path")
-    local_nonpersistent_flags+=("--path=")
-    local_nonpersistent_flags+=("-p")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -3968,9 +4997,9 @@ _apictl_mi_get_logs()
     noun_aliases=()
 }
 
-_apictl_mi_get_message-processors()
+_apictl_mi_get_endpoints()
 {
-    last_command="apictl_mi_get_message-processors"
+    last_command="apictl_mi_get_endpoints"
 
     command_aliases=()
 
@@ -3998,6 +5027,8 @@ _apictl_mi_get_message-processors()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4007,9 +5038,9 @@ _apictl_mi_get_message-processors()
     noun_aliases=()
 }
 
-_apictl_mi_get_message-stores()
+_apictl_mi_get_help()
 {
-    last_command="apictl_mi_get_message-stores"
+    last_command="apictl_mi_get_help"
 
     command_aliases=()
 
@@ -4021,34 +5052,21 @@ _apictl_mi_get_message-stores()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--environment=")
-    two_word_flags+=("--environment")
-    two_word_flags+=("-e")
-    local_nonpersistent_flags+=("--environment")
-    local_nonpersistent_flags+=("--environment=")
-    local_nonpersistent_flags+=("-e")
-    flags+=("--format=")
-    two_word_flags+=("--format")
-    local_nonpersistent_flags+=("--format")
-    local_nonpersistent_flags+=("--format=")
-    flags+=("--help")
-    flags+=("-h")
-    local_nonpersistent_flags+=("--help")
-    local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
-    must_have_one_flag+=("--environment=")
-    must_have_one_flag+=("-e")
     must_have_one_noun=()
+    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_mi_get_proxy-services()
+_apictl_mi_get_inbound-endpoints()
 {
-    last_command="apictl_mi_get_proxy-services"
+    last_command="apictl_mi_get_inbound-endpoints"
 
     command_aliases=()
 
@@ -4076,6 +5094,8 @@ _apictl_mi_get_proxy-services()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4085,9 +5105,9 @@ _apictl_mi_get_proxy-services()
     noun_aliases=()
 }
 
-_apictl_mi_get_sequences()
+_apictl_mi_get_local-entries()
 {
-    last_command="apictl_mi_get_sequences"
+    last_command="apictl_mi_get_local-entries"
 
     command_aliases=()
 
@@ -4115,6 +5135,8 @@ _apictl_mi_get_sequences()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4124,9 +5146,9 @@ _apictl_mi_get_sequences()
     noun_aliases=()
 }
 
-_apictl_mi_get_tasks()
+_apictl_mi_get_log-levels()
 {
-    last_command="apictl_mi_get_tasks"
+    last_command="apictl_mi_get_log-levels"
 
     command_aliases=()
 
@@ -4154,6 +5176,8 @@ _apictl_mi_get_tasks()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4163,9 +5187,9 @@ _apictl_mi_get_tasks()
     noun_aliases=()
 }
 
-_apictl_mi_get_templates()
+_apictl_mi_get_logs()
 {
-    last_command="apictl_mi_get_templates"
+    last_command="apictl_mi_get_logs"
 
     command_aliases=()
 
@@ -4183,16 +5207,48 @@ _apictl_mi_get_templates()
     local_nonpersistent_flags+=("--environment")
     local_nonpersistent_flags+=("--environment=")
     local_nonpersistent_flags+=("-e")
+    flags+=("--follow")
+    flags+=("-f")
+    local_nonpersistent_flags+=("--follow")
+    local_nonpersistent_flags+=("-f")
     flags+=("--format=")
     two_word_flags+=("--format")
     local_nonpersistent_flags+=("--format")
     local_nonpersistent_flags+=("--format=")
+    flags+=("--grep=")
+    two_word_flags+=("--grep")
+    local_nonpersistent_flags+=("--grep")
+    local_nonpersistent_flags+=("--grep=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--interval=")
+    two_word_flags+=("--interval")
+    local_nonpersistent_flags+=("--interval")
+    local_nonpersistent_flags+=("--interval=")
+    flags+=("--level=")
+    two_word_flags+=("--level")
+    local_nonpersistent_flags+=("--level")
+    local_nonpersistent_flags+=("--level=")
+    flags+=("--max-size=")
+    two_word_flags+=("--max-size")
+    local_nonpersistent_flags+=("--max-size")
+    local_nonpersistent_flags+=("--max-size=")
+    flags+=("--path=")
+    two_word_flags+=("--path")
+    two_word_flags+=("-p")
+    local_nonpersistent_flags+=("--path")
+    local_nonpersistent_flags+=("--path=")
+    local_nonpersistent_flags+=("-p")
+    flags+=("--since=")
+    two_word_flags+=("--since")
+    local_nonpersistent_flags+=("--since")
+    local_nonpersistent_flags+=("--since=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4202,9 +5258,9 @@ _apictl_mi_get_templates()
     noun_aliases=()
 }
 
-_apictl_mi_get_transaction-counts()
+_apictl_mi_get_message-processors()
 {
-    last_command="apictl_mi_get_transaction-counts"
+    last_command="apictl_mi_get_message-processors"
 
     command_aliases=()
 
@@ -4232,6 +5288,8 @@ _apictl_mi_get_transaction-counts()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4241,9 +5299,9 @@ _apictl_mi_get_transaction-counts()
     noun_aliases=()
 }
 
-_apictl_mi_get_transaction-reports()
+_apictl_mi_get_message-stores()
 {
-    last_command="apictl_mi_get_transaction-reports"
+    last_command="apictl_mi_get_message-stores"
 
     command_aliases=()
 
@@ -4261,30 +5319,742 @@ _apictl_mi_get_transaction-reports()
     local_nonpersistent_flags+=("--environment")
     local_nonpersistent_flags+=("--environment=")
     local_nonpersistent_flags+=("-e")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
-    flags+=("--path=")
-    two_word_flags+=("--path")
-    two_word_flags+=("-p")
-    local_nonpersistent_flags+=("--path")
-    local_nonpersistent_flags+=("--path=")
-    local_nonpersistent_flags+=("-p")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_get_proxy-services()
+{
+    last_command="apictl_mi_get_proxy-services"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_get_sequences()
+{
+    last_command="apictl_mi_get_sequences"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_get_tasks()
+{
+    last_command="apictl_mi_get_tasks"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_get_templates()
+{
+    last_command="apictl_mi_get_templates"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_get_transaction-counts()
+{
+    last_command="apictl_mi_get_transaction-counts"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_get_transaction-reports()
+{
+    last_command="apictl_mi_get_transaction-reports"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--path=")
+    two_word_flags+=("--path")
+    two_word_flags+=("-p")
+    local_nonpersistent_flags+=("--path")
+    local_nonpersistent_flags+=("--path=")
+    local_nonpersistent_flags+=("-p")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_get_users()
+{
+    last_command="apictl_mi_get_users"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--pattern=")
+    two_word_flags+=("--pattern")
+    two_word_flags+=("-p")
+    local_nonpersistent_flags+=("--pattern")
+    local_nonpersistent_flags+=("--pattern=")
+    local_nonpersistent_flags+=("-p")
+    flags+=("--role=")
+    two_word_flags+=("--role")
+    two_word_flags+=("-r")
+    local_nonpersistent_flags+=("--role")
+    local_nonpersistent_flags+=("--role=")
+    local_nonpersistent_flags+=("-r")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_get()
+{
+    last_command="apictl_mi_get"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("apis")
+    commands+=("composite-apps")
+    commands+=("connectors")
+    commands+=("data-services")
+    commands+=("endpoints")
+    commands+=("help")
+    commands+=("inbound-endpoints")
+    commands+=("local-entries")
+    commands+=("log-levels")
+    commands+=("logs")
+    commands+=("message-processors")
+    commands+=("message-stores")
+    commands+=("proxy-services")
+    commands+=("sequences")
+    commands+=("tasks")
+    commands+=("templates")
+    commands+=("transaction-counts")
+    commands+=("transaction-reports")
+    commands+=("users")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_help()
+{
+    last_command="apictl_mi_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_mi_login()
+{
+    last_command="apictl_mi_login"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--password=")
+    two_word_flags+=("--password")
+    two_word_flags+=("-p")
+    local_nonpersistent_flags+=("--password")
+    local_nonpersistent_flags+=("--password=")
+    local_nonpersistent_flags+=("-p")
+    flags+=("--password-stdin")
+    local_nonpersistent_flags+=("--password-stdin")
+    flags+=("--username=")
+    two_word_flags+=("--username")
+    two_word_flags+=("-u")
+    local_nonpersistent_flags+=("--username")
+    local_nonpersistent_flags+=("--username=")
+    local_nonpersistent_flags+=("-u")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_logout()
+{
+    last_command="apictl_mi_logout"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_snapshot_diff()
+{
+    last_command="apictl_mi_snapshot_diff"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_snapshot_help()
+{
+    last_command="apictl_mi_snapshot_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_mi_snapshot()
+{
+    last_command="apictl_mi_snapshot"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("diff")
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_undeploy_capp()
+{
+    last_command="apictl_mi_undeploy_capp"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--interval=")
+    two_word_flags+=("--interval")
+    local_nonpersistent_flags+=("--interval")
+    local_nonpersistent_flags+=("--interval=")
+    flags+=("--timeout=")
+    two_word_flags+=("--timeout")
+    local_nonpersistent_flags+=("--timeout")
+    local_nonpersistent_flags+=("--timeout=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_undeploy_help()
+{
+    last_command="apictl_mi_undeploy_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_mi_undeploy()
+{
+    last_command="apictl_mi_undeploy"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("capp")
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_update_hashicorp-secret()
+{
+    last_command="apictl_mi_update_hashicorp-secret"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_mi_update_help()
+{
+    last_command="apictl_mi_update_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
-    must_have_one_flag+=("--environment=")
-    must_have_one_flag+=("-e")
     must_have_one_noun=()
+    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_mi_get_users()
+_apictl_mi_update_log-level()
 {
-    last_command="apictl_mi_get_users"
+    last_command="apictl_mi_update_log-level"
 
     command_aliases=()
 
@@ -4302,28 +6072,14 @@ _apictl_mi_get_users()
     local_nonpersistent_flags+=("--environment")
     local_nonpersistent_flags+=("--environment=")
     local_nonpersistent_flags+=("-e")
-    flags+=("--format=")
-    two_word_flags+=("--format")
-    local_nonpersistent_flags+=("--format")
-    local_nonpersistent_flags+=("--format=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
-    flags+=("--pattern=")
-    two_word_flags+=("--pattern")
-    two_word_flags+=("-p")
-    local_nonpersistent_flags+=("--pattern")
-    local_nonpersistent_flags+=("--pattern=")
-    local_nonpersistent_flags+=("-p")
-    flags+=("--role=")
-    two_word_flags+=("--role")
-    two_word_flags+=("-r")
-    local_nonpersistent_flags+=("--role")
-    local_nonpersistent_flags+=("--role=")
-    local_nonpersistent_flags+=("-r")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4333,32 +6089,16 @@ _apictl_mi_get_users()
     noun_aliases=()
 }
 
-_apictl_mi_get()
+_apictl_mi_update()
 {
-    last_command="apictl_mi_get"
+    last_command="apictl_mi_update"
 
     command_aliases=()
 
     commands=()
-    commands+=("apis")
-    commands+=("composite-apps")
-    commands+=("connectors")
-    commands+=("data-services")
-    commands+=("endpoints")
+    commands+=("hashicorp-secret")
     commands+=("help")
-    commands+=("inbound-endpoints")
-    commands+=("local-entries")
-    commands+=("log-levels")
-    commands+=("logs")
-    commands+=("message-processors")
-    commands+=("message-stores")
-    commands+=("proxy-services")
-    commands+=("sequences")
-    commands+=("tasks")
-    commands+=("templates")
-    commands+=("transaction-counts")
-    commands+=("transaction-reports")
-    commands+=("users")
+    commands+=("log-level")
 
     flags=()
     two_word_flags=()
@@ -4372,6 +6112,8 @@ _apictl_mi_get()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4379,13 +6121,27 @@ _apictl_mi_get()
     noun_aliases=()
 }
 
-_apictl_mi_help()
+_apictl_mi()
 {
-    last_command="apictl_mi_help"
+    last_command="apictl_mi"
 
     command_aliases=()
 
     commands=()
+    commands+=("activate")
+    commands+=("add")
+    commands+=("apply")
+    commands+=("deactivate")
+    commands+=("delete")
+    commands+=("deploy")
+    commands+=("export")
+    commands+=("get")
+    commands+=("help")
+    commands+=("login")
+    commands+=("logout")
+    commands+=("snapshot")
+    commands+=("undeploy")
+    commands+=("update")
 
     flags=()
     two_word_flags=()
@@ -4393,19 +6149,24 @@ _apictl_mi_help()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
     must_have_one_noun=()
-    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_mi_login()
+_apictl_params_help()
 {
-    last_command="apictl_mi_login"
+    last_command="apictl_params_help"
 
     command_aliases=()
 
@@ -4417,36 +6178,21 @@ _apictl_mi_login()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--help")
-    flags+=("-h")
-    local_nonpersistent_flags+=("--help")
-    local_nonpersistent_flags+=("-h")
-    flags+=("--password=")
-    two_word_flags+=("--password")
-    two_word_flags+=("-p")
-    local_nonpersistent_flags+=("--password")
-    local_nonpersistent_flags+=("--password=")
-    local_nonpersistent_flags+=("-p")
-    flags+=("--password-stdin")
-    local_nonpersistent_flags+=("--password-stdin")
-    flags+=("--username=")
-    two_word_flags+=("--username")
-    two_word_flags+=("-u")
-    local_nonpersistent_flags+=("--username")
-    local_nonpersistent_flags+=("--username=")
-    local_nonpersistent_flags+=("-u")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
     must_have_one_noun=()
+    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_mi_logout()
+_apictl_params_render()
 {
-    last_command="apictl_mi_logout"
+    last_command="apictl_params_render"
 
     command_aliases=()
 
@@ -4458,12 +6204,26 @@ _apictl_mi_logout()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4471,9 +6231,9 @@ _apictl_mi_logout()
     noun_aliases=()
 }
 
-_apictl_mi_update_hashicorp-secret()
+_apictl_params_validate()
 {
-    last_command="apictl_mi_update_hashicorp-secret"
+    last_command="apictl_params_validate"
 
     command_aliases=()
 
@@ -4485,34 +6245,41 @@ _apictl_mi_update_hashicorp-secret()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--environment=")
-    two_word_flags+=("--environment")
-    two_word_flags+=("-e")
-    local_nonpersistent_flags+=("--environment")
-    local_nonpersistent_flags+=("--environment=")
-    local_nonpersistent_flags+=("-e")
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--type=")
+    two_word_flags+=("--type")
+    local_nonpersistent_flags+=("--type")
+    local_nonpersistent_flags+=("--type=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
-    must_have_one_flag+=("--environment=")
-    must_have_one_flag+=("-e")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_mi_update_help()
+_apictl_params()
 {
-    last_command="apictl_mi_update_help"
+    last_command="apictl_params"
 
     command_aliases=()
 
     commands=()
+    commands+=("help")
+    commands+=("render")
+    commands+=("validate")
 
     flags=()
     two_word_flags=()
@@ -4520,19 +6287,24 @@ _apictl_mi_update_help()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
     must_have_one_noun=()
-    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_mi_update_log-level()
+_apictl_project_help()
 {
-    last_command="apictl_mi_update_log-level"
+    last_command="apictl_project_help"
 
     command_aliases=()
 
@@ -4544,37 +6316,25 @@ _apictl_mi_update_log-level()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--environment=")
-    two_word_flags+=("--environment")
-    two_word_flags+=("-e")
-    local_nonpersistent_flags+=("--environment")
-    local_nonpersistent_flags+=("--environment=")
-    local_nonpersistent_flags+=("-e")
-    flags+=("--help")
-    flags+=("-h")
-    local_nonpersistent_flags+=("--help")
-    local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
-    must_have_one_flag+=("--environment=")
-    must_have_one_flag+=("-e")
     must_have_one_noun=()
+    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_mi_update()
+_apictl_project_migrate()
 {
-    last_command="apictl_mi_update"
+    last_command="apictl_project_migrate"
 
     command_aliases=()
 
     commands=()
-    commands+=("hashicorp-secret")
-    commands+=("help")
-    commands+=("log-level")
 
     flags=()
     two_word_flags=()
@@ -4582,35 +6342,41 @@ _apictl_mi_update()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--dry-run")
+    local_nonpersistent_flags+=("--dry-run")
+    flags+=("--from=")
+    two_word_flags+=("--from")
+    local_nonpersistent_flags+=("--from")
+    local_nonpersistent_flags+=("--from=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--to=")
+    two_word_flags+=("--to")
+    local_nonpersistent_flags+=("--to")
+    local_nonpersistent_flags+=("--to=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--from=")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_mi()
+_apictl_project()
 {
-    last_command="apictl_mi"
+    last_command="apictl_project"
 
     command_aliases=()
 
     commands=()
-    commands+=("activate")
-    commands+=("add")
-    commands+=("deactivate")
-    commands+=("delete")
-    commands+=("get")
     commands+=("help")
-    commands+=("login")
-    commands+=("logout")
-    commands+=("update")
+    commands+=("migrate")
 
     flags=()
     two_word_flags=()
@@ -4624,6 +6390,8 @@ _apictl_mi()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4651,6 +6419,8 @@ _apictl_remove_env()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4674,6 +6444,8 @@ _apictl_remove_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4704,6 +6476,8 @@ _apictl_remove()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4772,6 +6546,8 @@ _apictl_secret_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4800,6 +6576,8 @@ _apictl_secret_init()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4830,6 +6608,8 @@ _apictl_secret()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4875,6 +6655,8 @@ _apictl_set()
     local_nonpersistent_flags+=("--vcs-deletion-enabled")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4936,6 +6718,8 @@ _apictl_undeploy_api()
     local_nonpersistent_flags+=("-v")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -4998,6 +6782,8 @@ _apictl_undeploy_api-product()
     local_nonpersistent_flags+=("--rev=")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -5026,6 +6812,8 @@ _apictl_undeploy_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -5057,6 +6845,47 @@ _apictl_undeploy()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_validate()
+{
+    last_command="apictl_validate"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--ruleset=")
+    two_word_flags+=("--ruleset")
+    local_nonpersistent_flags+=("--ruleset")
+    local_nonpersistent_flags+=("--ruleset=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -5078,6 +6907,8 @@ _apictl_vcs_deploy()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--dry-run")
+    local_nonpersistent_flags+=("--dry-run")
     flags+=("--environment=")
     two_word_flags+=("--environment")
     two_word_flags+=("-e")
@@ -5088,10 +6919,24 @@ _apictl_vcs_deploy()
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--parallel=")
+    two_word_flags+=("--parallel")
+    local_nonpersistent_flags+=("--parallel")
+    local_nonpersistent_flags+=("--parallel=")
     flags+=("--skip-rollback")
     local_nonpersistent_flags+=("--skip-rollback")
+    flags+=("--skip-validation")
+    local_nonpersistent_flags+=("--skip-validation")
+    flags+=("--substitution-mode=")
+    two_word_flags+=("--substitution-mode")
+    local_nonpersistent_flags+=("--substitution-mode")
+    local_nonpersistent_flags+=("--substitution-mode=")
+    flags+=("--substitution-report")
+    local_nonpersistent_flags+=("--substitution-report")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -5117,6 +6962,8 @@ _apictl_vcs_help()
 
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -5149,6 +6996,8 @@ _apictl_vcs_init()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -5182,6 +7031,8 @@ _apictl_vcs_status()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -5215,6 +7066,8 @@ _apictl_vcs()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -5242,6 +7095,8 @@ _apictl_version()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -5259,7 +7114,9 @@ _apictl_root_command()
     commands+=("add")
     commands+=("bundle")
     commands+=("change-status")
+    commands+=("credentials")
     commands+=("delete")
+    commands+=("diff")
     commands+=("export")
     commands+=("gen")
     commands+=("get")
@@ -5271,10 +7128,13 @@ _apictl_root_command()
     commands+=("logout")
     commands+=("mg")
     commands+=("mi")
+    commands+=("params")
+    commands+=("project")
     commands+=("remove")
     commands+=("secret")
     commands+=("set")
     commands+=("undeploy")
+    commands+=("validate")
     commands+=("vcs")
     commands+=("version")
 
@@ -5290,6 +7150,8 @@ _apictl_root_command()
     local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
+    flags+=("--output=")
+    two_word_flags+=("--output")
     flags+=("--verbose")
 
     must_have_one_flag=()
@@ -0,0 +1,299 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package params
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Keys of a params file used to layer params files
+const (
+	// path to the base params file, relative to the params file
+	extendsKey = "extends"
+	// configs merged into the configs of all the environments
+	defaultsKey = "defaults"
+
+	environmentsKey       = "environments"
+	environmentNameKey    = "name"
+	environmentConfigsKey = "configs"
+)
+
+// paramsLayer is the content of a params file as a json object
+type paramsLayer map[string]interface{}
+
+// paramsLoader loads a params file with the files it extends
+type paramsLoader struct {
+	// schema is the type of the params each file is validated against. The files are not validated if nil
+	schema reflect.Type
+	// errors found while validating the files
+	errors []ParamsValidationError
+}
+
+// load loads a params file merged with the files it extends
+// @param path : Path to the params file
+// @param extendedBy : Params files extending this file, used to detect cycles
+func (loader *paramsLoader) load(path string, extendedBy []string) (paramsLayer, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range extendedBy {
+		if file == absPath {
+			return nil, errors.New("params files extend each other: " +
+				strings.Join(append(extendedBy, absPath), " -> "))
+		}
+	}
+
+	fileContent, err := getEnvSubstitutedFileContent(absPath)
+	if err != nil {
+		return nil, err
+	}
+	if loader.schema != nil {
+		fileErrors := decodeParams(path, []byte(fileContent), reflect.New(loader.schema).Interface())
+		loader.errors = append(loader.errors, fileErrors...)
+		// the files with syntax errors cannot be merged
+		if len(fileErrors) > 0 && fileErrors[0].syntaxError {
+			return paramsLayer{}, nil
+		}
+	}
+	jsonContent, err := utils.YamlToJson([]byte(fileContent))
+	if err != nil {
+		return nil, err
+	}
+	layer := paramsLayer{}
+	if err := json.Unmarshal(jsonContent, &layer); err != nil {
+		return nil, errors.New("invalid params file " + path + ": " + err.Error())
+	}
+	// an empty file is unmarshalled as null
+	if layer == nil {
+		layer = paramsLayer{}
+	}
+
+	extends, ok := layer[extendsKey]
+	if !ok {
+		return layer, nil
+	}
+	delete(layer, extendsKey)
+	basePath, ok := extends.(string)
+	if !ok || basePath == "" {
+		return nil, errors.New(extendsKey + " of " + path + " should be the path to a params file")
+	}
+	if !filepath.IsAbs(basePath) {
+		basePath = filepath.Join(filepath.Dir(absPath), basePath)
+	}
+	utils.Logln(utils.LogPrefixInfo + "Loading base params of " + path + " from " + basePath)
+	base, err := loader.load(basePath, append(extendedBy, absPath))
+	if err != nil {
+		return nil, err
+	}
+	return base.merge(layer)
+}
+
+// merge merges a params layer over this layer. The environments of the layers are merged by the name.
+func (base paramsLayer) merge(layer paramsLayer) (paramsLayer, error) {
+	baseEnvironments, err := base.environments()
+	if err != nil {
+		return nil, err
+	}
+	environments, err := layer.environments()
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := mergeParamsObjects(base.withoutEnvironments(), layer.withoutEnvironments())
+	if err != nil {
+		return nil, err
+	}
+	mergedEnvironments := baseEnvironments
+	for _, environment := range environments {
+		index := findParamsEnvironment(mergedEnvironments, environment[environmentNameKey])
+		if index < 0 {
+			mergedEnvironments = append(mergedEnvironments, environment)
+			continue
+		}
+		if mergedEnvironments[index], err = mergeParamsObjects(mergedEnvironments[index], environment); err != nil {
+			return nil, err
+		}
+	}
+	if mergedEnvironments != nil {
+		merged[environmentsKey] = mergedEnvironments
+	}
+	return merged, nil
+}
+
+// applyDefaults merges the configs of the environments over the defaults and removes the defaults
+func (layer paramsLayer) applyDefaults() error {
+	defaults, ok := layer[defaultsKey]
+	if !ok {
+		return nil
+	}
+	delete(layer, defaultsKey)
+	defaultConfigs, ok := defaults.(map[string]interface{})
+	if !ok {
+		return errors.New(defaultsKey + " should be an object")
+	}
+	environments, err := layer.environments()
+	if err != nil {
+		return err
+	}
+	for _, environment := range environments {
+		configs, _ := environment[environmentConfigsKey].(map[string]interface{})
+		mergedConfigs, err := mergeParamsObjects(defaultConfigs, configs)
+		if err != nil {
+			return err
+		}
+		environment[environmentConfigsKey] = mergedConfigs
+	}
+	if environments != nil {
+		layer[environmentsKey] = environments
+	}
+	return nil
+}
+
+// environments returns the environments of the layer
+func (layer paramsLayer) environments() ([]map[string]interface{}, error) {
+	value, ok := layer[environmentsKey]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	// environments of the merged layers
+	if environments, ok := value.([]map[string]interface{}); ok {
+		return environments, nil
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New(environmentsKey + " should be a list")
+	}
+	environments := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		environment, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("each item of " + environmentsKey + " should be an object")
+		}
+		environments = append(environments, environment)
+	}
+	return environments, nil
+}
+
+// withoutEnvironments returns a copy of the layer without the environments
+func (layer paramsLayer) withoutEnvironments() map[string]interface{} {
+	object := make(map[string]interface{})
+	for key, value := range layer {
+		if key != environmentsKey {
+			object[key] = value
+		}
+	}
+	return object
+}
+
+// filterEnvironment removes all the environments except the given environment
+// @return whether the environment is defined
+func (layer paramsLayer) filterEnvironment(name string) (bool, error) {
+	environments, err := layer.environments()
+	if err != nil {
+		return false, err
+	}
+	index := findParamsEnvironment(environments, name)
+	if index < 0 {
+		return false, nil
+	}
+	layer[environmentsKey] = environments[index : index+1]
+	return true, nil
+}
+
+// toYaml converts the layer to YAML
+func (layer paramsLayer) toYaml() ([]byte, error) {
+	jsonContent, err := json.Marshal(layer)
+	if err != nil {
+		return nil, err
+	}
+	return utils.JsonToYaml(jsonContent)
+}
+
+// findParamsEnvironment returns the index of the environment with the given name or -1 if not found
+func findParamsEnvironment(environments []map[string]interface{}, name interface{}) int {
+	for index, environment := range environments {
+		if environment[environmentNameKey] == name {
+			return index
+		}
+	}
+	return -1
+}
+
+// mergeParamsObjects deep merges the second object over the first object using utils.MergeJSON
+func mergeParamsObjects(first, second map[string]interface{}) (map[string]interface{}, error) {
+	if first == nil {
+		first = make(map[string]interface{})
+	}
+	if second == nil {
+		second = make(map[string]interface{})
+	}
+	firstContent, err := json.Marshal(first)
+	if err != nil {
+		return nil, err
+	}
+	secondContent, err := json.Marshal(second)
+	if err != nil {
+		return nil, err
+	}
+	mergedContent, err := utils.MergeJSON(firstContent, secondContent)
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]interface{})
+	if err := json.Unmarshal(mergedContent, &merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// RenderParams returns the effective params of a params file. The base files are merged first, so that the values of
+// the params file override the values of the files it extends. Then the defaults are merged into the configs of each
+// environment, so that the environment specific configs override the defaults. Environment variables defined as
+// ${var} are substituted in all the files.
+// @param path : Path to the params file
+// @param environment : Name of the environment to render. All the environments are rendered if empty
+// @return the effective params as YAML
+// @return error if the params cannot be loaded or the environment is not defined
+func RenderParams(path, environment string) ([]byte, error) {
+	loader := &paramsLoader{}
+	layer, err := loader.load(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := layer.applyDefaults(); err != nil {
+		return nil, err
+	}
+	if environment != "" {
+		found, err := layer.filterEnvironment(environment)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, utils.NewNotFoundError("Environment '" + environment + "' does not exist in " + path)
+		}
+	}
+	return layer.toYaml()
+}
@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
-	"gopkg.in/yaml.v2"
 )
 
 // Configuration represents endpoint config
@@ -50,30 +49,45 @@ type APIIdentifier struct {
 	Version string `json:"version"`
 }
 
+// Environment contains the configurations of an API specific to an environment
 type Environment struct {
-	Name   string                 `yaml:"name"`
-	Config map[string]interface{} `yaml:"configs"`
+	Name   string              `yaml:"name"`
+	Config *EnvironmentConfigs `yaml:"configs,omitempty"`
 }
 
 // ApiParams represents environments defined in configuration file
 type ApiParams struct {
+	// Version of the params schema
+	Version string `yaml:"version,omitempty"`
+	// Extends is the path to the base params file. It is resolved when loading the params
+	Extends string `yaml:"extends,omitempty"`
+	// Defaults are merged into the configs of all the environments when loading the params
+	Defaults *EnvironmentConfigs `yaml:"defaults,omitempty"`
 	// Environments contains all environments in a configuration
 	Environments []Environment `yaml:"environments"`
 	Deploy       APIVCSParams  `yaml:"deploy"`
 }
 
+// ApiProductParams represents environments defined in the configuration file of an API Product
 type ApiProductParams struct {
-	Deploy ApiProductVCSParams `yaml:"deploy"`
+	Version      string              `yaml:"version,omitempty"`
+	Extends      string              `yaml:"extends,omitempty"`
+	Defaults     *EnvironmentConfigs `yaml:"defaults,omitempty"`
+	Environments []Environment       `yaml:"environments,omitempty"`
+	Deploy       ApiProductVCSParams `yaml:"deploy"`
 }
 
 type ApplicationParams struct {
-	Deploy ApplicationVCSParams `yaml:"deploy"`
+	Version string               `yaml:"version,omitempty"`
+	Extends string               `yaml:"extends,omitempty"`
+	Deploy  ApplicationVCSParams `yaml:"deploy"`
 }
 
 // ------------------- Structs for VCS Import Params ----------------------------------
 
 type ApplicationVCSParams struct {
 	Import ApplicationImportParams `yaml:"import"`
+	Keys   []ApplicationKeyParams  `yaml:"keys,omitempty"`
 }
 
 type APIVCSParams struct {
@@ -104,17 +118,28 @@ type ApplicationImportParams struct {
 	SkipSubscriptions bool   `yaml:"skipSubscriptions"`
 }
 
+// ApplicationKeyParams contains the settings of a key of an Application, which is generated or updated after
+// deploying the Application
+type ApplicationKeyParams struct {
+	KeyType     string   `yaml:"keyType"`
+	KeyManager  string   `yaml:"keyManager,omitempty"`
+	GrantTypes  []string `yaml:"grantTypes,omitempty"`
+	CallbackURL string   `yaml:"callbackUrl,omitempty"`
+}
+
 type ProjectParams struct {
 	Type                       string             `yaml:"type"`
 	AbsolutePath               string             `yaml:"absolutePath,omitempty"`
 	RelativePath               string             `yaml:"relativePath,omitempty"`
 	NickName                   string             `yaml:"nickName,omitempty"`
+	ParamsFile                 string             `yaml:"paramsFile,omitempty"`
 	FailedDuringPreviousDeploy bool               `yaml:"failedDuringPreviousDeploy,omitempty"`
 	Deleted                    bool               `yaml:"deleted,omitempty"`
 	ProjectInfo                ProjectInfo        `yaml:"projectInfo,omitempty"`
 	ApiParams                  *ApiParams         `yaml:"apiParams,omitempty"`
 	ApiProductParams           *ApiProductParams  `yaml:"apiProductParams,omitempty"`
 	ApplicationParams          *ApplicationParams `yaml:"applicationParams,omitempty"`
+	DeployStage                int                `yaml:"-"`
 }
 
 type ProjectInfo struct {
@@ -125,6 +150,34 @@ type ProjectInfo struct {
 
 // ---------------- End of Structs for Project Details ---------------------------------
 
+// AdminArtifactParams represents the environment specific configurations of the admin artifacts (throttling
+// policies, key managers and shared scopes) defined in a params file
+type AdminArtifactParams struct {
+	Version  string                `yaml:"version,omitempty"`
+	Extends  string                `yaml:"extends,omitempty"`
+	Defaults *AdminArtifactConfigs `yaml:"defaults,omitempty"`
+	// Environments contains all environments in a configuration
+	Environments []AdminArtifactEnvironment `yaml:"environments"`
+}
+
+// AdminArtifactEnvironment contains the configurations of the admin artifacts of an environment
+type AdminArtifactEnvironment struct {
+	Name    string               `yaml:"name"`
+	Configs AdminArtifactConfigs `yaml:"configs"`
+}
+
+// AdminArtifactConfigs contains the configurations merged into the data of the admin artifacts, by the name of the
+// artifact
+type AdminArtifactConfigs struct {
+	// Policies contains the configurations of the throttling policies by the policy type (advanced, application,
+	// subscription or custom) and the policy name
+	Policies map[string]map[string]map[string]interface{} `yaml:"policies"`
+	// KeyManagers contains the configurations of the key managers by the key manager name
+	KeyManagers map[string]map[string]interface{} `yaml:"keyManagers"`
+	// Scopes contains the configurations of the shared scopes by the scope name
+	Scopes map[string]map[string]interface{} `yaml:"scopes"`
+}
+
 // APIEndpointConfig contains details about endpoints in an API
 type APIEndpointConfig struct {
 	// EPConfig is representing endpoint configuration
@@ -147,7 +200,7 @@ func getEnvSubstitutedFileContent(path string) (string, error) {
 		return "", err
 	}
 
-	str, err := utils.EnvSubstituteForCurlyBraces(string(data))
+	str, err := utils.EnvSubstituteContent(path, string(data))
 	if err != nil {
 		return "", err
 	}
@@ -160,13 +213,8 @@ func getEnvSubstitutedFileContent(path string) (string, error) {
 func LoadApiParamsFromDirectory(path string) (*ApiParams, error) {
 	paramsFilePath := filepath.Join(path, utils.ParamFile)
 	utils.Logln(utils.LogPrefixInfo + "Loading params from " + paramsFilePath)
-	fileContent, err := getEnvSubstitutedFileContent(paramsFilePath)
-	if err != nil {
-		return nil, err
-	}
-
 	apiParams := &ApiParams{}
-	err = yaml.Unmarshal([]byte(fileContent), &apiParams)
+	err := unmarshalLayeredParams(paramsFilePath, apiParams)
 	if err != nil {
 		return nil, err
 	}
@@ -179,13 +227,8 @@ func LoadApiParamsFromDirectory(path string) (*ApiParams, error) {
 //	It returns an error or a valid ApiProductParams
 func LoadApiProductParamsFromDirectory(path string) (*ApiProductParams, error) {
 	paramsFilePath := filepath.Join(path, utils.ParamFileAPIProduct)
-	fileContent, err := getEnvSubstitutedFileContent(paramsFilePath)
-	if err != nil {
-		return nil, err
-	}
-
 	apiParams := &ApiProductParams{}
-	err = yaml.Unmarshal([]byte(fileContent), &apiParams)
+	err := unmarshalLayeredParams(paramsFilePath, apiParams)
 	if err != nil {
 		return nil, err
 	}
@@ -198,13 +241,8 @@ func LoadApiProductParamsFromDirectory(path string) (*ApiProductParams, error) {
 //	It returns an error or a valid ApplicationParams
 func LoadApplicationParamsFromDirectory(path string) (*ApplicationParams, error) {
 	paramsFilePath := filepath.Join(path, utils.ParamFileApplication)
-	fileContent, err := getEnvSubstitutedFileContent(paramsFilePath)
-	if err != nil {
-		return nil, err
-	}
-
 	apiParams := &ApplicationParams{}
-	err = yaml.Unmarshal([]byte(fileContent), &apiParams)
+	err := unmarshalLayeredParams(paramsFilePath, apiParams)
 	if err != nil {
 		return nil, err
 	}
@@ -215,13 +253,8 @@ func LoadApplicationParamsFromDirectory(path string) (*ApplicationParams, error)
 // LoadApiParamsFromFile loads an API Project configuration YAML file located in path.
 //	It returns an error or a valid ApiParams
 func LoadApiParamsFromFile(path string) (*ApiParams, error) {
-	fileContent, err := getEnvSubstitutedFileContent(path)
-	if err != nil {
-		return nil, err
-	}
-
 	apiParams := &ApiParams{}
-	err = yaml.Unmarshal([]byte(fileContent), &apiParams)
+	err := unmarshalLayeredParams(path, apiParams)
 	if err != nil {
 		return nil, err
 	}
@@ -232,13 +265,8 @@ func LoadApiParamsFromFile(path string) (*ApiParams, error) {
 // LoadApiProductParamsFromFile loads an API Product project configuration YAML file located in path.
 //	It returns an error or a valid ApiProductParams
 func LoadApiProductParamsFromFile(path string) (*ApiProductParams, error) {
-	fileContent, err := getEnvSubstitutedFileContent(path)
-	if err != nil {
-		return nil, err
-	}
-
 	apiParams := &ApiProductParams{}
-	err = yaml.Unmarshal([]byte(fileContent), &apiParams)
+	err := unmarshalLayeredParams(path, apiParams)
 	if err != nil {
 		return nil, err
 	}
@@ -249,18 +277,25 @@ func LoadApiProductParamsFromFile(path string) (*ApiProductParams, error) {
 // LoadApplicationParamsFromFile loads an Application project configuration YAML file located in path.
 //	It returns an error or a valid ApplicationParams
 func LoadApplicationParamsFromFile(path string) (*ApplicationParams, error) {
-	fileContent, err := getEnvSubstitutedFileContent(path)
+	apiParams := &ApplicationParams{}
+	err := unmarshalLayeredParams(path, apiParams)
 	if err != nil {
 		return nil, err
 	}
 
-	apiParams := &ApplicationParams{}
-	err = yaml.Unmarshal([]byte(fileContent), &apiParams)
+	return apiParams, err
+}
+
+// LoadAdminArtifactParamsFromFile loads an admin artifacts configuration YAML file located in path.
+//	It returns an error or a valid AdminArtifactParams
+func LoadAdminArtifactParamsFromFile(path string) (*AdminArtifactParams, error) {
+	adminArtifactParams := &AdminArtifactParams{}
+	err := unmarshalLayeredParams(path, adminArtifactParams)
 	if err != nil {
 		return nil, err
 	}
 
-	return apiParams, err
+	return adminArtifactParams, err
 }
 
 // ExtractAPIEndpointConfig extracts API endpoint information from a slice of byte b
@@ -283,3 +318,13 @@ func (config ApiParams) GetEnv(key string) *Environment {
 	}
 	return nil
 }
+
+// GetEnv returns the configurations of the admin artifacts of the environment, if not found returns nil
+func (config AdminArtifactParams) GetEnv(key string) *AdminArtifactEnvironment {
+	for index, env := range config.Environments {
+		if env.Name == key {
+			return &config.Environments[index]
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package params
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -29,14 +30,47 @@ func TestLoadApiParamsFromFileInvalidYAML(t *testing.T) {
 	conf, err := LoadApiParamsFromFile("testdata/api_params-invalid.yml")
 	assert.Error(t, err, "Should return an error for invalid yaml files")
 	assert.Nil(t, conf, "Should return nil when errors are returned")
+	assert.Contains(t, err.Error(), "unknown field endpoints",
+		"Should reject the endpoints of an environment which are not in its configs")
+
+	conf, err = LoadApiParamsFromFile("testdata/api_params-v4-invalid.yml")
+	assert.Error(t, err, "Should return an error for invalid yaml files")
+	assert.Nil(t, conf, "Should return nil when errors are returned")
+	assert.Contains(t, err.Error(), "invalid value foo, expected a number")
 }
 
 func TestLoadApiParamsFromFileWithoutEnv(t *testing.T) {
 	conf, err := LoadApiParamsFromFile("testdata/api_params-env.yml")
+	assert.Error(t, err, "Should return error for the params which are not in the configs of the environments")
+	assert.Nil(t, conf, "Conf should be nil")
+	assert.Contains(t, err.Error(), "unknown field endpoints")
+
+	conf, err = LoadApiParamsFromFile("testdata/api_params-v4-env.yml")
 	assert.Error(t, err, "Should return error when environment variables not present")
 	assert.Nil(t, conf, "Conf should be nil")
 }
 
+func TestLoadApiParamsFromFileWithPlaceholders(t *testing.T) {
+	conf, err := LoadApiParamsFromFile("testdata/api_params-v4-placeholders.yml")
+	assert.Nil(t, err, "Should accept placeholders in place of numbers and booleans")
+
+	dev := conf.GetEnv("dev").Config
+	config := dev.Endpoints.Production.Config
+	assert.Equal(t, &ParamsInt{Placeholder: "$FOO_DEV_RETRY"}, config.RetryTimeOut)
+	assert.Equal(t, &ParamsInt{Placeholder: "${secret:file:/run/secrets/retry_delay}"}, config.RetryDelay)
+	assert.Equal(t, &ParamsInt{Value: 2}, config.Factor)
+	assert.Equal(t, &ParamsBool{Placeholder: "$FOO_DEV_SECURITY"}, dev.Security.Production.Enabled)
+	assert.Equal(t, &ParamsBool{Value: true}, dev.DeploymentEnvironments[0].DisplayOnDevportal)
+
+	content, err := json.Marshal(config)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"retryTimeOut": "$FOO_DEV_RETRY", "retryDelay": "${secret:file:/run/secrets/retry_delay}",
+		"factor": 2}`, string(content), "Should keep the placeholders")
+	decoded := &EndpointConfigParams{}
+	assert.Nil(t, json.Unmarshal(content, decoded))
+	assert.Equal(t, config, decoded)
+}
+
 func TestLoadAPIFromFile(t *testing.T) {
 	apiData, err := loadAPIFromFile("testdata/api.json")
 	assert.Nil(t, err, "Error should be nil when correct json loaded")
@@ -53,9 +87,189 @@ func TestExtractAPIEndpointConfig(t *testing.T) {
 }
 
 func TestAPIConfig_ContainsEnv(t *testing.T) {
-	configData, err := LoadApiParamsFromFile("testdata/api_params.yml")
+	configData, err := LoadApiParamsFromFile("testdata/api_params-v4.yml")
 	assert.Nil(t, err, "Error should be nil for correct yaml loading")
 
 	assert.NotNil(t, configData.GetEnv("dev"), "Should contain correct environment")
 	assert.Nil(t, configData.GetEnv("prod"), "Should not contain undefined environment")
+
+	_, err = LoadApiParamsFromFile("testdata/api_params.yml")
+	assert.Error(t, err, "Should reject the environments without configs")
+	validationErrors, err := ValidateParamsFile("testdata/api_params.yml", ParamsTypeAPI)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(validationErrors), "Should report the endpoints of each environment")
+	assert.Equal(t, 3, validationErrors[0].Line)
+	assert.Equal(t, "unknown field endpoints", validationErrors[0].Message)
+}
+
+func TestLoadAdminArtifactParamsFromFile(t *testing.T) {
+	_, err := LoadAdminArtifactParamsFromFile("testdata/admin_params.yml")
+	assert.Error(t, err, "Should return error when environment variables not present")
+
+	_ = os.Setenv("ADMIN_PARAMS_TEST_SECRET", "okta-secret")
+	defer os.Unsetenv("ADMIN_PARAMS_TEST_SECRET")
+	configData, err := LoadAdminArtifactParamsFromFile("testdata/admin_params.yml")
+	assert.Nil(t, err, "Error should be nil for correct yaml loading")
+	assert.Nil(t, configData.GetEnv("qa"), "Should not contain undefined environment")
+
+	dev := configData.GetEnv("dev")
+	assert.NotNil(t, dev, "Should contain correct environment")
+	assert.Equal(t, map[string]interface{}{"requestCount": map[string]interface{}{"requestCount": 100}},
+		dev.Configs.Policies["advanced"]["10KPerMin"]["defaultLimit"])
+	assert.Equal(t, map[string]interface{}{"client_secret": "okta-secret"},
+		dev.Configs.KeyManagers["Okta"]["additionalProperties"])
+	assert.Equal(t, []interface{}{"admin"}, configData.GetEnv("prod").Configs.Scopes["admin_scope"]["bindings"])
+}
+
+func TestLoadApiParamsFromFileLayered(t *testing.T) {
+	_ = os.Setenv("LAYERED_PARAMS_TEST_PASSWORD", "secret")
+	defer os.Unsetenv("LAYERED_PARAMS_TEST_PASSWORD")
+	apiParams, err := LoadApiParamsFromFile("testdata/layered/params.yaml")
+	assert.Nil(t, err, "Error should be nil for correct yaml loading")
+	assert.Equal(t, 3, len(apiParams.Environments), "Environments of the base file should be inherited")
+	assert.True(t, apiParams.Deploy.Import.Update, "Deploy params of the base file should be inherited")
+
+	prod := apiParams.GetEnv("prod").Config
+	assert.Equal(t, "https://prod.backend.internal", *prod.Endpoints.Production.Url,
+		"Environment configs should override the defaults")
+	assert.Equal(t, &ParamsInt{Value: 120}, prod.Endpoints.Production.Config.RetryTimeOut,
+		"Environment configs should override the base file")
+	assert.Equal(t, &SecurityParams{Enabled: &ParamsBool{Value: true}, Type: "basic", Username: "admin", Password: "secret"},
+		prod.Security.Production, "Defaults of the base file and the params file should be merged")
+
+	assert.False(t, apiParams.GetEnv("test").Config.Security.Production.Enabled.Value)
+	assert.NotNil(t, apiParams.GetEnv("dev").Config.Endpoints, "Defaults should apply to environments without configs")
+}
+
+func TestRenderParams(t *testing.T) {
+	_ = os.Setenv("LAYERED_PARAMS_TEST_PASSWORD", "secret")
+	defer os.Unsetenv("LAYERED_PARAMS_TEST_PASSWORD")
+	content, err := RenderParams("testdata/layered/params.yaml", "test")
+	assert.Nil(t, err, "Error should be nil for correct yaml loading")
+	assert.False(t, strings.Contains(string(content), "extends"), "Rendered params should not extend other files")
+	assert.False(t, strings.Contains(string(content), "name: prod"), "Only the given environment should be rendered")
+	assert.True(t, strings.Contains(string(content), "name: test"), "Given environment should be rendered")
+
+	_, err = RenderParams("testdata/layered/params.yaml", "qa")
+	assert.Error(t, err, "Should return an error for undefined environments")
+
+	_, err = RenderParams("testdata/layered/cycle_a.yaml", "")
+	assert.Error(t, err, "Should return an error when params files extend each other")
+}
+
+func TestValidateParamsFile(t *testing.T) {
+	validationErrors, err := ValidateParamsFile("testdata/api_params-v4.yml", ParamsTypeAPI)
+	assert.Nil(t, err)
+	assert.Empty(t, validationErrors, "Valid params should not have errors")
+
+	dir, err := ioutil.TempDir("", "apictl-params")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	paramsFile := filepath.Join(dir, "api_params.yaml")
+	assert.Nil(t, ioutil.WriteFile(paramsFile, []byte(`version: v4.0.0
+environments:
+  - name: dev
+    configs:
+      endpoint:
+        production:
+          url: https://dev.backend
+      security:
+        production:
+          enabled: yes please
+  - name: prod
+    configs:
+      endpointRoutingPolicy: round_robin
+      security:
+        production:
+          type: oauth
+          grantType: client_credentials
+          tokenUrl: https://idp/token
+      mutualSslCerts:
+        - alias: client
+          path: client.crt
+          keyType: production
+`), 0644))
+
+	validationErrors, err = ValidateParamsFile(paramsFile, ParamsTypeAPI)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(validationErrors), "Unknown fields and invalid types should be reported")
+	assert.Equal(t, 5, validationErrors[0].Line)
+	assert.Equal(t, "unknown field endpoint", validationErrors[0].Message)
+	assert.Equal(t, 10, validationErrors[1].Line)
+	assert.Equal(t, "invalid value yes please, expected true or false", validationErrors[1].Message)
+
+	content, err := ioutil.ReadFile(paramsFile)
+	assert.Nil(t, err)
+	content = []byte(strings.Replace(strings.Replace(string(content), "endpoint:", "endpoints:", 1),
+		"yes please", "true", 1))
+	assert.Nil(t, ioutil.WriteFile(paramsFile, content, 0644))
+	validationErrors, err = ValidateParamsFile(paramsFile, ParamsTypeAPI)
+	assert.Nil(t, err)
+	var messages []string
+	for _, validationError := range validationErrors {
+		messages = append(messages, validationError.String())
+	}
+	assert.Equal(t, []string{
+		paramsFile + ":9: environments.0.configs.security.production.type: type is required",
+		paramsFile + ":13: environments.1.configs.endpointRoutingPolicy: invalid endpoint routing policy " +
+			"round_robin, it should be load_balanced or failover",
+		paramsFile + ":15: environments.1.configs.security.production: tokenUrl, clientId and clientSecret are " +
+			"required for oauth security",
+		paramsFile + ":22: environments.1.configs.mutualSslCerts.0.keyType: invalid key type production, it should " +
+			"be PRODUCTION or SANDBOX",
+	}, messages)
+
+	_, err = LoadApiParamsFromFile(paramsFile)
+	assert.Error(t, err, "Should return an error for invalid params")
+
+	_, err = ValidateParamsFile(paramsFile, "mg")
+	assert.Error(t, err, "Should return an error for unknown params types")
+}
+
+func TestValidateParamsFileMIAndMGSections(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-params")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	paramsFile := filepath.Join(dir, "api_params.yaml")
+	assert.Nil(t, ioutil.WriteFile(paramsFile, []byte(`environments:
+  - name: dev
+    configs:
+      endpoints:
+        production:
+          url: https://dev.backend
+      mi:
+        synapseProperties:
+          retryCount: 3
+      mg:
+        vhost: dev.gw.wso2.com
+`), 0644))
+
+	validationErrors, err := ValidateParamsFile(paramsFile, ParamsTypeAPI)
+	assert.Nil(t, err)
+	assert.Empty(t, validationErrors, "MI and MG sections should be accepted")
+
+	apiParams, err := LoadApiParamsFromFile(paramsFile)
+	assert.Nil(t, err)
+	configs := apiParams.Environments[0].Config
+	assert.Equal(t, "dev.gw.wso2.com", configs.MG["vhost"], "MG section should be passed through")
+	assert.Equal(t, map[string]interface{}{"retryCount": 3}, configs.MI["synapseProperties"],
+		"MI section should be passed through")
+
+	assert.Nil(t, ioutil.WriteFile(paramsFile, []byte(`environments:
+  - name: dev
+    configs:
+      mi: enabled
+      mg:
+        - dev.gw.wso2.com
+`), 0644))
+	validationErrors, err = ValidateParamsFile(paramsFile, ParamsTypeAPI)
+	assert.Nil(t, err)
+	var messages []string
+	for _, validationError := range validationErrors {
+		messages = append(messages, validationError.String())
+	}
+	assert.Equal(t, []string{
+		paramsFile + ":4: invalid value enabled, expected an object",
+		paramsFile + ":6: invalid list, expected an object",
+	}, messages)
 }
@@ -0,0 +1,509 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package params
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Versions of the params schema supported by this version of the CLI. The version of a params file is optional.
+var supportedParamsVersions = []string{"v4.0.0"}
+
+// Endpoint routing policies
+const (
+	EndpointRoutingPolicyLoadBalanced = "load_balanced"
+	EndpointRoutingPolicyFailover     = "failover"
+)
+
+// Types of the endpoint security
+const (
+	EndpointSecurityTypeBasic  = "basic"
+	EndpointSecurityTypeDigest = "digest"
+	EndpointSecurityTypeOAuth  = "oauth"
+)
+
+// Grant types of the OAuth endpoint security
+const (
+	EndpointSecurityGrantTypeClientCredentials = "client_credentials"
+	EndpointSecurityGrantTypePassword          = "password"
+)
+
+// Key types of the mutual SSL certificates
+const (
+	KeyTypeProduction = "PRODUCTION"
+	KeyTypeSandbox    = "SANDBOX"
+)
+
+// types of the throttling policies in the admin artifact params
+var throttlingPolicyTypes = []string{"advanced", "application", "subscription", "custom"}
+
+// EnvironmentConfigs are the environment specific configurations of an API
+type EnvironmentConfigs struct {
+	// Endpoints of the API
+	Endpoints *EndpointsParams `yaml:"endpoints,omitempty" json:"endpoints,omitempty"`
+	// EndpointRoutingPolicy is load_balanced or failover
+	EndpointRoutingPolicy string `yaml:"endpointRoutingPolicy,omitempty" json:"endpointRoutingPolicy,omitempty"`
+	// LoadBalanceEndpoints are the endpoints of the load_balanced routing policy
+	LoadBalanceEndpoints *LoadBalanceEndpointsParams `yaml:"loadBalanceEndpoints,omitempty" json:"loadBalanceEndpoints,omitempty"`
+	// FailoverEndpoints are the endpoints of the failover routing policy
+	FailoverEndpoints *FailoverEndpointsParams `yaml:"failoverEndpoints,omitempty" json:"failoverEndpoints,omitempty"`
+	// Security of the endpoints
+	Security *EndpointSecurityParams `yaml:"security,omitempty" json:"security,omitempty"`
+	// DeploymentEnvironments are the gateway environments the API is deployed to
+	DeploymentEnvironments []DeploymentEnvironmentParams `yaml:"deploymentEnvironments,omitempty" json:"deploymentEnvironments,omitempty"`
+	// Certs are the certificates of the endpoints
+	Certs []CertificateParams `yaml:"certs,omitempty" json:"certs,omitempty"`
+	// MutualSslCerts are the client certificates of the mutual SSL
+	MutualSslCerts []MutualSslCertificateParams `yaml:"mutualSslCerts,omitempty" json:"mutualSslCerts,omitempty"`
+	// Policies are the subscription throttling policies of the API
+	Policies []string `yaml:"policies,omitempty" json:"policies,omitempty"`
+	// DependentAPIs are the configurations of the APIs of an API Product by the name of the API
+	DependentAPIs map[string]*EnvironmentConfigs `yaml:"dependentAPIs,omitempty" json:"dependentAPIs,omitempty"`
+	// MI are the Micro Integrator specific configurations and MG are the Microgateway specific configurations.
+	// apictl does not interpret them, so only their structure is validated and they are passed to the intermediate
+	// params as they are, for the runtimes reading them.
+	MI map[string]interface{} `yaml:"mi,omitempty" json:"mi,omitempty"`
+	MG map[string]interface{} `yaml:"mg,omitempty" json:"mg,omitempty"`
+}
+
+// EndpointsParams are the production and sandbox endpoints
+type EndpointsParams struct {
+	Production *EndpointParams `yaml:"production,omitempty" json:"production,omitempty"`
+	Sandbox    *EndpointParams `yaml:"sandbox,omitempty" json:"sandbox,omitempty"`
+}
+
+// EndpointParams is an endpoint with its advanced configurations
+type EndpointParams struct {
+	Url    *string               `yaml:"url,omitempty" json:"url,omitempty"`
+	Config *EndpointConfigParams `yaml:"config,omitempty" json:"config,omitempty"`
+}
+
+// EndpointConfigParams are the advanced configurations of an endpoint
+type EndpointConfigParams struct {
+	RetryTimeOut       *ParamsInt `yaml:"retryTimeOut,omitempty" json:"retryTimeOut,omitempty"`
+	RetryDelay         *ParamsInt `yaml:"retryDelay,omitempty" json:"retryDelay,omitempty"`
+	Factor             *ParamsInt `yaml:"factor,omitempty" json:"factor,omitempty"`
+	SuspendDuration    *ParamsInt `yaml:"suspendDuration,omitempty" json:"suspendDuration,omitempty"`
+	SuspendMaxDuration *ParamsInt `yaml:"suspendMaxDuration,omitempty" json:"suspendMaxDuration,omitempty"`
+	SuspendErrorCode   []string   `yaml:"suspendErrorCode,omitempty" json:"suspendErrorCode,omitempty"`
+	RetryErroCode      []string   `yaml:"retryErroCode,omitempty" json:"retryErroCode,omitempty"`
+	ActionSelect       *string    `yaml:"actionSelect,omitempty" json:"actionSelect,omitempty"`
+	ActionDuration     *ParamsInt `yaml:"actionDuration,omitempty" json:"actionDuration,omitempty"`
+}
+
+// LoadBalanceEndpointsParams are the endpoints of the load_balanced routing policy
+type LoadBalanceEndpointsParams struct {
+	Production        []EndpointParams `yaml:"production,omitempty" json:"production,omitempty"`
+	Sandbox           []EndpointParams `yaml:"sandbox,omitempty" json:"sandbox,omitempty"`
+	SessionManagement *string          `yaml:"sessionManagement,omitempty" json:"sessionManagement,omitempty"`
+	SessionTimeOut    *ParamsInt       `yaml:"sessionTimeOut,omitempty" json:"sessionTimeOut,omitempty"`
+}
+
+// FailoverEndpointsParams are the endpoints of the failover routing policy
+type FailoverEndpointsParams struct {
+	Production          *EndpointParams  `yaml:"production,omitempty" json:"production,omitempty"`
+	Sandbox             *EndpointParams  `yaml:"sandbox,omitempty" json:"sandbox,omitempty"`
+	ProductionFailovers []EndpointParams `yaml:"productionFailovers,omitempty" json:"productionFailovers,omitempty"`
+	SandboxFailovers    []EndpointParams `yaml:"sandboxFailovers,omitempty" json:"sandboxFailovers,omitempty"`
+}
+
+// EndpointSecurityParams are the security configurations of the production and sandbox endpoints
+type EndpointSecurityParams struct {
+	Production *SecurityParams `yaml:"production,omitempty" json:"production,omitempty"`
+	Sandbox    *SecurityParams `yaml:"sandbox,omitempty" json:"sandbox,omitempty"`
+}
+
+// SecurityParams is the security configuration of an endpoint
+type SecurityParams struct {
+	Enabled          *ParamsBool       `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Type             string            `yaml:"type,omitempty" json:"type,omitempty"`
+	Username         string            `yaml:"username,omitempty" json:"username,omitempty"`
+	Password         string            `yaml:"password,omitempty" json:"password,omitempty"`
+	GrantType        string            `yaml:"grantType,omitempty" json:"grantType,omitempty"`
+	TokenUrl         string            `yaml:"tokenUrl,omitempty" json:"tokenUrl,omitempty"`
+	ClientId         string            `yaml:"clientId,omitempty" json:"clientId,omitempty"`
+	ClientSecret     string            `yaml:"clientSecret,omitempty" json:"clientSecret,omitempty"`
+	CustomParameters map[string]string `yaml:"customParameters,omitempty" json:"customParameters,omitempty"`
+}
+
+// DeploymentEnvironmentParams is a gateway environment the API is deployed to
+type DeploymentEnvironmentParams struct {
+	DeploymentEnvironment string      `yaml:"deploymentEnvironment" json:"deploymentEnvironment"`
+	DeploymentVhost       string      `yaml:"deploymentVhost,omitempty" json:"deploymentVhost,omitempty"`
+	DisplayOnDevportal    *ParamsBool `yaml:"displayOnDevportal,omitempty" json:"displayOnDevportal,omitempty"`
+}
+
+// CertificateParams is a certificate of an endpoint
+type CertificateParams struct {
+	HostName string `yaml:"hostName" json:"hostName"`
+	Alias    string `yaml:"alias" json:"alias"`
+	Path     string `yaml:"path" json:"path"`
+}
+
+// MutualSslCertificateParams is a client certificate of the mutual SSL
+type MutualSslCertificateParams struct {
+	TierName string `yaml:"tierName,omitempty" json:"tierName,omitempty"`
+	Alias    string `yaml:"alias" json:"alias"`
+	Path     string `yaml:"path" json:"path"`
+	KeyType  string `yaml:"keyType,omitempty" json:"keyType,omitempty"`
+}
+
+// matches the placeholders accepted in place of the numbers and the booleans of the params. They are kept as they are,
+// as they are resolved after the params are loaded: environment variables ($VAR, or ${VAR} which is not substituted)
+// and secret references (${secret:source:reference})
+var paramsPlaceholderRegex = regexp.MustCompile(`^\$(\w+|{[^}]+})$`)
+
+// ParamsInt is a number of the params, or a placeholder which is resolved after the params are loaded
+type ParamsInt struct {
+	Value       int
+	Placeholder string
+}
+
+// ParamsBool is a boolean of the params, or a placeholder which is resolved after the params are loaded
+type ParamsBool struct {
+	Value       bool
+	Placeholder string
+}
+
+// UnmarshalYAML decodes a number or a placeholder
+func (i *ParamsInt) UnmarshalYAML(node *yamlv3.Node) error {
+	if isParamsPlaceholder(node) {
+		i.Placeholder = node.Value
+		return nil
+	}
+	return node.Decode(&i.Value)
+}
+
+// MarshalYAML encodes the number or the placeholder
+func (i ParamsInt) MarshalYAML() (interface{}, error) {
+	if i.Placeholder != "" {
+		return i.Placeholder, nil
+	}
+	return i.Value, nil
+}
+
+// MarshalJSON encodes the number or the placeholder
+func (i ParamsInt) MarshalJSON() ([]byte, error) {
+	value, _ := i.MarshalYAML()
+	return json.Marshal(value)
+}
+
+// UnmarshalJSON decodes a number or a placeholder
+func (i *ParamsInt) UnmarshalJSON(data []byte) error {
+	if placeholder, ok := decodeParamsPlaceholder(data); ok {
+		i.Placeholder = placeholder
+		return nil
+	}
+	return json.Unmarshal(data, &i.Value)
+}
+
+// UnmarshalYAML decodes a boolean or a placeholder
+func (b *ParamsBool) UnmarshalYAML(node *yamlv3.Node) error {
+	if isParamsPlaceholder(node) {
+		b.Placeholder = node.Value
+		return nil
+	}
+	return node.Decode(&b.Value)
+}
+
+// MarshalYAML encodes the boolean or the placeholder
+func (b ParamsBool) MarshalYAML() (interface{}, error) {
+	if b.Placeholder != "" {
+		return b.Placeholder, nil
+	}
+	return b.Value, nil
+}
+
+// MarshalJSON encodes the boolean or the placeholder
+func (b ParamsBool) MarshalJSON() ([]byte, error) {
+	value, _ := b.MarshalYAML()
+	return json.Marshal(value)
+}
+
+// UnmarshalJSON decodes a boolean or a placeholder
+func (b *ParamsBool) UnmarshalJSON(data []byte) error {
+	if placeholder, ok := decodeParamsPlaceholder(data); ok {
+		b.Placeholder = placeholder
+		return nil
+	}
+	return json.Unmarshal(data, &b.Value)
+}
+
+// isParamsPlaceholder checks whether a YAML node is a string with a placeholder
+func isParamsPlaceholder(node *yamlv3.Node) bool {
+	return node.Kind == yamlv3.ScalarNode && node.ShortTag() == "!!str" && paramsPlaceholderRegex.MatchString(node.Value)
+}
+
+// decodeParamsPlaceholder decodes a JSON string with a placeholder
+func decodeParamsPlaceholder(data []byte) (string, bool) {
+	var placeholder string
+	if err := json.Unmarshal(data, &placeholder); err != nil {
+		return "", false
+	}
+	return placeholder, paramsPlaceholderRegex.MatchString(placeholder)
+}
+
+// paramsFieldError is an invalid value of a field of the params
+type paramsFieldError struct {
+	path    []string
+	message string
+}
+
+// paramsValidator is implemented by the params which are validated after loading them
+type paramsValidator interface {
+	validate() []paramsFieldError
+}
+
+// paramsFieldErrors collects the errors of the fields of the params
+type paramsFieldErrors []paramsFieldError
+
+// add adds an error of the field at the path
+func (errs *paramsFieldErrors) add(message string, path ...string) {
+	*errs = append(*errs, paramsFieldError{path: path, message: message})
+}
+
+// validateVersion validates the version of a params file
+func validateVersion(errs *paramsFieldErrors, version string) {
+	if version != "" && !containsParamsValue(supportedParamsVersions, version) {
+		errs.add("unsupported version "+version+", supported versions are "+strings.Join(supportedParamsVersions, ", "),
+			"version")
+	}
+}
+
+// validateEnvironmentNames validates that the names of the environments are given and unique
+func validateEnvironmentNames(errs *paramsFieldErrors, names []string) {
+	seen := make(map[string]bool)
+	for i, name := range names {
+		if name == "" {
+			errs.add("name is required", environmentsKey, strconv.Itoa(i), environmentNameKey)
+		} else if seen[name] {
+			errs.add("environment "+name+" is defined more than once", environmentsKey, strconv.Itoa(i),
+				environmentNameKey)
+		}
+		seen[name] = true
+	}
+}
+
+// validateEnvironments validates the names and the configurations of the environments
+func validateEnvironments(errs *paramsFieldErrors, environments []Environment) {
+	var names []string
+	for i, environment := range environments {
+		names = append(names, environment.Name)
+		if environment.Config != nil {
+			environment.Config.validate(errs, environmentsKey, strconv.Itoa(i), environmentConfigsKey)
+		}
+	}
+	validateEnvironmentNames(errs, names)
+}
+
+// validate validates the API params
+func (apiParams *ApiParams) validate() []paramsFieldError {
+	var errs paramsFieldErrors
+	validateVersion(&errs, apiParams.Version)
+	validateEnvironments(&errs, apiParams.Environments)
+	return errs
+}
+
+// validate validates the API Product params
+func (apiProductParams *ApiProductParams) validate() []paramsFieldError {
+	var errs paramsFieldErrors
+	validateVersion(&errs, apiProductParams.Version)
+	validateEnvironments(&errs, apiProductParams.Environments)
+	return errs
+}
+
+// validate validates the Application params
+func (applicationParams *ApplicationParams) validate() []paramsFieldError {
+	var errs paramsFieldErrors
+	validateVersion(&errs, applicationParams.Version)
+	return errs
+}
+
+// validate validates the admin artifact params
+func (adminArtifactParams *AdminArtifactParams) validate() []paramsFieldError {
+	var errs paramsFieldErrors
+	validateVersion(&errs, adminArtifactParams.Version)
+	var names []string
+	for i, environment := range adminArtifactParams.Environments {
+		names = append(names, environment.Name)
+		for policyType := range environment.Configs.Policies {
+			if !containsParamsValue(throttlingPolicyTypes, policyType) {
+				errs.add("invalid throttling policy type "+policyType+", it should be one of "+
+					strings.Join(throttlingPolicyTypes, ", "), environmentsKey, strconv.Itoa(i), environmentConfigsKey,
+					"policies", policyType)
+			}
+		}
+	}
+	validateEnvironmentNames(&errs, names)
+	return errs
+}
+
+// validate validates the configurations of an environment
+func (configs *EnvironmentConfigs) validate(errs *paramsFieldErrors, path ...string) {
+	field := func(keys ...string) []string {
+		return fieldPath(path, keys...)
+	}
+
+	if configs.Endpoints != nil {
+		configs.Endpoints.Production.validate(errs, field("endpoints", "production")...)
+		configs.Endpoints.Sandbox.validate(errs, field("endpoints", "sandbox")...)
+	}
+
+	switch configs.EndpointRoutingPolicy {
+	case "":
+	case EndpointRoutingPolicyLoadBalanced:
+		if configs.LoadBalanceEndpoints == nil {
+			errs.add("loadBalanceEndpoints are required for the "+EndpointRoutingPolicyLoadBalanced+" routing policy",
+				field("endpointRoutingPolicy")...)
+		}
+	case EndpointRoutingPolicyFailover:
+		if configs.FailoverEndpoints == nil {
+			errs.add("failoverEndpoints are required for the "+EndpointRoutingPolicyFailover+" routing policy",
+				field("endpointRoutingPolicy")...)
+		}
+	default:
+		errs.add("invalid endpoint routing policy "+configs.EndpointRoutingPolicy+", it should be "+
+			EndpointRoutingPolicyLoadBalanced+" or "+EndpointRoutingPolicyFailover, field("endpointRoutingPolicy")...)
+	}
+	if lb := configs.LoadBalanceEndpoints; lb != nil {
+		for i := range lb.Production {
+			lb.Production[i].validate(errs, field("loadBalanceEndpoints", "production", strconv.Itoa(i))...)
+		}
+		for i := range lb.Sandbox {
+			lb.Sandbox[i].validate(errs, field("loadBalanceEndpoints", "sandbox", strconv.Itoa(i))...)
+		}
+	}
+	if failover := configs.FailoverEndpoints; failover != nil {
+		failover.Production.validate(errs, field("failoverEndpoints", "production")...)
+		failover.Sandbox.validate(errs, field("failoverEndpoints", "sandbox")...)
+		for i := range failover.ProductionFailovers {
+			failover.ProductionFailovers[i].validate(errs,
+				field("failoverEndpoints", "productionFailovers", strconv.Itoa(i))...)
+		}
+		for i := range failover.SandboxFailovers {
+			failover.SandboxFailovers[i].validate(errs,
+				field("failoverEndpoints", "sandboxFailovers", strconv.Itoa(i))...)
+		}
+	}
+
+	if configs.Security != nil {
+		configs.Security.Production.validate(errs, field("security", "production")...)
+		configs.Security.Sandbox.validate(errs, field("security", "sandbox")...)
+	}
+
+	for i, deploymentEnvironment := range configs.DeploymentEnvironments {
+		if deploymentEnvironment.DeploymentEnvironment == "" {
+			errs.add("deploymentEnvironment is required", field("deploymentEnvironments", strconv.Itoa(i))...)
+		}
+	}
+	dependentAPINames := make([]string, 0, len(configs.DependentAPIs))
+	for name := range configs.DependentAPIs {
+		dependentAPINames = append(dependentAPINames, name)
+	}
+	sort.Strings(dependentAPINames)
+	for _, name := range dependentAPINames {
+		if dependentAPI := configs.DependentAPIs[name]; dependentAPI != nil {
+			dependentAPI.validate(errs, field("dependentAPIs", name)...)
+		}
+	}
+	for i, cert := range configs.Certs {
+		if cert.HostName == "" || cert.Alias == "" || cert.Path == "" {
+			errs.add("hostName, alias and path are required", field("certs", strconv.Itoa(i))...)
+		}
+	}
+	for i, cert := range configs.MutualSslCerts {
+		if cert.Alias == "" || cert.Path == "" {
+			errs.add("alias and path are required", field("mutualSslCerts", strconv.Itoa(i))...)
+		}
+		if cert.KeyType != "" && cert.KeyType != KeyTypeProduction && cert.KeyType != KeyTypeSandbox {
+			errs.add("invalid key type "+cert.KeyType+", it should be "+KeyTypeProduction+" or "+KeyTypeSandbox,
+				field("mutualSslCerts", strconv.Itoa(i), "keyType")...)
+		}
+	}
+}
+
+// validate validates an endpoint
+func (endpoint *EndpointParams) validate(errs *paramsFieldErrors, path ...string) {
+	if endpoint == nil {
+		return
+	}
+	// urls given as secret references are resolved only when importing
+	if endpoint.Url != nil && !utils.HasSecretReferences([]byte(*endpoint.Url)) && !utils.IsValidUrl(*endpoint.Url) {
+		errs.add("invalid url "+*endpoint.Url, fieldPath(path, "url")...)
+	}
+	if config := endpoint.Config; config != nil && config.ActionSelect != nil &&
+		*config.ActionSelect != "fault" && *config.ActionSelect != "discard" {
+		errs.add("invalid action "+*config.ActionSelect+", it should be fault or discard",
+			fieldPath(path, "config", "actionSelect")...)
+	}
+}
+
+// validate validates the security configuration of an endpoint
+func (security *SecurityParams) validate(errs *paramsFieldErrors, path ...string) {
+	// the security is not validated when it is disabled or enabled by a placeholder
+	if security == nil || (security.Enabled != nil && (!security.Enabled.Value || security.Enabled.Placeholder != "")) {
+		return
+	}
+	field := func(key string) []string {
+		return fieldPath(path, key)
+	}
+	switch security.Type {
+	case EndpointSecurityTypeBasic, EndpointSecurityTypeDigest:
+		if security.Username == "" {
+			errs.add("username is required for "+security.Type+" security", field("username")...)
+		}
+	case EndpointSecurityTypeOAuth:
+		if security.TokenUrl == "" || security.ClientId == "" || security.ClientSecret == "" {
+			errs.add("tokenUrl, clientId and clientSecret are required for "+security.Type+" security", path...)
+		}
+		if security.GrantType != EndpointSecurityGrantTypeClientCredentials &&
+			security.GrantType != EndpointSecurityGrantTypePassword {
+			errs.add("invalid grant type "+security.GrantType+", it should be "+
+				EndpointSecurityGrantTypeClientCredentials+" or "+EndpointSecurityGrantTypePassword,
+				field("grantType")...)
+		}
+	case "":
+		errs.add("type is required", field("type")...)
+	default:
+		errs.add("invalid security type "+security.Type+", it should be "+EndpointSecurityTypeBasic+", "+
+			EndpointSecurityTypeDigest+" or "+EndpointSecurityTypeOAuth, field("type")...)
+	}
+}
+
+// containsParamsValue returns whether the values contain the value
+func containsParamsValue(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldPath returns the path of a child field
+func fieldPath(path []string, keys ...string) []string {
+	return append(append([]string{}, path...), keys...)
+}
@@ -0,0 +1,239 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package params
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Types of the params files
+const (
+	ParamsTypeAPI         = "api"
+	ParamsTypeAPIProduct  = "api_product"
+	ParamsTypeApplication = "application"
+	ParamsTypeAdmin       = "admin"
+)
+
+// ParamsTypes are the types of the params files which can be validated
+var ParamsTypes = []string{ParamsTypeAPI, ParamsTypeAPIProduct, ParamsTypeApplication, ParamsTypeAdmin}
+
+// matches the line of the errors of the YAML parser (eg: "yaml: line 3: did not find expected key")
+var paramsErrorLineRegex = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// matches the unknown fields and the values of wrong types reported by the YAML decoder
+var (
+	paramsUnknownFieldRegex = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+	paramsInvalidTypeRegex  = regexp.MustCompile("^cannot unmarshal !!(\\w+)(?: `(.*)`)? into (.+)$")
+)
+
+// ParamsValidationError is an error of a params file
+type ParamsValidationError struct {
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+
+	syntaxError bool
+}
+
+// String returns the error in the format file:line: path: message
+func (e ParamsValidationError) String() string {
+	location := e.File
+	if e.Line > 0 {
+		location += ":" + strconv.Itoa(e.Line)
+	}
+	if e.Path != "" {
+		return location + ": " + e.Path + ": " + e.Message
+	}
+	return location + ": " + e.Message
+}
+
+// ValidateParamsFile validates a params file and the files it extends against the schema of the params type
+// @param path : Path to the params file
+// @param paramsType : Type of the params (api, api_product, application or admin)
+// @return the validation errors
+// @return error if the params file cannot be loaded
+func ValidateParamsFile(path, paramsType string) ([]ParamsValidationError, error) {
+	var out interface{}
+	switch paramsType {
+	case ParamsTypeAPI:
+		out = &ApiParams{}
+	case ParamsTypeAPIProduct:
+		out = &ApiProductParams{}
+	case ParamsTypeApplication:
+		out = &ApplicationParams{}
+	case ParamsTypeAdmin:
+		out = &AdminArtifactParams{}
+	default:
+		return nil, utils.NewValidationError("Invalid params type '" + paramsType + "'. It should be one of " +
+			strings.Join(ParamsTypes, ", "))
+	}
+	return validateLayeredParams(path, out)
+}
+
+// GetParamsTypeOfFile returns the type of the params by the name of the params file. Returns api if the type
+// cannot be decided by the name.
+func GetParamsTypeOfFile(path string) string {
+	switch {
+	case strings.HasSuffix(path, utils.ParamFileAPIProduct):
+		return ParamsTypeAPIProduct
+	case strings.HasSuffix(path, utils.ParamFileApplication):
+		return ParamsTypeApplication
+	}
+	return ParamsTypeAPI
+}
+
+// unmarshalLayeredParams loads a params file with the files it extends into out. Returns a validation error if
+// the params do not match the schema of out.
+func unmarshalLayeredParams(path string, out interface{}) error {
+	validationErrors, err := validateLayeredParams(path, out)
+	if err != nil {
+		return err
+	}
+	if len(validationErrors) > 0 {
+		messages := make([]string, 0, len(validationErrors))
+		for _, validationError := range validationErrors {
+			messages = append(messages, validationError.String())
+		}
+		return utils.NewValidationError("invalid params file " + path + "\n" + strings.Join(messages, "\n"))
+	}
+	return nil
+}
+
+// validateLayeredParams loads a params file with the files it extends into out and validates them. Each file is
+// validated against the schema, then the effective params are validated.
+func validateLayeredParams(path string, out interface{}) ([]ParamsValidationError, error) {
+	loader := &paramsLoader{schema: reflect.TypeOf(out).Elem()}
+	layer, err := loader.load(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(loader.errors) > 0 {
+		return loader.errors, nil
+	}
+	if err := layer.applyDefaults(); err != nil {
+		return nil, err
+	}
+	content, err := layer.toYaml()
+	if err != nil {
+		return nil, err
+	}
+	if validationErrors := decodeParams(path, content, out); len(validationErrors) > 0 {
+		// the lines of the effective params do not match the lines of the file
+		for i := range validationErrors {
+			validationErrors[i].Line = 0
+		}
+		return validationErrors, nil
+	}
+
+	validator, ok := out.(paramsValidator)
+	if !ok {
+		return nil, nil
+	}
+	fieldErrors := validator.validate()
+	if len(fieldErrors) == 0 {
+		return nil, nil
+	}
+	// the fields are located in the params file. Fields inherited from other files are located at the closest parent
+	fileContent, err := getEnvSubstitutedFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+	var validationErrors []ParamsValidationError
+	for _, fieldError := range fieldErrors {
+		validationErrors = append(validationErrors, ParamsValidationError{
+			File:    path,
+			Line:    utils.FindYAMLLine([]byte(fileContent), fieldError.path),
+			Path:    strings.Join(fieldError.path, "."),
+			Message: fieldError.message,
+		})
+	}
+	return validationErrors, nil
+}
+
+// decodeParams decodes the content of a params file into out rejecting the unknown fields
+// @return the syntax errors, the unknown fields and the values of wrong types
+func decodeParams(path string, content []byte, out interface{}) []ParamsValidationError {
+	decoder := yamlv3.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err := decoder.Decode(out)
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	typeError, ok := err.(*yamlv3.TypeError)
+	if !ok {
+		validationError := newParamsValidationError(path, err.Error())
+		validationError.syntaxError = true
+		return []ParamsValidationError{validationError}
+	}
+	var validationErrors []ParamsValidationError
+	for _, message := range typeError.Errors {
+		validationErrors = append(validationErrors, newParamsValidationError(path, message))
+	}
+	return validationErrors
+}
+
+// newParamsValidationError creates a validation error from an error of the YAML decoder
+func newParamsValidationError(path, message string) ParamsValidationError {
+	validationError := ParamsValidationError{File: path, Message: message}
+	if match := paramsErrorLineRegex.FindStringSubmatch(message); match != nil {
+		validationError.Line, _ = strconv.Atoi(match[1])
+		validationError.Message = match[2]
+	}
+	if match := paramsUnknownFieldRegex.FindStringSubmatch(validationError.Message); match != nil {
+		validationError.Message = "unknown field " + match[1]
+	} else if match := paramsInvalidTypeRegex.FindStringSubmatch(validationError.Message); match != nil {
+		switch match[1] {
+		case "seq":
+			validationError.Message = "invalid list, expected " + describeParamsType(match[3])
+		case "map":
+			validationError.Message = "invalid object, expected " + describeParamsType(match[3])
+		default:
+			validationError.Message = "invalid value " + match[2] + ", expected " + describeParamsType(match[3])
+		}
+	}
+	return validationError
+}
+
+// describeParamsType returns the name of a type used in the messages
+func describeParamsType(typeName string) string {
+	switch {
+	case strings.HasPrefix(typeName, "[]"):
+		return "a list"
+	case strings.HasPrefix(typeName, "map["), strings.HasPrefix(typeName, "params."):
+		return "an object"
+	case strings.HasPrefix(typeName, "int"):
+		return "a number"
+	case typeName == "bool":
+		return "true or false"
+	case typeName == "string":
+		return "a string"
+	}
+	return typeName
+}
@@ -68,3 +68,78 @@ type APIProductResource struct {
 	OutSequenceName      string                 `json:"outSequenceName,omitempty" yaml:"outSequenceName,omitempty"`
 	FaultSequenceName    string                 `json:"faultSequenceName,omitempty" yaml:"faultSequenceName,omitempty"`
 }
+
+// APIProductDefinitionFile represents the api_product.yaml of an API Product project
+type APIProductDefinitionFile struct {
+	Type        string                  `json:"type,omitempty" yaml:"type,omitempty"`
+	ApimVersion string                  `json:"version,omitempty" yaml:"version,omitempty"`
+	Data        APIProductDTODefinition `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+// APIProductDTODefinition represents an APIProductDTO artifact in APIM
+type APIProductDTODefinition struct {
+	ID                           string            `json:"id,omitempty" yaml:"id,omitempty"`
+	Name                         string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Description                  string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Context                      string            `json:"context,omitempty" yaml:"context,omitempty"`
+	Provider                     string            `json:"provider,omitempty" yaml:"provider,omitempty"`
+	State                        string            `json:"state,omitempty" yaml:"state,omitempty"`
+	EnableStore                  bool              `json:"enableStore,omitempty" yaml:"enableStore,omitempty"`
+	ResponseCachingEnabled       bool              `json:"responseCachingEnabled,omitempty" yaml:"responseCachingEnabled,omitempty"`
+	CacheTimeout                 int               `json:"cacheTimeout,omitempty" yaml:"cacheTimeout,omitempty"`
+	Visibility                   string            `json:"visibility,omitempty" yaml:"visibility,omitempty"`
+	VisibleRoles                 []string          `json:"visibleRoles,omitempty" yaml:"visibleRoles,omitempty"`
+	VisibleTenants               []string          `json:"visibleTenants,omitempty" yaml:"visibleTenants,omitempty"`
+	AccessControl                string            `json:"accessControl,omitempty" yaml:"accessControl,omitempty"`
+	AccessControlRoles           []string          `json:"accessControlRoles,omitempty" yaml:"accessControlRoles,omitempty"`
+	Transport                    []string          `json:"transport,omitempty" yaml:"transport,omitempty"`
+	Tags                         []string          `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Policies                     []string          `json:"policies,omitempty" yaml:"policies,omitempty"`
+	AuthorizationHeader          string            `json:"authorizationHeader,omitempty" yaml:"authorizationHeader,omitempty"`
+	SecurityScheme               []string          `json:"securityScheme,omitempty" yaml:"securityScheme,omitempty"`
+	SubscriptionAvailability     string            `json:"subscriptionAvailability,omitempty" yaml:"subscriptionAvailability,omitempty"`
+	SubscriptionAvailableTenants []string          `json:"subscriptionAvailableTenants,omitempty" yaml:"subscriptionAvailableTenants,omitempty"`
+	AdditionalProperties         map[string]string `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Monetization                 interface{}       `json:"monetization,omitempty" yaml:"monetization,omitempty"`
+	BusinessInformation          interface{}       `json:"businessInformation,omitempty" yaml:"businessInformation,omitempty"`
+	CorsConfiguration            interface{}       `json:"corsConfiguration,omitempty" yaml:"corsConfiguration,omitempty"`
+	APIs                         []APIProductAPI   `json:"apis" yaml:"apis"`
+	Scopes                       []interface{}     `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	Categories                   []string          `json:"categories,omitempty" yaml:"categories,omitempty"`
+}
+
+// APIProductAPI is an API of an API Product with the operations of the API included in the API Product
+type APIProductAPI struct {
+	Name       string         `json:"name" yaml:"name"`
+	APIID      string         `json:"apiId,omitempty" yaml:"apiId,omitempty"`
+	Version    string         `json:"version,omitempty" yaml:"version,omitempty"`
+	Provider   string         `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Operations []APIOperation `json:"operations" yaml:"operations"`
+}
+
+// APIs groups the product resources by the APIs in the order of their first resources
+func (product *APIProductDefinition) APIs() []APIProductAPI {
+	var apis []APIProductAPI
+	indexes := make(map[ID]int)
+	for _, resource := range product.ProductResources {
+		index, ok := indexes[resource.APIIdentifier]
+		if !ok {
+			index = len(apis)
+			indexes[resource.APIIdentifier] = index
+			apis = append(apis, APIProductAPI{
+				Name:     resource.APIIdentifier.APIName,
+				APIID:    resource.APIProductId,
+				Version:  resource.APIIdentifier.Version,
+				Provider: resource.APIIdentifier.ProviderName,
+			})
+		}
+		apis[index].Operations = append(apis[index].Operations, APIOperation{
+			Target:           resource.URITemplate.URITemplate,
+			Verb:             resource.URITemplate.HTTPVerb,
+			AuthType:         resource.URITemplate.AuthType,
+			ThrottlingPolicy: resource.URITemplate.ThrottlingTier,
+			Scopes:           []string{},
+		})
+	}
+	return apis
+}
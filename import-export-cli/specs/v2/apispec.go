@@ -81,6 +81,7 @@ type APIDTODefinition struct {
 	ThreatProtectionPolicies     interface{}       `json:"threatProtectionPolicies,omitempty" yaml:"threatProtectionPolicies,omitempty"`
 	Categories                   []string          `json:"categories,omitempty" yaml:"categories,omitempty"`
 	KeyManagers                  []string          `json:"keyManagers,omitempty" yaml:"keyManagers,omitempty"`
+	AdvertiseInfo                interface{}       `json:"advertiseInfo,omitempty" yaml:"advertiseInfo,omitempty"`
 }
 
 // APIDefinition represents an API artifact in APIM
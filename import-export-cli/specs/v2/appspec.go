@@ -27,3 +27,33 @@ type ApplicationDefinition struct {
 type Subscriber struct {
 	Name string `json:"name" yaml:"name"`
 }
+
+// ApplicationDefinitionFile represents the application.yaml of an Application project
+type ApplicationDefinitionFile struct {
+	Type        string                   `json:"type,omitempty" yaml:"type,omitempty"`
+	ApimVersion string                   `json:"version,omitempty" yaml:"version,omitempty"`
+	Data        ApplicationDTODefinition `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+// ApplicationDTODefinition represents an exported Application artifact in APIM
+type ApplicationDTODefinition struct {
+	ApplicationInfo ApplicationInfo           `json:"applicationInfo" yaml:"applicationInfo"`
+	SubscribedAPIs  []ApplicationSubscription `json:"subscribedAPIs" yaml:"subscribedAPIs"`
+}
+
+// ApplicationInfo represents the ApplicationDTO of an exported Application
+type ApplicationInfo struct {
+	Name             string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Description      string            `json:"description,omitempty" yaml:"description,omitempty"`
+	ThrottlingPolicy string            `json:"throttlingPolicy,omitempty" yaml:"throttlingPolicy,omitempty"`
+	TokenType        string            `json:"tokenType,omitempty" yaml:"tokenType,omitempty"`
+	Owner            string            `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Groups           []string          `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Attributes       map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// ApplicationSubscription is a subscription of an Application to an API or an API Product
+type ApplicationSubscription struct {
+	APIIdentifier    ID     `json:"apiId" yaml:"apiId"`
+	ThrottlingPolicy string `json:"throttlingPolicy,omitempty" yaml:"throttlingPolicy,omitempty"`
+}
@@ -0,0 +1,240 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Types of the asynchronous APIs in API Manager
+const (
+	AsyncAPITypeWebSocket = "WS"
+	AsyncAPITypeSSE       = "SSE"
+	AsyncAPITypeWebSub    = "WEBSUB"
+	AsyncAPITypeAsync     = "ASYNC"
+)
+
+// Verbs of the operations of an asynchronous API
+const (
+	AsyncAPIVerbSubscribe = "SUBSCRIBE"
+	AsyncAPIVerbPublish   = "PUBLISH"
+)
+
+// AsyncAPIDocument is the part of an AsyncAPI 2.x document used to populate an API definition
+type AsyncAPIDocument struct {
+	AsyncAPI string                    `json:"asyncapi"`
+	Info     oai3Info                  `json:"info"`
+	Servers  map[string]asyncAPIServer `json:"servers"`
+	Channels map[string]struct {
+		Subscribe json.RawMessage `json:"subscribe"`
+		Publish   json.RawMessage `json:"publish"`
+	} `json:"channels"`
+	// Extensions are the vendor extensions (x-*) of the document as json.RawMessage values
+	Extensions map[string]interface{} `json:"-"`
+}
+
+type asyncAPIServer struct {
+	oai3Server
+	Protocol string `json:"protocol"`
+}
+
+// IsAsyncAPI returns whether a JSON document is an AsyncAPI document
+func IsAsyncAPI(jsonContent []byte) bool {
+	var document struct {
+		AsyncAPI string `json:"asyncapi"`
+	}
+	if err := json.Unmarshal(jsonContent, &document); err != nil {
+		return false
+	}
+	return document.AsyncAPI != ""
+}
+
+// LoadAsyncAPIDocument loads an AsyncAPI 2.x document in JSON format
+func LoadAsyncAPIDocument(jsonContent []byte) (*AsyncAPIDocument, error) {
+	document := &AsyncAPIDocument{}
+	if err := json.Unmarshal(jsonContent, document); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(document.AsyncAPI, "2.") {
+		return nil, fmt.Errorf("AsyncAPI version %q is not supported, only AsyncAPI 2.x definitions are supported",
+			document.AsyncAPI)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(jsonContent, &fields); err != nil {
+		return nil, err
+	}
+	document.Extensions = make(map[string]interface{})
+	for key, value := range fields {
+		if strings.HasPrefix(key, "x-") {
+			document.Extensions[key] = value
+		}
+	}
+	return document, nil
+}
+
+// AsyncAPIPopulate populates the API definition using an AsyncAPI 2.x document. The type of the API is decided by the
+// protocols of the servers (ws/wss: WS, sse: SSE, websub: WEBSUB and ASYNC for the others) and the channels are used
+// as the operations
+func AsyncAPIPopulate(def *APIDTODefinition, document *AsyncAPIDocument) error {
+	def.Name = strings.ReplaceAll(document.Info.Title, " ", "")
+	def.Version = strings.ReplaceAll(document.Info.Version, " ", "")
+	def.Description = document.Info.Description
+	def.Provider = "admin"
+	def.Context = "/" + def.Name
+	def.Type = asyncAPIType(document.Servers)
+
+	if err := asyncAPIPopulateEndpoints(def, document); err != nil {
+		return err
+	}
+
+	// override the context if wso2 extension provided
+	basepath, ok, err := oai3WSO2Basepath(document.Extensions)
+	if err != nil {
+		return err
+	}
+	if ok {
+		applyWSO2BasePath(def, basepath)
+	}
+	def.Context = strings.ReplaceAll(def.Context, " ", "")
+
+	var channels []string
+	for channel := range document.Channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	var operations []interface{}
+	for _, channel := range channels {
+		var verbs []string
+		item := document.Channels[channel]
+		if item.Subscribe != nil || item.Publish == nil {
+			verbs = append(verbs, AsyncAPIVerbSubscribe)
+		}
+		// SSE and WebSub APIs only send events to the subscribers
+		if item.Publish != nil && def.Type != AsyncAPITypeSSE && def.Type != AsyncAPITypeWebSub {
+			verbs = append(verbs, AsyncAPIVerbPublish)
+		}
+		for _, verb := range verbs {
+			operations = append(operations, APIOperation{
+				Target:           channel,
+				Verb:             verb,
+				AuthType:         OperationAuthTypeDefault,
+				ThrottlingPolicy: OperationThrottlingDefault,
+				Scopes:           []string{},
+			})
+		}
+	}
+	if len(operations) == 0 {
+		return errors.New("the AsyncAPI definition does not define any channels")
+	}
+	def.Operations = operations
+	return nil
+}
+
+// asyncAPIType returns the type of the API using the protocol of the first server sorted by name
+func asyncAPIType(servers map[string]asyncAPIServer) string {
+	names := sortedAsyncAPIServers(servers)
+	if len(names) == 0 {
+		return AsyncAPITypeWebSocket
+	}
+	switch strings.ToLower(servers[names[0]].Protocol) {
+	case "ws", "wss":
+		return AsyncAPITypeWebSocket
+	case "sse":
+		return AsyncAPITypeSSE
+	case "websub":
+		return AsyncAPITypeWebSub
+	default:
+		return AsyncAPITypeAsync
+	}
+}
+
+// asyncAPIPopulateEndpoints populates the endpoints and the transports of the API. WebSocket and SSE APIs use the
+// first server (or x-wso2-production-endpoints) as the production endpoint and its path as the context. WebSub and other asynchronous APIs do not
+// have an endpoint in API Manager
+func asyncAPIPopulateEndpoints(def *APIDTODefinition, document *AsyncAPIDocument) error {
+	switch def.Type {
+	case AsyncAPITypeWebSocket:
+		def.Transport = []string{"ws", "wss"}
+	case AsyncAPITypeSSE:
+	default:
+		def.EndpointConfig = nil
+		def.EndpointImplementationType = ""
+		return nil
+	}
+
+	productionEp := &Endpoints{}
+	if names := sortedAsyncAPIServers(document.Servers); len(names) > 0 {
+		server := document.Servers[names[0]]
+		serverURL := server.URL
+		for name, variable := range server.Variables {
+			serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", variable.Default)
+		}
+		// the scheme of the URL is optional in AsyncAPI 2.0 since it is given as the protocol
+		if !strings.Contains(serverURL, "://") {
+			serverURL = strings.ToLower(server.Protocol) + "://" + serverURL
+		}
+		if def.Type == AsyncAPITypeSSE {
+			serverURL = "http" + strings.TrimPrefix(serverURL, "sse")
+		}
+		if parsedURL, err := url.Parse(serverURL); err == nil && parsedURL.Host != "" {
+			productionEp.Urls = []string{parsedURL.String()}
+			if parsedURL.Path != "" && parsedURL.Path != "/" {
+				def.Context = path.Clean(parsedURL.Path)
+			}
+		}
+	}
+	prodEp, _, err := oai3XWSO2ProductionEndpoints(document.Extensions)
+	if err != nil {
+		return err
+	}
+	if prodEp != nil {
+		productionEp = prodEp
+	}
+	sandboxEp, _, err := oai3XWso2SandboxEndpoints(document.Extensions)
+	if err != nil {
+		return err
+	}
+
+	// WebSocket endpoints are http endpoints with ws or wss URLs in API Manager
+	endpointConfig := map[string]interface{}{"endpoint_type": EpHttp}
+	if len(productionEp.Urls) > 0 {
+		endpointConfig["production_endpoints"] = map[string]interface{}{"url": productionEp.Urls[0]}
+	}
+	if sandboxEp != nil && len(sandboxEp.Urls) > 0 {
+		endpointConfig["sandbox_endpoints"] = map[string]interface{}{"url": sandboxEp.Urls[0]}
+	}
+	def.EndpointConfig = endpointConfig
+	return nil
+}
+
+// sortedAsyncAPIServers returns the names of the servers in the sorted order
+func sortedAsyncAPIServers(servers map[string]asyncAPIServer) []string {
+	var names []string
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,79 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func loadAsyncAPITestDocument(t *testing.T, file string) *AsyncAPIDocument {
+	content, err := ioutil.ReadFile(file)
+	assert.Nil(t, err, "err should be nil")
+	jsonContent, err := utils.YamlToJson(content)
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, IsAsyncAPI(jsonContent))
+	document, err := LoadAsyncAPIDocument(jsonContent)
+	assert.Nil(t, err, "err should be nil")
+	return document
+}
+
+func TestAsyncAPIPopulateWebSocket(t *testing.T) {
+	document := loadAsyncAPITestDocument(t, "testdata/chat_asyncapi.yaml")
+	def := &APIDTODefinition{}
+	err := AsyncAPIPopulate(def, document)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "ChatAPI", def.Name)
+	assert.Equal(t, AsyncAPITypeWebSocket, def.Type)
+	assert.Equal(t, "/chat", def.Context, "should use the path of the server as the context")
+	assert.Equal(t, []string{"ws", "wss"}, def.Transport)
+	assert.Equal(t, map[string]interface{}{
+		"endpoint_type":        EpHttp,
+		"production_endpoints": map[string]interface{}{"url": "ws://chat.example.com:8080/chat"},
+	}, def.EndpointConfig)
+
+	var operations []string
+	for _, operation := range def.Operations {
+		operations = append(operations, operation.(APIOperation).Verb+" "+operation.(APIOperation).Target)
+	}
+	assert.Equal(t, []string{"SUBSCRIBE /notifications", "SUBSCRIBE /rooms/{roomId}", "PUBLISH /rooms/{roomId}"},
+		operations)
+}
+
+func TestAsyncAPIPopulateWebSub(t *testing.T) {
+	document, err := LoadAsyncAPIDocument([]byte(`{"asyncapi": "2.0.0", "info": {"title": "Repo Events",
+		"version": "v1"}, "servers": {"hub": {"url": "https://hub.example.com", "protocol": "websub"}},
+		"channels": {"push": {"subscribe": {}, "publish": {}}}, "x-wso2-basePath": "/repos/{version}"}`))
+	assert.Nil(t, err, "err should be nil")
+	def := &APIDTODefinition{EndpointConfig: map[string]interface{}{}}
+	err = AsyncAPIPopulate(def, document)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, AsyncAPITypeWebSub, def.Type)
+	assert.Equal(t, "/repos/v1", def.Context)
+	assert.Nil(t, def.EndpointConfig, "WebSub APIs should not have an endpoint")
+	assert.Len(t, def.Operations, 1, "WebSub APIs should only have subscribe operations")
+}
+
+func TestLoadAsyncAPIDocumentUnsupportedVersion(t *testing.T) {
+	_, err := LoadAsyncAPIDocument([]byte(`{"asyncapi": "3.0.0"}`))
+	assert.NotNil(t, err, "should fail for AsyncAPI 3")
+}
@@ -0,0 +1,187 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Types of the operations of a GraphQL API in API Manager
+const (
+	GraphQLOperationQuery        = "QUERY"
+	GraphQLOperationMutation     = "MUTATION"
+	GraphQLOperationSubscription = "SUBSCRIPTION"
+)
+
+// matches the block strings, the strings and the comments of a GraphQL SDL document
+var graphQLIgnoredRegex = regexp.MustCompile(`(?s)"""(?:\\"""|.)*?"""|"(?:\\.|[^"\\\n])*"|#[^\n]*`)
+
+// matches the tokens (names, variables, directives and punctuators) of a GraphQL SDL document
+var graphQLTokenRegex = regexp.MustCompile(`[@$]?[_A-Za-z][_0-9A-Za-z]*|\.\.\.|[^\s,]`)
+
+// GraphQLSchema is the root operation types and the fields of the object types of a GraphQL SDL document
+type GraphQLSchema struct {
+	// RootTypes maps the operation types (QUERY, MUTATION and SUBSCRIPTION) to the names of their object types
+	RootTypes map[string]string
+	// Fields are the field names of the object types including the fields of the type extensions
+	Fields map[string][]string
+}
+
+// ParseGraphQLSchema parses the root operation types and the object types of a GraphQL SDL document
+func ParseGraphQLSchema(sdl string) (*GraphQLSchema, error) {
+	tokens := graphQLTokenRegex.FindAllString(graphQLIgnoredRegex.ReplaceAllString(sdl, " "), -1)
+	schema := &GraphQLSchema{
+		RootTypes: map[string]string{
+			GraphQLOperationQuery:        "Query",
+			GraphQLOperationMutation:     "Mutation",
+			GraphQLOperationSubscription: "Subscription",
+		},
+		Fields: make(map[string][]string),
+	}
+
+	// the bodies of the other definitions (eg: input types) are skipped using the depth of the braces
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		switch {
+		case tokens[i] == "{":
+			depth++
+		case tokens[i] == "}":
+			depth--
+		case depth > 0:
+		case tokens[i] == "schema":
+			body, end, err := graphQLBody(tokens, i+1)
+			if err != nil {
+				return nil, err
+			}
+			for j := 0; j+2 < len(body); j += 3 {
+				if body[j+1] != ":" {
+					return nil, errors.New("invalid schema definition near " + body[j])
+				}
+				schema.RootTypes[strings.ToUpper(body[j])] = body[j+2]
+			}
+			i = end
+		case tokens[i] == "type":
+			if i+1 >= len(tokens) {
+				return nil, errors.New("type name is missing")
+			}
+			name := tokens[i+1]
+			body, end, err := graphQLBody(tokens, i+2)
+			if err != nil {
+				return nil, errors.New("invalid type " + name + ": " + err.Error())
+			}
+			schema.Fields[name] = append(schema.Fields[name], graphQLFieldNames(body)...)
+			i = end
+		}
+	}
+	return schema, nil
+}
+
+// Operations returns the fields of the root operation types as operations of an API sorted by their names
+func (schema *GraphQLSchema) Operations() []APIOperation {
+	var operations []APIOperation
+	for _, operationType := range []string{GraphQLOperationQuery, GraphQLOperationMutation,
+		GraphQLOperationSubscription} {
+		fields := append([]string{}, schema.Fields[schema.RootTypes[operationType]]...)
+		sort.Strings(fields)
+		for _, field := range fields {
+			operations = append(operations, APIOperation{
+				Target:           field,
+				Verb:             operationType,
+				AuthType:         OperationAuthTypeDefault,
+				ThrottlingPolicy: OperationThrottlingDefault,
+				Scopes:           []string{},
+			})
+		}
+	}
+	return operations
+}
+
+// GraphQLPopulate populates the API definition using a GraphQL SDL document. The name of the API is used as the
+// context since the schema does not have a name
+func GraphQLPopulate(def *APIDTODefinition, sdl string) error {
+	schema, err := ParseGraphQLSchema(sdl)
+	if err != nil {
+		return err
+	}
+	if len(schema.Fields[schema.RootTypes[GraphQLOperationQuery]]) == 0 {
+		return errors.New("the schema does not define any fields in the query type " +
+			schema.RootTypes[GraphQLOperationQuery])
+	}
+	def.Type = "GRAPHQL"
+	def.Name = strings.ReplaceAll(def.Name, " ", "")
+	def.Context = "/" + def.Name
+	def.Operations = nil
+	for _, operation := range schema.Operations() {
+		def.Operations = append(def.Operations, operation)
+	}
+	return nil
+}
+
+// graphQLBody returns the tokens between the braces of the definition starting from the given index (skipping the
+// interfaces and the directives before the braces) and the index of the closing brace
+func graphQLBody(tokens []string, start int) ([]string, int, error) {
+	i := start
+	for i < len(tokens) && tokens[i] != "{" {
+		// a definition without fields (eg: type Query @key(fields: "id"))
+		if tokens[i] == "type" || tokens[i] == "extend" || tokens[i] == "schema" || tokens[i] == "}" {
+			return nil, i - 1, nil
+		}
+		i++
+	}
+	if i == len(tokens) {
+		return nil, i, nil
+	}
+	depth := 0
+	for end := i; end < len(tokens); end++ {
+		switch tokens[end] {
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth == 0 {
+				return tokens[i+1 : end], end, nil
+			}
+		}
+	}
+	return nil, 0, errors.New("closing brace is missing")
+}
+
+// graphQLFieldNames returns the names of the fields in the body of an object type. A field is a name followed by its
+// arguments or its type which is not the name of a directive or inside the arguments
+func graphQLFieldNames(body []string) []string {
+	var fields []string
+	depth := 0
+	for i, token := range body {
+		switch token {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		default:
+			if depth == 0 && i+1 < len(body) && (body[i+1] == ":" || body[i+1] == "(") &&
+				!strings.HasPrefix(token, "@") && (i == 0 || body[i-1] != ":") {
+				fields = append(fields, token)
+			}
+		}
+	}
+	return fields
+}
@@ -0,0 +1,60 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGraphQLSchema(t *testing.T) {
+	sdl, err := ioutil.ReadFile("testdata/starwars.graphql")
+	assert.Nil(t, err, "err should be nil")
+	schema, err := ParseGraphQLSchema(string(sdl))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "Root", schema.RootTypes[GraphQLOperationQuery], "should use the query type of the schema")
+	assert.Equal(t, []string{"hero", "human", "droid", "character", "search"}, schema.Fields["Root"],
+		"should include the fields of the type extension")
+	assert.Equal(t, []string{"id", "name"}, schema.Fields["Human"])
+	assert.Nil(t, schema.Fields["ReviewInput"], "should skip the input types")
+}
+
+func TestGraphQLPopulate(t *testing.T) {
+	sdl, err := ioutil.ReadFile("testdata/starwars.graphql")
+	assert.Nil(t, err, "err should be nil")
+	def := &APIDTODefinition{Name: "Star Wars"}
+	err = GraphQLPopulate(def, string(sdl))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "GRAPHQL", def.Type)
+	assert.Equal(t, "/StarWars", def.Context)
+
+	var operations []string
+	for _, operation := range def.Operations {
+		operations = append(operations, operation.(APIOperation).Verb+" "+operation.(APIOperation).Target)
+	}
+	assert.Equal(t, []string{"QUERY character", "QUERY droid", "QUERY hero", "QUERY human", "QUERY search",
+		"MUTATION createReview", "SUBSCRIPTION reviewAdded"}, operations)
+}
+
+func TestGraphQLPopulateWithoutQueryType(t *testing.T) {
+	err := GraphQLPopulate(&APIDTODefinition{}, "type Pet { name: String }")
+	assert.NotNil(t, err, "should fail without a query type")
+}
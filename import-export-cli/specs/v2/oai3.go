@@ -21,8 +21,11 @@ package v2
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
 
-	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
@@ -112,62 +115,406 @@ func oai3WSO2Basepath(exts map[string]interface{}) (string, bool, error) {
 	return "", false, nil
 }
 
-// oai3GetHttpVerbs generates verbs for api definition
-func oai3GetHttpVerbs(item *openapi3.PathItem) (verbs []string) {
-	if item.Get != nil {
-		verbs = append(verbs, "GET")
+// Security schemes of an API in API Manager
+const (
+	SecuritySchemeOAuth2                        = "oauth2"
+	SecuritySchemeBasicAuth                     = "basic_auth"
+	SecuritySchemeAPIKey                        = "api_key"
+	SecuritySchemeMutualSSL                     = "mutualssl"
+	SecuritySchemeOAuthBasicAuthAPIKeyMandatory = "oauth_basic_auth_api_key_mandatory"
+	SecuritySchemeMutualSSLMandatory            = "mutualssl_mandatory"
+)
+
+// Default authentication type and throttling policy of the operations
+const (
+	OperationAuthTypeDefault   = "Application & Application User"
+	OperationAuthTypeNone      = "None"
+	OperationThrottlingDefault = "Unlimited"
+)
+
+// HTTP methods of the operations of an OpenAPI 3 path item which can be operations of an API
+var oai3OperationVerbs = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// OpenAPI3Document is the part of an OpenAPI 3.0 or 3.1 document used to populate an API definition
+type OpenAPI3Document struct {
+	OpenAPI    string                                `json:"openapi"`
+	Info       oai3Info                              `json:"info"`
+	Servers    []oai3Server                          `json:"servers"`
+	Tags       []Tag                                 `json:"tags"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Webhooks   map[string]json.RawMessage            `json:"webhooks"`
+	Components struct {
+		SecuritySchemes map[string]oai3SecurityScheme `json:"securitySchemes"`
+	} `json:"components"`
+	Security []map[string][]string `json:"security"`
+	// Extensions are the vendor extensions (x-*) of the document as json.RawMessage values
+	Extensions map[string]interface{} `json:"-"`
+}
+
+type oai3Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+}
+
+type oai3Server struct {
+	URL       string `json:"url"`
+	Variables map[string]struct {
+		Default string `json:"default"`
+	} `json:"variables"`
+}
+
+type oai3SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+	Flows  map[string]struct {
+		Scopes map[string]string `json:"scopes"`
+		// ScopeBindings are the roles of the scopes
+		ScopeBindings map[string]interface{} `json:"x-scopes-bindings"`
+	} `json:"flows"`
+}
+
+type oai3Operation struct {
+	Security       *[]map[string][]string     `json:"security"`
+	Callbacks      map[string]json.RawMessage `json:"callbacks"`
+	AuthType       string                     `json:"x-auth-type"`
+	ThrottlingTier string                     `json:"x-throttling-tier"`
+	WSO2Throttling string                     `json:"x-wso2-throttling-tier"`
+	Scope          string                     `json:"x-scope"`
+}
+
+// APIOperation is an operation of an API in API Manager
+type APIOperation struct {
+	Target           string   `json:"target" yaml:"target"`
+	Verb             string   `json:"verb" yaml:"verb"`
+	AuthType         string   `json:"authType,omitempty" yaml:"authType,omitempty"`
+	ThrottlingPolicy string   `json:"throttlingPolicy,omitempty" yaml:"throttlingPolicy,omitempty"`
+	Scopes           []string `json:"scopes" yaml:"scopes"`
+}
+
+// APIScope is a scope of an API in API Manager
+type APIScope struct {
+	Scope struct {
+		Name        string   `json:"name" yaml:"name"`
+		DisplayName string   `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+		Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+		Bindings    []string `json:"bindings" yaml:"bindings"`
+	} `json:"scope" yaml:"scope"`
+	Shared bool `json:"shared" yaml:"shared"`
+}
+
+// IsOpenAPI3 returns whether a JSON document is an OpenAPI 3.x document
+func IsOpenAPI3(jsonContent []byte) bool {
+	var document struct {
+		OpenAPI string `json:"openapi"`
+	}
+	if err := json.Unmarshal(jsonContent, &document); err != nil {
+		return false
+	}
+	return strings.HasPrefix(document.OpenAPI, "3.")
+}
+
+// LoadOpenAPI3Document loads an OpenAPI 3.0 or 3.1 document in JSON format
+func LoadOpenAPI3Document(jsonContent []byte) (*OpenAPI3Document, error) {
+	document := &OpenAPI3Document{}
+	if err := json.Unmarshal(jsonContent, document); err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(jsonContent, &fields); err != nil {
+		return nil, err
+	}
+	document.Extensions = make(map[string]interface{})
+	for key, value := range fields {
+		if strings.HasPrefix(key, "x-") || key == "tags" {
+			document.Extensions[key] = value
+		}
+	}
+	return document, nil
+}
+
+// OpenAPI3Populate populates the API definition using an OpenAPI 3.0 or 3.1 document
+func OpenAPI3Populate(def *APIDTODefinition, document *OpenAPI3Document) error {
+	def.Name = document.Info.Title
+	def.Version = document.Info.Version
+	def.Provider = "admin"
+	def.Description = document.Info.Description
+	def.Context = fmt.Sprintf("/%s", def.Name)
+	def.Tags = oai3Tags(document.Extensions)
+
+	// the first server is used as the production endpoint and its path as the context
+	productionEp := &Endpoints{}
+	if serverURL := oai3ServerURL(document.Servers); serverURL != nil {
+		productionEp.Urls = []string{serverURL.String()}
+		if serverURL.Path != "" && serverURL.Path != "/" {
+			def.Context = path.Clean(serverURL.Path)
+		}
+	}
+
+	// override basepath if wso2 extension provided
+	basepath, ok, err := oai3WSO2Basepath(document.Extensions)
+	if err != nil {
+		return err
+	}
+	if ok {
+		applyWSO2BasePath(def, basepath)
+	}
+
+	// trim spaces if available
+	def.Name = strings.ReplaceAll(def.Name, " ", "")
+	def.Version = strings.ReplaceAll(def.Version, " ", "")
+	def.Context = strings.ReplaceAll(def.Context, " ", "")
+
+	cors, _, err := oai3XWSO2Cors(document.Extensions)
+	if err != nil {
+		return err
+	}
+	if cors != nil {
+		def.CorsConfiguration = cors
+	}
+
+	prodEp, _, err := oai3XWSO2ProductionEndpoints(document.Extensions)
+	if err != nil {
+		return err
+	}
+	if prodEp != nil {
+		productionEp = prodEp
+	}
+	sandboxEp, _, err := oai3XWso2SandboxEndpoints(document.Extensions)
+	if err != nil {
+		return err
+	}
+	if sandboxEp == nil {
+		sandboxEp = &Endpoints{}
+	}
+	if len(productionEp.Urls) > 0 || len(sandboxEp.Urls) > 0 {
+		ep, err := BuildAPIMEndpoints(productionEp, sandboxEp)
+		if err != nil {
+			return err
+		}
+		var endpointConfig map[string]interface{}
+		err = json.Unmarshal([]byte(ep), &endpointConfig)
+		if err != nil {
+			return err
+		}
+		def.EndpointConfig = &endpointConfig
+	}
+
+	if err := oai3PopulateAPIExtensions(def, document.Extensions); err != nil {
+		return err
+	}
+	def.SecurityScheme = oai3SecuritySchemes(document.Components.SecuritySchemes, def.SecurityScheme)
+	return oai3PopulateOperations(def, document)
+}
+
+// oai3ServerURL returns the URL of the first server with an absolute URL. The variables are replaced by their defaults
+func oai3ServerURL(servers []oai3Server) *url.URL {
+	for _, server := range servers {
+		serverURL := server.URL
+		for name, variable := range server.Variables {
+			serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", variable.Default)
+		}
+		parsedURL, err := url.Parse(serverURL)
+		if err == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") && parsedURL.Host != "" {
+			return parsedURL
+		}
 	}
-	if item.Post != nil {
-		verbs = append(verbs, "POST")
+	return nil
+}
+
+// oai3PopulateAPIExtensions populates the API level configurations given as x-wso2-* extensions
+func oai3PopulateAPIExtensions(def *APIDTODefinition, exts map[string]interface{}) error {
+	if v, ok := exts["x-wso2-auth-header"].(json.RawMessage); ok {
+		if err := json.Unmarshal(v, &def.AuthorizationHeader); err != nil {
+			return fmt.Errorf("invalid x-wso2-auth-header: %v", err)
+		}
+	}
+	if v, ok := exts["x-wso2-transports"].(json.RawMessage); ok {
+		if err := json.Unmarshal(v, &def.Transport); err != nil {
+			return fmt.Errorf("invalid x-wso2-transports: %v", err)
+		}
+	}
+	if v, ok := exts["x-wso2-throttling-tier"].(json.RawMessage); ok {
+		if err := json.Unmarshal(v, &def.APIThrottlingPolicy); err != nil {
+			return fmt.Errorf("invalid x-wso2-throttling-tier: %v", err)
+		}
+	}
+	return nil
+}
+
+// oai3SecuritySchemes returns the API Manager security schemes of the security schemes of the document. The given
+// schemes are returned if the document does not define any security schemes
+func oai3SecuritySchemes(securitySchemes map[string]oai3SecurityScheme, defaultSchemes []string) []string {
+	found := make(map[string]bool)
+	for _, securityScheme := range securitySchemes {
+		switch strings.ToLower(securityScheme.Type) {
+		case "oauth2", "openidconnect":
+			found[SecuritySchemeOAuth2] = true
+		case "apikey":
+			found[SecuritySchemeAPIKey] = true
+		case "http":
+			if strings.EqualFold(securityScheme.Scheme, "basic") {
+				found[SecuritySchemeBasicAuth] = true
+			}
+		case "mutualtls":
+			found[SecuritySchemeMutualSSL] = true
+		}
+	}
+	if len(found) == 0 {
+		return defaultSchemes
+	}
+	var schemes []string
+	for _, scheme := range []string{SecuritySchemeOAuth2, SecuritySchemeBasicAuth, SecuritySchemeAPIKey,
+		SecuritySchemeMutualSSL} {
+		if found[scheme] {
+			schemes = append(schemes, scheme)
+		}
+	}
+	if found[SecuritySchemeOAuth2] || found[SecuritySchemeBasicAuth] || found[SecuritySchemeAPIKey] {
+		schemes = append(schemes, SecuritySchemeOAuthBasicAuthAPIKeyMandatory)
+	} else {
+		schemes = append(schemes, SecuritySchemeMutualSSLMandatory)
+	}
+	return schemes
+}
+
+// oai3PopulateOperations populates the operations and the scopes of the API using the paths of the document.
+// The callbacks and the webhooks are requests sent by the API, so they are kept only in the definition.
+func oai3PopulateOperations(def *APIDTODefinition, document *OpenAPI3Document) error {
+	scopes := make(map[string]*APIScope)
+	oauthSchemes := make(map[string]bool)
+	for name, securityScheme := range document.Components.SecuritySchemes {
+		if !strings.EqualFold(securityScheme.Type, "oauth2") && !strings.EqualFold(securityScheme.Type, "openIdConnect") {
+			continue
+		}
+		oauthSchemes[name] = true
+		for _, flow := range securityScheme.Flows {
+			for scopeName, description := range flow.Scopes {
+				scope := addAPIScope(scopes, scopeName)
+				scope.Scope.Description = description
+				scope.Scope.Bindings = scopeBindings(flow.ScopeBindings[scopeName])
+			}
+		}
 	}
-	if item.Put != nil {
-		verbs = append(verbs, "PUT")
+
+	var targets []string
+	for target := range document.Paths {
+		targets = append(targets, target)
 	}
-	if item.Delete != nil {
-		verbs = append(verbs, "DELETE")
+	sort.Strings(targets)
+
+	var operations []interface{}
+	for _, target := range targets {
+		for _, verb := range oai3OperationVerbs {
+			content, ok := document.Paths[target][verb]
+			if !ok {
+				continue
+			}
+			var operation oai3Operation
+			if err := json.Unmarshal(content, &operation); err != nil {
+				return fmt.Errorf("invalid operation %s %s: %v", strings.ToUpper(verb), target, err)
+			}
+			if len(operation.Callbacks) > 0 {
+				utils.Logln(utils.LogPrefixInfo + "Callbacks of " + strings.ToUpper(verb) + " " + target +
+					" are kept only in the definition")
+			}
+
+			apiOperation := APIOperation{
+				Target:           target,
+				Verb:             strings.ToUpper(verb),
+				AuthType:         OperationAuthTypeDefault,
+				ThrottlingPolicy: OperationThrottlingDefault,
+				Scopes:           []string{},
+			}
+			security := document.Security
+			if operation.Security != nil {
+				security = *operation.Security
+				// an empty security requirement disables the security of the operation
+				if len(security) == 0 {
+					apiOperation.AuthType = OperationAuthTypeNone
+				}
+			}
+			for _, requirement := range security {
+				for schemeName, schemeScopes := range requirement {
+					if !oauthSchemes[schemeName] {
+						continue
+					}
+					for _, scopeName := range schemeScopes {
+						apiOperation.Scopes = appendIfMissing(apiOperation.Scopes, scopeName)
+						addAPIScope(scopes, scopeName)
+					}
+				}
+			}
+			if operation.Scope != "" {
+				apiOperation.Scopes = appendIfMissing(apiOperation.Scopes, operation.Scope)
+				addAPIScope(scopes, operation.Scope)
+			}
+			if operation.AuthType != "" {
+				apiOperation.AuthType = operation.AuthType
+			}
+			if operation.WSO2Throttling != "" {
+				apiOperation.ThrottlingPolicy = operation.WSO2Throttling
+			}
+			if operation.ThrottlingTier != "" {
+				apiOperation.ThrottlingPolicy = operation.ThrottlingTier
+			}
+			operations = append(operations, apiOperation)
+		}
 	}
-	if item.Patch != nil {
-		verbs = append(verbs, "PATCH")
+	if len(document.Webhooks) > 0 {
+		utils.Logln(utils.LogPrefixInfo + "Webhooks are kept only in the definition")
 	}
-	if item.Head != nil {
-		verbs = append(verbs, "HEAD")
+	def.Operations = operations
+
+	var scopeNames []string
+	for name := range scopes {
+		scopeNames = append(scopeNames, name)
 	}
-	if item.Options != nil {
-		verbs = append(verbs, "OPTIONS")
+	sort.Strings(scopeNames)
+	var apiScopes []interface{}
+	for _, name := range scopeNames {
+		apiScopes = append(apiScopes, scopes[name])
 	}
-	return
+	def.Scopes = apiScopes
+	return nil
 }
 
-// generateFieldsFromSwagger3 using swagger
-func OpenAPI3Populate(def *APIDefinition, swagger *openapi3.Swagger) {
-	def.ID.APIName = utils.ToPascalCase(swagger.Info.Title)
-	def.ID.Version = swagger.Info.Version
-	def.Description = swagger.Info.Description
-	def.Context = fmt.Sprintf("/%s/%s", def.ID.APIName, def.ID.Version)
-	def.ContextTemplate = fmt.Sprintf("/%s/{version}", def.ID.APIName)
+// addAPIScope adds a scope with the given name if it is not already added
+func addAPIScope(scopes map[string]*APIScope, name string) *APIScope {
+	scope, ok := scopes[name]
+	if !ok {
+		scope = &APIScope{}
+		scope.Scope.Name = name
+		scope.Scope.DisplayName = name
+		scope.Scope.Bindings = []string{}
+		scopes[name] = scope
+	}
+	return scope
+}
 
-	// TODO implement xwso2 vendor extensions, since swagger 2.0 parser works out of the box this was not implemented now
-	var uriTemplates []URITemplates
-	for uri, info := range swagger.Paths {
-		uriTemplate := URITemplates{}
-		uriTemplate.URITemplate = uri
-		verbs := oai3GetHttpVerbs(info)
-		uriTemplate.HTTPVerbs = verbs
-		if len(verbs) > 0 {
-			uriTemplate.HTTPVerb = verbs[0]
+// scopeBindings returns the roles of a scope given as a comma separated string or a list
+func scopeBindings(value interface{}) []string {
+	bindings := []string{}
+	switch roles := value.(type) {
+	case string:
+		for _, role := range strings.Split(roles, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				bindings = append(bindings, role)
+			}
+		}
+	case []interface{}:
+		for _, role := range roles {
+			bindings = append(bindings, fmt.Sprint(role))
 		}
-		authTypes := make([]string, len(verbs))
-		throttlingTiers := make([]string, len(verbs))
-		for i := 0; i < len(verbs); i++ {
-			authTypes[i] = "Any"
-			throttlingTiers[i] = "Unlimited"
+	}
+	return bindings
+}
+
+func appendIfMissing(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
 		}
-		uriTemplate.AuthType = "Any"
-		uriTemplate.AuthTypes = authTypes
-		uriTemplate.ThrottlingTier = "Unlimited"
-		uriTemplate.ThrottlingTiers = throttlingTiers
-		uriTemplate.Scopes = make([]*Scopes, len(verbs))
-		uriTemplates = append(uriTemplates, uriTemplate)
 	}
-	def.URITemplates = uriTemplates
+	return append(values, value)
 }
@@ -19,10 +19,12 @@
 package v2
 
 import (
+	"io/ioutil"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var petstoreProdUrls = []string{"https://petstore.swagger.io/v2", "https://petstore.swagger.io/v2/1", "https://petstore.swagger.io/v2/2"}
@@ -71,3 +73,74 @@ func Test_oai3WSO2Cors(t *testing.T) {
 	assert.ElementsMatch(t, []string{"GET", "PUT", "POST"}, cors.AccessControlAllowMethods, "should have same elements for access control")
 	assert.ElementsMatch(t, []string{"test.com", "example.com"}, cors.AccessControlAllowOrigins, "should have same elements for origins")
 }
+
+func loadOpenAPI3TestDocument(t *testing.T, file string) *OpenAPI3Document {
+	content, err := ioutil.ReadFile(file)
+	assert.Nil(t, err, "err should be nil")
+	jsonContent, err := utils.YamlToJson(content)
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, IsOpenAPI3(jsonContent), "should be an OpenAPI 3 document")
+	document, err := LoadOpenAPI3Document(jsonContent)
+	assert.Nil(t, err, "err should be nil")
+	return document
+}
+
+func TestOpenAPI3Populate(t *testing.T) {
+	document := loadOpenAPI3TestDocument(t, "testdata/petstore_oas3_full.yaml")
+	def := &APIDTODefinition{}
+	assert.Nil(t, OpenAPI3Populate(def, document), "err should be nil")
+
+	assert.Equal(t, "SwaggerPetstore", def.Name, "should return correct api name")
+	assert.Equal(t, "1.0.0", def.Version)
+	assert.Equal(t, "/v3", def.Context, "should use the path of the server as the context")
+	assert.Equal(t, []string{"pet"}, def.Tags)
+	assert.Equal(t, "X-Authorization", def.AuthorizationHeader)
+	assert.Equal(t, []string{"https"}, def.Transport)
+	assert.Equal(t, "Gold", def.APIThrottlingPolicy)
+	assert.Equal(t, []string{SecuritySchemeOAuth2, SecuritySchemeAPIKey, SecuritySchemeOAuthBasicAuthAPIKeyMandatory},
+		def.SecurityScheme)
+
+	endpointConfig := *def.EndpointConfig.(*map[string]interface{})
+	assert.Equal(t, EpHttp, endpointConfig["endpoint_type"])
+	assert.Equal(t, "https://api.petstore.io/v3",
+		endpointConfig["production_endpoints"].(map[string]interface{})["url"], "should use the server as the endpoint")
+
+	assert.Equal(t, []interface{}{
+		APIOperation{Target: "/health", Verb: "GET", AuthType: OperationAuthTypeNone,
+			ThrottlingPolicy: OperationThrottlingDefault, Scopes: []string{}},
+		APIOperation{Target: "/pets", Verb: "GET", AuthType: OperationAuthTypeDefault, ThrottlingPolicy: "10KPerMin",
+			Scopes: []string{"read:pets"}},
+		APIOperation{Target: "/pets", Verb: "POST", AuthType: OperationAuthTypeDefault,
+			ThrottlingPolicy: OperationThrottlingDefault, Scopes: []string{"write:pets", "read:pets"}},
+	}, def.Operations, "callbacks should not be operations")
+
+	assert.Equal(t, 2, len(def.Scopes))
+	readScope := def.Scopes[0].(*APIScope)
+	assert.Equal(t, "read:pets", readScope.Scope.Name)
+	assert.Equal(t, "Read the pets", readScope.Scope.Description)
+	assert.Equal(t, []string{}, readScope.Scope.Bindings)
+	writeScope := def.Scopes[1].(*APIScope)
+	assert.Equal(t, []string{"admin", "manager"}, writeScope.Scope.Bindings)
+}
+
+func TestOpenAPI31Populate(t *testing.T) {
+	document := loadOpenAPI3TestDocument(t, "testdata/petstore_oas31.json")
+	def := &APIDTODefinition{}
+	assert.Nil(t, OpenAPI3Populate(def, document), "err should be nil")
+
+	assert.Equal(t, "3.1.0", document.OpenAPI)
+	assert.Equal(t, "PetWebhooks", def.Name)
+	assert.Equal(t, "/pet-webhooks/2.0.0", def.Context, "should use x-wso2-basePath as the context")
+	assert.Equal(t, []string{SecuritySchemeMutualSSL, SecuritySchemeMutualSSLMandatory}, def.SecurityScheme)
+	assert.Equal(t, []interface{}{
+		APIOperation{Target: "/pets/{petId}", Verb: "DELETE", AuthType: OperationAuthTypeDefault,
+			ThrottlingPolicy: OperationThrottlingDefault, Scopes: []string{}},
+	}, def.Operations, "webhooks should not be operations")
+	assert.Empty(t, def.Scopes)
+}
+
+func TestIsOpenAPI3(t *testing.T) {
+	assert.True(t, IsOpenAPI3([]byte(`{"openapi": "3.0.3"}`)))
+	assert.False(t, IsOpenAPI3([]byte(`{"swagger": "2.0"}`)))
+	assert.False(t, IsOpenAPI3([]byte(`not json`)))
+}
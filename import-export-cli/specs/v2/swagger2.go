@@ -198,6 +198,16 @@ func buildHttpEndpoint(production *Endpoints, sandbox *Endpoints) string {
 	return jsonObj.String()
 }
 
+// applyWSO2BasePath sets the context of the API using the x-wso2-basePath extension
+func applyWSO2BasePath(def *APIDTODefinition, basepath string) {
+	if !strings.Contains(basepath, "{version}") {
+		def.Context = path.Clean(basepath)
+		def.IsDefaultVersion = true
+	} else {
+		def.Context = path.Clean(strings.ReplaceAll(basepath, "{version}", def.Version))
+	}
+}
+
 // generateFieldsFromSwagger3 using swagger
 func Swagger2Populate(def *APIDTODefinition, document *loads.Document) error {
 	def.Name = document.Spec().Info.Title
@@ -214,13 +224,7 @@ func Swagger2Populate(def *APIDTODefinition, document *loads.Document) error {
 
 	// override basepath if wso2 extension provided
 	if basepath, ok := swagger2XWO2BasePath(document); ok {
-		def.Context = path.Clean(basepath)
-		if !strings.Contains(basepath, "{version}") {
-			def.Context = path.Clean(basepath)
-			def.IsDefaultVersion = true
-		} else {
-			def.Context = path.Clean(strings.ReplaceAll(basepath, "{version}", def.Version))
-		}
+		applyWSO2BasePath(def, basepath)
 	}
 
 	// trim spaces if available
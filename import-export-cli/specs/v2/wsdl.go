@@ -0,0 +1,193 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Types of the WSDL of a SOAP API in API Manager
+const (
+	WSDLTypeFile    = "WSDL"
+	WSDLTypeArchive = "ZIP"
+)
+
+// WSDLDocument is the part of a WSDL 1.1 (definitions) or a WSDL 2.0 (description) document used to populate an API
+// definition
+type WSDLDocument struct {
+	XMLName       xml.Name
+	Name          string `xml:"name,attr"`
+	Documentation string `xml:"documentation"`
+	Services      []struct {
+		Name string `xml:"name,attr"`
+		// Ports are the WSDL 1.1 ports of which the address is given by soap:address, soap12:address or http:address
+		Ports []struct {
+			Address struct {
+				Location string `xml:"location,attr"`
+			} `xml:"address"`
+		} `xml:"port"`
+		// Endpoints are the WSDL 2.0 endpoints
+		Endpoints []struct {
+			Address string `xml:"address,attr"`
+		} `xml:"endpoint"`
+	} `xml:"service"`
+}
+
+// IsWSDLArchive returns whether the content is a zip archive
+func IsWSDLArchive(content []byte) bool {
+	return bytes.HasPrefix(content, []byte("PK\x03\x04"))
+}
+
+// LoadWSDLDocument loads a WSDL 1.1 or 2.0 document
+func LoadWSDLDocument(content []byte) (*WSDLDocument, error) {
+	document := &WSDLDocument{}
+	if err := xml.Unmarshal(content, document); err != nil {
+		return nil, err
+	}
+	if document.XMLName.Local != "definitions" && document.XMLName.Local != "description" {
+		return nil, errors.New("not a WSDL document: the root element is " + document.XMLName.Local)
+	}
+	return document, nil
+}
+
+// LoadWSDLArchive loads the WSDL document which defines the service in a zip archive. The other documents of the
+// archive are the documents imported by it
+func LoadWSDLArchive(content []byte) (*WSDLDocument, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	files := make(map[string]*zip.File)
+	for _, file := range reader.File {
+		if strings.EqualFold(path.Ext(file.Name), ".wsdl") && !file.FileInfo().IsDir() {
+			names = append(names, file.Name)
+			files[file.Name] = file
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		entry, err := files[name].Open()
+		if err != nil {
+			return nil, err
+		}
+		entryContent, err := ioutil.ReadAll(entry)
+		entry.Close()
+		if err != nil {
+			return nil, err
+		}
+		document, err := LoadWSDLDocument(entryContent)
+		if err != nil {
+			return nil, errors.New(name + ": " + err.Error())
+		}
+		if len(document.Services) > 0 {
+			return document, nil
+		}
+	}
+	return nil, errors.New("the archive does not contain a WSDL document with a service")
+}
+
+// WSDLPopulate populates the API definition of a pass-through SOAP API using a WSDL document. The name of the first
+// service is used as the name and the context, and its first address as the production endpoint
+func WSDLPopulate(def *APIDTODefinition, document *WSDLDocument, wsdlType string) error {
+	if len(document.Services) == 0 {
+		return errors.New("the WSDL document does not define a service")
+	}
+	service := document.Services[0]
+	def.Name = strings.ReplaceAll(service.Name, " ", "")
+	def.Context = "/" + def.Name
+	def.Description = strings.TrimSpace(document.Documentation)
+	def.Type = "SOAP"
+	def.WsdlInfo = map[string]string{"type": wsdlType}
+
+	var address string
+	for _, port := range service.Ports {
+		if address = port.Address.Location; address != "" {
+			break
+		}
+	}
+	for _, endpoint := range service.Endpoints {
+		if address != "" {
+			break
+		}
+		address = endpoint.Address
+	}
+	if address != "" {
+		ep, err := BuildAPIMEndpoints(&Endpoints{Urls: []string{address}}, &Endpoints{})
+		if err != nil {
+			return err
+		}
+		var endpointConfig map[string]interface{}
+		if err := json.Unmarshal([]byte(ep), &endpointConfig); err != nil {
+			return err
+		}
+		def.EndpointConfig = &endpointConfig
+	}
+
+	// a pass-through SOAP API has a single operation accepting all the SOAP requests
+	def.Operations = []interface{}{APIOperation{
+		Target:           "/*",
+		Verb:             "POST",
+		AuthType:         OperationAuthTypeDefault,
+		ThrottlingPolicy: OperationThrottlingDefault,
+		Scopes:           []string{},
+	}}
+	return nil
+}
+
+// SOAPPassthroughSwagger returns the swagger definition of the operations of a pass-through SOAP API
+func SOAPPassthroughSwagger(def *APIDTODefinition) map[string]interface{} {
+	return map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":   def.Name,
+			"version": def.Version,
+		},
+		"paths": map[string]interface{}{
+			"/*": map[string]interface{}{
+				"post": map[string]interface{}{
+					"parameters": []interface{}{
+						map[string]interface{}{
+							"in":       "body",
+							"name":     "SOAP Request",
+							"required": true,
+							"schema":   map[string]interface{}{"type": "string"},
+						},
+						map[string]interface{}{
+							"in":   "header",
+							"name": "SOAPAction",
+							"type": "string",
+						},
+					},
+					"responses": map[string]interface{}{
+						"default": map[string]interface{}{"description": "Default response"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWSDLPopulate(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/phoneverify.wsdl")
+	assert.Nil(t, err, "err should be nil")
+	assert.False(t, IsWSDLArchive(content))
+	document, err := LoadWSDLDocument(content)
+	assert.Nil(t, err, "err should be nil")
+
+	def := &APIDTODefinition{}
+	err = WSDLPopulate(def, document, WSDLTypeFile)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "PhoneVerify", def.Name)
+	assert.Equal(t, "/PhoneVerify", def.Context)
+	assert.Equal(t, "SOAP", def.Type)
+	assert.Equal(t, "Validates phone numbers", def.Description)
+	assert.Equal(t, map[string]string{"type": WSDLTypeFile}, def.WsdlInfo)
+	endpointConfig := *def.EndpointConfig.(*map[string]interface{})
+	assert.Equal(t, "http://ws.cdyne.com/phoneverify/phoneverify.asmx",
+		endpointConfig["production_endpoints"].(map[string]interface{})["url"], "should use the first address")
+	assert.Equal(t, "/*", def.Operations[0].(APIOperation).Target)
+	assert.Equal(t, "POST", def.Operations[0].(APIOperation).Verb)
+}
+
+func TestLoadWSDLArchive(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/phoneverify.wsdl")
+	assert.Nil(t, err, "err should be nil")
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	entries := map[string][]byte{
+		"phoneverify/types.wsdl":   []byte(`<definitions xmlns="http://schemas.xmlsoap.org/wsdl/" name="Types"/>`),
+		"phoneverify/service.wsdl": content,
+	}
+	for name, entryContent := range entries {
+		entry, err := writer.Create(name)
+		assert.Nil(t, err, "err should be nil")
+		_, err = entry.Write(entryContent)
+		assert.Nil(t, err, "err should be nil")
+	}
+	assert.Nil(t, writer.Close(), "err should be nil")
+
+	assert.True(t, IsWSDLArchive(archive.Bytes()))
+	document, err := LoadWSDLArchive(archive.Bytes())
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "PhoneVerify", document.Services[0].Name, "should load the document with the service")
+}
+
+func TestLoadWSDLDocumentInvalid(t *testing.T) {
+	_, err := LoadWSDLDocument([]byte(`<project name="pom"/>`))
+	assert.NotNil(t, err, "should fail for a document which is not a WSDL")
+}
@@ -68,11 +68,20 @@ const ExportedApisDirName = "apis"
 const ExportedApiProductsDirName = "api-products"
 const ExportedAppsDirName = "apps"
 const ExportedMigrationArtifactsDirName = "migration"
+const ExportedThrottlingPoliciesDirName = "policies"
+const ExportedKeyManagersDirName = "key-managers"
+const ExportedScopesDirName = "scopes"
 const CertificatesDirName = "certs"
+const DeployedCarbonAppsDirName = "carbonapps"
 
 const (
 	InitProjectDefinitions          = "Definitions"
 	InitProjectDefinitionsSwagger   = InitProjectDefinitions + string(os.PathSeparator) + "swagger.yaml"
+	InitProjectSwaggerJson          = InitProjectDefinitions + string(os.PathSeparator) + "swagger.json"
+	InitProjectGraphQLSchema        = InitProjectDefinitions + string(os.PathSeparator) + "schema.graphql"
+	InitProjectAsyncAPIYaml         = InitProjectDefinitions + string(os.PathSeparator) + "asyncapi.yaml"
+	InitProjectAsyncAPIJson         = InitProjectDefinitions + string(os.PathSeparator) + "asyncapi.json"
+	InitProjectWSDL                 = "WSDL"
 	InitProjectImage                = "Image"
 	InitProjectDocs                 = "Docs"
 	InitProjectSequences            = "Sequences"
@@ -91,6 +100,10 @@ const DeploymentCertificatesDirectory = "certificates"
 var DefaultExportDirPath = filepath.Join(ConfigDirPath, DefaultExportDirName)
 var DefaultCertDirPath = filepath.Join(ConfigDirPath, CertificatesDirName)
 
+// DeployedCarbonAppsDirPath keeps the last CAR file deployed by apictl for each Carbon App of an environment, which is
+// used to roll back a failed deployment
+var DeployedCarbonAppsDirPath = filepath.Join(ConfigDirPath, DeployedCarbonAppsDirName)
+
 const defaultApiApplicationImportExportSuffix = "api/am/admin/v2"
 const defaultPublisherApiImportExportSuffix = "api/am/publisher/v2"
 const defaultApiListEndpointSuffix = "api/am/publisher/v2/apis"
@@ -144,6 +157,8 @@ const SearchAndTag = "&"
 // Other
 const DefaultTokenValidityPeriod = 3600
 const DefaultHttpRequestTimeout = 10000
+const DefaultKeyManager = "Resident Key Manager"
+const DefaultKeyGrantType = "client_credentials"
 
 // TLSRenegotiationNever : never negotiate
 const TLSRenegotiationNever = "never"
@@ -156,9 +171,15 @@ const TLSRenegotiationFreely = "freely"
 
 // Migration export
 const MaxAPIsToExportOnce = 20
-const MigrationAPIsExportMetadataFileName = "migration-apis-export-metadata.yaml"
-const LastSucceededApiFileName = "last-succeeded-api.log"
-const LastSuceededContentDelimiter = " " // space
+const MigrationAPIsExportManifestFileName = "migration-apis-export-manifest.yaml"
+const DefaultExportAPIsConcurrency = 4
+const MigrationAPIsImportManifestFilePrefix = "migration-apis-import-manifest-"
+const DefaultImportAPIsConcurrency = 4
+
+// Statuses of the APIs in the migration export and import manifests
+const MigrationStatusPending = "pending"
+const MigrationStatusSucceeded = "succeeded"
+const MigrationStatusFailed = "failed"
 const DefaultResourceTenantDomain = "tenant-default"
 const ApplicationId = "applicationId"
 const ApiId = "apiId"
@@ -186,6 +207,9 @@ const (
 const ParamFile = "params.yaml"
 const ParamsIntermediateFile = "intermediate_params.yaml"
 
+// workspace manifest which declares the projects of a multi-project repository
+const WorkspaceManifestFile = "apictl.yaml"
+
 const (
 	ParamFileAPI         = "api_params.yaml"
 	ParamFileAPIProduct  = "api_product_params.yaml"
@@ -224,6 +248,9 @@ const MiCmdLiteral = "mi"
 // MiManagementAPIContext
 const MiManagementAPIContext = "management"
 
+// MINodeEnvSeparator separates the environment and the node number when addressing a node of a Micro Integrator cluster
+const MINodeEnvSeparator = "#"
+
 // Mi Management Resource paths
 const MiManagementCarbonAppResource = "applications"
 const MiManagementServiceResource = "services"
@@ -0,0 +1,157 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Types of field level differences
+const (
+	DiffTypeAdded    = "added"
+	DiffTypeRemoved  = "removed"
+	DiffTypeModified = "modified"
+)
+
+// FieldDiff represents a difference of a single field between two documents
+type FieldDiff struct {
+	Path   string      `json:"path" yaml:"path"`
+	Type   string      `json:"type" yaml:"type"`
+	Before interface{} `json:"before,omitempty" yaml:"before,omitempty"`
+	After  interface{} `json:"after,omitempty" yaml:"after,omitempty"`
+}
+
+// DiffJSON compares two JSON documents and returns the field level differences from before to after.
+// An empty document is treated as null.
+func DiffJSON(before, after []byte) ([]FieldDiff, error) {
+	var beforeValue, afterValue interface{}
+	if len(before) != 0 {
+		if err := json.Unmarshal(before, &beforeValue); err != nil {
+			return nil, err
+		}
+	}
+	if len(after) != 0 {
+		if err := json.Unmarshal(after, &afterValue); err != nil {
+			return nil, err
+		}
+	}
+	return DiffValues(beforeValue, afterValue), nil
+}
+
+// DiffValues compares two values decoded from JSON (maps, slices and scalars) and returns the field level
+// differences from before to after. Object keys are visited in sorted order so the result is deterministic.
+func DiffValues(before, after interface{}) []FieldDiff {
+	diffs := []FieldDiff{}
+	diffValues("", before, after, &diffs)
+	return diffs
+}
+
+func diffValues(path string, before, after interface{}, diffs *[]FieldDiff) {
+	if before == nil && after == nil {
+		return
+	}
+	if before == nil {
+		*diffs = append(*diffs, FieldDiff{Path: path, Type: DiffTypeAdded, After: after})
+		return
+	}
+	if after == nil {
+		*diffs = append(*diffs, FieldDiff{Path: path, Type: DiffTypeRemoved, Before: before})
+		return
+	}
+
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		keys := make([]string, 0, len(beforeMap)+len(afterMap))
+		for key := range beforeMap {
+			keys = append(keys, key)
+		}
+		for key := range afterMap {
+			if _, ok := beforeMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			diffValues(joinDiffPath(path, key), beforeMap[key], afterMap[key], diffs)
+		}
+		return
+	}
+
+	beforeList, beforeIsList := before.([]interface{})
+	afterList, afterIsList := after.([]interface{})
+	if beforeIsList && afterIsList {
+		for i := 0; i < len(beforeList) || i < len(afterList); i++ {
+			var beforeItem, afterItem interface{}
+			if i < len(beforeList) {
+				beforeItem = beforeList[i]
+			}
+			if i < len(afterList) {
+				afterItem = afterList[i]
+			}
+			diffValues(path+"["+strconv.Itoa(i)+"]", beforeItem, afterItem, diffs)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*diffs = append(*diffs, FieldDiff{Path: path, Type: DiffTypeModified, Before: before, After: after})
+	}
+}
+
+func joinDiffPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// RemoveFieldsFromJSON removes the given top level or dot separated nested fields (eg: data.id) from a JSON
+// document decoded into a map. Fields that do not exist are ignored.
+func RemoveFieldsFromJSON(document map[string]interface{}, fields []string) {
+	for _, field := range fields {
+		removeField(document, strings.Split(field, "."))
+	}
+}
+
+func removeField(document map[string]interface{}, keys []string) {
+	if len(keys) == 1 {
+		delete(document, keys[0])
+		return
+	}
+	if child, ok := document[keys[0]].(map[string]interface{}); ok {
+		removeField(child, keys[1:])
+	}
+}
+
+// FormatDiffValue returns a compact JSON representation of a value in a FieldDiff to be printed in the console
+func FormatDiffValue(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "<unknown>"
+	}
+	return string(data)
+}
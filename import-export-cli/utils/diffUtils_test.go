@@ -0,0 +1,62 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffJSONWithoutChanges(t *testing.T) {
+	diffs, err := DiffJSON([]byte(`{"name":"PizzaShackAPI","tags":["pizza"]}`),
+		[]byte(`{"tags":["pizza"],"name":"PizzaShackAPI"}`))
+	assert.Nil(t, err, "Error should be null")
+	assert.Empty(t, diffs, "Should not return differences for equal documents")
+}
+
+func TestDiffJSONWithChanges(t *testing.T) {
+	before := `{"data":{"name":"PizzaShackAPI","description":"Pizza","endpointConfig":{"production_endpoints":{"url":"http://a"}},"tags":["pizza"]}}`
+	after := `{"data":{"name":"PizzaShackAPI","endpointConfig":{"production_endpoints":{"url":"http://b"}},"tags":["pizza","food"]}}`
+	diffs, err := DiffJSON([]byte(before), []byte(after))
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, []FieldDiff{
+		{Path: "data.description", Type: DiffTypeRemoved, Before: "Pizza"},
+		{Path: "data.endpointConfig.production_endpoints.url", Type: DiffTypeModified, Before: "http://a",
+			After: "http://b"},
+		{Path: "data.tags[1]", Type: DiffTypeAdded, After: "food"},
+	}, diffs, "Should return the field level differences in order")
+}
+
+func TestDiffJSONInvalidDocument(t *testing.T) {
+	_, err := DiffJSON([]byte(`{"name":`), []byte(`{}`))
+	assert.NotNil(t, err, "Should return an error for an invalid JSON document")
+}
+
+func TestRemoveFieldsFromJSON(t *testing.T) {
+	document := map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":   "123",
+			"name": "PizzaShackAPI",
+		},
+	}
+	RemoveFieldsFromJSON(document, []string{"data.id", "data.lastUpdatedTime", "type.id"})
+	assert.Equal(t, map[string]interface{}{"data": map[string]interface{}{"name": "PizzaShackAPI"}}, document,
+		"Should remove only the existing fields")
+}
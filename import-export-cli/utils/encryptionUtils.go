@@ -155,6 +155,14 @@ func GetKeyStoreConfigFromFile(filePath string) (*KeyStoreConfig, error) {
 }
 
 func getEncryptionKey(keyStoreConfig *KeyStoreConfig) (*rsa.PublicKey, error) {
+	rsaKey, err := getDecryptionKey(keyStoreConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &rsaKey.PublicKey, nil
+}
+
+func getDecryptionKey(keyStoreConfig *KeyStoreConfig) (*rsa.PrivateKey, error) {
 	keyStorePath := keyStoreConfig.KeyStorePath
 	keyStorePassword, _ := base64.StdEncoding.DecodeString(keyStoreConfig.KeyStorePassword)
 	keyStore, err := readKeyStore(keyStorePath, keyStorePassword)
@@ -168,11 +176,14 @@ func getEncryptionKey(keyStoreConfig *KeyStoreConfig) (*rsa.PublicKey, error) {
 		return nil, errors.New("Reading Key Entry: " + err.Error())
 	}
 	key, err := x509.ParsePKCS8PrivateKey(pke.PrivateKey)
-	rsaKey := key.(*rsa.PrivateKey)
 	if err != nil {
 		return nil, errors.New("Parsing Key Entry: " + err.Error())
 	}
-	return &rsaKey.PublicKey, nil
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("Parsing Key Entry: not an RSA key")
+	}
+	return rsaKey, nil
 }
 
 func encrypt(encryptionKey *rsa.PublicKey, plainTextSecrets map[string]string, encryptFunction encryptFunc) (map[string]string, error) {
@@ -199,14 +210,14 @@ func getPlainTextSecrets(secretConfig SecretConfig) map[string]string {
 
 func printSecretsToConsole(secrets map[string]string) {
 	for alias, secret := range secrets {
-		fmt.Println(alias, ":", secret)
+		fmt.Fprintln(MessageWriter(), alias, ":", secret)
 	}
 }
 
 func printSecretsToPropertiesFile(secrets map[string]string) {
 	secretFilePath := getSecretFilePath(encryptedSecretsPropertiesFileName)
 	WritePropertiesToFile(secrets, secretFilePath)
-	fmt.Println("Secret properties file created in", secretFilePath)
+	fmt.Fprintln(MessageWriter(), "Secret properties file created in", secretFilePath)
 }
 
 func printSecretsToYamlFile(secrets map[string]string, namespace string) {
@@ -222,8 +233,8 @@ func printSecretsToYamlFile(secrets map[string]string, namespace string) {
 	}
 	secretFilePath := getSecretFilePath(encryptedSecretsYamlFileName)
 	WriteConfigFile(secretConfig, secretFilePath)
-	fmt.Println("Kubernetes secret file created in", secretFilePath, "with default name and namespace")
-	fmt.Println("You can change the default values as required before applying.")
+	fmt.Fprintln(MessageWriter(), "Kubernetes secret file created in", secretFilePath, "with default name and namespace")
+	fmt.Fprintln(MessageWriter(), "You can change the default values as required before applying.")
 }
 
 func getSecretFilePath(fileName string) string {
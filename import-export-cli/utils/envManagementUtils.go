@@ -20,6 +20,8 @@ package utils
 
 import (
 	"errors"
+	"net/url"
+	"strconv"
 	"strings"
 )
 
@@ -396,7 +398,18 @@ func HasOnlyMIEndpoint(envEndpoints *EnvEndpoints) bool {
 }
 
 // GetMIManagementEndpointOfEnv return the Mi Management Endpoint of a given environment
+// env can also be a node of a Micro Integrator cluster in the form <environment>#<node-number>
 func GetMIManagementEndpointOfEnv(env, filePath string) (string, error) {
+	if clusterEnv, index, ok := splitMINodeEnv(env); ok {
+		nodes, err := GetMINodesOfEnv(clusterEnv, filePath)
+		if err != nil {
+			return "", err
+		}
+		if index < 1 || index > len(nodes) {
+			return "", errors.New("node " + strconv.Itoa(index) + " does not exist in environment '" + clusterEnv + "'")
+		}
+		return nodes[index-1].Endpoint, nil
+	}
 	envEndpoints, err := GetEndpointsOfEnvironment(env, filePath)
 	if err != nil {
 		return "", err
@@ -404,6 +417,71 @@ func GetMIManagementEndpointOfEnv(env, filePath string) (string, error) {
 	return envEndpoints.MiManagementEndpoint, nil
 }
 
+// GetMINodesOfEnv returns the Micro Integrator nodes of a given environment
+// An environment without a list of nodes has a single node which is its Mi Management Endpoint
+func GetMINodesOfEnv(env, filePath string) ([]MINode, error) {
+	envEndpoints, err := GetEndpointsOfEnvironment(env, filePath)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := envEndpoints.MiManagementNodes
+	if len(endpoints) == 0 {
+		if envEndpoints.MiManagementEndpoint == "" {
+			return nil, errors.New("MI does not exists in environment '" + env + "'")
+		}
+		endpoints = []string{envEndpoints.MiManagementEndpoint}
+	}
+	var nodes []MINode
+	for i, endpoint := range endpoints {
+		nodes = append(nodes, MINode{
+			Name:     getMINodeName(endpoint),
+			Env:      env + MINodeEnvSeparator + strconv.Itoa(i+1),
+			Endpoint: endpoint,
+		})
+	}
+	return nodes, nil
+}
+
+// IsMIClusterEnv checks whether the Micro Integrator of a given environment has more than one node
+func IsMIClusterEnv(env, filePath string) bool {
+	if _, _, ok := splitMINodeEnv(env); ok {
+		return false
+	}
+	envEndpoints, err := GetEndpointsOfEnvironment(env, filePath)
+	if err != nil {
+		return false
+	}
+	return len(envEndpoints.MiManagementNodes) > 1
+}
+
+// GetClusterEnvOfMINode returns the environment of a node addressed in the form <environment>#<node-number>
+// It returns false if env does not address a node
+func GetClusterEnvOfMINode(env string) (string, bool) {
+	clusterEnv, _, ok := splitMINodeEnv(env)
+	return clusterEnv, ok
+}
+
+// splitMINodeEnv splits a node environment of the form <environment>#<node-number>
+func splitMINodeEnv(env string) (string, int, bool) {
+	i := strings.LastIndex(env, MINodeEnvSeparator)
+	if i < 0 {
+		return "", 0, false
+	}
+	index, err := strconv.Atoi(env[i+len(MINodeEnvSeparator):])
+	if err != nil {
+		return "", 0, false
+	}
+	return env[:i], index, true
+}
+
+// getMINodeName returns the host and port of a node endpoint, which is used to identify the node in the output
+func getMINodeName(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return endpoint
+}
+
 // GetMIManagementEndpointOfResource return the full resource url of a resource
 func GetMIManagementEndpointOfResource(resource, env, filePath string) string {
 	miEndpoint, _ := GetMIManagementEndpointOfEnv(env, filePath)
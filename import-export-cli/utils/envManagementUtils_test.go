@@ -178,3 +178,44 @@ func TestGetKeysOfEnvironment(t *testing.T) {
 	defer os.Remove(testKeysFilePath)
 
 }
+
+func TestGetMINodesOfEnv(t *testing.T) {
+	testMainConfigFileName := "test_main_config.yaml"
+	testMainConfigFilePath := filepath.Join(CurrentDir, testMainConfigFileName)
+	mainConfig := new(MainConfig)
+	mainConfig.Environments = make(map[string]EnvEndpoints)
+	mainConfig.Environments["dev"] = EnvEndpoints{MiManagementEndpoint: "https://localhost:9164"}
+	mainConfig.Environments["prod"] = EnvEndpoints{
+		MiManagementEndpoint: "https://mi1.com:9164",
+		MiManagementNodes:    []string{"https://mi1.com:9164", "https://mi2.com:9164"},
+	}
+	WriteConfigFile(mainConfig, testMainConfigFilePath)
+	defer os.Remove(testMainConfigFilePath)
+
+	nodes, err := GetMINodesOfEnv("dev", testMainConfigFilePath)
+	if err != nil || len(nodes) != 1 || nodes[0].Endpoint != "https://localhost:9164" {
+		t.Errorf("Expected a single node of dev, got %v, %v\n", nodes, err)
+	}
+	if IsMIClusterEnv("dev", testMainConfigFilePath) {
+		t.Error("Expected dev not to be a cluster")
+	}
+
+	nodes, err = GetMINodesOfEnv("prod", testMainConfigFilePath)
+	if err != nil || len(nodes) != 2 {
+		t.Fatalf("Expected two nodes of prod, got %v, %v\n", nodes, err)
+	}
+	if nodes[1].Name != "mi2.com:9164" || nodes[1].Env != "prod#2" {
+		t.Errorf("Expected node mi2.com:9164 addressed as prod#2, got %s addressed as %s\n", nodes[1].Name, nodes[1].Env)
+	}
+	if !IsMIClusterEnv("prod", testMainConfigFilePath) || IsMIClusterEnv("prod#2", testMainConfigFilePath) {
+		t.Error("Expected only prod to be a cluster")
+	}
+
+	endpoint, err := GetMIManagementEndpointOfEnv("prod#2", testMainConfigFilePath)
+	if err != nil || endpoint != "https://mi2.com:9164" {
+		t.Errorf("Expected '%s', got '%s', %v\n", "https://mi2.com:9164", endpoint, err)
+	}
+	if _, err = GetMIManagementEndpointOfEnv("prod#3", testMainConfigFilePath); err == nil {
+		t.Error("Expected an error for a node that does not exist")
+	}
+}
@@ -1,66 +1,172 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/go-multierror"
 )
 
-// Match for $VAR or ${VAR} and capture VAR inside a group
-var re = regexp.MustCompile(`\${?(\w+)}?`)
+// Modes of the environment variable substitution
+const (
+	// EnvSubstitutionModeStrict fails the substitution if a variable without a default value is not set
+	EnvSubstitutionModeStrict = "strict"
+	// EnvSubstitutionModeLenient leaves the variables which are not set as they are
+	EnvSubstitutionModeLenient = "lenient"
+)
+
+// Sources of the values of the substituted variables
+const (
+	EnvSubstitutionSourceEnvironment = "environment"
+	EnvSubstitutionSourceDefault     = "default"
+	EnvSubstitutionSourceUnset       = "unset"
+)
+
+// EnvSubstitutionMode is the mode used to substitute the environment variables (strict or lenient)
+var EnvSubstitutionMode = EnvSubstitutionModeStrict
+
+// Match for ${VAR}, ${VAR:-default}, ${VAR:?message} and the escaped $${VAR}. VAR, the operator and the default
+// value or the message are captured inside groups
+var recb = regexp.MustCompile(`\$?\${(\w+)(?:(:-|:\?)([^}]*))?}`)
+
+const envDefaultOperator = ":-"
+const envRequiredOperator = ":?"
 
-// Match for ${VAR} and capture VAR inside a group
-var recb = regexp.MustCompile(`\${(\w+)}`)
+// substitutions done since the start of the command. Projects are substituted concurrently when importing in parallel
+var envSubstitutionReport []EnvSubstitution
+var envSubstitutionReportMutex sync.Mutex
+
+// EnvSubstitution is a variable substituted in a file
+type EnvSubstitution struct {
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line"`
+	Variable string `json:"variable"`
+	// Source of the value (environment, default or unset if the variable is left as it is in the lenient mode)
+	Source string `json:"source"`
+}
+
+// EnvSubstitutionConfig configures which project files are substituted when importing. It is read from the
+// envSubstitution section of the meta file of the project (eg: api_meta.yaml)
+type EnvSubstitutionConfig struct {
+	// Files are the paths (or glob patterns) of the files or the directories relative to the project. Defaults to
+	// EnvReplaceFilePaths
+	Files []string `json:"files,omitempty" yaml:"files,omitempty"`
+}
 
 // ErrRequiredEnvKeyMissing represents error used for indicate environment key missing
 type ErrRequiredEnvKeyMissing struct {
 	// Key is the missing entity
 	Key string
+	// Message is the message given as ${VAR:?message}
+	Message string
 }
 
 func (e ErrRequiredEnvKeyMissing) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s is required: %s", e.Key, e.Message)
+	}
 	return fmt.Sprintf("%s is required, please set the environment variable", e.Key)
 }
 
+// GetEnvSubstitutionReport returns the variables substituted since the start of the command
+func GetEnvSubstitutionReport() []EnvSubstitution {
+	envSubstitutionReportMutex.Lock()
+	defer envSubstitutionReportMutex.Unlock()
+	report := make([]EnvSubstitution, len(envSubstitutionReport))
+	copy(report, envSubstitutionReport)
+	return report
+}
+
 // EnvSubstituteForCurlyBraces substitutes variables from environment to the content.
 // It uses regex to match in ${var} format for variables and look up them in the environment before processing.
 // returns an error if anything happen
 func EnvSubstituteForCurlyBraces(content string) (string, error) {
+	return EnvSubstituteContent("", content)
+}
+
+// EnvSubstituteContent substitutes the variables from environment in the content of a file.
+//
+//	${VAR} is replaced with the value of VAR. It fails in the strict mode if VAR is not set.
+//	${VAR:-default} is replaced with default if VAR is not set.
+//	${VAR:?message} fails with message if VAR is not set.
+//	$${VAR} is replaced with ${VAR} without substituting it.
+//
+// The substitutions are added to the substitution report with the given file name.
+func EnvSubstituteContent(file, content string) (string, error) {
 	var errorResults error
-	missingEnvKeys := false
-	matches := recb.FindAllStringSubmatch(content, -1) // matches is [][]string
-
-	for _, match := range matches {
-		Logln(LogPrefixInfo+"Looking for:", match[0])
-		if os.Getenv(match[1]) == "" {
-			missingEnvKeys = true
-			errorResults = multierror.Append(errorResults, &ErrRequiredEnvKeyMissing{Key: match[0]})
-		} else {
-			content = strings.ReplaceAll(content, match[0], os.Getenv(match[1]))
+	var substitutions []EnvSubstitution
+	var result strings.Builder
+	lastIndex := 0
+
+	for _, match := range recb.FindAllStringSubmatchIndex(content, -1) {
+		result.WriteString(content[lastIndex:match[0]])
+		lastIndex = match[1]
+		expression := content[match[0]:match[1]]
+		if strings.HasPrefix(expression, "$$") {
+			result.WriteString(expression[1:])
+			continue
 		}
+
+		name := content[match[2]:match[3]]
+		operator, operand := "", ""
+		if match[4] >= 0 {
+			operator = content[match[4]:match[5]]
+			operand = content[match[6]:match[7]]
+		}
+		Logln(LogPrefixInfo+"Looking for:", expression)
+		substitution := EnvSubstitution{File: file, Line: strings.Count(content[:match[0]], "\n") + 1, Variable: name}
+
+		value := os.Getenv(name)
+		switch {
+		case value != "":
+			substitution.Source = EnvSubstitutionSourceEnvironment
+		case operator == envDefaultOperator:
+			value = operand
+			substitution.Source = EnvSubstitutionSourceDefault
+		case operator == envRequiredOperator:
+			errorResults = multierror.Append(errorResults, &ErrRequiredEnvKeyMissing{Key: expression, Message: operand})
+			continue
+		case EnvSubstitutionMode == EnvSubstitutionModeLenient:
+			Logln(LogPrefixWarning + name + " is not set, leaving " + expression + " as it is")
+			value = expression
+			substitution.Source = EnvSubstitutionSourceUnset
+		default:
+			errorResults = multierror.Append(errorResults, &ErrRequiredEnvKeyMissing{Key: expression})
+			continue
+		}
+		result.WriteString(value)
+		substitutions = append(substitutions, substitution)
 	}
 
-	if missingEnvKeys {
+	if errorResults != nil {
 		return "", errorResults
 	}
-
-	return content, nil
+	result.WriteString(content[lastIndex:])
+	envSubstitutionReportMutex.Lock()
+	envSubstitutionReport = append(envSubstitutionReport, substitutions...)
+	envSubstitutionReportMutex.Unlock()
+	return result.String(), nil
 }
 
 // Substitutes all the environment variables added in the file specified in the 'file' input and changes are
 // updated in the file.
 // If any required environment variable is not set will throw an error.
 func EnvSubstituteInFile(file string) error {
+	return envSubstituteInFile(file, file)
+}
+
+func envSubstituteInFile(file, name string) error {
 	content, err := ioutil.ReadFile(file)
 	if err != nil {
 		return err
 	}
-	substitutedContent, err := EnvSubstituteForCurlyBraces(string(content))
+	substitutedContent, err := EnvSubstituteContent(name, string(content))
 	if err != nil {
 		return err
 	}
@@ -98,3 +204,59 @@ func EnvSubstituteInFolder(folderPath string) error {
 	}
 	return nil
 }
+
+// EnvSubstituteInProject substitutes the environment variables in the files of a project allowed by the config
+// @param projectPath : Path to the project directory
+// @param config : Files to substitute. EnvReplaceFilePaths are substituted if nil
+// @return error if a file cannot be substituted
+func EnvSubstituteInProject(projectPath string, config *EnvSubstitutionConfig) error {
+	allowedPaths := EnvReplaceFilePaths
+	if config != nil && config.Files != nil {
+		allowedPaths = config.Files
+	}
+	for _, allowedPath := range allowedPaths {
+		if _, err := filepath.Match(allowedPath, ""); err != nil {
+			return errors.New("invalid path " + allowedPath + " in the files to substitute: " + err.Error())
+		}
+	}
+	// the files are named relative to the parent of the project in the report (eg: PizzaShackAPI/Sequences/x.xml)
+	baseDirectory := filepath.Dir(projectPath)
+	return filepath.Walk(projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(projectPath, path)
+		if err != nil {
+			return err
+		}
+		if !isEnvSubstitutionAllowed(filepath.ToSlash(relativePath), allowedPaths) {
+			return nil
+		}
+		name, err := filepath.Rel(baseDirectory, path)
+		if err != nil {
+			return err
+		}
+		Logln(LogPrefixInfo+"Substituting env variables in: ", path)
+		return envSubstituteInFile(path, filepath.ToSlash(name))
+	})
+}
+
+// isEnvSubstitutionAllowed returns whether a file is one of the allowed files or inside one of the allowed directories
+func isEnvSubstitutionAllowed(file string, allowedPaths []string) bool {
+	for _, allowedPath := range allowedPaths {
+		allowedPath = strings.Trim(filepath.ToSlash(allowedPath), "/")
+		if file == allowedPath || strings.HasPrefix(file, allowedPath+"/") {
+			return true
+		}
+		// a pattern matches the file or one of its parent directories
+		for parent := file; parent != "."; parent = filepath.ToSlash(filepath.Dir(parent)) {
+			if matched, _ := filepath.Match(allowedPath, parent); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
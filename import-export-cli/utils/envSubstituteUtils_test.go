@@ -1,7 +1,11 @@
 package utils
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,3 +25,99 @@ func TestInjectEnvShouldPassWhenEnvPresents(t *testing.T) {
 	assert.Nil(t, err, "Error should be null")
 	assert.Equal(t, "myval", str, "Should correctly replace environment variable")
 }
+
+func TestEnvSubstituteDefaultsAndEscaping(t *testing.T) {
+	_ = os.Setenv("BACKEND_HOST", "backend.internal")
+	defer os.Unsetenv("BACKEND_HOST")
+	_ = os.Unsetenv("BACKEND_PORT")
+	data := `{"url": "https://${BACKEND_HOST}:${BACKEND_PORT:-8243}", "template": "$${BACKEND_HOST}",
+"schema": {"$ref": "#/components/schemas/Pet"}, "password": "${secret:file:/run/secrets/password}"}`
+	str, err := EnvSubstituteContent("swagger.json", data)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"url": "https://backend.internal:8243", "template": "${BACKEND_HOST}",
+"schema": {"$ref": "#/components/schemas/Pet"}, "password": "${secret:file:/run/secrets/password}"}`, str)
+
+	report := GetEnvSubstitutionReport()
+	assert.Equal(t, []EnvSubstitution{
+		{File: "swagger.json", Line: 1, Variable: "BACKEND_HOST", Source: EnvSubstitutionSourceEnvironment},
+		{File: "swagger.json", Line: 1, Variable: "BACKEND_PORT", Source: EnvSubstitutionSourceDefault},
+	}, report[len(report)-2:])
+}
+
+func TestEnvSubstituteRequiredMessageAndModes(t *testing.T) {
+	_ = os.Unsetenv("BACKEND_PASSWORD")
+	_, err := EnvSubstituteContent("", "${BACKEND_PASSWORD:?set the password of the backend}")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "set the password of the backend")
+
+	EnvSubstitutionMode = EnvSubstitutionModeLenient
+	defer func() { EnvSubstitutionMode = EnvSubstitutionModeStrict }()
+	str, err := EnvSubstituteContent("", "password: ${BACKEND_PASSWORD}")
+	assert.Nil(t, err)
+	assert.Equal(t, "password: ${BACKEND_PASSWORD}", str)
+	report := GetEnvSubstitutionReport()
+	assert.Equal(t, EnvSubstitutionSourceUnset, report[len(report)-1].Source)
+
+	_, err = EnvSubstituteContent("", "${BACKEND_PASSWORD:?required in all the modes}")
+	assert.NotNil(t, err)
+}
+
+func TestEnvSubstituteConcurrently(t *testing.T) {
+	_ = os.Setenv("CONCURRENT_SUBSTITUTION_TEST_VALUE", "value")
+	defer os.Unsetenv("CONCURRENT_SUBSTITUTION_TEST_VALUE")
+	before := len(GetEnvSubstitutionReport())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				str, err := EnvSubstituteContent(fmt.Sprintf("api-%d.yaml", i), "${CONCURRENT_SUBSTITUTION_TEST_VALUE}")
+				assert.Nil(t, err)
+				assert.Equal(t, "value", str)
+				_ = GetEnvSubstitutionReport()
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Len(t, GetEnvSubstitutionReport(), before+8*20, "should keep the substitutions of all the goroutines")
+}
+
+func TestEnvSubstituteInProject(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apictl-substitution")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	project := filepath.Join(dir, "PizzaShackAPI")
+	files := map[string]string{
+		"Sequences/in-sequence/custom.xml": "${SUBSTITUTION_TEST_VALUE}",
+		"Definitions/swagger.yaml":         "host: ${SUBSTITUTION_TEST_VALUE}",
+		"Docs/readme.md":                   "${SUBSTITUTION_TEST_VALUE}",
+	}
+	for name, content := range files {
+		path := filepath.Join(project, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	_ = os.Setenv("SUBSTITUTION_TEST_VALUE", "value")
+	defer os.Unsetenv("SUBSTITUTION_TEST_VALUE")
+
+	readFile := func(name string) string {
+		content, err := ioutil.ReadFile(filepath.Join(project, filepath.FromSlash(name)))
+		assert.Nil(t, err)
+		return string(content)
+	}
+
+	// only the sequences are substituted by default
+	assert.Nil(t, EnvSubstituteInProject(project, nil))
+	assert.Equal(t, "value", readFile("Sequences/in-sequence/custom.xml"))
+	assert.Equal(t, "host: ${SUBSTITUTION_TEST_VALUE}", readFile("Definitions/swagger.yaml"))
+	report := GetEnvSubstitutionReport()
+	assert.Equal(t, "PizzaShackAPI/Sequences/in-sequence/custom.xml", report[len(report)-1].File)
+
+	assert.Nil(t, EnvSubstituteInProject(project, &EnvSubstitutionConfig{Files: []string{"Definitions/*.yaml"}}))
+	assert.Equal(t, "host: value", readFile("Definitions/swagger.yaml"))
+	assert.Equal(t, "${SUBSTITUTION_TEST_VALUE}", readFile("Docs/readme.md"))
+
+	assert.NotNil(t, EnvSubstituteInProject(project, &EnvSubstitutionConfig{Files: []string{"["}}))
+}
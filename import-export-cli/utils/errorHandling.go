@@ -26,9 +26,16 @@ import (
 	"os"
 )
 
+// HandleErrorAndExit prints the error and exits with the exit code of the error (see GetExitCode)
 func HandleErrorAndExit(msg string, err error) {
+	HandleErrorAndExitWithCode(msg, err, GetExitCode(err))
+}
+
+// HandleErrorAndExitWithCode prints the error and exits with the given exit code
+func HandleErrorAndExitWithCode(msg string, err error, exitCode int) {
 	HandleErrorAndContinue(msg, err)
-	printAndExit()
+	printCommandResult(exitCode, newCommandError(msg, err))
+	printAndExit(exitCode)
 }
 
 func HandleErrorAndContinue(msg string, err error) {
@@ -47,19 +54,21 @@ func HandleErrorAndContinue(msg string, err error) {
 	}
 }
 
-func printAndExit() {
-	fmt.Println("Exit status 1")
-	os.Exit(1)
+func printAndExit(exitCode int) {
+	os.Exit(exitCode)
 }
 
 // Log information of erroneous http response and exit program
 func PrintErrorResponseAndExit(response *resty.Response) {
-	fmt.Printf("\nResponse Status: %v. \n", response.Status())
+	fmt.Fprintf(MessageWriter(), "\nResponse Status: %v. \n", response.Status())
 	Logf("\nResponse :%v", cast.ToString(response.Body()))
 	Logf("\nResponse Headers: %v", response.Header())
 	Logf("\nResponse Time:%v", response.Time())
 	Logf("\nResponse Received At:%v", response.ReceivedAt())
-	printAndExit()
+	SetResultHttpStatus(response.StatusCode())
+	exitCode := GetExitCodeOfHttpStatus(response.StatusCode())
+	printCommandResult(exitCode, newCommandError("Response Status: "+response.Status(), NewHttpStatusError(response)))
+	printAndExit(exitCode)
 }
 
 func GetHttpErrorResponse(err error) error {
@@ -52,14 +52,14 @@ func WriteConfigFile(c interface{}, configFilePath string) {
 func GetEnvKeysAllFromFile(envKeysAllFilePath string) *EnvKeysAll {
 	data, err := ioutil.ReadFile(envKeysAllFilePath)
 	if err != nil {
-		fmt.Println("Error reading " + envKeysAllFilePath)
+		fmt.Fprintln(MessageWriter(), "Error reading "+envKeysAllFilePath)
 		os.Create(envKeysAllFilePath)
 		data, err = ioutil.ReadFile(envKeysAllFilePath)
 	}
 
 	var envKeysAll EnvKeysAll
 	if err := envKeysAll.ParseEnvKeysFromFile(data); err != nil {
-		fmt.Println(LogPrefixError + "parsing " + envKeysAllFilePath)
+		fmt.Fprintln(MessageWriter(), LogPrefixError+"parsing "+envKeysAllFilePath)
 		return nil
 	}
 
@@ -168,7 +168,7 @@ func CreateDirIfNotExist(path string) (err error) {
 func CreateDir(path string) (err error) {
 	err = os.Mkdir(path, os.ModePerm)
 	if err != nil {
-		fmt.Println("Error in creating the directory:" + path + "\n" + err.Error())
+		fmt.Fprintln(MessageWriter(), "Error in creating the directory:"+path+"\n"+err.Error())
 	}
 	return err
 }
@@ -176,7 +176,7 @@ func CreateDir(path string) (err error) {
 func RemoveDirectory(path string) (err error) {
 	err = os.RemoveAll(path)
 	if err != nil {
-		fmt.Println("Error in deleting the directory:" + path + "\n" + err.Error())
+		fmt.Fprintln(MessageWriter(), "Error in deleting the directory:"+path+"\n"+err.Error())
 	}
 	return err
 }
@@ -186,7 +186,7 @@ func RemoveDirectoryIfExists(path string) (err error) {
 	if exists, err := IsDirExists(path); exists {
 		err = os.RemoveAll(path)
 		if err != nil {
-			fmt.Println("Error in deleting the directory:" + path + "\n" + err.Error())
+			fmt.Fprintln(MessageWriter(), "Error in deleting the directory:"+path+"\n"+err.Error())
 		}
 	}
 	return err
@@ -197,7 +197,7 @@ func RemoveFileIfExists(path string) (err error) {
 	if exists := IsFileExist(path); exists {
 		err = os.Remove(path)
 		if err != nil {
-			fmt.Println("Error in deleting the directory:" + path + "\n" + err.Error())
+			fmt.Fprintln(MessageWriter(), "Error in deleting the directory:"+path+"\n"+err.Error())
 		}
 	}
 	return err
@@ -82,7 +82,7 @@ func ReadCertsFromDir() *x509.CertPool {
 						certs.AppendCertsFromPEM(fileData)
 					}
 				} else {
-					fmt.Printf(PlainTextWarnMessage, certificate.Name())
+					fmt.Fprintf(MessageWriter(), PlainTextWarnMessage, certificate.Name())
 				}
 			}
 		}
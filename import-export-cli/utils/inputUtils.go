@@ -70,7 +70,7 @@ func ReadInput(printText string, defaultVal Default, validate func(value string)
 	}
 
 	for retry {
-		fmt.Print(text)
+		fmt.Fprint(MessageWriter(), text)
 		inputValue, err := reader.ReadString('\n')
 		value = strings.TrimSpace(inputValue)
 
@@ -85,7 +85,7 @@ func ReadInput(printText string, defaultVal Default, validate func(value string)
 		isValid := validate(value)
 
 		if !isValid {
-			fmt.Println(invalidText)
+			fmt.Fprintln(MessageWriter(), invalidText)
 			if !retryOnInvalid {
 				return value, errors.New("input validation failed")
 			}
@@ -102,10 +102,10 @@ func ReadPassword(printText string) (string, error) {
 	if printText == "" {
 		printText = "Enter Password: "
 	}
-	fmt.Print(printText + ": ")
+	fmt.Fprint(MessageWriter(), printText+": ")
 
 	password, err := terminal.ReadPassword(int(syscall.Stdin))
-	fmt.Println("")
+	fmt.Fprintln(MessageWriter(), "")
 	if err != nil {
 		return "", err
 	}
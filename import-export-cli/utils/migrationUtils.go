@@ -19,8 +19,11 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"io/ioutil"
-	"path/filepath"
+	"os"
 	"strings"
 
 	"gopkg.in/yaml.v2"
@@ -42,56 +45,67 @@ func GetMigrationExportTenantDirName(cmdResourceTenantDomain string) (resourceTe
 	return resourceTenantDirName
 }
 
-// Read the details of finally and successfully exported API into the last-succeeded-api.log file
-func ReadLastSucceededAPIFileData(exportRelatedFilesPath string) API {
-	var lastSucceededApiFilePath = filepath.Join(exportRelatedFilesPath, LastSucceededApiFileName)
-	data, err := ioutil.ReadFile(lastSucceededApiFilePath)
-	str := string(data)
-	var splittedString = strings.Split(str, " ")
-	var api = API{"", strings.TrimSpace(splittedString[0]), "", strings.TrimSpace(splittedString[1]), strings.TrimSpace(splittedString[2]), ""}
-
+// Read the migration-apis-export-manifest.yaml file
+func ReadMigrationApisExportManifest(filePath string) (*MigrationApisExportManifest, error) {
+	data, err := ioutil.ReadFile(filePath)
 	if err != nil {
-		HandleErrorAndExit("Error in reading file "+lastSucceededApiFilePath, err)
+		return nil, err
 	}
-	return api
+	manifest := &MigrationApisExportManifest{}
+	if err := yaml.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
 }
 
-// Write the last-succeeded-api.log file. It includes the meta data of the API, which was successfully exported finally
-func WriteLastSuceededAPIFileData(exportRelatedFilesPath string, api API) {
-	var lastSucceededApiFilePath = filepath.Join(exportRelatedFilesPath, LastSucceededApiFileName)
-	var content []byte
-	content = []byte(api.Name + LastSuceededContentDelimiter + api.Version + LastSuceededContentDelimiter + api.Provider)
-	var error = ioutil.WriteFile(lastSucceededApiFilePath, content, 0644)
+// Write the migration-apis-export-manifest.yaml file. The manifest is written to a temporary file first and renamed,
+// so an interrupted write never leaves a partially written manifest behind.
+func WriteMigrationApisExportManifest(manifest *MigrationApisExportManifest, filePath string) error {
+	return writeFileAtomically(manifest, filePath)
+}
 
-	if error != nil {
-		HandleErrorAndExit("Error in writing file "+lastSucceededApiFilePath, error)
+// Marshals the value to yaml and writes it to a temporary file which is then renamed to the given file
+func writeFileAtomically(value interface{}, filePath string) error {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	tmpFilePath := filePath + ".tmp"
+	if err := ioutil.WriteFile(tmpFilePath, data, 0644); err != nil {
+		return err
 	}
+	return os.Rename(tmpFilePath, filePath)
 }
 
-// Read the migration-apis-export-metadata.yaml file
-func (migrationApisExportMetadata *MigrationApisExportMetadata) ReadMigrationApisExportMetadataFile(filePath string) error {
+// Read the migration-apis-import-manifest-<environment>.yaml file
+func ReadMigrationApisImportManifest(filePath string) (*MigrationApisImportManifest, error) {
 	data, err := ioutil.ReadFile(filePath)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if err := yaml.Unmarshal(data, migrationApisExportMetadata); err != nil {
-		return err
+	manifest := &MigrationApisImportManifest{}
+	if err := yaml.Unmarshal(data, manifest); err != nil {
+		return nil, err
 	}
-	return nil
+	return manifest, nil
 }
 
-// Write the migration-apis-export-metadata.yaml file. This includes the below meta data of the API export process,
-// including the list of APIs exported at each iteration. (APIs are exported as multiple iterations)
-// api_list_offset => offset index of list of APIs fetched from APIM server at the perticular iteration
-// user => username of the user that executes the operation
-// on_tenant => which tenant's APIs are exported
-func WriteMigrationApisExportMetadataFile(apis []API, cmdResourceTenantDomain string,
-	cmdUsername string, exportRelatedFilesPath string, apiListOffset int) {
-	var exportMetaData = new(MigrationApisExportMetadata)
-	exportMetaData.ApiListOffset = apiListOffset
-	exportMetaData.ApiListToExport = apis
-	exportMetaData.OnTenant = cmdResourceTenantDomain
-	exportMetaData.User = cmdUsername
+// Write the migration-apis-import-manifest-<environment>.yaml file. The manifest is replaced atomically in the same way
+// as the export manifest.
+func WriteMigrationApisImportManifest(manifest *MigrationApisImportManifest, filePath string) error {
+	return writeFileAtomically(manifest, filePath)
+}
 
-	WriteConfigFile(exportMetaData, filepath.Join(exportRelatedFilesPath, MigrationAPIsExportMetadataFileName))
+// Returns the sha256 checksum of the given file as a hex string
+func GetFileChecksum(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
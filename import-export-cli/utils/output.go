@@ -0,0 +1,308 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Exit codes of the commands. Scripts can use these to identify the type of the failure.
+const (
+	ExitCodeSuccess         = 0
+	ExitCodeGeneralError    = 1
+	ExitCodeValidationError = 2
+	ExitCodeAuthError       = 3
+	ExitCodeNotFound        = 4
+	ExitCodeConflict        = 5
+	ExitCodeNetworkError    = 6
+	ExitCodeServerError     = 7
+)
+
+// Output formats of the command results
+const (
+	OutputFormatText = "text"
+	OutputFormatJSON = "json"
+)
+
+// Statuses of a command result
+const (
+	CommandResultStatusSuccess = "success"
+	CommandResultStatusError   = "error"
+)
+
+// OutputFormat is the format the result of the command is printed in
+var OutputFormat = OutputFormatText
+
+// CommandResult is the structured result of a command printed when the output format is json
+type CommandResult struct {
+	Command        string            `json:"command"`
+	Status         string            `json:"status"`
+	ExitCode       int               `json:"exitCode"`
+	Resource       map[string]string `json:"resource,omitempty"`
+	HttpStatus     int               `json:"httpStatus,omitempty"`
+	Error          *CommandError     `json:"error,omitempty"`
+	DurationMillis int64             `json:"durationMillis"`
+	Data           interface{}       `json:"data,omitempty"`
+}
+
+// CommandError is the error of a failed command
+type CommandError struct {
+	Message     string `json:"message"`
+	Code        int    `json:"code,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// HttpStatusError is an error response received from a server
+type HttpStatusError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+// Error returns the status and the body of the response
+func (e *HttpStatusError) Error() string {
+	return e.Status + ":<" + e.Body + ">"
+}
+
+// NewHttpStatusError creates an error from an error response
+func NewHttpStatusError(resp *resty.Response) *HttpStatusError {
+	return &HttpStatusError{StatusCode: resp.StatusCode(), Status: resp.Status(), Body: string(resp.Body())}
+}
+
+// ExitCodeError is an error which decides the exit code of the command
+type ExitCodeError struct {
+	ExitCode int
+	message  string
+}
+
+// Error returns the failure message
+func (e *ExitCodeError) Error() string {
+	return e.message
+}
+
+// NewValidationError creates an error of an invalid input given by the user
+func NewValidationError(message string) error {
+	return &ExitCodeError{ExitCode: ExitCodeValidationError, message: message}
+}
+
+// NewNotFoundError creates an error of a resource which is not available in the server
+func NewNotFoundError(message string) error {
+	return &ExitCodeError{ExitCode: ExitCodeNotFound, message: message}
+}
+
+// NewConflictError creates an error of a resource which already exists in the server
+func NewConflictError(message string) error {
+	return &ExitCodeError{ExitCode: ExitCodeConflict, message: message}
+}
+
+// NewAuthError creates an error of a failed authentication or authorization
+func NewAuthError(message string) error {
+	return &ExitCodeError{ExitCode: ExitCodeAuthError, message: message}
+}
+
+// CommandFailure is the error returned by a command which failed. The error is printed and the command exits with the
+// exit code of Err only after the command has returned, so the deferred cleanups of the command are completed.
+type CommandFailure struct {
+	Message string
+	Err     error
+}
+
+// Error returns the failure message with the reason
+func (e *CommandFailure) Error() string {
+	return e.Message + " Reason: " + e.Err.Error()
+}
+
+// Unwrap returns the reason of the failure
+func (e *CommandFailure) Unwrap() error {
+	return e.Err
+}
+
+// NewCommandFailure creates the error returned by a command which failed with the given message and reason
+func NewCommandFailure(msg string, err error) error {
+	return &CommandFailure{Message: msg, Err: err}
+}
+
+// matches the http status code in error messages like "404:<...>", "409 Conflict:<...>" or "Status: 401 Unauthorized"
+var httpStatusInErrorRegex = regexp.MustCompile(`(?:^|Status: )([1-5][0-9]{2})\b`)
+
+var commandResult *CommandResult
+var commandStartTime time.Time
+var commandResultLock sync.Mutex
+
+// resultWriter is the writer the json result is printed into
+var resultWriter io.Writer = os.Stdout
+
+// IsJSONOutput returns whether the result of the command should be printed as json
+func IsJSONOutput() bool {
+	return OutputFormat == OutputFormatJSON
+}
+
+// MessageWriter returns the writer the messages of the command should be printed into. When the output format is
+// json, the standard output is reserved for the result, so the messages are printed to the standard error.
+func MessageWriter() io.Writer {
+	if IsJSONOutput() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// StartCommandResult starts recording the result of the given command
+// @param command : full name of the command (eg: apictl import api)
+func StartCommandResult(command string) {
+	commandResultLock.Lock()
+	defer commandResultLock.Unlock()
+	commandStartTime = time.Now()
+	commandResult = &CommandResult{Command: command, Resource: map[string]string{}}
+}
+
+// SetResultResource records an identifier of the resource the command works on (eg: name, version, environment)
+func SetResultResource(key, value string) {
+	commandResultLock.Lock()
+	defer commandResultLock.Unlock()
+	if commandResult != nil && value != "" {
+		commandResult.Resource[key] = value
+	}
+}
+
+// SetResultHttpStatus records the http status of the response received for the command
+func SetResultHttpStatus(statusCode int) {
+	commandResultLock.Lock()
+	defer commandResultLock.Unlock()
+	if commandResult != nil {
+		commandResult.HttpStatus = statusCode
+	}
+}
+
+// SetResultData records additional command specific data of the result
+func SetResultData(data interface{}) {
+	commandResultLock.Lock()
+	defer commandResultLock.Unlock()
+	if commandResult != nil {
+		commandResult.Data = data
+	}
+}
+
+// PrintCommandResult prints the successful result of the command if the output format is json
+func PrintCommandResult() {
+	printCommandResult(ExitCodeSuccess, nil)
+}
+
+// printCommandResult prints the result of the command with the given exit code and the error
+func printCommandResult(exitCode int, commandError *CommandError) {
+	commandResultLock.Lock()
+	defer commandResultLock.Unlock()
+	if !IsJSONOutput() || commandResult == nil {
+		return
+	}
+	commandResult.ExitCode = exitCode
+	commandResult.Error = commandError
+	commandResult.Status = CommandResultStatusSuccess
+	if exitCode != ExitCodeSuccess {
+		commandResult.Status = CommandResultStatusError
+	}
+	commandResult.DurationMillis = time.Since(commandStartTime).Milliseconds()
+	data, err := json.Marshal(commandResult)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error while printing the result:", err)
+		return
+	}
+	fmt.Fprintln(resultWriter, string(data))
+	// the result of a command is printed only once
+	commandResult = nil
+}
+
+// newCommandError creates the error of the command result. The error code and the description are read from the
+// error response of the server if available.
+func newCommandError(msg string, err error) *CommandError {
+	commandError := &CommandError{Message: msg}
+	if err == nil {
+		return commandError
+	}
+	commandError.Message = msg + " Reason: " + err.Error()
+	errMessage := err.Error()
+	var httpStatusError *HttpStatusError
+	if errors.As(err, &httpStatusError) {
+		errMessage = httpStatusError.Body
+	}
+	// the error response is a json object in the error message
+	start, end := strings.Index(errMessage, "{"), strings.LastIndex(errMessage, "}")
+	if start >= 0 && end > start {
+		var errorResponse HttpErrorResponse
+		if json.Unmarshal([]byte(errMessage[start:end+1]), &errorResponse) == nil {
+			commandError.Code = errorResponse.Code
+			commandError.Description = errorResponse.Description
+		}
+	}
+	return commandError
+}
+
+// GetExitCode returns the exit code for the given error
+func GetExitCode(err error) int {
+	if err == nil {
+		return ExitCodeGeneralError
+	}
+	var httpStatusError *HttpStatusError
+	if errors.As(err, &httpStatusError) {
+		return GetExitCodeOfHttpStatus(httpStatusError.StatusCode)
+	}
+	var exitCodeError *ExitCodeError
+	if errors.As(err, &exitCodeError) {
+		return exitCodeError.ExitCode
+	}
+	var netError net.Error
+	if errors.As(err, &netError) {
+		return ExitCodeNetworkError
+	}
+	if match := httpStatusInErrorRegex.FindStringSubmatch(err.Error()); match != nil {
+		statusCode, _ := strconv.Atoi(match[1])
+		return GetExitCodeOfHttpStatus(statusCode)
+	}
+	return ExitCodeGeneralError
+}
+
+// GetExitCodeOfHttpStatus returns the exit code for an error response with the given status code
+func GetExitCodeOfHttpStatus(statusCode int) int {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ExitCodeAuthError
+	case statusCode == http.StatusNotFound:
+		return ExitCodeNotFound
+	case statusCode == http.StatusConflict:
+		return ExitCodeConflict
+	case statusCode == http.StatusBadRequest || statusCode == http.StatusPreconditionFailed ||
+		statusCode == http.StatusUnsupportedMediaType || statusCode == http.StatusUnprocessableEntity:
+		return ExitCodeValidationError
+	case statusCode >= http.StatusInternalServerError:
+		return ExitCodeServerError
+	}
+	return ExitCodeGeneralError
+}
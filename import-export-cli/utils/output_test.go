@@ -0,0 +1,86 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExitCode(t *testing.T) {
+	assert.Equal(t, ExitCodeAuthError, GetExitCode(&HttpStatusError{StatusCode: 401, Status: "401 Unauthorized"}))
+	assert.Equal(t, ExitCodeNotFound, GetExitCode(fmt.Errorf("wrapped: %w",
+		&HttpStatusError{StatusCode: 404, Status: "404 Not Found"})))
+	assert.Equal(t, ExitCodeConflict, GetExitCode(errors.New("409 Conflict:<{\"code\":409}>")))
+	assert.Equal(t, ExitCodeServerError, GetExitCode(errors.New("Request didn't respond 200 OK. Status: 503 Unavailable")))
+	assert.Equal(t, ExitCodeValidationError, GetExitCode(NewValidationError("invalid flag")))
+	assert.Equal(t, ExitCodeNotFound, GetExitCode(NewNotFoundError("API not found")))
+	assert.Equal(t, ExitCodeAuthError, GetExitCode(NewAuthError("Authorization failed")))
+	assert.Equal(t, ExitCodeNetworkError, GetExitCode(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	assert.Equal(t, ExitCodeGeneralError, GetExitCode(errors.New("unable to read the file")))
+	assert.Equal(t, ExitCodeGeneralError, GetExitCode(nil))
+}
+
+func TestNewCommandErrorWithErrorResponse(t *testing.T) {
+	err := &HttpStatusError{StatusCode: 409, Status: "409 Conflict",
+		Body: `{"code":409,"message":"Conflict","description":"API already exists"}`}
+	commandError := newCommandError("Error importing API", err)
+	assert.Equal(t, 409, commandError.Code)
+	assert.Equal(t, "API already exists", commandError.Description)
+	assert.Contains(t, commandError.Message, "Error importing API")
+
+	commandError = newCommandError("Error importing API", errors.New("unable to read the file"))
+	assert.Equal(t, 0, commandError.Code)
+	assert.Empty(t, commandError.Description)
+}
+
+func TestPrintCommandResult(t *testing.T) {
+	var out bytes.Buffer
+	writer := resultWriter
+	defer func() {
+		OutputFormat, resultWriter = OutputFormatText, writer
+	}()
+	OutputFormat = OutputFormatJSON
+	StartCommandResult("apictl delete api")
+	resultWriter = &out
+	// the messages should not be mixed with the result
+	assert.Equal(t, os.Stdout, writer)
+	assert.Equal(t, os.Stderr, MessageWriter())
+	SetResultResource("name", "PizzaShackAPI")
+	SetResultHttpStatus(404)
+	printCommandResult(ExitCodeNotFound, newCommandError("Error while deleting API", nil))
+	// the result is printed only once
+	PrintCommandResult()
+
+	var result CommandResult
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, "apictl delete api", result.Command)
+	assert.Equal(t, CommandResultStatusError, result.Status)
+	assert.Equal(t, ExitCodeNotFound, result.ExitCode)
+	assert.Equal(t, "PizzaShackAPI", result.Resource["name"])
+	assert.Equal(t, 404, result.HttpStatus)
+	assert.Equal(t, "Error while deleting API", result.Error.Message)
+}
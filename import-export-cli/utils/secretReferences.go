@@ -0,0 +1,417 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/magiconair/properties"
+)
+
+// Secret sources supported in the secret references
+const (
+	SecretSourceFile     = "file"
+	SecretSourceVault    = "vault"
+	SecretSourceKeyStore = "keystore"
+)
+
+// Environment variables used to locate the secrets
+const (
+	// VaultAddressEnvVar is the address of the Vault compatible server (defaults to defaultVaultAddress)
+	VaultAddressEnvVar = "VAULT_ADDR"
+	// VaultTokenEnvVar is the token used to read the secrets from the Vault compatible server
+	VaultTokenEnvVar = "VAULT_TOKEN"
+	// SecretsFileEnvVar is the properties file with the secrets encrypted by "apictl secret create -o file"
+	// (defaults to security/wso2-secrets.properties in the working directory)
+	SecretsFileEnvVar = "APICTL_SECRETS_FILE"
+)
+
+const defaultVaultAddress = "http://127.0.0.1:8200"
+const vaultTokenHeader = "X-Vault-Token"
+const vaultSecretKeySeparator = "#"
+
+// Match for ${secret:source:reference} and capture the source and the reference inside groups
+var secretReferencePattern = regexp.MustCompile(`\${secret:(\w+):([^}]+)}`)
+
+// SecretSource resolves the value of a secret from a reference (eg: the path of a file)
+type SecretSource func(reference string) (string, error)
+
+var secretSources = map[string]SecretSource{
+	SecretSourceFile:     resolveFileSecret,
+	SecretSourceVault:    resolveVaultSecret,
+	SecretSourceKeyStore: resolveKeyStoreSecret,
+}
+
+// RegisterSecretSource adds a source which resolves the secret references of the form ${secret:name:reference}
+func RegisterSecretSource(name string, source SecretSource) {
+	secretSources[name] = source
+}
+
+// ErrSecretReferenceUnresolved represents an error of resolving a secret reference
+type ErrSecretReferenceUnresolved struct {
+	// Reference is the secret reference in the ${secret:source:reference} format
+	Reference string
+	Err       error
+}
+
+func (e ErrSecretReferenceUnresolved) Error() string {
+	return fmt.Sprintf("unable to resolve %s: %v", e.Reference, e.Err)
+}
+
+// secretResolver resolves secret references and caches the values resolved during a single import
+type secretResolver struct {
+	values map[string]string
+}
+
+func newSecretResolver() *secretResolver {
+	return &secretResolver{values: make(map[string]string)}
+}
+
+// HasSecretReferences returns whether the content contains secret references
+func HasSecretReferences(content []byte) bool {
+	return secretReferencePattern.Match(content)
+}
+
+// ResolveSecretReferences substitutes the secret references defined as ${secret:source:reference} in the content.
+// The resolved values are only kept in memory, so the result should not be written to the disk.
+// returns an error listing all the references which could not be resolved
+func ResolveSecretReferences(content string) (string, error) {
+	return newSecretResolver().resolve(content, "")
+}
+
+// ResolveSecretReferencesInZip substitutes the secret references in the files of a zip archive in memory
+// @param zipPath : Path to the zip archive
+// @return the content of the zip archive with the secrets resolved, nil if the archive has no secret references
+// @return error if a secret reference cannot be resolved
+func ResolveSecretReferencesInZip(zipPath string) ([]byte, error) {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	resolver := newSecretResolver()
+	resolved := false
+	buffer := &bytes.Buffer{}
+	archive := zip.NewWriter(buffer)
+	for _, file := range reader.File {
+		header := &zip.FileHeader{
+			Name:          file.Name,
+			Method:        file.Method,
+			Modified:      file.Modified,
+			ExternalAttrs: file.ExternalAttrs,
+		}
+		writer, err := archive.CreateHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		content, err := readZipEntry(file)
+		if err != nil {
+			return nil, err
+		}
+		if HasSecretReferences(content) {
+			Logln(LogPrefixInfo+"Resolving secret references in", file.Name)
+			substitutedContent, err := resolver.resolve(string(content), file.Name)
+			if err != nil {
+				return nil, err
+			}
+			content = []byte(substitutedContent)
+			resolved = true
+		}
+		if _, err := writer.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	if !resolved {
+		return nil, nil
+	}
+	return buffer.Bytes(), nil
+}
+
+func readZipEntry(file *zip.File) ([]byte, error) {
+	entry, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer entry.Close()
+	return ioutil.ReadAll(entry)
+}
+
+// resolve substitutes the secret references in the content of a file. The values are escaped according to the type of
+// the file given by its name, so that a value with quotes or line breaks does not change the structure of the file
+func (r *secretResolver) resolve(content, fileName string) (string, error) {
+	var errorResults error
+	var result strings.Builder
+	lastIndex := 0
+	for _, match := range secretReferencePattern.FindAllStringSubmatchIndex(content, -1) {
+		reference := content[match[0]:match[1]]
+		result.WriteString(content[lastIndex:match[0]])
+		lastIndex = match[1]
+		value, ok := r.values[reference]
+		if !ok {
+			Logln(LogPrefixInfo+"Resolving secret:", reference)
+			var err error
+			value, err = resolveSecret(content[match[2]:match[3]], content[match[4]:match[5]])
+			if err != nil {
+				errorResults = multierror.Append(errorResults, &ErrSecretReferenceUnresolved{Reference: reference, Err: err})
+				continue
+			}
+			r.values[reference] = value
+		}
+		escapedValue, err := escapeSecretValue(content, match[0], match[1], value, fileName)
+		if err != nil {
+			errorResults = multierror.Append(errorResults, &ErrSecretReferenceUnresolved{Reference: reference,
+				Err: errors.New(err.Error() + " in " + fileName)})
+			continue
+		}
+		result.WriteString(escapedValue)
+	}
+	if errorResults != nil {
+		return "", errorResults
+	}
+	result.WriteString(content[lastIndex:])
+	return result.String(), nil
+}
+
+// escapeSecretValue escapes the value of the secret reference found between start and end of the content of a file
+// JSON files are expected to have the references inside strings. In YAML files, a reference which is the whole plain
+// value is replaced with a double quoted value, while a reference inside a quoted value is escaped for the quotes.
+func escapeSecretValue(content string, start, end int, value, fileName string) (string, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".json":
+		return escapeJSONString(value), nil
+	case ".yaml", ".yml":
+		return escapeYamlSecretValue(content, start, end, value)
+	case ".xml":
+		var buffer bytes.Buffer
+		if err := xml.EscapeText(&buffer, []byte(value)); err != nil {
+			return "", err
+		}
+		return buffer.String(), nil
+	}
+	return value, nil
+}
+
+// escapeJSONString escapes a value to be written inside a JSON string
+func escapeJSONString(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted[1 : len(quoted)-1])
+}
+
+func escapeYamlSecretValue(content string, start, end int, value string) (string, error) {
+	lineStart := strings.LastIndex(content[:start], "\n") + 1
+	lineEnd := strings.Index(content[end:], "\n")
+	if lineEnd < 0 {
+		lineEnd = len(content)
+	} else {
+		lineEnd += end
+	}
+	switch yamlQuoteAt(content[lineStart:start]) {
+	case '"':
+		return escapeJSONString(value), nil
+	case '\'':
+		if strings.ContainsAny(value, "\r\n") {
+			return "", errors.New("a secret with line breaks cannot be used in a single quoted value, use double quotes")
+		}
+		return strings.ReplaceAll(value, "'", "''"), nil
+	}
+	before := strings.TrimSpace(content[lineStart:start])
+	after := strings.TrimSpace(content[end:lineEnd])
+	if (before == "" || strings.HasSuffix(before, ":") || strings.HasSuffix(before, "-")) &&
+		(after == "" || strings.HasPrefix(after, "#")) {
+		quoted, _ := json.Marshal(value)
+		return string(quoted), nil
+	}
+	if value != strings.TrimSpace(value) || strings.ContainsAny(value, "\r\n\"'#:{}[],&*!|>%@`") {
+		return "", errors.New("a secret with special characters cannot be a part of a plain value, quote the value")
+	}
+	return value, nil
+}
+
+// yamlQuoteAt returns the quote of the YAML flow scalar open at the end of a line, or zero if there is none. A quote
+// only starts a scalar at the start of the line or after an indicator such as ": " or "- "
+func yamlQuoteAt(line string) rune {
+	var quote rune
+	scalarStart := true
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if quote != 0 {
+			switch {
+			case quote == '"' && c == '\\':
+				i++
+			case quote == '\'' && c == '\'' && i+1 < len(line) && line[i+1] == '\'':
+				i++
+			case rune(c) == quote:
+				quote = 0
+				scalarStart = false
+			}
+			continue
+		}
+		switch c {
+		case ' ', '\t':
+		case '"', '\'':
+			if scalarStart {
+				quote = rune(c)
+			}
+		case '#':
+			if i == 0 || line[i-1] == ' ' {
+				return 0
+			}
+			scalarStart = false
+		case ':', '-':
+			scalarStart = i+1 == len(line) || line[i+1] == ' '
+		case '[', '{', ',':
+			scalarStart = true
+		default:
+			scalarStart = false
+		}
+	}
+	return quote
+}
+
+func resolveSecret(sourceName, reference string) (string, error) {
+	source, ok := secretSources[sourceName]
+	if !ok {
+		return "", errors.New("unknown secret source " + sourceName)
+	}
+	return source(strings.TrimSpace(reference))
+}
+
+// resolveFileSecret reads a secret from a file (eg: a docker or kubernetes secret mounted to /run/secrets)
+func resolveFileSecret(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// resolveVaultSecret reads a key of a secret (path#key) using the HTTP API of a Vault compatible server.
+// Both the KV version 1 and version 2 responses are supported.
+func resolveVaultSecret(reference string) (string, error) {
+	separatorIndex := strings.LastIndex(reference, vaultSecretKeySeparator)
+	if separatorIndex <= 0 || separatorIndex == len(reference)-1 {
+		return "", errors.New("vault secret reference should be in the format path" + vaultSecretKeySeparator + "key")
+	}
+	secretPath := strings.Trim(reference[:separatorIndex], "/")
+	key := reference[separatorIndex+1:]
+
+	address := os.Getenv(VaultAddressEnvVar)
+	if address == "" {
+		address = defaultVaultAddress
+	}
+	headers := make(map[string]string)
+	headers[HeaderAccept] = "application/json"
+	if token := os.Getenv(VaultTokenEnvVar); token != "" {
+		headers[vaultTokenHeader] = token
+	}
+	resp, err := InvokeGETRequest(strings.TrimRight(address, "/")+"/v1/"+secretPath, headers)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", errors.New("reading " + secretPath + " from the vault failed with " + resp.Status())
+	}
+
+	secret := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	if err := json.Unmarshal(resp.Body(), &secret); err != nil {
+		return "", err
+	}
+	data := secret.Data
+	// KV version 2 wraps the values of the secret with its metadata
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nested
+		}
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", errors.New("key " + key + " was not found in " + secretPath)
+	}
+	if text, isText := value.(string); isText {
+		return text, nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+// resolveKeyStoreSecret decrypts a secret encrypted by "apictl secret create -o file" using the private key of the
+// key store configured by "apictl secret init"
+func resolveKeyStoreSecret(alias string) (string, error) {
+	secretsFile := os.Getenv(SecretsFileEnvVar)
+	if secretsFile == "" {
+		currentDir, _ := os.Getwd()
+		secretsFile = filepath.Join(currentDir, "security", encryptedSecretsPropertiesFileName)
+	}
+	secrets, err := properties.LoadFile(secretsFile, properties.UTF8)
+	if err != nil {
+		return "", err
+	}
+	encryptedSecret, ok := secrets.Get(alias)
+	if !ok {
+		return "", errors.New("alias " + alias + " was not found in " + secretsFile)
+	}
+	keyStoreConfig, err := GetKeyStoreConfigFromFile(GetKeyStoreConfigFilePath())
+	if err != nil {
+		return "", err
+	}
+	privateKey, err := getDecryptionKey(keyStoreConfig)
+	if err != nil {
+		return "", err
+	}
+	return decryptSecret(privateKey, encryptedSecret)
+}
+
+// decryptSecret decrypts a base64 encoded secret encrypted with either of the supported algorithms
+func decryptSecret(key *rsa.PrivateKey, encryptedSecret string) (string, error) {
+	encryptedBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encryptedSecret))
+	if err != nil {
+		return "", err
+	}
+	plainText, err := rsa.DecryptOAEP(sha1.New(), nil, key, encryptedBytes, nil)
+	if err != nil {
+		plainText, err = rsa.DecryptPKCS1v15(nil, key, encryptedBytes)
+		if err != nil {
+			return "", errors.New("decrypting the secret failed")
+		}
+	}
+	return string(plainText), nil
+}
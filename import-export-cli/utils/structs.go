@@ -51,13 +51,24 @@ type EnvKeys struct {
 }
 
 type EnvEndpoints struct {
-	ApiManagerEndpoint   string `yaml:"apim"`
-	PublisherEndpoint    string `yaml:"publisher"`
-	DevPortalEndpoint    string `yaml:"devportal"`
-	RegistrationEndpoint string `yaml:"registration"`
-	AdminEndpoint        string `yaml:"admin"`
-	TokenEndpoint        string `yaml:"token"`
-	MiManagementEndpoint string `yaml:"mi"`
+	ApiManagerEndpoint   string   `yaml:"apim"`
+	PublisherEndpoint    string   `yaml:"publisher"`
+	DevPortalEndpoint    string   `yaml:"devportal"`
+	RegistrationEndpoint string   `yaml:"registration"`
+	AdminEndpoint        string   `yaml:"admin"`
+	TokenEndpoint        string   `yaml:"token"`
+	MiManagementEndpoint string   `yaml:"mi"`
+	MiManagementNodes    []string `yaml:"miNodes,omitempty"`
+}
+
+// MINode is a node of the Micro Integrator cluster of an environment
+type MINode struct {
+	// Name of the node, which is the host and port of its endpoint
+	Name string
+	// Env addresses the node as an environment in the form <environment>#<node-number>
+	Env string
+	// Endpoint is the Mi Management Endpoint of the node
+	Endpoint string
 }
 
 type MgwEndpoints struct {
@@ -103,7 +114,7 @@ type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 	TokenType    string `json:"token_type"`
-	ExpiresIn    int32  `json:"expires_in"`
+	ExpiresIn    int64  `json:"expires_in"`
 }
 
 type APIListResponse struct {
@@ -121,11 +132,60 @@ type ApplicationListResponse struct {
 	List  []Application `json:"list"`
 }
 
-type MigrationApisExportMetadata struct {
-	ApiListOffset   int    `yaml:"api_list_offset"`
-	User            string `yaml:"user"`
-	OnTenant        string `yaml:"on_tenant"`
-	ApiListToExport []API  `yaml:"apis_to_export"`
+// MigrationApisExportManifest is the checkpoint of the export apis command. The status of each API is recorded so
+// that an interrupted export can be resumed and the failed APIs can be retried.
+type MigrationApisExportManifest struct {
+	Environment    string `yaml:"environment"`
+	User           string `yaml:"user"`
+	OnTenant       string `yaml:"on_tenant"`
+	Format         string `yaml:"format"`
+	PreserveStatus bool   `yaml:"preserve_status"`
+	AllRevisions   bool   `yaml:"all_revisions"`
+	// ListingCompleted is true once the complete list of APIs has been fetched from the server
+	ListingCompleted bool                       `yaml:"listing_completed"`
+	StartedTime      string                     `yaml:"started_time"`
+	UpdatedTime      string                     `yaml:"updated_time"`
+	APIs             []*MigrationApiExportEntry `yaml:"apis"`
+}
+
+// MigrationApiExportEntry is the export status of a single API
+type MigrationApiExportEntry struct {
+	ID           string                     `yaml:"id"`
+	Name         string                     `yaml:"name"`
+	Version      string                     `yaml:"version"`
+	Provider     string                     `yaml:"provider"`
+	Status       string                     `yaml:"status"`
+	Attempts     int                        `yaml:"attempts"`
+	Error        string                     `yaml:"error,omitempty"`
+	ExportedTime string                     `yaml:"exported_time,omitempty"`
+	Files        []MigrationApiExportedFile `yaml:"files,omitempty"`
+}
+
+// MigrationApiExportedFile is an archive of the working copy or a revision of an exported API
+type MigrationApiExportedFile struct {
+	Revision string `yaml:"revision,omitempty"`
+	File     string `yaml:"file"`
+	Checksum string `yaml:"checksum"`
+}
+
+// MigrationApisImportManifest is the checkpoint of the import apis command. The status of each archive is recorded so
+// that an interrupted import can be resumed and the failed archives can be retried.
+type MigrationApisImportManifest struct {
+	Environment     string                     `yaml:"environment"`
+	SourceDirectory string                     `yaml:"source_directory"`
+	StartedTime     string                     `yaml:"started_time"`
+	UpdatedTime     string                     `yaml:"updated_time"`
+	Archives        []*MigrationApiImportEntry `yaml:"archives"`
+}
+
+// MigrationApiImportEntry is the import status of a single archive of an API
+type MigrationApiImportEntry struct {
+	File         string `yaml:"file"`
+	Checksum     string `yaml:"checksum"`
+	Status       string `yaml:"status"`
+	Attempts     int    `yaml:"attempts"`
+	Error        string `yaml:"error,omitempty"`
+	ImportedTime string `yaml:"imported_time,omitempty"`
 }
 
 type HttpErrorResponse struct {
@@ -139,7 +199,9 @@ type HttpErrorResponse struct {
 //Key generation request
 type KeygenRequest struct {
 	KeyType                 string   `json:"keyType"`
+	KeyManager              string   `json:"keyManager,omitempty"`
 	GrantTypesToBeSupported []string `json:"grantTypesToBeSupported"`
+	CallbackURL             string   `json:"callbackUrl,omitempty"`
 	ValidityTime            int      `json:"validityTime"`
 }
 
@@ -220,6 +282,8 @@ type AppDetails struct {
 
 // Application key details
 type ApplicationKey struct {
+	KeyMappingID        string      `json:"keyMappingId,omitempty"`
+	KeyManager          string      `json:"keyManager,omitempty"`
 	ConsumerKey         string      `json:"consumerKey"`
 	ConsumerSecret      string      `json:"consumerSecret"`
 	SupportedGrantTypes []string    `json:"supportedGrantTypes"`
@@ -228,6 +292,14 @@ type ApplicationKey struct {
 	KeyType             string      `json:"keyType"`
 }
 
+// Application key update request
+type ApplicationKeyUpdateRequest struct {
+	KeyType             string   `json:"keyType"`
+	KeyManager          string   `json:"keyManager"`
+	SupportedGrantTypes []string `json:"supportedGrantTypes"`
+	CallbackURL         string   `json:"callbackUrl,omitempty"`
+}
+
 // Application creation request
 type AppCreateRequest struct {
 	Name             string `json:"name"`
@@ -356,6 +428,8 @@ type MetaData struct {
 	Name    string `json:"name"`
 	Version string `json:"version,omitempty"`
 	Owner   string `json:"owner,omitempty"`
+	// EnvSubstitution configures the files of the project in which the environment variables are substituted
+	EnvSubstitution *EnvSubstitutionConfig `json:"envSubstitution,omitempty" yaml:"envSubstitution,omitempty"`
 }
 
 type RevisionListResponse struct {
@@ -30,6 +30,16 @@ import (
 	"github.com/renstrom/dedent"
 )
 
+// OAuthTokenScopes are the scopes requested for the access tokens used by the CLI
+const OAuthTokenScopes = "apim:app_import_export+apim:api_import_export+apim:api_product_import_export+" +
+	"apim:app_manage+apim:sub_manage+apim:api_view+apim:api_delete+apim:app_owner_change+apim:subscribe+" +
+	"apim:api_publish"
+
+// OAuthAdminTokenScopes are the scopes requested for the access tokens used to export and import the admin artifacts
+// (throttling policies, key managers and shared scopes). They are requested only by those commands
+const OAuthAdminTokenScopes = "apim:admin+apim:tier_view+apim:tier_manage+apim:admin_operations+" +
+	"apim:shared_scope_manage"
+
 // ExecutePreCommandWithBasicAuth deals with generating tokens needed for executing a particular command
 // @param environment : Environment on which the particular command is run
 // @param flagUsername : Username entered using the flag --username (-u). Could be blank
@@ -54,14 +64,14 @@ func ExecutePreCommandWithBasicAuth(environment, flagUsername, flagPassword, mai
 				if flagUsername != username {
 					// username entered with flag -u is not the same as username found
 					// in env_keys_all.yaml file
-					fmt.Println("Username entered with flag -u for the environment '" +
-						environment + "' is not the same as username found in file '" + EnvKeysAllFilePath + "'")
-					fmt.Println("Execute '" + ProjectName + " reset-user -e " + environment + "' to clear user data")
+					fmt.Fprintln(MessageWriter(), "Username entered with flag -u for the environment '"+
+						environment+"' is not the same as username found in file '"+EnvKeysAllFilePath+"'")
+					fmt.Fprintln(MessageWriter(), "Execute '"+ProjectName+" reset-user -e "+environment+"' to clear user data")
 					HandleErrorAndExit("Username mismatch", nil)
 				} else {
 					// username entered with flag -u is the same as username found in env_keys_all.yaml file
 					if flagPassword == "" {
-						fmt.Println("For Username: " + username)
+						fmt.Fprintln(MessageWriter(), "For Username: "+username)
 						password = PromptForPassword()
 					} else {
 						// flagPassword is not blank
@@ -76,7 +86,7 @@ func ExecutePreCommandWithBasicAuth(environment, flagUsername, flagPassword, mai
 					password = flagPassword
 				} else {
 					// flagPassword is blank
-					fmt.Println("For username: " + username)
+					fmt.Fprintln(MessageWriter(), "For username: "+username)
 					password = PromptForPassword()
 				}
 			}
@@ -92,7 +102,7 @@ func ExecutePreCommandWithBasicAuth(environment, flagUsername, flagPassword, mai
 				username = flagUsername
 				if flagPassword == "" {
 					// flagPassword is blank
-					fmt.Println("For Username: " + username)
+					fmt.Fprintln(MessageWriter(), "For Username: "+username)
 					password = PromptForPassword()
 				} else {
 					// flagPassword is not blank
@@ -106,7 +116,7 @@ func ExecutePreCommandWithBasicAuth(environment, flagUsername, flagPassword, mai
 			}
 
 			if err != nil {
-				fmt.Println("Error:", err)
+				fmt.Fprintln(MessageWriter(), "Error:", err)
 			}
 		}
 
@@ -157,14 +167,14 @@ func ExecutePreCommandWithOAuth(environment, flagUsername, flagPassword, mainCon
 				if flagUsername != username {
 					// username entered with flag -u is not the same as username found
 					// in env_keys_all.yaml file
-					fmt.Println("Username entered with flag -u for the environment '" +
-						environment + "' is not the same as username found in file '" + EnvKeysAllFilePath + "'")
-					fmt.Println("Execute '" + ProjectName + " reset-user -e " + environment + "' to clear user data")
+					fmt.Fprintln(MessageWriter(), "Username entered with flag -u for the environment '"+
+						environment+"' is not the same as username found in file '"+EnvKeysAllFilePath+"'")
+					fmt.Fprintln(MessageWriter(), "Execute '"+ProjectName+" reset-user -e "+environment+"' to clear user data")
 					HandleErrorAndExit("Username mismatch", nil)
 				} else {
 					// username entered with flag -u is the same as username found in env_keys_all.yaml file
 					if flagPassword == "" {
-						fmt.Println("For Username: " + username)
+						fmt.Fprintln(MessageWriter(), "For Username: "+username)
 						password = PromptForPassword()
 					} else {
 						// flagPassword is not blank
@@ -179,7 +189,7 @@ func ExecutePreCommandWithOAuth(environment, flagUsername, flagPassword, mainCon
 					password = flagPassword
 				} else {
 					// flagPassword is blank
-					fmt.Println("For username: " + username)
+					fmt.Fprintln(MessageWriter(), "For username: "+username)
 					password = PromptForPassword()
 				}
 			}
@@ -200,7 +210,7 @@ func ExecutePreCommandWithOAuth(environment, flagUsername, flagPassword, mainCon
 				username = flagUsername
 				if flagPassword == "" {
 					// flagPassword is blank
-					fmt.Println("For Username: " + username)
+					fmt.Fprintln(MessageWriter(), "For Username: "+username)
 					password = PromptForPassword()
 				} else {
 					// flagPassword is not blank
@@ -313,8 +323,7 @@ func GetBase64EncodedCredentials(key, secret string) (encodedValue string) {
 // @return error
 func GetOAuthTokens(username, password, b64EncodedClientIDClientSecret, url string) (map[string]string, error) {
 	body := "grant_type=password&username=" + username + "&password=" + encodeURL.QueryEscape(password) +
-		"&scope=apim:app_import_export+apim:api_import_export+apim:api_product_import_export+apim:app_manage+" +
-		"apim:sub_manage+apim:api_view+apim:api_delete+apim:app_owner_change+apim:subscribe+apim:api_publish"
+		"&scope=" + OAuthTokenScopes
 
 	// set headers
 	headers := make(map[string]string)
@@ -340,3 +349,58 @@ func GetOAuthTokens(username, password, b64EncodedClientIDClientSecret, url stri
 
 	return responseDataMap, nil // contains 'access_token', 'refresh_token' etc
 }
+
+// GetOAuthTokenResponse requests tokens from the token endpoint using the password grant type
+// @param username
+// @param password
+// @param b64EncodedClientIDClientSecret
+// @param url : OAuth token endpoint
+// @param scopes : Scopes to request, separated by + (eg: OAuthTokenScopes)
+// @return token response with the access token, the refresh token and the validity period
+// @return error
+func GetOAuthTokenResponse(username, password, b64EncodedClientIDClientSecret, url,
+	scopes string) (*TokenResponse, error) {
+	body := "grant_type=password&username=" + username + "&password=" + encodeURL.QueryEscape(password) +
+		"&scope=" + scopes
+	return requestOAuthTokens(body, b64EncodedClientIDClientSecret, url)
+}
+
+// RefreshOAuthTokens requests new tokens from the token endpoint using the refresh token grant type
+// @param refreshToken : refresh token issued with the previous access token
+// @param b64EncodedClientIDClientSecret
+// @param url : OAuth token endpoint
+// @param scopes : Scopes to request, separated by +. They should be the scopes of the previous access token
+// @return token response with the access token, the refresh token and the validity period
+// @return error
+func RefreshOAuthTokens(refreshToken, b64EncodedClientIDClientSecret, url, scopes string) (*TokenResponse, error) {
+	body := "grant_type=refresh_token&refresh_token=" + encodeURL.QueryEscape(refreshToken) +
+		"&scope=" + scopes
+	return requestOAuthTokens(body, b64EncodedClientIDClientSecret, url)
+}
+
+// requestOAuthTokens invokes the token endpoint with the given url encoded body
+func requestOAuthTokens(body, b64EncodedClientIDClientSecret, url string) (*TokenResponse, error) {
+	headers := make(map[string]string)
+	headers[HeaderContentType] = HeaderValueXWWWFormUrlEncoded
+	headers[HeaderAuthorization] = HeaderValueAuthBasicPrefix + " " + b64EncodedClientIDClientSecret
+	headers[HeaderAccept] = HeaderValueApplicationJSON
+
+	Logln(LogPrefixInfo + "connecting to " + url)
+	resp, err := InvokePOSTRequest(url, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New("Unable to connect. " +
+			"Status: " + resp.Status())
+	}
+
+	tokenResponse := &TokenResponse{}
+	if err := json.Unmarshal(resp.Body(), tokenResponse); err != nil {
+		return nil, err
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, errors.New("access_token not found")
+	}
+	return tokenResponse, nil
+}
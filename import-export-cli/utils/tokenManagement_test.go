@@ -21,6 +21,7 @@ package utils
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/renstrom/dedent"
@@ -98,6 +99,48 @@ func TestGetOAuthTokensOK(t *testing.T) {
 	}
 }
 
+func TestRefreshOAuthTokensOK(t *testing.T) {
+	var oauthStub = getOAuthStubOK(t)
+	defer oauthStub.Close()
+
+	tokenResponse, err := RefreshOAuthTokens(sampleRefreshToken, "", oauthStub.URL, OAuthTokenScopes)
+	if err != nil {
+		t.Fatal("Error in RefreshOAuthTokens()", err)
+	}
+	if tokenResponse.AccessToken != sampleAccessToken {
+		t.Error("Error in RefreshOAuthTokens(): Incorrect AccessToken")
+	}
+	if tokenResponse.RefreshToken != sampleRefreshToken {
+		t.Error("Error in RefreshOAuthTokens(): Incorrect RefreshToken")
+	}
+	if tokenResponse.ExpiresIn != 1487166427829 {
+		t.Error("Error in RefreshOAuthTokens(): Incorrect ExpiresIn")
+	}
+}
+
+func TestGetOAuthTokenResponseScopes(t *testing.T) {
+	var requestedScopes []string
+	var oauthStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedScopes = append(requestedScopes, r.FormValue("scope"))
+		w.Header().Set(HeaderContentType, HeaderValueApplicationJSON)
+		w.Write([]byte(`{"access_token": "` + sampleAccessToken + `", "expires_in": 3600}`))
+	}))
+	defer oauthStub.Close()
+
+	if _, err := GetOAuthTokenResponse("admin", "admin", "", oauthStub.URL, OAuthTokenScopes); err != nil {
+		t.Fatal("Error in GetOAuthTokenResponse()", err)
+	}
+	if _, err := RefreshOAuthTokens(sampleRefreshToken, "", oauthStub.URL, OAuthAdminTokenScopes); err != nil {
+		t.Fatal("Error in RefreshOAuthTokens()", err)
+	}
+	if strings.Contains(requestedScopes[0], "apim:admin") {
+		t.Error("Admin scopes should not be requested for the access tokens of the CLI:", requestedScopes[0])
+	}
+	if requestedScopes[1] != strings.Replace(OAuthAdminTokenScopes, "+", " ", -1) {
+		t.Error("Admin scopes should be requested:", requestedScopes[1])
+	}
+}
+
 // Registration Server - OK
 func getRegistrationStubOK(t *testing.T) *httptest.Server {
 	var registrationStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
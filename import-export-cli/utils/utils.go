@@ -20,6 +20,7 @@ package utils
 
 import (
 	"bufio"
+	"bytes"
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
@@ -102,6 +103,25 @@ func InvokePOSTRequestWithFileAndQueryParams(queryParam map[string]string, url s
 		SetFile(fileParamName, filePath).Post(url)
 }
 
+// InvokePOSTRequestWithFileContentAndQueryParams uploads the content of a file kept in memory as multipart form data
+func InvokePOSTRequestWithFileContentAndQueryParams(queryParam map[string]string, url string,
+	headers map[string]string, fileParamName, fileName string, content []byte) (*resty.Response, error) {
+
+	client := resty.New()
+
+	if Insecure {
+		client.SetTLSClientConfig(
+			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
+				Renegotiation: TLSRenegotiationMode})
+	} else {
+		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	}
+
+	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	return client.R().SetHeaders(headers).SetQueryParams(queryParam).
+		SetFileReader(fileParamName, fileName, bytes.NewReader(content)).Post(url)
+}
+
 // Invoke http-get request using go-resty
 func InvokeGETRequest(url string, headers map[string]string) (*resty.Response, error) {
 	client := resty.New()
@@ -242,24 +262,24 @@ func InvokePATCHRequest(url string, headers map[string]string, body map[string]s
 func PromptForUsername() string {
 	reader := bufio.NewReader(os.Stdin)
 
-	fmt.Print("Enter Username: ")
+	fmt.Fprint(MessageWriter(), "Enter Username: ")
 	username, _ := reader.ReadString('\n')
 
 	return username
 }
 
 func PromptForPassword() string {
-	fmt.Print("Enter Password: ")
+	fmt.Fprint(MessageWriter(), "Enter Password: ")
 	bytePassword, _ := terminal.ReadPassword(0)
 	password := string(bytePassword)
-	fmt.Println()
+	fmt.Fprintln(MessageWriter())
 	return password
 }
 
 // ShowHelpCommandTip function will print the instructions for displaying help info on a specific command
 // @params cmdLiteral Command on which help command is to be displayed
 func ShowHelpCommandTip(cmdLiteral string) {
-	fmt.Printf("Execute '%s %s --help' for more info.\n", ProjectName, cmdLiteral)
+	fmt.Fprintf(MessageWriter(), "Execute '%s %s --help' for more info.\n", ProjectName, cmdLiteral)
 }
 
 // return a string containing the file name, function name
@@ -308,8 +328,8 @@ func WriteToFileSystem(exportAPIName, exportAPIVersion, exportEnvironment, expor
 	if err != nil {
 		HandleErrorAndExit("Error creating zip archive", err)
 	}
-	fmt.Println("Successfully exported API!")
-	fmt.Println("Find the exported API at " + pFile)
+	fmt.Fprintln(MessageWriter(), "Successfully exported API!")
+	fmt.Fprintln(MessageWriter(), "Find the exported API at "+pFile)
 
 }
 
@@ -0,0 +1,63 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"strconv"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// FindYAMLLine returns the line number of the field at the given path of a YAML or a JSON document. The path is a list
+// of object keys and list indexes (eg: data, operations, 0, verb). If the field does not exist, the line of the closest
+// parent is returned. Returns 0 if the document cannot be parsed.
+func FindYAMLLine(content []byte, path []string) int {
+	var document yamlv3.Node
+	if err := yamlv3.Unmarshal(content, &document); err != nil || len(document.Content) == 0 {
+		return 0
+	}
+	node := document.Content[0]
+	line := node.Line
+	for _, key := range path {
+		next, keyLine := findYAMLChild(node, key)
+		if next == nil {
+			break
+		}
+		node, line = next, keyLine
+	}
+	return line
+}
+
+// findYAMLChild returns the child node with the given key (or index) and the line of the key
+func findYAMLChild(node *yamlv3.Node, key string) (*yamlv3.Node, int) {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1], node.Content[i].Line
+			}
+		}
+	case yamlv3.SequenceNode:
+		index, err := strconv.Atoi(key)
+		if err == nil && index >= 0 && index < len(node.Content) {
+			return node.Content[index], node.Content[index].Line
+		}
+	}
+	return nil, 0
+}